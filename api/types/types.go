@@ -268,6 +268,8 @@ const (
 	ComponentAnnotation         string = BaseQualifier + "/component"
 	SiteControllerIgnore        string = InternalQualifier + "/site-controller-ignore"
 	RouterComponent             string = "router"
+	CollectorComponent          string = "collector"
+	GrantServerComponent        string = "grant-server"
 	ClaimExpiration             string = BaseQualifier + "/claim-expiration"
 	ClaimsRemaining             string = BaseQualifier + "/claims-remaining"
 	ClaimsMade                  string = BaseQualifier + "/claims-made"
@@ -4,16 +4,20 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	iflag "github.com/skupperproject/skupper/internal/flag"
+	"github.com/skupperproject/skupper/internal/kube/certificates"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/controller"
 	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 	"github.com/skupperproject/skupper/pkg/version"
 )
@@ -58,12 +62,20 @@ func main() {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
+	certificateConfig, err := certificates.BoundConfig(flags)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 
 	var namespace string
 	var kubeconfig string
 	iflag.StringVar(flags, &namespace, "namespace", "NAMESPACE", "", "The Kubernetes namespace scope for the controller")
 	iflag.StringVar(flags, &kubeconfig, "kubeconfig", "KUBECONFIG", "", "A path to the kubeconfig file to use")
 
+	var metricsAddress string
+	iflag.StringVar(flags, &metricsAddress, "metrics-address", "SKUPPER_METRICS_ADDRESS", ":9000", "The address on which prometheus metrics for the controller are served (disabled if empty)")
+
 	var watchNamespace string
 	iflag.StringVar(flags, &watchNamespace, "watch-namespace", "WATCH_NAMESPACE", metav1.NamespaceAll, "The Kubernetes namespace the controller should monitor for controlled resources (will monitor all if not specified)")
 	// if -version used, report and exit
@@ -73,6 +85,14 @@ func main() {
 		fmt.Println(version.Version)
 		os.Exit(0)
 	}
+	if err := certificateConfig.Verify(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	if err := grantConfig.Verify(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 	log.Printf("Version: %s", version.Version)
 	if watchNamespace == metav1.NamespaceAll {
 		log.Println("Skupper controller watching all namespaces")
@@ -88,11 +108,23 @@ func main() {
 		log.Fatal("Error getting van client ", err.Error())
 	}
 
-	controller, err := controller.NewController(cli, grantConfig, securedAccessConfig, watchNamespace, cli.Namespace)
+	reg := prometheus.NewRegistry()
+	controller, err := controller.NewController(cli, grantConfig, securedAccessConfig, certificateConfig, watchNamespace, cli.Namespace, reg)
 	if err != nil {
 		log.Fatal("Error getting new site controller ", err.Error())
 	}
 
+	if metricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler(reg))
+		go func() {
+			log.Printf("Serving metrics on %s", metricsAddress)
+			if err := http.ListenAndServe(metricsAddress, mux); err != nil {
+				log.Printf("Metrics server stopped: %s", err)
+			}
+		}()
+	}
+
 	if err = controller.Run(stopCh); err != nil {
 		log.Fatal("Error running site controller: ", err.Error())
 	}
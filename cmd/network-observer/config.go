@@ -4,10 +4,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"time"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/pkg/utils/tlscfg"
 )
 
@@ -24,12 +26,42 @@ type Config struct {
 	RouterTLS     TLSSpec
 	FlowRecordTTL time.Duration
 
+	FlowHistoryDir       string
+	FlowHistoryRetention time.Duration
+
+	TopologyHistoryRetention time.Duration
+
+	OTLPEndpoint string
+	OTLPHeaders  string
+	OTLPInterval time.Duration
+	OTLPTLS      TLSSpec
+
 	VanflowLoggingProfile string
+	VanflowLoggingRules   string
+	VanflowLoggingSinks   string
 
 	EnableProfile bool
 	CORSAllowAll  bool
+
+	AuthMode            string
+	AuthHtpasswdFile    string
+	AuthOpenShiftAPI    string
+	AuthOpenShiftTLS    TLSSpec
+	AuthOIDCIssuer      string
+	AuthOIDCClientID    string
+	AuthOIDCUserClaim   string
+	AuthOIDCGroupsClaim string
+	AuthOIDCTLS         TLSSpec
+	AuthPolicyFile      string
 }
 
+const (
+	authModeUnsecured = "unsecured"
+	authModeBasic     = "basic"
+	authModeOpenShift = "openshift"
+	authModeOIDC      = "oidc"
+)
+
 type TLSSpec struct {
 	CA         string
 	Cert       string
@@ -69,6 +101,49 @@ func (t TLSSpec) config() (*tls.Config, error) {
 	return config, nil
 }
 
+// configureAuth returns the Authenticator for the configured auth mode,
+// or nil when the API is unsecured.
+func configureAuth(cfg Config) (auth.Authenticator, error) {
+	switch cfg.AuthMode {
+	case authModeUnsecured, "":
+		return nil, nil
+	case authModeBasic:
+		if cfg.AuthHtpasswdFile == "" {
+			return nil, fmt.Errorf("auth-htpasswd-file is required for basic auth")
+		}
+		return auth.NewHtpasswd(cfg.AuthHtpasswdFile, "skupper")
+	case authModeOpenShift:
+		tlsConfig, err := cfg.AuthOpenShiftTLS.config()
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewOpenShift(auth.OpenShiftConfig{
+			APIServer:  cfg.AuthOpenShiftAPI,
+			HTTPClient: httpClient(tlsConfig),
+		})
+	case authModeOIDC:
+		tlsConfig, err := cfg.AuthOIDCTLS.config()
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewOIDC(auth.OIDCConfig{
+			Issuer:        cfg.AuthOIDCIssuer,
+			ClientID:      cfg.AuthOIDCClientID,
+			UsernameClaim: cfg.AuthOIDCUserClaim,
+			GroupsClaim:   cfg.AuthOIDCGroupsClaim,
+			HTTPClient:    httpClient(tlsConfig),
+		})
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q: options are %s, %s, %s and %s", cfg.AuthMode, authModeUnsecured, authModeBasic, authModeOpenShift, authModeOIDC)
+	}
+}
+
+func httpClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 10 * time.Second}
+}
+
 func parsePrometheusAPI(base string) (*url.URL, error) {
 	targetPromAPI, err := url.Parse(base)
 	if err != nil {
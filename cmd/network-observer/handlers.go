@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"io/fs"
 	"net/http"
 	"net/http/httputil"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 )
 
 func handleMetrics(reg *prometheus.Registry) http.Handler {
@@ -27,6 +30,33 @@ func handleNoContent() http.Handler {
 	})
 }
 
+// handleUser reports the authenticated user, or no content when the API
+// is unsecured.
+func handleUser() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.UserFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(user)
+	})
+}
+
+// handleLogout challenges basic auth clients for new credentials, so that
+// browsers forget those they have cached. There is nothing to do for the
+// other auth modes, where tokens are held by the client.
+func handleLogout(authn auth.Authenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authn.(*auth.Htpasswd); ok {
+			auth.Unauthorized(w, authn)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
 func handleProxyPrometheusAPI(prefix string, target *url.URL) http.Handler {
 	proxy := httputil.NewSingleHostReverseProxy(target)
 	return http.StripPrefix(prefix,
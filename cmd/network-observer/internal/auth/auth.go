@@ -0,0 +1,80 @@
+// Package auth authenticates requests to the network observer and
+// decides which sites the authenticated user may see.
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// ErrUnauthenticated is returned by an Authenticator when a request does
+// not carry valid credentials.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// User is an authenticated user.
+type User struct {
+	Name   string   `json:"username"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Authenticator identifies the user making a request.
+type Authenticator interface {
+	// Authenticate returns the user making the request, or an error
+	// wrapping ErrUnauthenticated if the request carries no valid
+	// credentials.
+	Authenticate(r *http.Request) (*User, error)
+	// Challenge is the WWW-Authenticate header value sent with a 401
+	// response.
+	Challenge() string
+}
+
+type contextKey struct{}
+
+// WithUser returns a copy of ctx carrying the user.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, user)
+}
+
+// UserFromContext returns the user the request context was authenticated
+// for, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(contextKey{}).(*User)
+	return user, ok && user != nil
+}
+
+// Middleware rejects requests that the Authenticator cannot authenticate,
+// and otherwise adds the user to the request context.
+func Middleware(logger *slog.Logger, authn Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := authn.Authenticate(r)
+			if err != nil {
+				if !errors.Is(err, ErrUnauthenticated) {
+					logger.Error("error authenticating request", slog.String("path", r.URL.Path), slog.Any("error", err))
+				}
+				Unauthorized(w, authn)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
+
+// Unauthorized writes a 401 response challenging the client for
+// credentials.
+func Unauthorized(w http.ResponseWriter, authn Authenticator) {
+	w.Header().Set("WWW-Authenticate", authn.Challenge())
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
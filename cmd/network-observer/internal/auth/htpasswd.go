@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Htpasswd authenticates requests using HTTP basic auth against users in
+// an htpasswd file, such as one mounted from a secret. Passwords may be
+// hashed with bcrypt or SHA1. The file is read again when it changes.
+type Htpasswd struct {
+	path  string
+	realm string
+
+	mu      sync.Mutex
+	modTime time.Time
+	users   map[string]string
+}
+
+var _ Authenticator = (*Htpasswd)(nil)
+
+func NewHtpasswd(path string, realm string) (*Htpasswd, error) {
+	h := &Htpasswd{
+		path:  path,
+		realm: realm,
+	}
+	if _, err := h.load(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Htpasswd) Challenge() string {
+	return fmt.Sprintf("Basic realm=%q", h.realm)
+}
+
+func (h *Htpasswd) Authenticate(r *http.Request) (*User, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, fmt.Errorf("%w: no basic auth credentials", ErrUnauthenticated)
+	}
+	users, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := users[username]
+	if !ok || !checkPassword(hash, password) {
+		return nil, fmt.Errorf("%w: invalid credentials for %q", ErrUnauthenticated, username)
+	}
+	return &User{Name: username}, nil
+}
+
+// load returns the users in the file, reading it again if it has been
+// modified since it was last read.
+func (h *Htpasswd) load() (map[string]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	info, err := os.Stat(h.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading htpasswd file: %w", err)
+	}
+	if h.users != nil && info.ModTime().Equal(h.modTime) {
+		return h.users, nil
+	}
+	data, err := os.ReadFile(h.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading htpasswd file: %w", err)
+	}
+	users, err := parseHtpasswd(data)
+	if err != nil {
+		return nil, err
+	}
+	h.users = users
+	h.modTime = info.ModTime()
+	return users, nil
+}
+
+func parseHtpasswd(data []byte) (map[string]string, error) {
+	users := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		username, hash, ok := strings.Cut(line, ":")
+		if !ok || username == "" {
+			return nil, fmt.Errorf("invalid htpasswd entry on line %d", lineNumber)
+		}
+		if !supportedHash(hash) {
+			return nil, fmt.Errorf("unsupported password hash for %q on line %d: only bcrypt and SHA1 are supported", username, lineNumber)
+		}
+		users[username] = hash
+	}
+	return users, scanner.Err()
+}
+
+func supportedHash(hash string) bool {
+	return strings.HasPrefix(hash, "{SHA}") ||
+		strings.HasPrefix(hash, "$2y$") ||
+		strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$")
+}
+
+func checkPassword(hash string, password string) bool {
+	if encoded, ok := strings.CutPrefix(hash, "{SHA}"); ok {
+		sum := sha1.Sum([]byte(password))
+		expected := base64.StdEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(encoded), []byte(expected)) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
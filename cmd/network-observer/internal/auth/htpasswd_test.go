@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gotest.tools/v3/assert"
+)
+
+func TestHtpasswd(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NilError(t, err)
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	content := "# console users\n" +
+		"alice:" + string(hash) + "\n" +
+		// htpasswd -s bob password
+		"bob:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n"
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	htpasswd, err := NewHtpasswd(path, "skupper")
+	assert.NilError(t, err)
+	assert.Equal(t, htpasswd.Challenge(), `Basic realm="skupper"`)
+
+	authenticate := func(username, password string) (*User, error) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if username != "" {
+			r.SetBasicAuth(username, password)
+		}
+		return htpasswd.Authenticate(r)
+	}
+	user, err := authenticate("alice", "secret")
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "alice")
+	user, err = authenticate("bob", "password")
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "bob")
+	_, err = authenticate("alice", "password")
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+	_, err = authenticate("carol", "secret")
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+	_, err = authenticate("", "")
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+
+	// changes to the file are picked up
+	assert.NilError(t, os.WriteFile(path, []byte("carol:"+string(hash)+"\n"), 0o600))
+	later := time.Now().Add(time.Minute)
+	assert.NilError(t, os.Chtimes(path, later, later))
+	user, err = authenticate("carol", "secret")
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "carol")
+	_, err = authenticate("alice", "secret")
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+
+	assert.NilError(t, os.WriteFile(path, []byte("dave:$apr1$abc$def\n"), 0o600))
+	_, err = NewHtpasswd(path, "skupper")
+	assert.Error(t, err, `unsupported password hash for "dave" on line 1: only bcrypt and SHA1 are supported`)
+	_, err = NewHtpasswd(filepath.Join(t.TempDir(), "missing"), "skupper")
+	assert.ErrorContains(t, err, "error reading htpasswd file")
+}
+
+func TestMiddleware(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NilError(t, err)
+	path := filepath.Join(t.TempDir(), "htpasswd")
+	assert.NilError(t, os.WriteFile(path, []byte("alice:"+string(hash)+"\n"), 0o600))
+	htpasswd, err := NewHtpasswd(path, "skupper")
+	assert.NilError(t, err)
+
+	handler := Middleware(slog.Default(), htpasswd)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFromContext(r.Context())
+		assert.Assert(t, ok)
+		w.Write([]byte(user.Name))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, w.Code, http.StatusUnauthorized)
+	assert.Equal(t, w.Header().Get("WWW-Authenticate"), `Basic realm="skupper"`)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.SetBasicAuth("alice", "secret")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "alice")
+}
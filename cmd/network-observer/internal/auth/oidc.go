@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-jose/go-jose/v4"
+)
+
+const (
+	// minimum time between fetching keys for an unrecognised key ID
+	oidcKeyRefreshInterval = time.Minute
+)
+
+// oidcSigningAlgs are the algorithms tokens may be signed with.
+var oidcSigningAlgs = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+}
+
+type OIDCConfig struct {
+	// Issuer is the issuer URL of the OpenID provider, from which its
+	// configuration is discovered.
+	Issuer string
+	// ClientID is the audience tokens must have been issued for.
+	ClientID string
+	// UsernameClaim is the claim that names the user, by default
+	// preferred_username, falling back to sub.
+	UsernameClaim string
+	// GroupsClaim is the claim listing the user's groups, by default
+	// groups.
+	GroupsClaim string
+	HTTPClient  *http.Client
+}
+
+// OIDC authenticates requests bearing an ID token issued by an OpenID
+// Connect provider, verifying the token's signature against the keys the
+// provider publishes.
+type OIDC struct {
+	config   OIDCConfig
+	now      func() time.Time
+	keys     *oidcKeySet
+	verifier *oidc.IDTokenVerifier
+}
+
+var _ Authenticator = (*OIDC)(nil)
+
+func NewOIDC(config OIDCConfig) (*OIDC, error) {
+	if config.Issuer == "" {
+		return nil, errors.New("an OIDC issuer is required")
+	}
+	if config.ClientID == "" {
+		return nil, errors.New("an OIDC client ID is required")
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	o := &OIDC{
+		config: config,
+		now:    time.Now,
+	}
+	o.keys = &oidcKeySet{
+		issuer: config.Issuer,
+		client: config.HTTPClient,
+		now:    func() time.Time { return o.now() },
+	}
+	o.verifier = oidc.NewVerifier(config.Issuer, o.keys, &oidc.Config{
+		ClientID:             config.ClientID,
+		SupportedSigningAlgs: oidcSigningAlgs,
+		Now:                  func() time.Time { return o.now() },
+	})
+	return o, nil
+}
+
+func (o *OIDC) Challenge() string {
+	return "Bearer"
+}
+
+func (o *OIDC) Authenticate(r *http.Request) (*User, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, fmt.Errorf("%w: no bearer token", ErrUnauthenticated)
+	}
+	// failures to reach the provider are reported as such, rather than
+	// as invalid credentials, so that they are logged
+	var providerErr error
+	ctx := context.WithValue(r.Context(), providerErrKey{}, &providerErr)
+	idToken, err := o.verifier.Verify(ctx, token)
+	if providerErr != nil {
+		return nil, providerErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	return o.user(claims)
+}
+
+func (o *OIDC) user(claims map[string]any) (*User, error) {
+	var name string
+	if o.config.UsernameClaim != "" {
+		name, _ = claims[o.config.UsernameClaim].(string)
+	} else {
+		name, _ = claims["preferred_username"].(string)
+		if name == "" {
+			name, _ = claims["sub"].(string)
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: token has no username claim", ErrUnauthenticated)
+	}
+	user := &User{Name: name}
+	if groups, ok := claims[o.config.GroupsClaim].([]any); ok {
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				user.Groups = append(user.Groups, s)
+			}
+		}
+	}
+	return user, nil
+}
+
+type providerErrKey struct{}
+
+// oidcKeySet holds the signing keys published by an OpenID provider,
+// discovering and fetching them when a token names a key not yet
+// known. Keys are fetched without holding the lock, with concurrent
+// requests waiting on the same fetch, and at most once per
+// oidcKeyRefreshInterval.
+type oidcKeySet struct {
+	issuer string
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	jwksURI     string
+	keys        jose.JSONWebKeySet
+	lastRefresh time.Time
+	refreshing  chan struct{}
+}
+
+var _ oidc.KeySet = (*oidcKeySet)(nil)
+
+func (k *oidcKeySet) VerifySignature(ctx context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token, signatureAlgorithms())
+	if err != nil {
+		return nil, err
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, errors.New("token must have exactly one signature")
+	}
+	kid := jws.Signatures[0].Header.KeyID
+	keys, err := k.get(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if payload, err := jws.Verify(&key); err == nil {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("invalid token signature")
+}
+
+// get returns the signing keys with the given ID, refreshing the keys
+// if there are none.
+func (k *oidcKeySet) get(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	for {
+		k.mu.Lock()
+		if keys := k.lookup(kid); len(keys) > 0 {
+			k.mu.Unlock()
+			return keys, nil
+		}
+		if refreshing := k.refreshing; refreshing != nil {
+			k.mu.Unlock()
+			select {
+			case <-refreshing:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		if !k.lastRefresh.IsZero() && k.now().Sub(k.lastRefresh) < oidcKeyRefreshInterval {
+			k.mu.Unlock()
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		refreshing := make(chan struct{})
+		k.refreshing = refreshing
+		k.lastRefresh = k.now()
+		jwksURI := k.jwksURI
+		k.mu.Unlock()
+
+		jwksURI, keys, err := k.fetch(context.WithoutCancel(ctx), jwksURI)
+
+		k.mu.Lock()
+		if err == nil {
+			k.jwksURI = jwksURI
+			k.keys = keys
+		}
+		k.refreshing = nil
+		close(refreshing)
+		found := k.lookup(kid)
+		k.mu.Unlock()
+		if err != nil {
+			if providerErr, ok := ctx.Value(providerErrKey{}).(*error); ok {
+				*providerErr = err
+			}
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return found, nil
+	}
+}
+
+// lookup returns the known signing keys with the given ID. Tokens need
+// not name their key when the provider has only one. Must be called
+// with the lock held.
+func (k *oidcKeySet) lookup(kid string) []jose.JSONWebKey {
+	var keys []jose.JSONWebKey
+	for _, key := range k.keys.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if key.KeyID == kid || (kid == "" && len(k.keys.Keys) == 1) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// fetch discovers the provider's key set, if not already known, and
+// fetches the keys in it.
+func (k *oidcKeySet) fetch(ctx context.Context, jwksURI string) (string, jose.JSONWebKeySet, error) {
+	ctx = oidc.ClientContext(ctx, k.client)
+	if jwksURI == "" {
+		provider, err := oidc.NewProvider(ctx, k.issuer)
+		if err != nil {
+			return "", jose.JSONWebKeySet{}, fmt.Errorf("error discovering OIDC provider configuration: %w", err)
+		}
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := provider.Claims(&discovery); err != nil {
+			return "", jose.JSONWebKeySet{}, fmt.Errorf("error discovering OIDC provider configuration: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return "", jose.JSONWebKeySet{}, errors.New("OIDC provider configuration has no jwks_uri")
+		}
+		jwksURI = discovery.JWKSURI
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return "", jose.JSONWebKeySet{}, err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return "", jose.JSONWebKeySet{}, fmt.Errorf("error fetching OIDC provider keys: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", jose.JSONWebKeySet{}, fmt.Errorf("error fetching OIDC provider keys: %s returned %s", jwksURI, resp.Status)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return "", jose.JSONWebKeySet{}, fmt.Errorf("error fetching OIDC provider keys: %w", err)
+	}
+	return jwksURI, keys, nil
+}
+
+func signatureAlgorithms() []jose.SignatureAlgorithm {
+	var algs []jose.SignatureAlgorithm
+	for _, alg := range oidcSigningAlgs {
+		algs = append(algs, jose.SignatureAlgorithm(alg))
+	}
+	return algs
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type testProvider struct {
+	server  *httptest.Server
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	fetches int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	p := &testProvider{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.server.URL,
+			"jwks_uri": p.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.fetches++
+		b64 := base64.RawURLEncoding.EncodeToString
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+				{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+				{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": b64(rsaKey.N.Bytes()), "e": "AQAB"},
+			},
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *testProvider) token(t *testing.T, alg string, kid string, claims map[string]any) string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.NilError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NilError(t, err)
+	signed := b64(header) + "." + b64(payload)
+	var signature []byte
+	switch alg {
+	case "RS256":
+		digest := crypto.SHA256.New()
+		digest.Write([]byte(signed))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, p.rsaKey, crypto.SHA256, digest.Sum(nil))
+		assert.NilError(t, err)
+	case "ES256":
+		digest := crypto.SHA256.New()
+		digest.Write([]byte(signed))
+		r, s, err := ecdsa.Sign(rand.Reader, p.ecKey, digest.Sum(nil))
+		assert.NilError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(signature)
+}
+
+func TestOIDC(t *testing.T) {
+	provider := newTestProvider(t)
+	oidc, err := NewOIDC(OIDCConfig{Issuer: provider.server.URL, ClientID: "network-observer"})
+	assert.NilError(t, err)
+	now := time.Now()
+	oidc.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":                provider.server.URL,
+			"aud":                []string{"network-observer", "other"},
+			"sub":                "1234",
+			"preferred_username": "alice",
+			"groups":             []string{"team-a", "team-b"},
+			"exp":                now.Add(time.Hour).Unix(),
+			"iat":                now.Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	authenticate := func(token string) (*User, error) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return oidc.Authenticate(r)
+	}
+
+	user, err := authenticate(provider.token(t, "RS256", "rsa-1", claims(nil)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, user, &User{Name: "alice", Groups: []string{"team-a", "team-b"}})
+
+	user, err = authenticate(provider.token(t, "ES256", "ec-1", claims(map[string]any{"preferred_username": nil, "aud": "network-observer"})))
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "1234")
+	assert.Equal(t, provider.fetches, 1)
+
+	for name, token := range map[string]string{
+		"no token":           "",
+		"malformed":          "not.a.jwt.token",
+		"wrong audience":     provider.token(t, "RS256", "rsa-1", claims(map[string]any{"aud": "someone-else"})),
+		"wrong issuer":       provider.token(t, "RS256", "rsa-1", claims(map[string]any{"iss": "https://elsewhere"})),
+		"expired":            provider.token(t, "RS256", "rsa-1", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})),
+		"not yet valid":      provider.token(t, "RS256", "rsa-1", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})),
+		"no expiry":          provider.token(t, "RS256", "rsa-1", claims(map[string]any{"exp": nil})),
+		"algorithm mismatch": provider.token(t, "ES256", "rsa-1", claims(nil)),
+		"unsigned":           provider.token(t, "none", "rsa-1", claims(nil)),
+		"encryption key":     provider.token(t, "RS256", "enc-1", claims(nil)),
+		"unknown key":        provider.token(t, "RS256", "rsa-2", claims(nil)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticate(token)
+			assert.Assert(t, errors.Is(err, ErrUnauthenticated), err)
+		})
+	}
+	// unknown keys do not cause the provider to be asked again straight away
+	assert.Equal(t, provider.fetches, 1)
+	now = now.Add(oidcKeyRefreshInterval)
+	_, err = authenticate(provider.token(t, "RS256", "rsa-2", claims(nil)))
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+	assert.Equal(t, provider.fetches, 2)
+	now = now.Add(-oidcKeyRefreshInterval)
+
+	// a tampered token is rejected
+	token := provider.token(t, "RS256", "rsa-1", claims(nil))
+	forged := provider.token(t, "RS256", "rsa-1", claims(map[string]any{"preferred_username": "mallory"}))
+	_, err = authenticate(forged[:len(forged)-len(token)/3] + token[len(token)-len(token)/3:])
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+
+	_, err = NewOIDC(OIDCConfig{Issuer: provider.server.URL})
+	assert.Error(t, err, "an OIDC client ID is required")
+}
+
+func TestOIDCDiscoveryIssuer(t *testing.T) {
+	provider := newTestProvider(t)
+	// the provider's configuration is for an issuer without the slash
+	issuer := provider.server.URL + "/"
+	oidc, err := NewOIDC(OIDCConfig{Issuer: issuer, ClientID: "network-observer"})
+	assert.NilError(t, err)
+	token := provider.token(t, "RS256", "rsa-1", map[string]any{
+		"iss":                issuer,
+		"aud":                "network-observer",
+		"preferred_username": "alice",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	_, err = oidc.Authenticate(r)
+	assert.ErrorContains(t, err, "error discovering OIDC provider configuration")
+	assert.Assert(t, !errors.Is(err, ErrUnauthenticated))
+	assert.Equal(t, provider.fetches, 0)
+}
+
+func TestOIDCConcurrentKeyFetch(t *testing.T) {
+	provider := newTestProvider(t)
+	release := make(chan struct{})
+	handler := provider.server.Config.Handler
+	provider.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/keys" {
+			<-release
+		}
+		handler.ServeHTTP(w, r)
+	})
+	oidc, err := NewOIDC(OIDCConfig{Issuer: provider.server.URL, ClientID: "network-observer"})
+	assert.NilError(t, err)
+	token := provider.token(t, "RS256", "rsa-1", map[string]any{
+		"iss":                provider.server.URL,
+		"aud":                "network-observer",
+		"preferred_username": "alice",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	})
+	results := make(chan error)
+	for i := 0; i < 4; i++ {
+		go func() {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+			_, err := oidc.Authenticate(r)
+			results <- err
+		}()
+	}
+	// the keys are fetched without holding the lock, so they can be
+	// looked up while the fetch is in progress
+	time.Sleep(100 * time.Millisecond)
+	oidc.keys.mu.Lock()
+	assert.Assert(t, oidc.keys.refreshing != nil)
+	oidc.keys.mu.Unlock()
+	close(release)
+	for i := 0; i < 4; i++ {
+		assert.NilError(t, <-results)
+	}
+	// and concurrent requests wait on the same fetch
+	assert.Equal(t, provider.fetches, 1)
+}
+
+func TestOIDCGroupsClaim(t *testing.T) {
+	provider := newTestProvider(t)
+	oidc, err := NewOIDC(OIDCConfig{Issuer: provider.server.URL, ClientID: "network-observer", GroupsClaim: "roles"})
+	assert.NilError(t, err)
+	token := provider.token(t, "RS256", "rsa-1", map[string]any{
+		"iss":                provider.server.URL,
+		"aud":                "network-observer",
+		"preferred_username": "alice",
+		"groups":             []string{"team-a"},
+		"roles":              []string{"admin"},
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	user, err := oidc.Authenticate(r)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, user, &User{Name: "alice", Groups: []string{"admin"}})
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// how long the user for a token is remembered
+	openshiftCacheTTL = time.Minute
+	// tokens remembered beyond this many are discarded once expired
+	openshiftCacheSize = 1024
+)
+
+type OpenShiftConfig struct {
+	// APIServer is the URL of the OpenShift API server, such as
+	// https://kubernetes.default.svc.
+	APIServer  string
+	HTTPClient *http.Client
+}
+
+// OpenShift authenticates requests bearing an OpenShift OAuth access
+// token, by asking the API server who the token belongs to.
+type OpenShift struct {
+	config OpenShiftConfig
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedUser
+}
+
+type cachedUser struct {
+	user    *User
+	expires time.Time
+}
+
+var _ Authenticator = (*OpenShift)(nil)
+
+func NewOpenShift(config OpenShiftConfig) (*OpenShift, error) {
+	if config.APIServer == "" {
+		return nil, errors.New("an OpenShift API server URL is required")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	config.APIServer = strings.TrimSuffix(config.APIServer, "/")
+	return &OpenShift{
+		config: config,
+		now:    time.Now,
+		cache:  map[[sha256.Size]byte]cachedUser{},
+	}, nil
+}
+
+func (o *OpenShift) Challenge() string {
+	return "Bearer"
+}
+
+func (o *OpenShift) Authenticate(r *http.Request) (*User, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		if forwarded := r.Header.Get("X-Forwarded-Access-Token"); forwarded != "" {
+			token, ok = forwarded, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: no bearer token", ErrUnauthenticated)
+	}
+	key := sha256.Sum256([]byte(token))
+	if user, ok := o.cached(key); ok {
+		return user, nil
+	}
+	user, err := o.lookup(r, token)
+	if err != nil {
+		return nil, err
+	}
+	o.remember(key, user)
+	return user, nil
+}
+
+func (o *OpenShift) lookup(r *http.Request, token string) (*User, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, o.config.APIServer+"/apis/user.openshift.io/v1/users/~", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := o.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up OpenShift user: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("%w: token rejected by OpenShift", ErrUnauthenticated)
+	default:
+		return nil, fmt.Errorf("error looking up OpenShift user: %s", resp.Status)
+	}
+	var result struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Groups []string `json:"groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding OpenShift user: %w", err)
+	}
+	if result.Metadata.Name == "" {
+		return nil, fmt.Errorf("%w: token has no user", ErrUnauthenticated)
+	}
+	return &User{Name: result.Metadata.Name, Groups: result.Groups}, nil
+}
+
+func (o *OpenShift) cached(key [sha256.Size]byte) (*User, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entry, ok := o.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if o.now().After(entry.expires) {
+		delete(o.cache, key)
+		return nil, false
+	}
+	return entry.user, true
+}
+
+func (o *OpenShift) remember(key [sha256.Size]byte, user *User) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := o.now()
+	if len(o.cache) >= openshiftCacheSize {
+		for k, entry := range o.cache {
+			if now.After(entry.expires) {
+				delete(o.cache, k)
+			}
+		}
+	}
+	if len(o.cache) < openshiftCacheSize {
+		o.cache[key] = cachedUser{user: user, expires: now.Add(openshiftCacheTTL)}
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOpenShift(t *testing.T) {
+	lookups := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		if r.URL.Path != "/apis/user.openshift.io/v1/users/~" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer alice-token":
+			json.NewEncoder(w).Encode(map[string]any{
+				"metadata": map[string]string{"name": "alice"},
+				"groups":   []string{"team-a"},
+			})
+		case "Bearer broken-token":
+			http.Error(w, "oops", http.StatusInternalServerError)
+		default:
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		}
+	}))
+	defer api.Close()
+
+	openshift, err := NewOpenShift(OpenShiftConfig{APIServer: api.URL + "/"})
+	assert.NilError(t, err)
+	now := time.Now()
+	openshift.now = func() time.Time { return now }
+
+	request := func(header string, value string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return r
+	}
+
+	user, err := openshift.Authenticate(request("Authorization", "Bearer alice-token"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, user, &User{Name: "alice", Groups: []string{"team-a"}})
+	assert.Equal(t, lookups, 1)
+
+	// the user is remembered for the same token, however it is presented
+	user, err = openshift.Authenticate(request("X-Forwarded-Access-Token", "alice-token"))
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "alice")
+	assert.Equal(t, lookups, 1)
+
+	now = now.Add(openshiftCacheTTL + time.Second)
+	_, err = openshift.Authenticate(request("Authorization", "Bearer alice-token"))
+	assert.NilError(t, err)
+	assert.Equal(t, lookups, 2)
+
+	_, err = openshift.Authenticate(request("", ""))
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+	_, err = openshift.Authenticate(request("Authorization", "Bearer bob-token"))
+	assert.Assert(t, errors.Is(err, ErrUnauthenticated))
+	_, err = openshift.Authenticate(request("Authorization", "Bearer broken-token"))
+	assert.Assert(t, err != nil && !errors.Is(err, ErrUnauthenticated), err)
+	assert.Equal(t, lookups, 4)
+
+	_, err = NewOpenShift(OpenShiftConfig{})
+	assert.Error(t, err, "an OpenShift API server URL is required")
+}
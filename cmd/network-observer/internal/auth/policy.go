@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+)
+
+// wildcard matches any user, group, site or namespace
+const wildcard = "*"
+
+// Policy decides which sites a user may see. A user sees a site when any
+// rule that applies to them lists the site's name or namespace. Users to
+// whom no rule applies see no sites.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule grants the users and members of the groups it lists access to the
+// sites it lists, and to the sites in the namespaces it lists.
+type Rule struct {
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Sites      []string `json:"sites,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// LoadPolicy reads a Policy from a YAML or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading authorization policy: %w", err)
+	}
+	var policy Policy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return nil, fmt.Errorf("error parsing authorization policy: %w", err)
+	}
+	for i, rule := range policy.Rules {
+		if len(rule.Users) == 0 && len(rule.Groups) == 0 {
+			return nil, fmt.Errorf("authorization policy rule %d applies to no users or groups", i)
+		}
+		if len(rule.Sites) == 0 && len(rule.Namespaces) == 0 {
+			return nil, fmt.Errorf("authorization policy rule %d grants access to no sites or namespaces", i)
+		}
+	}
+	return &policy, nil
+}
+
+// Allows returns true if the user may see the site with the given name in
+// the given namespace. A nil Policy allows everything.
+func (p *Policy) Allows(user *User, site string, namespace string) bool {
+	if p == nil {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	for _, rule := range p.Rules {
+		if rule.appliesTo(user) && rule.grants(site, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAll returns true if the user may see every site, present or
+// future: a rule that applies to them grants all sites or namespaces. A
+// nil Policy allows everything.
+func (p *Policy) AllowsAll(user *User) bool {
+	if p == nil {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	for _, rule := range p.Rules {
+		if rule.appliesTo(user) && (slices.Contains(rule.Sites, wildcard) || slices.Contains(rule.Namespaces, wildcard)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) appliesTo(user *User) bool {
+	if matches(r.Users, user.Name) {
+		return true
+	}
+	for _, group := range user.Groups {
+		if matches(r.Groups, group) {
+			return true
+		}
+	}
+	return slices.Contains(r.Groups, wildcard)
+}
+
+func (r Rule) grants(site string, namespace string) bool {
+	return matches(r.Sites, site) || (namespace != "" && matches(r.Namespaces, namespace)) || slices.Contains(r.Namespaces, wildcard)
+}
+
+func matches(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, wildcard)
+}
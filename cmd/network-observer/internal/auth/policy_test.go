@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPolicyAllows(t *testing.T) {
+	policy := &Policy{Rules: []Rule{
+		{Users: []string{"alice"}, Sites: []string{"west"}},
+		{Groups: []string{"team-b"}, Namespaces: []string{"east"}},
+		{Users: []string{"admin"}, Namespaces: []string{"*"}},
+		{Groups: []string{"*"}, Sites: []string{"public"}},
+	}}
+	testcases := []struct {
+		User      *User
+		Site      string
+		Namespace string
+		Expected  bool
+	}{
+		{User: &User{Name: "alice"}, Site: "west", Namespace: "west", Expected: true},
+		{User: &User{Name: "alice"}, Site: "east", Namespace: "east", Expected: false},
+		{User: &User{Name: "bob", Groups: []string{"team-b"}}, Site: "east-1", Namespace: "east", Expected: true},
+		{User: &User{Name: "bob", Groups: []string{"team-b"}}, Site: "west", Namespace: "west", Expected: false},
+		{User: &User{Name: "admin"}, Site: "anything", Namespace: "", Expected: true},
+		{User: &User{Name: "carol"}, Site: "public", Expected: true},
+		{User: &User{Name: "carol"}, Site: "west", Namespace: "west", Expected: false},
+		{User: nil, Site: "public", Expected: false},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, policy.Allows(tc.User, tc.Site, tc.Namespace), tc.Expected, "%v %s/%s", tc.User, tc.Namespace, tc.Site)
+	}
+	var unrestricted *Policy
+	assert.Assert(t, unrestricted.Allows(nil, "west", "west"))
+}
+
+func TestPolicyAllowsAll(t *testing.T) {
+	policy := &Policy{Rules: []Rule{
+		{Users: []string{"alice"}, Namespaces: []string{"west"}},
+		{Users: []string{"admin"}, Namespaces: []string{"*"}},
+		{Groups: []string{"ops"}, Sites: []string{"*"}},
+	}}
+	assert.Assert(t, !policy.AllowsAll(&User{Name: "alice"}))
+	assert.Assert(t, policy.AllowsAll(&User{Name: "admin"}))
+	assert.Assert(t, policy.AllowsAll(&User{Name: "bob", Groups: []string{"ops"}}))
+	assert.Assert(t, !policy.AllowsAll(nil))
+	var unrestricted *Policy
+	assert.Assert(t, unrestricted.AllowsAll(nil))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "policy.yaml")
+		assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	policy, err := LoadPolicy(write(`
+rules:
+- users: [alice]
+  sites: [west]
+- groups: [team-b]
+  namespaces: [east]
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, policy, &Policy{Rules: []Rule{
+		{Users: []string{"alice"}, Sites: []string{"west"}},
+		{Groups: []string{"team-b"}, Namespaces: []string{"east"}},
+	}})
+
+	testcases := map[string]string{
+		"rules:\n- sites: [west]\n":                          "authorization policy rule 0 applies to no users or groups",
+		"rules:\n- users: [a]\n  sites: [b]\n- users: [a]\n": "authorization policy rule 1 grants access to no sites or namespaces",
+	}
+	for content, expected := range testcases {
+		_, err := LoadPolicy(write(content))
+		assert.Error(t, err, expected)
+	}
+	_, err = LoadPolicy(write("rules:\n- user: [alice]\n"))
+	assert.ErrorContains(t, err, "error parsing authorization policy")
+	_, err = LoadPolicy(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "error reading authorization policy")
+}
@@ -32,6 +32,7 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 		session:        sessionCtr,
 		discovery:      eventsource.NewDiscovery(sessionCtr, eventsource.DiscoveryOptions{}),
 		sources:        make(map[string]eventSource),
+		flowStores:     make(map[string]store.Interface),
 		events:         make(chan changeEvent, 1024),
 		purgeQueue:     make(chan store.SourceRef, 8),
 		recordRouting:  make(eventsource.RecordStoreMap),
@@ -63,6 +64,8 @@ type Collector struct {
 	logger        *slog.Logger
 	flowRecordTTL time.Duration
 	flowLogging   func(vanflow.RecordMessage)
+	flowArchive   func([]store.Entry)
+	flowExporter  FlowExporter
 
 	session   session.Container
 	discovery *eventsource.Discovery
@@ -70,6 +73,12 @@ type Collector struct {
 	mu      sync.Mutex
 	sources map[string]eventSource
 
+	// flowStores holds the flows of each source by source ID, for
+	// GetRecord. It is guarded by its own lock as records are resolved
+	// while handleForgotten holds mu, waiting on the record handlers.
+	flowsMu    sync.RWMutex
+	flowStores map[string]store.Interface
+
 	Records       store.Interface
 	graph         *graph
 	recordRouting eventsource.RecordStoreMap
@@ -79,8 +88,9 @@ type Collector struct {
 	pairManager    *pairManager
 	metricsAdaptor *opmetrics.Adaptor
 
-	events     chan changeEvent
-	purgeQueue chan store.SourceRef
+	events        chan changeEvent
+	purgeQueue    chan store.SourceRef
+	subscriptions subscriptions
 
 	metrics metrics
 }
@@ -94,6 +104,27 @@ func (c *Collector) GetGraph() Graph {
 	return c.graph
 }
 
+// SetFlowArchive sets a function to receive the ConnectionRecord and
+// RequestRecord entries that are about to be purged once their flows have
+// outlived the flow record TTL. The flows can still be read from the
+// records when it is called. It must be set before Run.
+func (c *Collector) SetFlowArchive(archive func(expired []store.Entry)) {
+	c.flowArchive = archive
+}
+
+// FlowExporter receives connections and requests once their flows have
+// been reconciled and terminated.
+type FlowExporter interface {
+	ExportConnection(connection ConnectionRecord, flow vanflow.TransportBiflowRecord)
+	ExportRequest(request RequestRecord, flow vanflow.AppBiflowRecord)
+}
+
+// SetFlowExporter sets a FlowExporter for terminated flows. It must be set
+// before Run.
+func (c *Collector) SetFlowExporter(exporter FlowExporter) {
+	c.flowExporter = exporter
+}
+
 func (c *Collector) Run(ctx context.Context) error {
 	c.session.Start(ctx)
 	g, ctx := errgroup.WithContext(ctx)
@@ -273,6 +304,7 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 }
 
 func (c *Collector) handleStoreAdd(e store.Entry) {
+	c.subscriptions.publish(RecordEvent{Type: RecordAdded, Entry: e})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
@@ -287,6 +319,7 @@ func (c *Collector) handleStoreAdd(e store.Entry) {
 }
 
 func (c *Collector) handleStoreChange(p, e store.Entry) {
+	c.subscriptions.publish(RecordEvent{Type: RecordModified, Entry: e})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
@@ -300,6 +333,7 @@ func (c *Collector) handleStoreChange(p, e store.Entry) {
 	}
 }
 func (c *Collector) handleStoreDelete(e store.Entry) {
+	c.subscriptions.publish(RecordEvent{Type: RecordDeleted, Entry: e})
 	switch e.Record.(type) {
 	case RequestRecord:
 		return
@@ -350,37 +384,15 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 			eventsource.FromSourceAddress(),
 		}
 
-		router := eventsource.RecordStoreRouter{
-			Stores: c.recordRouting,
-			Source: sourceRef(source),
-		}
-
 		switch source.Type {
 		case "CONTROLLER":
 			addresses = append(addresses, eventsource.FromSourceAddressHeartbeats()) // listen to .heartbeats
 		case "ROUTER":
 			addresses = append(addresses, eventsource.FromSourceAddressFlows()) // listen to .flows
-			sourceCtr.manager = newConnectionmanager(
-				ctx,
-				c.logger.With(slog.String("eventsource", fmt.Sprintf("%d/%s", source.Version, source.ID))),
-				sourceRef(source),
-				c.Records,
-				c.graph,
-				c.metrics,
-				c.flowRecordTTL,
-			)
-
-			// route flow records to source-specific stores
-			router.Stores = maps.Clone(router.Stores)
-			for _, typ := range flowRecordTypes {
-				router.Stores[typ.String()] = sourceCtr.manager.flows
-			}
-		}
-
-		if c.flowLogging != nil {
-			client.OnRecord(c.flowLogging)
 		}
-		client.OnRecord(router.Route)
+		var handler eventsource.RecordMessageHandler
+		handler, sourceCtr.manager = c.recordHandler(ctx, source)
+		client.OnRecord(handler)
 
 		for _, address := range addresses {
 			client.Listen(ctx, address)
@@ -406,6 +418,64 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 	}
 }
 
+// recordHandler returns the handler for the records of a source, routing
+// them to their stores before logging them so that the flow logger can
+// resolve them from the stores. Flow records from routers are routed to a
+// store of their own, held by the returned connectionManager.
+func (c *Collector) recordHandler(ctx context.Context, source eventsource.Info) (eventsource.RecordMessageHandler, *connectionManager) {
+	router := eventsource.RecordStoreRouter{
+		Stores: c.recordRouting,
+		Source: sourceRef(source),
+	}
+	var manager *connectionManager
+	if source.Type == "ROUTER" {
+		manager = newConnectionmanager(
+			ctx,
+			c.logger.With(slog.String("eventsource", fmt.Sprintf("%d/%s", source.Version, source.ID))),
+			sourceRef(source),
+			c.Records,
+			c.graph,
+			c.metrics,
+			c.flowRecordTTL,
+			c.flowArchive,
+			c.flowExporter,
+		)
+
+		// route flow records to source-specific stores
+		router.Stores = maps.Clone(router.Stores)
+		for _, typ := range flowRecordTypes {
+			router.Stores[typ.String()] = manager.flows
+		}
+		c.flowsMu.Lock()
+		c.flowStores[source.ID] = manager.flows
+		c.flowsMu.Unlock()
+	}
+	if c.flowLogging == nil {
+		return router.Route, manager
+	}
+	return func(msg vanflow.RecordMessage) {
+		router.Route(msg)
+		c.flowLogging(msg)
+	}, manager
+}
+
+// GetRecord returns the record with the ID from the Records or, for flow
+// records, from the flows of the sources. Unlike the Records it is safe to
+// call while handling the records of a source.
+func (c *Collector) GetRecord(id string) (store.Entry, bool) {
+	if entry, ok := c.Records.Get(id); ok {
+		return entry, true
+	}
+	c.flowsMu.RLock()
+	defer c.flowsMu.RUnlock()
+	for _, flows := range c.flowStores {
+		if entry, ok := flows.Get(id); ok {
+			return entry, true
+		}
+	}
+	return store.Entry{}, false
+}
+
 func (c *Collector) handleForgotten(source eventsource.Info) {
 	c.logger.Info("handling forgotten source", slog.String("id", source.ID))
 	c.mu.Lock()
@@ -418,6 +488,9 @@ func (c *Collector) handleForgotten(source eventsource.Info) {
 		}
 		delete(c.sources, source.ID)
 	}
+	c.flowsMu.Lock()
+	delete(c.flowStores, source.ID)
+	c.flowsMu.Unlock()
 	c.purgeQueue <- sourceRef(source)
 }
 
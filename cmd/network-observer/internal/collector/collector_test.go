@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"gotest.tools/v3/assert"
+)
+
+func TestFlowLoggingResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	rules, err := flowlog.ParseRules([]byte(`
+rules:
+- priority: 1
+  recordTypes: [TransportBiflowRecord, AppBiflowRecord]
+  match:
+    routingKey: backend
+    site: west
+  sample:
+    strategy: all
+- priority: 2
+  sample:
+    strategy: none
+`))
+	assert.Assert(t, err)
+	var logged []string
+	handler := flowlog.NewHandler(ctx, func(msg string, args ...any) {
+		logged = append(logged, msg)
+	}, rules)
+
+	c := New(tlog, session.NewMockContainerFactory(), prometheus.NewRegistry(), time.Minute, handler.Handle)
+	handler.SetResolver(flowlog.StoreResolver(c.GetRecord))
+	handle, _ := c.recordHandler(ctx, eventsource.Info{ID: "router-1", Version: 1, Type: "ROUTER"})
+
+	handle(vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1"), Parent: ptrTo("router-1"), Address: ptrTo("backend"), Protocol: ptrTo("tcp")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-2"), Parent: ptrTo("router-1"), Address: ptrTo("frontend"), Protocol: ptrTo("tcp")},
+	}})
+	assert.Equal(t, len(logged), 0)
+
+	// a request is resolved through the connection in the same message
+	handle(vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-1"), Parent: ptrTo("listener-1")},
+		vanflow.AppBiflowRecord{BaseRecord: vanflow.NewBase("request-1"), Parent: ptrTo("flow-1"), Protocol: ptrTo("http1")},
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-2"), Parent: ptrTo("listener-2")},
+	}})
+	assert.Equal(t, len(logged), 2)
+
+	// partial updates and later requests are resolved through the stored
+	// connection
+	handle(vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-1"), Octets: ptrTo(uint64(64))},
+		vanflow.AppBiflowRecord{BaseRecord: vanflow.NewBase("request-2"), Parent: ptrTo("flow-1")},
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-2"), Octets: ptrTo(uint64(64))},
+	}})
+	assert.Equal(t, len(logged), 4)
+}
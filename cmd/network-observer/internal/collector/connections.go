@@ -30,7 +30,9 @@ type connectionManager struct {
 	requestMetricsCache   map[labelSet]appMetrics
 	transportMetricsCache map[labelSet]transportMetrics
 
-	ttl time.Duration
+	ttl      time.Duration
+	archive  func([]store.Entry)
+	exporter FlowExporter
 
 	transportProcessingTime prometheus.Observer
 	appProcessingTime       prometheus.Observer
@@ -47,7 +49,7 @@ type connectionManager struct {
 	routerCache     map[string]routerAttrs
 }
 
-func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration) *connectionManager {
+func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration, archive func([]store.Entry), exporter FlowExporter) *connectionManager {
 	m := &connectionManager{
 		logger:                  log,
 		records:                 records,
@@ -56,6 +58,8 @@ func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.So
 		idp:                     newStableIdentityProvider(),
 		metrics:                 metrics,
 		ttl:                     ttl,
+		archive:                 archive,
+		exporter:                exporter,
 		transportProcessingTime: metrics.internal.flowProcessingTime.WithLabelValues(vanflow.TransportBiflowRecord{}.GetTypeMeta().String()),
 		appProcessingTime:       metrics.internal.flowProcessingTime.WithLabelValues(vanflow.AppBiflowRecord{}.GetTypeMeta().String()),
 		transportFlows: &keyedLRUCache[transportState]{
@@ -114,6 +118,7 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 		if terminated {
 			state.Terminated = true
 			metrics.closed.Inc()
+			c.exportConnection(record)
 		}
 	}
 	if !state.LatencySet && record.Latency != nil && record.LatencyReverse != nil {
@@ -161,6 +166,7 @@ func (c *connectionManager) handleAppFlow(record vanflow.AppBiflowRecord) {
 				"method": normalizeHTTPMethod(record.Method),
 				"code":   normalizeHTTPResponseClass(record.Result),
 			}).Inc()
+			c.exportRequest(record)
 		}
 	}
 	c.appFlows.Push(record.ID, state)
@@ -184,6 +190,32 @@ func (c *connectionManager) handleChange(p, e store.Entry) {
 	}
 }
 
+func (c *connectionManager) exportConnection(record vanflow.TransportBiflowRecord) {
+	if c.exporter == nil {
+		return
+	}
+	entry, ok := c.records.Get(record.ID)
+	if !ok {
+		return
+	}
+	if connection, ok := entry.Record.(ConnectionRecord); ok {
+		c.exporter.ExportConnection(connection, record)
+	}
+}
+
+func (c *connectionManager) exportRequest(record vanflow.AppBiflowRecord) {
+	if c.exporter == nil {
+		return
+	}
+	entry, ok := c.records.Get(record.ID)
+	if !ok {
+		return
+	}
+	if request, ok := entry.Record.(RequestRecord); ok {
+		c.exporter.ExportRequest(request, record)
+	}
+}
+
 func normalizeHTTPMethod(method *string) string {
 	m := dref(method)
 	switch {
@@ -512,64 +544,38 @@ func (c *connectionManager) run(ctx context.Context) {
 				defer func() {
 					reconcileEvictions.Observe(time.Since(start).Seconds())
 				}()
-				{
-					terminated := map[string]struct{}{}
-					stale := map[string]struct{}{}
-					flowStates := c.transportFlows.Items()
-					for i := len(flowStates) - 1; i >= 0; i-- {
-						state := flowStates[i]
-						if !state.LastSeen.Before(time.Now().Add(-1 * c.ttl)) {
-							break
-						}
-						if state.Terminated {
-							terminated[state.ID] = struct{}{}
-						} else {
-							stale[state.ID] = struct{}{}
-						}
-					}
-
-					if ct := len(terminated); ct > 0 {
-						c.logger.Debug("purging terminated transport flows", slog.Int("count", ct))
-						for id := range terminated {
-							c.flows.Delete(id)
-							c.records.Delete(id)
-						}
-					}
-					if ct := len(stale); ct > 0 {
-						c.logger.Info("purging stale transport flows", slog.Int("count", ct))
-						for id := range stale {
-							c.flows.Delete(id)
-							c.records.Delete(id)
-						}
+				now := time.Now()
+				transportTerminated, transportStale := expiredFlows(c.transportFlows.Items(), now.Add(-1*c.ttl))
+				appTerminated, appStale := expiredFlows(c.appFlows.Items(), now.Add(-1*c.ttl))
+
+				// archive before any flow is deleted, requests need their
+				// transport flow as well as their own
+				c.archiveExpired(transportTerminated, transportStale, appTerminated, appStale)
+
+				if ct := len(transportTerminated); ct > 0 {
+					c.logger.Debug("purging terminated transport flows", slog.Int("count", ct))
+					for _, id := range transportTerminated {
+						c.flows.Delete(id)
+						c.records.Delete(id)
 					}
 				}
-				{
-					terminated := map[string]struct{}{}
-					stale := map[string]struct{}{}
-					flowStates := c.appFlows.Items()
-					for i := len(flowStates) - 1; i >= 0; i-- {
-						state := flowStates[i]
-						if !state.LastSeen.Before(time.Now().Add(-1 * c.ttl)) {
-							break
-						}
-						if state.Terminated {
-							terminated[state.ID] = struct{}{}
-						} else {
-							stale[state.ID] = struct{}{}
-						}
+				if ct := len(transportStale); ct > 0 {
+					c.logger.Info("purging stale transport flows", slog.Int("count", ct))
+					for _, id := range transportStale {
+						c.flows.Delete(id)
+						c.records.Delete(id)
 					}
-
-					if ct := len(terminated); ct > 0 {
-						c.logger.Debug("purging terminated app flows", slog.Int("count", ct))
-						for id := range terminated {
-							c.flows.Delete(id)
-						}
+				}
+				if ct := len(appTerminated); ct > 0 {
+					c.logger.Debug("purging terminated app flows", slog.Int("count", ct))
+					for _, id := range appTerminated {
+						c.flows.Delete(id)
 					}
-					if ct := len(stale); ct > 0 {
-						c.logger.Info("purging stale app flows", slog.Int("count", ct))
-						for id := range stale {
-							c.flows.Delete(id)
-						}
+				}
+				if ct := len(appStale); ct > 0 {
+					c.logger.Info("purging stale app flows", slog.Int("count", ct))
+					for _, id := range appStale {
+						c.flows.Delete(id)
 					}
 				}
 			}()
@@ -861,6 +867,54 @@ type transportState struct {
 	LastSeen  time.Time
 }
 
+func (s appState) lifecycle() (string, time.Time, bool) {
+	return s.ID, s.LastSeen, s.Terminated
+}
+
+func (s transportState) lifecycle() (string, time.Time, bool) {
+	return s.ID, s.LastSeen, s.Terminated
+}
+
+type flowLifecycle interface {
+	lifecycle() (id string, lastSeen time.Time, terminated bool)
+}
+
+// expiredFlows returns the IDs of terminated and stale flows last seen
+// before cutoff, given states ordered least recently seen last.
+func expiredFlows[T flowLifecycle](states []T, cutoff time.Time) (terminated []string, stale []string) {
+	for i := len(states) - 1; i >= 0; i-- {
+		id, lastSeen, isTerminated := states[i].lifecycle()
+		if !lastSeen.Before(cutoff) {
+			break
+		}
+		if isTerminated {
+			terminated = append(terminated, id)
+		} else {
+			stale = append(stale, id)
+		}
+	}
+	return terminated, stale
+}
+
+// archiveExpired passes the ConnectionRecords and RequestRecords for the
+// expired flows to the archive, if there is one.
+func (c *connectionManager) archiveExpired(ids ...[]string) {
+	if c.archive == nil {
+		return
+	}
+	var expired []store.Entry
+	for _, set := range ids {
+		for _, id := range set {
+			if entry, ok := c.records.Get(id); ok {
+				expired = append(expired, entry)
+			}
+		}
+	}
+	if len(expired) > 0 {
+		c.archive(expired)
+	}
+}
+
 type keyedLRUCache[T any] struct {
 	mu   sync.Mutex
 	byID map[string]*list.Element
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"sync"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// subscriptionBufferSize is the number of events a subscriber may fall
+// behind by before its subscription is ended.
+const subscriptionBufferSize = 256
+
+// RecordEventType describes how a record in the store changed.
+type RecordEventType string
+
+const (
+	RecordAdded    RecordEventType = "ADDED"
+	RecordModified RecordEventType = "MODIFIED"
+	RecordDeleted  RecordEventType = "DELETED"
+)
+
+// RecordEvent is a change to a record in the collector's store.
+type RecordEvent struct {
+	Type  RecordEventType
+	Entry store.Entry
+}
+
+// Subscribe returns a channel receiving every change made to records of
+// the given types in the store, or to all records when no types are given,
+// until the context is cancelled. Subscribers must keep up: the channel is
+// closed early when a subscriber falls too far behind, and it is left to
+// the subscriber to start again from the current state.
+func (c *Collector) Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan RecordEvent {
+	return c.subscriptions.subscribe(ctx, types...)
+}
+
+type subscriptions struct {
+	mu sync.Mutex
+	// subscribers holds the record types each subscriber receives changes
+	// to, nil for all of them
+	subscribers map[chan RecordEvent]map[vanflow.TypeMeta]struct{}
+}
+
+func (s *subscriptions) subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan RecordEvent {
+	events := make(chan RecordEvent, subscriptionBufferSize)
+	var typeSet map[vanflow.TypeMeta]struct{}
+	if len(types) > 0 {
+		typeSet = make(map[vanflow.TypeMeta]struct{}, len(types))
+		for _, t := range types {
+			typeSet[t] = struct{}{}
+		}
+	}
+	s.mu.Lock()
+	if s.subscribers == nil {
+		s.subscribers = make(map[chan RecordEvent]map[vanflow.TypeMeta]struct{})
+	}
+	s.subscribers[events] = typeSet
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.unsubscribe(events)
+	}()
+	return events
+}
+
+func (s *subscriptions) unsubscribe(events chan RecordEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[events]; ok {
+		delete(s.subscribers, events)
+		close(events)
+	}
+}
+
+// publish sends the event to every subscriber of its record type without
+// blocking, ending the subscriptions of any that are not keeping up.
+func (s *subscriptions) publish(event RecordEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var typeMeta vanflow.TypeMeta
+	if event.Entry.Record != nil {
+		typeMeta = event.Entry.Record.GetTypeMeta()
+	}
+	for events, types := range s.subscribers {
+		if _, ok := types[typeMeta]; types != nil && !ok {
+			continue
+		}
+		select {
+		case events <- event:
+		default:
+			delete(s.subscribers, events)
+			close(events)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestSubscriptions(t *testing.T) {
+	var subs subscriptions
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slow := subs.subscribe(ctx)
+	fast := subs.subscribe(ctx)
+
+	site := store.Entry{Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1")}}
+	for i := 0; i < subscriptionBufferSize; i++ {
+		subs.publish(RecordEvent{Type: RecordModified, Entry: site})
+		event := <-fast
+		assert.Equal(t, event.Type, RecordModified)
+		assert.Equal(t, event.Entry.Record.Identity(), "s1")
+	}
+	// the slow subscriber has fallen behind and is dropped
+	subs.publish(RecordEvent{Type: RecordDeleted, Entry: site})
+	assert.Equal(t, (<-fast).Type, RecordDeleted)
+	for i := 0; i < subscriptionBufferSize; i++ {
+		<-slow
+	}
+	_, ok := <-slow
+	assert.Assert(t, !ok)
+
+	cancel()
+	_, ok = <-fast
+	assert.Assert(t, !ok)
+}
+
+func TestSubscriptionTypes(t *testing.T) {
+	var subs subscriptions
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sites := subs.subscribe(ctx, vanflow.SiteRecord{}.GetTypeMeta())
+
+	subs.publish(RecordEvent{Type: RecordAdded, Entry: store.Entry{Record: vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1")}}})
+	subs.publish(RecordEvent{Type: RecordAdded, Entry: store.Entry{Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1")}}})
+	event := <-sites
+	assert.Equal(t, event.Entry.Record.Identity(), "s1")
+	assert.Equal(t, len(sites), 0)
+}
@@ -0,0 +1,160 @@
+package flowlog
+
+import (
+	"strconv"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// maxParentDepth bounds how far a StoreResolver follows the parents of a
+// record: a request, its connection, the listener, the router and the site.
+const maxParentDepth = 5
+
+// Attributes of a record that rules can be filtered by.
+type Attributes struct {
+	// RoutingKey is the address of the listener or connector the record
+	// belongs to.
+	RoutingKey string
+	// SiteID and SiteName identify the site the record belongs to.
+	SiteID   string
+	SiteName string
+	// Protocol of the listener, connector or flow.
+	Protocol string
+	// Error is set for flows that report an error, and for requests
+	// with an HTTP error status.
+	Error bool
+}
+
+// AttributeFilter matches records by their attributes. Empty fields match
+// any value.
+type AttributeFilter struct {
+	RoutingKey string
+	// Site matches either the ID or the name of the site.
+	Site     string
+	Protocol string
+	Error    *bool
+}
+
+// Matches returns true when the attributes match every field set in the
+// filter.
+func (f *AttributeFilter) Matches(attrs Attributes) bool {
+	if f.RoutingKey != "" && f.RoutingKey != attrs.RoutingKey {
+		return false
+	}
+	if f.Site != "" && f.Site != attrs.SiteID && f.Site != attrs.SiteName {
+		return false
+	}
+	if f.Protocol != "" && f.Protocol != attrs.Protocol {
+		return false
+	}
+	if f.Error != nil && *f.Error != attrs.Error {
+		return false
+	}
+	return true
+}
+
+// Resolver determines the attributes of a record.
+type Resolver func(record vanflow.Record) Attributes
+
+// RecordAttributes is a Resolver that only knows the attributes held by
+// the record itself.
+func RecordAttributes(record vanflow.Record) Attributes {
+	attrs, _ := ownAttributes(record)
+	return attrs
+}
+
+// Lookup returns a stored record by ID, as store.Interface.Get does.
+type Lookup func(id string) (store.Entry, bool)
+
+// StoreResolver returns a Resolver that completes the attributes of a
+// record with those of the records it belongs to, as returned by lookup.
+// Vanflow records are sent as partial updates, so the attributes of the
+// stored version of the record are used as well. Flows are kept apart
+// from the other records by the collector, so the lookup must find both.
+func StoreResolver(lookup Lookup) Resolver {
+	return func(record vanflow.Record) Attributes {
+		attrs, parent := ownAttributes(record)
+		if entry, ok := lookup(record.Identity()); ok {
+			stored, storedParent := ownAttributes(entry.Record)
+			attrs = merge(attrs, stored)
+			if parent == "" {
+				parent = storedParent
+			}
+		}
+		for depth := 0; parent != "" && depth < maxParentDepth; depth++ {
+			entry, ok := lookup(parent)
+			if !ok {
+				break
+			}
+			var related Attributes
+			related, parent = ownAttributes(entry.Record)
+			// errors are only those of the record itself
+			related.Error = false
+			attrs = merge(attrs, related)
+		}
+		return attrs
+	}
+}
+
+// merge fills the attributes not known yet from another set.
+func merge(attrs Attributes, other Attributes) Attributes {
+	if attrs.RoutingKey == "" {
+		attrs.RoutingKey = other.RoutingKey
+	}
+	if attrs.SiteID == "" {
+		attrs.SiteID = other.SiteID
+		attrs.SiteName = other.SiteName
+	}
+	if attrs.Protocol == "" {
+		attrs.Protocol = other.Protocol
+	}
+	attrs.Error = attrs.Error || other.Error
+	return attrs
+}
+
+// ownAttributes returns the attributes held by the record itself, along
+// with the ID of the record it belongs to.
+func ownAttributes(record vanflow.Record) (Attributes, string) {
+	var attrs Attributes
+	switch r := record.(type) {
+	case vanflow.SiteRecord:
+		attrs.SiteID = r.ID
+		attrs.SiteName = dref(r.Name)
+		return attrs, ""
+	case vanflow.RouterRecord:
+		return attrs, dref(r.Parent)
+	case vanflow.LinkRecord:
+		return attrs, dref(r.Parent)
+	case vanflow.RouterAccessRecord:
+		return attrs, dref(r.Parent)
+	case vanflow.ProcessRecord:
+		return attrs, dref(r.Parent)
+	case vanflow.ListenerRecord:
+		attrs.RoutingKey = dref(r.Address)
+		attrs.Protocol = dref(r.Protocol)
+		return attrs, dref(r.Parent)
+	case vanflow.ConnectorRecord:
+		attrs.RoutingKey = dref(r.Address)
+		attrs.Protocol = dref(r.Protocol)
+		return attrs, dref(r.Parent)
+	case vanflow.TransportBiflowRecord:
+		attrs.Error = r.ErrorListener != nil || r.ErrorConnector != nil
+		return attrs, dref(r.Parent)
+	case vanflow.AppBiflowRecord:
+		attrs.Protocol = dref(r.Protocol)
+		if status, err := strconv.Atoi(dref(r.Result)); err == nil {
+			attrs.Error = status >= 400
+		}
+		return attrs, dref(r.Parent)
+	}
+	return attrs, ""
+}
+
+func dref[T any](ptr *T) T {
+	var out T
+	if ptr != nil {
+		out = *ptr
+	}
+	return out
+}
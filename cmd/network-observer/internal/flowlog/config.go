@@ -0,0 +1,205 @@
+package flowlog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"sigs.k8s.io/yaml"
+)
+
+// Names of the sampling strategies in a rules file.
+const (
+	StrategyAll               = "all"
+	StrategyNone              = "none"
+	StrategyRateLimited       = "rateLimited"
+	StrategyTransportFlowHash = "transportFlowHash"
+)
+
+// recordTypes are the record types a rules file can refer to by name.
+var recordTypes = []vanflow.Record{
+	vanflow.SiteRecord{},
+	vanflow.RouterRecord{},
+	vanflow.LinkRecord{},
+	vanflow.ListenerRecord{},
+	vanflow.ConnectorRecord{},
+	vanflow.ProcessRecord{},
+	vanflow.LogRecord{},
+	vanflow.RouterAccessRecord{},
+	vanflow.TransportBiflowRecord{},
+	vanflow.AppBiflowRecord{},
+}
+
+// RulesConfig is the declarative form of a set of Rules, as read from a
+// rules file.
+type RulesConfig struct {
+	Rules []RuleConfig `json:"rules"`
+}
+
+// RuleConfig is the declarative form of a Rule. A rule with no record
+// types applies to records of every type.
+type RuleConfig struct {
+	Priority    int             `json:"priority,omitempty"`
+	RecordTypes []string        `json:"recordTypes,omitempty"`
+	Match       *MatchConfig    `json:"match,omitempty"`
+	Sample      *StrategyConfig `json:"sample"`
+}
+
+// MatchConfig restricts a rule to records with the given attributes.
+type MatchConfig struct {
+	RoutingKey string `json:"routingKey,omitempty"`
+	Site       string `json:"site,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	Error      *bool  `json:"error,omitempty"`
+}
+
+// StrategyConfig is the declarative form of a SampleStrategy.
+type StrategyConfig struct {
+	Strategy string `json:"strategy"`
+	// Limit and Burst of a rateLimited strategy.
+	Limit float64 `json:"limit,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+	// Percent of the transport flows sampled by a transportFlowHash
+	// strategy, in the range [0, 1), and the strategy further applied to
+	// them.
+	Percent float64         `json:"percent,omitempty"`
+	Then    *StrategyConfig `json:"then,omitempty"`
+}
+
+// LoadRules reads a set of Rules from a YAML or JSON file.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading flow logging rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses a set of Rules in YAML or JSON.
+func ParseRules(data []byte) ([]Rule, error) {
+	var config RulesConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing flow logging rules: %w", err)
+	}
+	return config.ToRules()
+}
+
+// ToRules converts the configuration into Rules.
+func (c RulesConfig) ToRules() ([]Rule, error) {
+	byName := make(map[string]vanflow.Record, len(recordTypes))
+	for _, record := range recordTypes {
+		byName[record.GetTypeMeta().Type] = record
+	}
+	rules := make([]Rule, 0, len(c.Rules))
+	for i, ruleConfig := range c.Rules {
+		rule := Rule{Priority: ruleConfig.Priority}
+		if len(ruleConfig.RecordTypes) == 0 {
+			rule.Match = NewRecordTypeSetAll()
+		} else {
+			var records []vanflow.Record
+			for _, name := range ruleConfig.RecordTypes {
+				record, ok := byName[name]
+				if !ok {
+					return nil, fmt.Errorf("flow logging rule %d: unknown record type %q", i, name)
+				}
+				records = append(records, record)
+			}
+			rule.Match = NewRecordTypeSet(records...)
+		}
+		if match := ruleConfig.Match; match != nil {
+			rule.Filter = &AttributeFilter{
+				RoutingKey: match.RoutingKey,
+				Site:       match.Site,
+				Protocol:   match.Protocol,
+				Error:      match.Error,
+			}
+		}
+		if ruleConfig.Sample == nil {
+			return nil, fmt.Errorf("flow logging rule %d: no sample strategy", i)
+		}
+		strategy, err := ruleConfig.Sample.toStrategy()
+		if err != nil {
+			return nil, fmt.Errorf("flow logging rule %d: %w", i, err)
+		}
+		rule.Strategy = strategy
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (c *StrategyConfig) toStrategy() (SampleStrategy, error) {
+	switch c.Strategy {
+	case StrategyAll:
+		return Unlimited(), nil
+	case StrategyNone:
+		return doNotSample, nil
+	case StrategyRateLimited:
+		if c.Limit < 0 || c.Burst < 0 {
+			return nil, fmt.Errorf("limit and burst of a %s strategy must not be negative", StrategyRateLimited)
+		}
+		return RateLimited(c.Limit, c.Burst), nil
+	case StrategyTransportFlowHash:
+		if c.Percent < 0 || c.Percent >= 1.0 {
+			return nil, fmt.Errorf("percent of a %s strategy must be in the range [0, 1)", StrategyTransportFlowHash)
+		}
+		var parent SampleStrategy
+		if c.Then != nil {
+			var err error
+			if parent, err = c.Then.toStrategy(); err != nil {
+				return nil, err
+			}
+		}
+		return TransportFlowHash(c.Percent, parent), nil
+	default:
+		return nil, fmt.Errorf("unknown sample strategy %q: options are %s, %s, %s and %s",
+			c.Strategy, StrategyAll, StrategyNone, StrategyRateLimited, StrategyTransportFlowHash)
+	}
+}
+
+// WatchRules reads the rules file again whenever it is modified, until
+// the context is cancelled, and replaces the rules of the handler with
+// it. When the file cannot be read or parsed the current rules are kept.
+func WatchRules(ctx context.Context, logger *slog.Logger, path string, interval time.Duration, handler *Handler) {
+	watchFile(ctx, logger, path, interval, func() error {
+		rules, err := LoadRules(path)
+		if err != nil {
+			return err
+		}
+		handler.SetRules(rules)
+		logger.Info("reloaded flow logging rules", slog.String("path", path), slog.Int("rules", len(rules)))
+		return nil
+	})
+}
+
+// watchFile calls reload whenever the file is modified, until the context
+// is cancelled.
+func watchFile(ctx context.Context, logger *slog.Logger, path string, interval time.Duration, reload func() error) {
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				logger.Error("error checking flow logging configuration", slog.String("path", path), slog.Any("error", err))
+				continue
+			}
+			if info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			if err := reload(); err != nil {
+				logger.Error("keeping current flow logging configuration", slog.String("path", path), slog.Any("error", err))
+			}
+		}
+	}
+}
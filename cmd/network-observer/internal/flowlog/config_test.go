@@ -0,0 +1,233 @@
+package flowlog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestParseRules(t *testing.T) {
+	testcases := []struct {
+		Name          string
+		Data          string
+		ExpectedRules int
+		ExpectedError string
+	}{
+		{
+			Name: "valid",
+			Data: `
+rules:
+- priority: 1
+  recordTypes: [AppBiflowRecord]
+  match:
+    routingKey: backend
+    error: true
+  sample:
+    strategy: all
+- priority: 2
+  recordTypes: [AppBiflowRecord, TransportBiflowRecord]
+  sample:
+    strategy: transportFlowHash
+    percent: 0.1
+    then:
+      strategy: rateLimited
+      limit: 10
+      burst: 5
+- priority: 3
+  sample:
+    strategy: none
+`,
+			ExpectedRules: 3,
+		},
+		{
+			Name:          "empty",
+			Data:          ``,
+			ExpectedRules: 0,
+		},
+		{
+			Name: "unknown field",
+			Data: `
+rules:
+- priority: 1
+  sample:
+    strategy: all
+  unknown: true
+`,
+			ExpectedError: "error parsing flow logging rules",
+		},
+		{
+			Name: "unknown record type",
+			Data: `
+rules:
+- recordTypes: [Biflow]
+  sample:
+    strategy: all
+`,
+			ExpectedError: `flow logging rule 0: unknown record type "Biflow"`,
+		},
+		{
+			Name: "no strategy",
+			Data: `
+rules:
+- recordTypes: [SiteRecord]
+`,
+			ExpectedError: "flow logging rule 0: no sample strategy",
+		},
+		{
+			Name: "unknown strategy",
+			Data: `
+rules:
+- sample:
+    strategy: some
+`,
+			ExpectedError: `flow logging rule 0: unknown sample strategy "some"`,
+		},
+		{
+			Name: "percent out of range",
+			Data: `
+rules:
+- sample:
+    strategy: transportFlowHash
+    percent: 1.5
+`,
+			ExpectedError: "flow logging rule 0: percent of a transportFlowHash strategy must be in the range [0, 1)",
+		},
+		{
+			Name: "negative limit",
+			Data: `
+rules:
+- sample:
+    strategy: all
+- sample:
+    strategy: rateLimited
+    limit: -1
+`,
+			ExpectedError: "flow logging rule 1: limit and burst of a rateLimited strategy must not be negative",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rules, err := ParseRules([]byte(tc.Data))
+			if tc.ExpectedError != "" {
+				assert.ErrorContains(t, err, tc.ExpectedError)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, len(rules), tc.ExpectedRules)
+		})
+	}
+}
+
+func TestRuleFilters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	records := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")}, store.SourceRef{})
+	records.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1")}, store.SourceRef{})
+	records.Add(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1"), Parent: ptrTo("router-1"), Address: ptrTo("backend"), Protocol: ptrTo("tcp")}, store.SourceRef{})
+	records.Add(vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-2"), Parent: ptrTo("router-1"), Address: ptrTo("frontend"), Protocol: ptrTo("tcp")}, store.SourceRef{})
+
+	rules, err := ParseRules([]byte(`
+rules:
+- priority: 1
+  recordTypes: [TransportBiflowRecord]
+  match:
+    routingKey: backend
+    site: west
+  sample:
+    strategy: all
+- priority: 2
+  recordTypes: [TransportBiflowRecord]
+  match:
+    error: true
+  sample:
+    strategy: all
+- priority: 3
+  sample:
+    strategy: none
+`))
+	assert.Assert(t, err)
+
+	var logged []string
+	handler := NewHandler(ctx, func(msg string, args ...any) {
+		logged = append(logged, msg)
+	}, rules)
+	handler.SetResolver(StoreResolver(records.Get))
+
+	handler.Handle(vanflow.RecordMessage{Records: []vanflow.Record{
+		// matches the routing key and site of its listener
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-1"), Parent: ptrTo("listener-1")},
+		// other routing key
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-2"), Parent: ptrTo("listener-2")},
+		// other routing key with an error
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-3"), Parent: ptrTo("listener-2"), ErrorConnector: ptrTo("connection refused")},
+		// not a transport flow
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1")},
+	}})
+	assert.Equal(t, len(logged), 2)
+
+	// partial updates are matched by the stored record
+	records.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-4"), Parent: ptrTo("listener-1")}, store.SourceRef{})
+	handler.Handle(vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow-4")},
+	}})
+	assert.Equal(t, len(logged), 3)
+}
+
+func TestWatchRules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules := func(strategy string, modTime time.Time) {
+		t.Helper()
+		data := "rules:\n- recordTypes: [SiteRecord]\n  sample:\n    strategy: " + strategy + "\n"
+		assert.Assert(t, os.WriteFile(path, []byte(data), 0644))
+		assert.Assert(t, os.Chtimes(path, modTime, modTime))
+	}
+	writeRules(StrategyNone, time.Now().Add(-time.Minute))
+	rules, err := LoadRules(path)
+	assert.Assert(t, err)
+
+	var ct atomic.Int64
+	handler := NewHandler(ctx, func(string, ...any) { ct.Add(1) }, rules)
+	go WatchRules(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), path, 10*time.Millisecond, handler)
+
+	site := vanflow.RecordMessage{Records: []vanflow.Record{vanflow.SiteRecord{}}}
+	handler.Handle(site)
+	assert.Equal(t, ct.Load(), int64(0))
+
+	modTime := time.Now()
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		handler.Handle(site)
+		if ct.Load() == 0 {
+			// the watcher may not have seen the original file yet
+			modTime = modTime.Add(time.Second)
+			writeRules(StrategyAll, modTime)
+			return poll.Continue("rules not reloaded")
+		}
+		return poll.Success()
+	}, poll.WithDelay(10*time.Millisecond), poll.WithTimeout(5*time.Second))
+
+	// invalid rules are not applied
+	assert.Assert(t, os.WriteFile(path, []byte("rules: [{sample: {strategy: some}}]"), 0644))
+	time.Sleep(50 * time.Millisecond)
+	prev := ct.Load()
+	handler.Handle(site)
+	assert.Equal(t, ct.Load(), prev+1)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
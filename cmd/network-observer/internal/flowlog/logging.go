@@ -25,6 +25,9 @@ type Rule struct {
 	Priority int
 	// Match is the set of record types the rule applies to
 	Match RecordTypeSet
+	// Filter optionally narrows the rule down to the records of those
+	// types whose attributes match.
+	Filter *AttributeFilter
 	// Strategy for sampling records
 	Strategy SampleStrategy
 }
@@ -33,21 +36,18 @@ type MessageHandler func(vanflow.RecordMessage)
 
 // New creates a MessageHandler given a set of rules and a log output function
 func New(ctx context.Context, logFn func(msg string, args ...any), rules []Rule) MessageHandler {
-	handler := &handler{
-		logFn: logFn,
-	}
+	return NewHandler(ctx, logFn, rules).Handle
+}
 
-	for _, rule := range rules {
-		if rule.Strategy == nil || rule.Match == nil {
-			continue
-		}
-		handler.rules = append(handler.rules, rule)
+// NewHandler creates a Handler given a set of rules and a log output
+// function. Its rules can be replaced while it is in use.
+func NewHandler(ctx context.Context, logFn func(msg string, args ...any), rules []Rule) *Handler {
+	handler := &Handler{
+		logFn: logFn,
 	}
-	slices.SortFunc(handler.rules, func(l, r Rule) int {
-		return l.Priority - r.Priority
-	})
+	handler.SetRules(rules)
 	go handler.report(ctx)
-	return handler.handle
+	return handler
 }
 
 type SampleStrategy interface {
@@ -151,15 +151,49 @@ func NewRecordTypeSetAll() RecordTypeSet {
 	return set
 }
 
-type handler struct {
-	logFn func(msg string, args ...any)
-	rules []Rule
+// Handler logs the vanflow records it handles according to a set of
+// rules that can be replaced while it is in use.
+type Handler struct {
+	logFn    func(msg string, args ...any)
+	resolver Resolver
+	current  atomic.Pointer[handler]
+}
 
-	resolved sync.Map
-	sampled  sync.Map
+// SetRules replaces the rules of the handler. The records left out under
+// the previous rules are reported first.
+func (h *Handler) SetRules(rules []Rule) {
+	next := &handler{
+		logFn:    h.logFn,
+		resolver: h.resolver,
+	}
+	for _, rule := range rules {
+		if rule.Strategy == nil || rule.Match == nil {
+			continue
+		}
+		next.rules = append(next.rules, rule)
+	}
+	slices.SortStableFunc(next.rules, func(l, r Rule) int {
+		return l.Priority - r.Priority
+	})
+	if prev := h.current.Swap(next); prev != nil {
+		prev.logReport()
+	}
+}
+
+// SetResolver sets how the attributes of records are determined for
+// the rules with a Filter. By default only the attributes held by the
+// record itself are known. It must be set before the handler is used.
+func (h *Handler) SetResolver(resolver Resolver) {
+	h.resolver = resolver
+	h.current.Load().resolver = resolver
+}
+
+// Handle logs the records of a message that are sampled.
+func (h *Handler) Handle(msg vanflow.RecordMessage) {
+	h.current.Load().handle(msg)
 }
 
-func (h *handler) report(ctx context.Context) {
+func (h *Handler) report(ctx context.Context) {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 	for {
@@ -167,11 +201,20 @@ func (h *handler) report(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			h.logReport()
+			h.current.Load().logReport()
 		}
 	}
 }
 
+type handler struct {
+	logFn    func(msg string, args ...any)
+	rules    []Rule
+	resolver Resolver
+
+	resolved sync.Map
+	sampled  sync.Map
+}
+
 func (h *handler) logReport() {
 	sampleCounts := make(map[string]int)
 	h.sampled.Range(func(k, v any) bool {
@@ -192,31 +235,53 @@ func (h *handler) logReport() {
 	h.logFn("some vanflow records were not logged", counts...)
 }
 
-func (h *handler) resolve(typ vanflow.TypeMeta) SampleStrategy {
+// resolve returns the rules that may apply to records of a type, up to
+// the first one that applies to all of them.
+func (h *handler) resolve(typ vanflow.TypeMeta) []Rule {
 	r, ok := h.resolved.Load(typ)
 	if ok {
-		return r.(SampleStrategy)
+		return r.([]Rule)
 	}
-	var strategy SampleStrategy = doNotSample
+	var candidates []Rule
 	for _, rule := range h.rules {
-		if rule.Match.matchesAll() {
-			strategy = rule.Strategy
-			break
+		if _, ok := rule.Match[typ]; !ok && !rule.Match.matchesAll() {
+			continue
 		}
-		if _, ok := rule.Match[typ]; ok {
-			strategy = rule.Strategy
+		candidates = append(candidates, rule)
+		if rule.Filter == nil {
 			break
 		}
 	}
-	h.resolved.Store(typ, strategy)
-	return strategy
+	h.resolved.Store(typ, candidates)
+	return candidates
+}
+
+func (h *handler) strategy(record vanflow.Record) SampleStrategy {
+	var attrs *Attributes
+	for _, rule := range h.resolve(record.GetTypeMeta()) {
+		if rule.Filter != nil {
+			if attrs == nil {
+				resolver := h.resolver
+				if resolver == nil {
+					resolver = RecordAttributes
+				}
+				resolved := resolver(record)
+				attrs = &resolved
+			}
+			if !rule.Filter.Matches(*attrs) {
+				continue
+			}
+		}
+		return rule.Strategy
+	}
+	return doNotSample
 }
 
 func (h *handler) handle(msg vanflow.RecordMessage) {
 	attrs := slog.Group("message", slog.String("to", msg.To), slog.String("subject", msg.Subject))
 	for _, record := range msg.Records {
 		typ := record.GetTypeMeta()
-		strategy := h.resolve(typ)
+		strategy := h.strategy(record)
 		if !strategy.Sample(record) {
 			if strategy != doNotSample {
 				prev, _ := h.sampled.LoadOrStore(typ, new(atomic.Int64))
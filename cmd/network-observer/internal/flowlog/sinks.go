@@ -0,0 +1,292 @@
+package flowlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"sigs.k8s.io/yaml"
+)
+
+// SinksConfig is the declarative form of a set of Sinks, as read from a
+// sinks file.
+type SinksConfig struct {
+	Sinks []SinkConfig `json:"sinks"`
+}
+
+// SinkConfig configures a Sink: exactly one destination for the records
+// and the rules sampling them.
+type SinkConfig struct {
+	Name   string            `json:"name"`
+	File   *FileSinkConfig   `json:"file,omitempty"`
+	Syslog *SyslogSinkConfig `json:"syslog,omitempty"`
+	Unix   *UnixSinkConfig   `json:"unix,omitempty"`
+	Rules  []RuleConfig      `json:"rules"`
+}
+
+// FileSinkConfig writes records as JSON lines to a file. The file is
+// rotated once it would exceed MaxSizeMB or has been written to for
+// longer than MaxAge, keeping up to MaxBackups rotated files. Zero values
+// leave the respective limit unset.
+type FileSinkConfig struct {
+	Path       string `json:"path"`
+	MaxSizeMB  int    `json:"maxSizeMB,omitempty"`
+	MaxAge     string `json:"maxAge,omitempty"`
+	MaxBackups int    `json:"maxBackups,omitempty"`
+}
+
+// SyslogSinkConfig sends records as JSON in RFC5424 messages to a syslog
+// server. Network is udp (the default) or tcp, Facility defaults to
+// local0 and AppName to skupper-network-observer.
+type SyslogSinkConfig struct {
+	Network  string `json:"network,omitempty"`
+	Address  string `json:"address"`
+	Facility string `json:"facility,omitempty"`
+	AppName  string `json:"appName,omitempty"`
+}
+
+// UnixSinkConfig writes records as JSON lines to a unix socket.
+type UnixSinkConfig struct {
+	Path string `json:"path"`
+}
+
+// destination returns the configuration of the destination of the sink,
+// which cannot change while it is open.
+func (c SinkConfig) destination() any {
+	return []any{c.File, c.Syslog, c.Unix}
+}
+
+// ParseSinks parses a SinksConfig in YAML or JSON, validating it.
+func ParseSinks(data []byte) (SinksConfig, error) {
+	var config SinksConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return config, fmt.Errorf("error parsing flow log sinks: %w", err)
+	}
+	names := map[string]bool{}
+	for i, sink := range config.Sinks {
+		if sink.Name == "" {
+			return config, fmt.Errorf("flow log sink %d: no name", i)
+		}
+		if names[sink.Name] {
+			return config, fmt.Errorf("flow log sink %d: duplicate name %q", i, sink.Name)
+		}
+		names[sink.Name] = true
+		destinations := 0
+		for _, set := range []bool{sink.File != nil, sink.Syslog != nil, sink.Unix != nil} {
+			if set {
+				destinations++
+			}
+		}
+		if destinations != 1 {
+			return config, fmt.Errorf("flow log sink %q: exactly one of file, syslog or unix is required", sink.Name)
+		}
+		if _, err := (RulesConfig{Rules: sink.Rules}).ToRules(); err != nil {
+			return config, fmt.Errorf("flow log sink %q: %w", sink.Name, err)
+		}
+	}
+	return config, nil
+}
+
+// LoadSinks reads a SinksConfig from a YAML or JSON file.
+func LoadSinks(path string) (SinksConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SinksConfig{}, fmt.Errorf("error reading flow log sinks: %w", err)
+	}
+	return ParseSinks(data)
+}
+
+// Sink logs the vanflow records sampled by its own rules to a destination
+// as JSON. Records are queued for the destination, and dropped while the
+// queue is full.
+type Sink struct {
+	config  SinkConfig
+	handler *Handler
+	writer  *queuedWriter
+}
+
+// OpenSink opens the destination of a sink.
+func OpenSink(ctx context.Context, logger *slog.Logger, config SinkConfig) (*Sink, error) {
+	rules, err := (RulesConfig{Rules: config.Rules}).ToRules()
+	if err != nil {
+		return nil, fmt.Errorf("flow log sink %q: %w", config.Name, err)
+	}
+	logger = logger.With(slog.String("sink", config.Name))
+	dest, err := openWriter(logger, config)
+	if err != nil {
+		return nil, fmt.Errorf("flow log sink %q: %w", config.Name, err)
+	}
+	writer := newQueuedWriter(logger, dest)
+	out := slog.New(slog.NewJSONHandler(writer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// every record is logged at the same level
+			if len(groups) == 0 && a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	return &Sink{
+		config:  config,
+		handler: NewHandler(ctx, out.Info, rules),
+		writer:  writer,
+	}, nil
+}
+
+func openWriter(logger *slog.Logger, config SinkConfig) (io.WriteCloser, error) {
+	switch {
+	case config.File != nil:
+		var maxAge time.Duration
+		if config.File.MaxAge != "" {
+			var err error
+			if maxAge, err = time.ParseDuration(config.File.MaxAge); err != nil {
+				return nil, fmt.Errorf("invalid maxAge: %w", err)
+			}
+		}
+		if config.File.Path == "" {
+			return nil, fmt.Errorf("no file path")
+		}
+		return newRotatingFile(config.File.Path, int64(config.File.MaxSizeMB)<<20, maxAge, config.File.MaxBackups)
+	case config.Syslog != nil:
+		network := config.Syslog.Network
+		if network == "" {
+			network = "udp"
+		}
+		facilityName := config.Syslog.Facility
+		if facilityName == "" {
+			facilityName = "local0"
+		}
+		facility, ok := syslogFacilities[facilityName]
+		if !ok {
+			return nil, fmt.Errorf("unknown syslog facility %q", facilityName)
+		}
+		appName := config.Syslog.AppName
+		if appName == "" {
+			appName = "skupper-network-observer"
+		}
+		if config.Syslog.Address == "" {
+			return nil, fmt.Errorf("no syslog address")
+		}
+		return newSyslogWriter(logger, network, config.Syslog.Address, facility, appName)
+	case config.Unix != nil:
+		if config.Unix.Path == "" {
+			return nil, fmt.Errorf("no unix socket path")
+		}
+		return newSocketWriter(logger, config.Unix.Path), nil
+	}
+	return nil, fmt.Errorf("no destination")
+}
+
+// Name of the sink.
+func (s *Sink) Name() string {
+	return s.config.Name
+}
+
+// Handle logs the records of a message sampled by the rules of the sink.
+func (s *Sink) Handle(msg vanflow.RecordMessage) {
+	s.handler.Handle(msg)
+}
+
+// Dropped returns the number of records dropped as the queue of the sink
+// was full.
+func (s *Sink) Dropped() uint64 {
+	return s.writer.Dropped()
+}
+
+// Close writes out the queued records and closes the destination of the
+// sink.
+func (s *Sink) Close() error {
+	return s.writer.Close()
+}
+
+// Sinks is a set of Sinks handling the same records.
+type Sinks []*Sink
+
+// OpenSinks opens every sink in the configuration.
+func OpenSinks(ctx context.Context, logger *slog.Logger, config SinksConfig) (Sinks, error) {
+	var sinks Sinks
+	for _, sinkConfig := range config.Sinks {
+		sink, err := OpenSink(ctx, logger, sinkConfig)
+		if err != nil {
+			sinks.Close()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// Handle passes the message to every sink.
+func (s Sinks) Handle(msg vanflow.RecordMessage) {
+	for _, sink := range s {
+		sink.Handle(msg)
+	}
+}
+
+// SetResolver sets the Resolver of every sink. It must be set before the
+// sinks are used.
+func (s Sinks) SetResolver(resolver Resolver) {
+	for _, sink := range s {
+		sink.handler.SetResolver(resolver)
+	}
+}
+
+// SetRules replaces the rules of each sink with those of the sink of the
+// same name in the configuration. Sinks cannot be added, removed or
+// moved to another destination while open.
+func (s Sinks) SetRules(config SinksConfig) error {
+	if len(config.Sinks) != len(s) {
+		return fmt.Errorf("flow log sinks cannot be added or removed without a restart")
+	}
+	rules := make([][]Rule, len(s))
+	for i, sink := range s {
+		next := config.Sinks[i]
+		if next.Name != sink.config.Name || !reflect.DeepEqual(next.destination(), sink.config.destination()) {
+			return fmt.Errorf("flow log sink %q cannot be changed without a restart", sink.config.Name)
+		}
+		var err error
+		if rules[i], err = (RulesConfig{Rules: next.Rules}).ToRules(); err != nil {
+			return fmt.Errorf("flow log sink %q: %w", next.Name, err)
+		}
+	}
+	for i, sink := range s {
+		sink.config = config.Sinks[i]
+		sink.handler.SetRules(rules[i])
+	}
+	return nil
+}
+
+// Close closes every sink.
+func (s Sinks) Close() error {
+	var errs []error
+	for _, sink := range s {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("flow log sink %q: %w", sink.config.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WatchSinks reads the sinks file again whenever it is modified, until the
+// context is cancelled, and replaces the rules of the sinks with it. When
+// the file cannot be read or parsed, or the sinks themselves changed, the
+// current rules are kept.
+func WatchSinks(ctx context.Context, logger *slog.Logger, path string, interval time.Duration, sinks Sinks) {
+	watchFile(ctx, logger, path, interval, func() error {
+		config, err := LoadSinks(path)
+		if err != nil {
+			return err
+		}
+		if err := sinks.SetRules(config); err != nil {
+			return err
+		}
+		logger.Info("reloaded flow log sink rules", slog.String("path", path), slog.Int("sinks", len(sinks)))
+		return nil
+	})
+}
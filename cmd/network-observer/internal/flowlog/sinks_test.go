@@ -0,0 +1,490 @@
+package flowlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestParseSinks(t *testing.T) {
+	testcases := []struct {
+		Name          string
+		Data          string
+		ExpectedSinks int
+		ExpectedError string
+	}{
+		{
+			Name: "valid",
+			Data: `
+sinks:
+- name: file
+  file:
+    path: /var/log/flows.jsonl
+    maxSizeMB: 100
+    maxAge: 24h
+    maxBackups: 7
+  rules:
+  - sample:
+      strategy: all
+- name: siem
+  syslog:
+    network: tcp
+    address: siem.example.com:514
+  rules:
+  - recordTypes: [TransportBiflowRecord]
+    match:
+      error: true
+    sample:
+      strategy: all
+- name: local
+  unix:
+    path: /run/flows.sock
+  rules: []
+`,
+			ExpectedSinks: 3,
+		},
+		{
+			Name: "no name",
+			Data: `
+sinks:
+- unix:
+    path: /run/flows.sock
+`,
+			ExpectedError: "flow log sink 0: no name",
+		},
+		{
+			Name: "duplicate name",
+			Data: `
+sinks:
+- name: local
+  unix:
+    path: /run/flows.sock
+- name: local
+  unix:
+    path: /run/other.sock
+`,
+			ExpectedError: `flow log sink 1: duplicate name "local"`,
+		},
+		{
+			Name: "no destination",
+			Data: `
+sinks:
+- name: local
+`,
+			ExpectedError: `flow log sink "local": exactly one of file, syslog or unix is required`,
+		},
+		{
+			Name: "two destinations",
+			Data: `
+sinks:
+- name: local
+  unix:
+    path: /run/flows.sock
+  file:
+    path: /var/log/flows.jsonl
+`,
+			ExpectedError: `flow log sink "local": exactly one of file, syslog or unix is required`,
+		},
+		{
+			Name: "invalid rules",
+			Data: `
+sinks:
+- name: local
+  unix:
+    path: /run/flows.sock
+  rules:
+  - sample:
+      strategy: some
+`,
+			ExpectedError: `flow log sink "local": flow logging rule 0: unknown sample strategy "some"`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			config, err := ParseSinks([]byte(tc.Data))
+			if tc.ExpectedError != "" {
+				assert.ErrorContains(t, err, tc.ExpectedError)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, len(config.Sinks), tc.ExpectedSinks)
+		})
+	}
+}
+
+func TestOpenSinkErrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	testcases := []struct {
+		Name          string
+		Config        SinkConfig
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid max age",
+			Config:        SinkConfig{Name: "file", File: &FileSinkConfig{Path: filepath.Join(t.TempDir(), "flows.jsonl"), MaxAge: "1 day"}},
+			ExpectedError: `flow log sink "file": invalid maxAge`,
+		},
+		{
+			Name:          "unknown network",
+			Config:        SinkConfig{Name: "siem", Syslog: &SyslogSinkConfig{Network: "sctp", Address: "localhost:514"}},
+			ExpectedError: `flow log sink "siem": unsupported syslog network "sctp"`,
+		},
+		{
+			Name:          "unknown facility",
+			Config:        SinkConfig{Name: "siem", Syslog: &SyslogSinkConfig{Address: "localhost:514", Facility: "local9"}},
+			ExpectedError: `flow log sink "siem": unknown syslog facility "local9"`,
+		},
+		{
+			Name:          "no socket path",
+			Config:        SinkConfig{Name: "local", Unix: &UnixSinkConfig{}},
+			ExpectedError: `flow log sink "local": no unix socket path`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := OpenSink(context.Background(), logger, tc.Config)
+			assert.ErrorContains(t, err, tc.ExpectedError)
+		})
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "flows.jsonl")
+
+	t.Run("size", func(t *testing.T) {
+		f, err := newRotatingFile(path, 10, 0, 2)
+		assert.Assert(t, err)
+		defer f.Close()
+		for i := 0; i < 5; i++ {
+			_, err := f.Write([]byte("record-" + strconv.Itoa(i) + "\n"))
+			assert.Assert(t, err)
+			// rotated files are named by the time of rotation
+			time.Sleep(time.Millisecond)
+		}
+		data, err := os.ReadFile(path)
+		assert.Assert(t, err)
+		assert.Equal(t, string(data), "record-4\n")
+		backups, err := filepath.Glob(path + ".*")
+		assert.Assert(t, err)
+		assert.Equal(t, len(backups), 2)
+		data, err = os.ReadFile(backups[1])
+		assert.Assert(t, err)
+		assert.Equal(t, string(data), "record-3\n")
+	})
+
+	t.Run("age", func(t *testing.T) {
+		path := filepath.Join(dir, "aged.jsonl")
+		f, err := newRotatingFile(path, 0, time.Hour, 0)
+		assert.Assert(t, err)
+		defer f.Close()
+		_, err = f.Write([]byte("old\n"))
+		assert.Assert(t, err)
+		f.opened = f.opened.Add(-time.Hour)
+		_, err = f.Write([]byte("new\n"))
+		assert.Assert(t, err)
+		data, err := os.ReadFile(path)
+		assert.Assert(t, err)
+		assert.Equal(t, string(data), "new\n")
+		backups, err := filepath.Glob(path + ".*")
+		assert.Assert(t, err)
+		assert.Equal(t, len(backups), 1)
+	})
+
+	t.Run("appends to existing file", func(t *testing.T) {
+		path := filepath.Join(dir, "existing.jsonl")
+		assert.Assert(t, os.WriteFile(path, []byte("existing\n"), 0644))
+		f, err := newRotatingFile(path, 20, 0, 0)
+		assert.Assert(t, err)
+		defer f.Close()
+		_, err = f.Write([]byte("appended\n"))
+		assert.Assert(t, err)
+		_, err = f.Write([]byte("rotated\n"))
+		assert.Assert(t, err)
+		data, err := os.ReadFile(path)
+		assert.Assert(t, err)
+		assert.Equal(t, string(data), "rotated\n")
+	})
+
+	t.Run("age of existing file", func(t *testing.T) {
+		path := filepath.Join(dir, "existing-aged.jsonl")
+		assert.Assert(t, os.WriteFile(path, []byte("old\n"), 0644))
+		modTime := time.Now().Add(-2 * time.Hour)
+		assert.Assert(t, os.Chtimes(path, modTime, modTime))
+		f, err := newRotatingFile(path, 0, time.Hour, 0)
+		assert.Assert(t, err)
+		defer f.Close()
+		_, err = f.Write([]byte("new\n"))
+		assert.Assert(t, err)
+		data, err := os.ReadFile(path)
+		assert.Assert(t, err)
+		assert.Equal(t, string(data), "new\n")
+	})
+
+	t.Run("prune leaves other files", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "flows.jsonl")
+		others := []string{path + ".bak", path + ".20060102", path + ".lock"}
+		for _, other := range others {
+			assert.Assert(t, os.WriteFile(other, []byte("other\n"), 0644))
+		}
+		f, err := newRotatingFile(path, 10, 0, 1)
+		assert.Assert(t, err)
+		defer f.Close()
+		for i := 0; i < 4; i++ {
+			_, err := f.Write([]byte("record-" + strconv.Itoa(i) + "\n"))
+			assert.Assert(t, err)
+			time.Sleep(time.Millisecond)
+		}
+		for _, other := range others {
+			_, err := os.Stat(other)
+			assert.Assert(t, err)
+		}
+		matches, err := filepath.Glob(path + ".*")
+		assert.Assert(t, err)
+		assert.Equal(t, len(matches), len(others)+1)
+	})
+}
+
+// blockingWriter holds up writes until it is released.
+type blockingWriter struct {
+	release chan struct{}
+	writes  chan string
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.writes <- string(p)
+	return len(p), nil
+}
+
+func (w *blockingWriter) Close() error {
+	close(w.writes)
+	return nil
+}
+
+func TestQueuedWriter(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dest := &blockingWriter{release: make(chan struct{}), writes: make(chan string, 2*queueSize)}
+	w := newQueuedWriter(logger, dest)
+
+	// one write is held up by the destination, the queue takes the rest
+	// and those beyond are dropped
+	written := 0
+	for i := 0; i < 2*queueSize; i++ {
+		if _, err := w.Write([]byte(strconv.Itoa(i))); err == nil {
+			written++
+		} else {
+			assert.Equal(t, err, errSinkFull)
+		}
+	}
+	assert.Assert(t, written >= queueSize && written <= queueSize+1, "written %d", written)
+	assert.Equal(t, w.Dropped(), uint64(2*queueSize-written))
+
+	close(dest.release)
+	assert.Assert(t, w.Close())
+	assert.Equal(t, len(dest.writes), written)
+	assert.Equal(t, <-dest.writes, "0")
+	_, err := w.Write([]byte("closed"))
+	assert.Equal(t, err, os.ErrClosed)
+}
+
+var rfc5424Pattern = regexp.MustCompile(`^<134>1 \S+ \S+ skupper-network-observer \d+ - - (\{.*\})$`)
+
+func TestSyslogSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	message := vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")},
+	}}
+
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		assert.Assert(t, err)
+		defer conn.Close()
+
+		sink, err := OpenSink(ctx, logger, SinkConfig{
+			Name:   "siem",
+			Syslog: &SyslogSinkConfig{Address: conn.LocalAddr().String()},
+			Rules:  []RuleConfig{{Sample: &StrategyConfig{Strategy: StrategyAll}}},
+		})
+		assert.Assert(t, err)
+		defer sink.Close()
+		sink.Handle(message)
+
+		buf := make([]byte, 65536)
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		assert.Assert(t, err)
+		assertSyslogRecord(t, string(buf[:n]))
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.Assert(t, err)
+		defer listener.Close()
+
+		sink, err := OpenSink(ctx, logger, SinkConfig{
+			Name:   "siem",
+			Syslog: &SyslogSinkConfig{Network: "tcp", Address: listener.Addr().String()},
+			Rules:  []RuleConfig{{Sample: &StrategyConfig{Strategy: StrategyAll}}},
+		})
+		assert.Assert(t, err)
+		defer sink.Close()
+		sink.Handle(message)
+		sink.Handle(message)
+
+		conn, err := listener.Accept()
+		assert.Assert(t, err)
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		reader := bufio.NewReader(conn)
+		for i := 0; i < 2; i++ {
+			// octet counting framing
+			length, err := reader.ReadString(' ')
+			assert.Assert(t, err)
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			assert.Assert(t, err)
+			msg := make([]byte, n)
+			_, err = io.ReadFull(reader, msg)
+			assert.Assert(t, err)
+			assertSyslogRecord(t, string(msg))
+		}
+	})
+}
+
+func assertSyslogRecord(t *testing.T, msg string) {
+	t.Helper()
+	match := rfc5424Pattern.FindStringSubmatch(msg)
+	assert.Assert(t, match != nil, "not an RFC5424 message: %s", msg)
+	assertSiteRecord(t, match[1])
+}
+
+func assertSiteRecord(t *testing.T, line string) {
+	t.Helper()
+	var out struct {
+		Msg    string         `json:"msg"`
+		Level  string         `json:"level"`
+		Record map[string]any `json:"record"`
+	}
+	assert.Assert(t, json.Unmarshal([]byte(line), &out))
+	assert.Equal(t, out.Msg, vanflow.SiteRecord{}.GetTypeMeta().String())
+	assert.Equal(t, out.Level, "")
+	assert.Equal(t, out.Record["Name"], "west")
+}
+
+func TestUnixSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// unix socket paths are limited in length
+	dir, err := os.MkdirTemp("", "flowlog")
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "flows.sock")
+
+	sink, err := OpenSink(ctx, logger, SinkConfig{
+		Name:  "local",
+		Unix:  &UnixSinkConfig{Path: path},
+		Rules: []RuleConfig{{RecordTypes: []string{"SiteRecord"}, Sample: &StrategyConfig{Strategy: StrategyAll}}},
+	})
+	assert.Assert(t, err)
+	defer sink.Close()
+	message := vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1")},
+	}}
+
+	// records are dropped while nothing is listening
+	sink.Handle(message)
+	dest := sink.writer.out.(*connWriter)
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		dest.mu.Lock()
+		defer dest.mu.Unlock()
+		if !dest.failed {
+			return poll.Continue("record not dropped yet")
+		}
+		return poll.Success()
+	})
+
+	listener, err := net.Listen("unix", path)
+	assert.Assert(t, err)
+	defer listener.Close()
+	dest.mu.Lock()
+	dest.retryAt = time.Time{}
+	dest.mu.Unlock()
+	sink.Handle(message)
+
+	conn, err := listener.Accept()
+	assert.Assert(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	assert.Assert(t, err)
+	assertSiteRecord(t, line)
+
+	assert.Assert(t, sink.Close())
+	_, err = reader.ReadString('\n')
+	assert.Equal(t, err, io.EOF)
+}
+
+func TestSinksSetRules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	path := filepath.Join(t.TempDir(), "flows.jsonl")
+	config, err := ParseSinks([]byte(`
+sinks:
+- name: file
+  file:
+    path: ` + path + `
+  rules:
+  - recordTypes: [RouterRecord]
+    sample:
+      strategy: all
+`))
+	assert.Assert(t, err)
+	sinks, err := OpenSinks(ctx, logger, config)
+	assert.Assert(t, err)
+	defer sinks.Close()
+
+	message := vanflow.RecordMessage{Records: []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")},
+	}}
+	sinks.Handle(message)
+
+	config.Sinks[0].Rules[0].RecordTypes = []string{"SiteRecord"}
+	assert.Assert(t, sinks.SetRules(config))
+	sinks.Handle(message)
+	// closing writes out the queued records
+	assert.Assert(t, sinks.Close())
+
+	data, err := os.ReadFile(path)
+	assert.Assert(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, len(lines), 1)
+	assertSiteRecord(t, lines[0])
+
+	moved := SinksConfig{Sinks: []SinkConfig{config.Sinks[0]}}
+	moved.Sinks[0].File = &FileSinkConfig{Path: path + ".moved"}
+	assert.ErrorContains(t, sinks.SetRules(moved), `flow log sink "file" cannot be changed without a restart`)
+	assert.ErrorContains(t, sinks.SetRules(SinksConfig{}), "flow log sinks cannot be added or removed without a restart")
+}
@@ -0,0 +1,377 @@
+package flowlog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// dialTimeout and writeTimeout bound how long a record can hold up
+	// the queue of a remote sink that is unresponsive.
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 5 * time.Second
+	// retryInterval is how long records are dropped for once a remote sink
+	// fails, before connecting again.
+	retryInterval = 10 * time.Second
+
+	// syslog severity of flow log messages: informational
+	syslogSeverity = 6
+	// rfc5424Timestamp is the TIMESTAMP format of RFC5424 messages.
+	rfc5424Timestamp = "2006-01-02T15:04:05.000000Z07:00"
+
+	// queueSize is the number of records a sink holds while its
+	// destination is slow, before dropping them.
+	queueSize = 1024
+	// rotatedSuffix is the time format of the suffix of rotated files.
+	rotatedSuffix = "20060102T150405.000000"
+)
+
+// rotatedPattern matches the suffix of rotated files.
+var rotatedPattern = regexp.MustCompile(`^\.\d{8}T\d{6}\.\d{6}$`)
+
+var (
+	errSinkUnavailable = errors.New("flow log sink unavailable")
+	errSinkFull        = errors.New("flow log sink queue full")
+)
+
+// syslogFacilities are the facility codes by name.
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"authpriv": 10,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// rotatingFile writes to a file that is rotated once it reaches a maximum
+// size or age, keeping up to a maximum number of the rotated files.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+func newRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	if f.size > 0 {
+		// an existing file has been written to since it was last modified
+		f.opened = info.ModTime()
+	}
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) due(size int64) bool {
+	if f.maxSize > 0 && f.size+size > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && time.Since(f.opened) >= f.maxAge
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	backup := f.path + "." + time.Now().UTC().Format(rotatedSuffix)
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// prune removes the oldest rotated files beyond maxBackups. Other files
+// sharing the name of the file are left alone.
+func (f *rotatingFile) prune() error {
+	if f.maxBackups <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, match := range matches {
+		if rotatedPattern.MatchString(match[len(f.path):]) {
+			backups = append(backups, match)
+		}
+	}
+	if len(backups) <= f.maxBackups {
+		return nil
+	}
+	slices.Sort(backups)
+	for _, backup := range backups[:len(backups)-f.maxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// queuedWriter hands writes over to a goroutine writing them to another
+// writer, so that a slow destination does not hold up the collector. Up to
+// queueSize writes are queued, those beyond are dropped and counted.
+type queuedWriter struct {
+	logger *slog.Logger
+	out    io.WriteCloser
+	queue  chan []byte
+	done   chan struct{}
+
+	mu       sync.RWMutex
+	closed   bool
+	dropped  atomic.Uint64
+	dropping atomic.Bool
+}
+
+func newQueuedWriter(logger *slog.Logger, out io.WriteCloser) *queuedWriter {
+	w := &queuedWriter{
+		logger: logger,
+		out:    out,
+		queue:  make(chan []byte, queueSize),
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *queuedWriter) run() {
+	defer close(w.done)
+	for p := range w.queue {
+		// errors are handled by the destination, as there is no one
+		// left to return them to
+		w.out.Write(p)
+		if len(w.queue) == 0 && w.dropping.CompareAndSwap(true, false) {
+			w.logger.Info("flow log sink caught up", slog.Uint64("dropped", w.dropped.Load()))
+		}
+	}
+}
+
+// Write queues a copy of p, or drops it when the queue is full.
+func (w *queuedWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	select {
+	case w.queue <- bytes.Clone(p):
+		return len(p), nil
+	default:
+		w.dropped.Add(1)
+		if w.dropping.CompareAndSwap(false, true) {
+			w.logger.Error("flow log sink is falling behind, dropping records until it catches up")
+		}
+		return 0, errSinkFull
+	}
+}
+
+// Dropped returns the number of writes dropped as the queue was full.
+func (w *queuedWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close writes out the queued writes and closes the destination.
+func (w *queuedWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+	<-w.done
+	return w.out.Close()
+}
+
+// connWriter writes to a connection that is established on first use, and
+// again once retryInterval has passed after it fails. Writes in between are
+// dropped.
+type connWriter struct {
+	logger  *slog.Logger
+	network string
+	address string
+	// frame returns the bytes sent over the connection for a write
+	frame func(p []byte) []byte
+
+	mu      sync.Mutex
+	conn    net.Conn
+	retryAt time.Time
+	failed  bool
+	closed  bool
+}
+
+func (w *connWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, net.ErrClosed
+	}
+	if w.conn == nil {
+		if time.Now().Before(w.retryAt) {
+			return 0, errSinkUnavailable
+		}
+		conn, err := net.DialTimeout(w.network, w.address, dialTimeout)
+		if err != nil {
+			w.fail(err)
+			return 0, err
+		}
+		if w.failed {
+			w.logger.Info("flow log sink reconnected")
+			w.failed = false
+		}
+		w.conn = conn
+	}
+	w.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := w.conn.Write(w.frame(p)); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		w.fail(err)
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// fail holds off connecting again for retryInterval, logging the error
+// that caused it unless the sink had already failed.
+func (w *connWriter) fail(err error) {
+	w.retryAt = time.Now().Add(retryInterval)
+	if !w.failed {
+		w.logger.Error("flow log sink failed, dropping records until it reconnects",
+			slog.String("address", w.address), slog.Any("error", err))
+		w.failed = true
+	}
+}
+
+func (w *connWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// newSyslogWriter returns a writer sending each write as an RFC5424
+// message to a syslog server over udp or tcp. Messages sent over tcp are
+// framed by octet counting as in RFC6587.
+func newSyslogWriter(logger *slog.Logger, network string, address string, facility int, appName string) (*connWriter, error) {
+	switch network {
+	case "udp", "tcp":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q: options are udp and tcp", network)
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	header := fmt.Sprintf("<%d>1 %%s %s %s %d - - ", facility*8+syslogSeverity, hostname, appName, os.Getpid())
+	return &connWriter{
+		logger:  logger,
+		network: network,
+		address: address,
+		frame: func(p []byte) []byte {
+			msg := fmt.Sprintf(header, time.Now().UTC().Format(rfc5424Timestamp))
+			msg += string(bytes.TrimRight(p, "\n"))
+			if network == "tcp" {
+				msg = strconv.Itoa(len(msg)) + " " + msg
+			}
+			return []byte(msg)
+		},
+	}, nil
+}
+
+// newSocketWriter returns a writer sending each write unchanged over a
+// unix socket.
+func newSocketWriter(logger *slog.Logger, path string) *connWriter {
+	return &connWriter{
+		logger:  logger,
+		network: "unix",
+		address: path,
+		frame:   func(p []byte) []byte { return p },
+	}
+}
@@ -0,0 +1,225 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+)
+
+const (
+	segmentDuration = time.Hour
+	segmentLayout   = "2006010215"
+	segmentSuffix   = ".jsonl"
+
+	kindConnection = "connection"
+	kindRequest    = "request"
+)
+
+// FileStore is a Store that appends records as lines of JSON to files in a
+// directory, one per hour of record end time. Files holding only records
+// that ended before the retention period are removed.
+type FileStore struct {
+	mu        sync.Mutex
+	dir       string
+	retention time.Duration
+	now       func() time.Time
+}
+
+var _ Store = (*FileStore)(nil)
+
+type line struct {
+	Kind       string                     `json:"kind"`
+	Connection *api.ConnectionRecord      `json:"connection,omitempty"`
+	Request    *api.ApplicationFlowRecord `json:"request,omitempty"`
+}
+
+// NewFileStore creates a FileStore in dir, creating the directory if
+// necessary and removing any files that have passed the retention period.
+func NewFileStore(dir string, retention time.Duration) (*FileStore, error) {
+	if retention <= 0 {
+		return nil, fmt.Errorf("flow history retention must be positive, got %s", retention)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating flow history directory: %w", err)
+	}
+	s := &FileStore{
+		dir:       dir,
+		retention: retention,
+		now:       time.Now,
+	}
+	if err := s.prune(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) Archive(connections []api.ConnectionRecord, requests []api.ApplicationFlowRecord) error {
+	segments := map[string][]line{}
+	for i := range connections {
+		name := s.segmentFor(connections[i])
+		segments[name] = append(segments[name], line{Kind: kindConnection, Connection: &connections[i]})
+	}
+	for i := range requests {
+		name := s.segmentFor(requests[i])
+		segments[name] = append(segments[name], line{Kind: kindRequest, Request: &requests[i]})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for name, lines := range segments {
+		if err := s.append(name, lines); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.prune(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *FileStore) Connections(start, end uint64) ([]api.ConnectionRecord, error) {
+	var results []api.ConnectionRecord
+	err := s.scan(start, func(l line) {
+		if l.Kind == kindConnection && l.Connection != nil && intersects(*l.Connection, start, end) {
+			results = append(results, *l.Connection)
+		}
+	})
+	return results, err
+}
+
+func (s *FileStore) ApplicationFlows(start, end uint64) ([]api.ApplicationFlowRecord, error) {
+	var results []api.ApplicationFlowRecord
+	err := s.scan(start, func(l line) {
+		if l.Kind == kindRequest && l.Request != nil && intersects(*l.Request, start, end) {
+			results = append(results, *l.Request)
+		}
+	})
+	return results, err
+}
+
+// segmentFor returns the name of the file for a record, by its end time
+// or, for records that never terminated, its start time.
+func (s *FileStore) segmentFor(record api.Record) string {
+	t := s.now()
+	if ts := record.GetEndTime(); ts != 0 {
+		t = time.UnixMicro(int64(ts))
+	} else if ts := record.GetStartTime(); ts != 0 {
+		t = time.UnixMicro(int64(ts))
+	}
+	return t.UTC().Format(segmentLayout) + segmentSuffix
+}
+
+func (s *FileStore) append(name string, lines []line) error {
+	file, err := os.OpenFile(filepath.Join(s.dir, name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, l := range lines {
+		if err := enc.Encode(l); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// scan calls fn for each record in the files that may hold records that
+// ended at or after start. Lines that cannot be decoded, such as one left
+// incomplete by a crash, are skipped.
+func (s *FileStore) scan(start uint64, fn func(line)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segments, err := s.segments()
+	if err != nil {
+		return err
+	}
+	from := time.UnixMicro(int64(start))
+	for _, segment := range segments {
+		if segment.start.Add(segmentDuration).Before(from) {
+			continue
+		}
+		file, err := os.Open(filepath.Join(s.dir, segment.name))
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var l line
+			if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+				continue
+			}
+			fn(l)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("error reading flow history file %s: %w", segment.name, err)
+		}
+	}
+	return nil
+}
+
+// prune removes files holding only records that ended before the
+// retention period.
+func (s *FileStore) prune() error {
+	segments, err := s.segments()
+	if err != nil {
+		return err
+	}
+	cutoff := s.now().Add(-s.retention)
+	var errs []error
+	for _, segment := range segments {
+		if !segment.start.Add(segmentDuration).Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, segment.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type segment struct {
+	name  string
+	start time.Time
+}
+
+func (s *FileStore) segments() ([]segment, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading flow history directory: %w", err)
+	}
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		start, err := time.ParseInLocation(segmentLayout, strings.TrimSuffix(name, segmentSuffix), time.UTC)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{name: name, start: start})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].start.Before(segments[j].start)
+	})
+	return segments, nil
+}
@@ -0,0 +1,117 @@
+package history
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func micros(t time.Time) uint64 {
+	return uint64(t.UnixMicro())
+}
+
+func connectionIDs(records []api.ConnectionRecord) []string {
+	ids := []string{}
+	for _, r := range records {
+		ids = append(ids, r.Identity)
+	}
+	return ids
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Hour).Add(-30 * time.Minute)
+	s, err := NewFileStore(dir, 24*time.Hour)
+	assert.NilError(t, err)
+	s.now = func() time.Time { return now }
+
+	assert.NilError(t, s.Archive(
+		[]api.ConnectionRecord{
+			{Identity: "conn:1", StartTime: micros(now.Add(-3 * time.Hour)), EndTime: micros(now.Add(-2 * time.Hour))},
+			{Identity: "conn:2", StartTime: micros(now.Add(-90 * time.Minute)), EndTime: micros(now.Add(-time.Hour))},
+			{Identity: "conn:3", StartTime: micros(now.Add(-30 * time.Minute))},
+		},
+		[]api.ApplicationFlowRecord{
+			{Identity: "req:1", ConnectionId: "conn:2", StartTime: micros(now.Add(-80 * time.Minute)), EndTime: micros(now.Add(-79 * time.Minute))},
+		},
+	))
+
+	all, err := s.Connections(0, micros(now))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(all), []string{"conn:1", "conn:2", "conn:3"})
+
+	window, err := s.Connections(micros(now.Add(-100*time.Minute)), micros(now.Add(-70*time.Minute)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(window), []string{"conn:2"})
+
+	// records that never terminated intersect any later window
+	recent, err := s.Connections(micros(now.Add(-time.Minute)), micros(now))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(recent), []string{"conn:3"})
+
+	requests, err := s.ApplicationFlows(0, micros(now))
+	assert.NilError(t, err)
+	assert.Equal(t, len(requests), 1)
+	assert.Equal(t, requests[0].ConnectionId, "conn:2")
+
+	// history survives a restart, skipping anything left incomplete
+	segment := filepath.Join(dir, now.Add(-time.Hour).Format(segmentLayout)+segmentSuffix)
+	file, err := os.OpenFile(segment, os.O_APPEND|os.O_WRONLY, 0)
+	assert.NilError(t, err)
+	_, err = file.WriteString(`{"kind":"connection","connection":{"ident`)
+	assert.NilError(t, err)
+	assert.NilError(t, file.Close())
+	s, err = NewFileStore(dir, 24*time.Hour)
+	assert.NilError(t, err)
+	s.now = func() time.Time { return now }
+	all, err = s.Connections(0, micros(now))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(all), []string{"conn:1", "conn:2", "conn:3"})
+
+	// records are removed once they pass the retention period
+	oldest := filepath.Join(dir, now.Add(-2*time.Hour).Format(segmentLayout)+segmentSuffix)
+	now = now.Add(23 * time.Hour)
+	assert.NilError(t, s.Archive(nil, nil))
+	all, err = s.Connections(0, micros(now))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(all), []string{"conn:2", "conn:3"})
+	_, err = os.Stat(oldest)
+	assert.Assert(t, os.IsNotExist(err))
+
+	_, err = NewFileStore(dir, 0)
+	assert.Error(t, err, "flow history retention must be positive, got 0s")
+}
+
+func TestArchiver(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flows := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	start := time.Now().Add(-time.Hour)
+	flows.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1", start, start.Add(time.Minute)), Octets: ptrTo(uint64(42))}, store.SourceRef{})
+	expired := []store.Entry{
+		{Record: collector.ConnectionRecord{ID: "flow:1", Protocol: "tcp", RoutingKey: "backend", FlowStore: flows}},
+		// no longer has a flow
+		{Record: collector.ConnectionRecord{ID: "flow:2", FlowStore: flows}},
+	}
+
+	history, err := NewFileStore(t.TempDir(), time.Hour*24)
+	assert.NilError(t, err)
+	NewArchiver(slog.Default(), stor, history)(expired)
+
+	connections, err := history.Connections(0, micros(time.Now()))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, connectionIDs(connections), []string{"flow:1"})
+	assert.Equal(t, connections[0].Octets, uint64(42))
+	assert.Equal(t, connections[0].RoutingKey, "backend")
+}
+
+func ptrTo[T any](c T) *T {
+	return &c
+}
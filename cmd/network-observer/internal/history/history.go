@@ -0,0 +1,49 @@
+// Package history persists connection and application flow records once
+// the collector no longer holds them in memory, so that they can be
+// queried for longer than the flow record TTL and survive a restart.
+package history
+
+import (
+	"log/slog"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// Store retains historical records. Times are in microseconds since the
+// unix epoch, matching the API records.
+type Store interface {
+	// Archive retains the given records.
+	Archive(connections []api.ConnectionRecord, requests []api.ApplicationFlowRecord) error
+	// Connections returns the retained connections with a lifetime that
+	// intersects the range from start to end.
+	Connections(start, end uint64) ([]api.ConnectionRecord, error)
+	// ApplicationFlows returns the retained application flows with a
+	// lifetime that intersects the range from start to end.
+	ApplicationFlows(start, end uint64) ([]api.ApplicationFlowRecord, error)
+}
+
+// NewArchiver returns a function that archives expired ConnectionRecord and
+// RequestRecord entries in the history Store. It must be called while the
+// flows backing the records can still be read from stor.
+func NewArchiver(logger *slog.Logger, stor store.Interface, history Store) func(expired []store.Entry) {
+	return func(expired []store.Entry) {
+		connections := views.NewConnectionsSliceProvider(stor)(expired)
+		requests := views.NewRequestSliceProvider(stor)(expired)
+		if len(connections) == 0 && len(requests) == 0 {
+			return
+		}
+		if err := history.Archive(connections, requests); err != nil {
+			logger.Error("failed to archive flow records",
+				slog.Int("connections", len(connections)),
+				slog.Int("requests", len(requests)),
+				slog.Any("error", err))
+		}
+	}
+}
+
+func intersects(record api.Record, start, end uint64) bool {
+	recordEnd := record.GetEndTime()
+	return record.GetStartTime() <= end && (recordEnd == 0 || recordEnd >= start)
+}
@@ -0,0 +1,358 @@
+// Package otlp exports the connections and requests observed by the
+// collector to an OpenTelemetry collector, as spans and metrics sent using
+// the OTLP/HTTP protocol with JSON encoding.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/version"
+)
+
+const (
+	scopeName = "github.com/skupperproject/skupper/cmd/network-observer"
+
+	// spans queued beyond this many between exports are dropped
+	maxQueuedSpans = 10000
+)
+
+type Config struct {
+	// Endpoint is the base URL of the OTLP/HTTP receiver, for example
+	// http://otel-collector:4318. Spans are sent to /v1/traces and metrics
+	// to /v1/metrics beneath it.
+	Endpoint string
+	// Headers are added to each export request, for example to
+	// authenticate with the receiver.
+	Headers map[string]string
+	// Interval between exports, ten seconds when not set.
+	Interval time.Duration
+	// ServiceName identifies this network observer in the exported
+	// resource.
+	ServiceName string
+	TLSConfig   *tls.Config
+}
+
+// Exporter is a collector.FlowExporter that queues spans for terminated
+// connections and requests, and accumulates metrics from them, sending
+// both to an OTLP receiver at each interval.
+type Exporter struct {
+	logger   *slog.Logger
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+	interval time.Duration
+	resource resource
+	start    time.Time
+
+	mu      sync.Mutex
+	spans   []span
+	dropped int
+	sums    map[seriesKey]int64
+}
+
+var _ collector.FlowExporter = (*Exporter)(nil)
+
+func New(logger *slog.Logger, cfg Config) *Exporter {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg.TLSConfig
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "skupper-network-observer"
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &Exporter{
+		logger:   logger,
+		client:   &http.Client{Transport: transport, Timeout: 10 * time.Second},
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		headers:  cfg.Headers,
+		interval: interval,
+		resource: resource{
+			Attributes: []keyValue{
+				stringAttr("service.name", serviceName),
+				stringAttr("service.version", version.Version),
+			},
+		},
+		start: time.Now(),
+		sums:  make(map[seriesKey]int64),
+	}
+}
+
+// ParseHeaders parses headers in the comma separated key=value form used by
+// OTEL_EXPORTER_OTLP_HEADERS.
+func ParseHeaders(in string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, part := range strings.Split(in, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q: expected key=value", part)
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// Run exports at each interval until the context is cancelled, when any
+// remaining spans and the final metrics are exported.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			e.flush(flushCtx)
+			return nil
+		case <-ticker.C:
+			e.flush(ctx)
+		}
+	}
+}
+
+func (e *Exporter) ExportConnection(connection collector.ConnectionRecord, flow vanflow.TransportBiflowRecord) {
+	attrs := newAttributes()
+	attrs.string("skupper.flow.id", flow.ID)
+	attrs.string("skupper.routing_key", connection.RoutingKey)
+	attrs.string("network.protocol.name", connection.Protocol)
+	attrs.string("skupper.listener.id", connection.Listener.ID)
+	attrs.string("skupper.connector.id", connection.Connector.ID)
+	attrs.string("server.address", connection.ConnectorHost)
+	attrs.string("server.port", connection.ConnectorPort)
+	attrs.string("client.address", dref(flow.SourceHost))
+	attrs.string("client.port", dref(flow.SourcePort))
+	attrs.endpoints(connection.Source, connection.SourceSite, connection.SourceRouter, connection.Dest, connection.DestSite, connection.DestRouter)
+	attrs.int("skupper.octets", flow.Octets)
+	attrs.int("skupper.octets_reverse", flow.OctetsReverse)
+	attrs.int("skupper.latency_us", flow.Latency)
+	attrs.int("skupper.latency_reverse_us", flow.LatencyReverse)
+
+	var errs []string
+	if flow.ErrorListener != nil {
+		errs = append(errs, "listener: "+*flow.ErrorListener)
+	}
+	if flow.ErrorConnector != nil {
+		errs = append(errs, "connector: "+*flow.ErrorConnector)
+	}
+	s := span{
+		TraceID:           traceID(flow.ID),
+		SpanID:            spanID(flow.ID),
+		Name:              connection.RoutingKey,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: unixNano(flowTime(flow.StartTime)),
+		EndTimeUnixNano:   unixNano(flowTime(flow.EndTime)),
+		Attributes:        attrs.values,
+	}
+	if len(errs) > 0 {
+		s.Status = status{Code: statusCodeError, Message: strings.Join(errs, ", ")}
+	}
+
+	series := seriesFor(connection.RoutingKey, connection.Protocol, connection.SourceSite, connection.DestSite, connection.Source, connection.Dest)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queue(s)
+	e.sums[series.metric(metricConnections)] += 1
+	e.sums[series.metric(metricOctetsSent)] += int64(dref(flow.Octets))
+	e.sums[series.metric(metricOctetsReceived)] += int64(dref(flow.OctetsReverse))
+}
+
+func (e *Exporter) ExportRequest(request collector.RequestRecord, flow vanflow.AppBiflowRecord) {
+	method := dref(flow.Method)
+	attrs := newAttributes()
+	attrs.string("skupper.flow.id", flow.ID)
+	attrs.string("skupper.connection.id", request.TransportID)
+	attrs.string("skupper.routing_key", request.RoutingKey)
+	attrs.string("network.protocol.name", request.Protocol)
+	attrs.string("skupper.listener.id", request.Listener.ID)
+	attrs.string("skupper.connector.id", request.Connector.ID)
+	attrs.endpoints(request.Source, request.SourceSite, request.SourceRouter, request.Dest, request.DestSite, request.DestRouter)
+	attrs.string("http.request.method", method)
+	code, err := strconv.Atoi(dref(flow.Result))
+	if err == nil {
+		attrs.values = append(attrs.values, intAttr("http.response.status_code", int64(code)))
+	}
+	attrs.int("skupper.octets", flow.Octets)
+	attrs.int("skupper.octets_reverse", flow.OctetsReverse)
+
+	name := request.RoutingKey
+	if method != "" {
+		name = method + " " + name
+	}
+	s := span{
+		TraceID:           traceID(request.TransportID),
+		SpanID:            spanID(flow.ID),
+		ParentSpanID:      spanID(request.TransportID),
+		Name:              name,
+		Kind:              spanKindServer,
+		StartTimeUnixNano: unixNano(flowTime(flow.StartTime)),
+		EndTimeUnixNano:   unixNano(flowTime(flow.EndTime)),
+		Attributes:        attrs.values,
+	}
+	if code >= 500 {
+		s.Status = status{Code: statusCodeError}
+	}
+
+	series := seriesFor(request.RoutingKey, request.Protocol, request.SourceSite, request.DestSite, request.Source, request.Dest)
+	series.Method = method
+	if err == nil {
+		series.Status = strconv.Itoa(code)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queue(s)
+	e.sums[series.metric(metricRequests)] += 1
+}
+
+func (e *Exporter) queue(s span) {
+	if len(e.spans) >= maxQueuedSpans {
+		e.dropped++
+		return
+	}
+	e.spans = append(e.spans, s)
+}
+
+func (e *Exporter) flush(ctx context.Context) {
+	now := time.Now()
+	e.mu.Lock()
+	spans, dropped := e.spans, e.dropped
+	e.spans, e.dropped = nil, 0
+	metrics := e.metrics(now)
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		e.logger.Warn("dropped spans exceeding export queue", slog.Int("count", dropped))
+	}
+	if len(spans) > 0 {
+		req := exportTraceServiceRequest{
+			ResourceSpans: []resourceSpans{{
+				Resource:   e.resource,
+				ScopeSpans: []scopeSpans{{Scope: e.scope(), Spans: spans}},
+			}},
+		}
+		if err := e.post(ctx, "/v1/traces", req); err != nil {
+			e.logger.Error("failed to export spans", slog.Int("count", len(spans)), slog.Any("error", err))
+		}
+	}
+	if len(metrics) > 0 {
+		req := exportMetricsServiceRequest{
+			ResourceMetrics: []resourceMetrics{{
+				Resource:     e.resource,
+				ScopeMetrics: []scopeMetrics{{Scope: e.scope(), Metrics: metrics}},
+			}},
+		}
+		if err := e.post(ctx, "/v1/metrics", req); err != nil {
+			e.logger.Error("failed to export metrics", slog.Any("error", err))
+		}
+	}
+}
+
+func (e *Exporter) scope() scope {
+	return scope{Name: scopeName, Version: version.Version}
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+type attributes struct {
+	values []keyValue
+}
+
+func newAttributes() *attributes {
+	return &attributes{}
+}
+
+func (a *attributes) string(key, value string) {
+	if value != "" {
+		a.values = append(a.values, stringAttr(key, value))
+	}
+}
+
+func (a *attributes) int(key string, value *uint64) {
+	if value != nil {
+		a.values = append(a.values, intAttr(key, int64(*value)))
+	}
+}
+
+func (a *attributes) endpoints(source, sourceSite, sourceRouter, dest, destSite, destRouter collector.NamedReference) {
+	a.string("skupper.source.process.id", source.ID)
+	a.string("skupper.source.process.name", source.Name)
+	a.string("skupper.source.site.id", sourceSite.ID)
+	a.string("skupper.source.site.name", sourceSite.Name)
+	a.string("skupper.source.router.name", sourceRouter.Name)
+	a.string("skupper.dest.process.id", dest.ID)
+	a.string("skupper.dest.process.name", dest.Name)
+	a.string("skupper.dest.site.id", destSite.ID)
+	a.string("skupper.dest.site.name", destSite.Name)
+	a.string("skupper.dest.router.name", destRouter.Name)
+}
+
+// traceID and spanID derive stable identifiers from flow IDs, so that the
+// span for a request shares the trace of, and is the child of, the span
+// for its connection.
+func traceID(flowID string) string {
+	sum := sha256.Sum256([]byte("trace:" + flowID))
+	return hex.EncodeToString(sum[:16])
+}
+
+func spanID(flowID string) string {
+	sum := sha256.Sum256([]byte("span:" + flowID))
+	return hex.EncodeToString(sum[:8])
+}
+
+func flowTime(t *vanflow.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
@@ -0,0 +1,267 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"gotest.tools/v3/assert"
+)
+
+// receiver stands in for an OpenTelemetry collector's OTLP/HTTP receiver.
+type receiver struct {
+	mu      sync.Mutex
+	traces  []exportTraceServiceRequest
+	metrics []exportMetricsServiceRequest
+	headers []http.Header
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	r.headers = append(r.headers, req.Header.Clone())
+	var err error
+	switch req.URL.Path {
+	case "/v1/traces":
+		var body exportTraceServiceRequest
+		err = json.NewDecoder(req.Body).Decode(&body)
+		r.traces = append(r.traces, body)
+	case "/v1/metrics":
+		var body exportMetricsServiceRequest
+		err = json.NewDecoder(req.Body).Decode(&body)
+		r.metrics = append(r.metrics, body)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Write([]byte("{}"))
+}
+
+func ptrTo[T any](c T) *T {
+	return &c
+}
+
+func attrMap(attrs []keyValue) map[string]string {
+	out := map[string]string{}
+	for _, kv := range attrs {
+		switch {
+		case kv.Value.StringValue != nil:
+			out[kv.Key] = *kv.Value.StringValue
+		case kv.Value.IntValue != nil:
+			out[kv.Key] = *kv.Value.IntValue
+		}
+	}
+	return out
+}
+
+func testRecords() (collector.ConnectionRecord, vanflow.TransportBiflowRecord, collector.RequestRecord, vanflow.AppBiflowRecord) {
+	t0 := time.Date(2024, time.June, 1, 10, 0, 0, 0, time.UTC)
+	connection := collector.ConnectionRecord{
+		ID:            "flow:1",
+		RoutingKey:    "backend",
+		Protocol:      "tcp",
+		ConnectorHost: "10.0.0.5",
+		ConnectorPort: "8080",
+		Listener:      collector.NamedReference{ID: "listener:1"},
+		Connector:     collector.NamedReference{ID: "connector:1"},
+		Source:        collector.NamedReference{ID: "p1", Name: "frontend"},
+		SourceSite:    collector.NamedReference{ID: "site-a", Name: "east"},
+		SourceRouter:  collector.NamedReference{ID: "router-a", Name: "east-router"},
+		Dest:          collector.NamedReference{ID: "p2", Name: "backend-pod"},
+		DestSite:      collector.NamedReference{ID: "site-b", Name: "west"},
+		DestRouter:    collector.NamedReference{ID: "router-b", Name: "west-router"},
+	}
+	transport := vanflow.TransportBiflowRecord{
+		BaseRecord:     vanflow.NewBase("flow:1", t0, t0.Add(time.Second)),
+		SourceHost:     ptrTo("10.0.0.1"),
+		SourcePort:     ptrTo("54321"),
+		Octets:         ptrTo(uint64(100)),
+		OctetsReverse:  ptrTo(uint64(2000)),
+		ErrorConnector: ptrTo("connection refused"),
+	}
+	request := collector.RequestRecord{
+		ID:           "req:1",
+		TransportID:  "flow:1",
+		RoutingKey:   connection.RoutingKey,
+		Protocol:     "http1",
+		Listener:     connection.Listener,
+		Connector:    connection.Connector,
+		Source:       connection.Source,
+		SourceSite:   connection.SourceSite,
+		SourceRouter: connection.SourceRouter,
+		Dest:         connection.Dest,
+		DestSite:     connection.DestSite,
+		DestRouter:   connection.DestRouter,
+	}
+	app := vanflow.AppBiflowRecord{
+		BaseRecord: vanflow.NewBase("req:1", t0.Add(time.Millisecond), t0.Add(2*time.Millisecond)),
+		Parent:     ptrTo("flow:1"),
+		Method:     ptrTo("GET"),
+		Result:     ptrTo("503"),
+	}
+	return connection, transport, request, app
+}
+
+func TestExporter(t *testing.T) {
+	collectorStandIn := &receiver{}
+	srv := httptest.NewServer(collectorStandIn)
+	defer srv.Close()
+
+	exporter := New(slog.Default(), Config{
+		Endpoint: srv.URL + "/",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Interval: time.Hour,
+	})
+	connection, transport, request, app := testRecords()
+	exporter.ExportConnection(connection, transport)
+	exporter.ExportRequest(request, app)
+	exporter.ExportConnection(connection, transport)
+	exporter.flush(context.TODO())
+
+	assert.Equal(t, len(collectorStandIn.traces), 1)
+	assert.Equal(t, len(collectorStandIn.metrics), 1)
+	for _, h := range collectorStandIn.headers {
+		assert.Equal(t, h.Get("Authorization"), "Bearer token")
+	}
+
+	rs := collectorStandIn.traces[0].ResourceSpans[0]
+	assert.Equal(t, attrMap(rs.Resource.Attributes)["service.name"], "skupper-network-observer")
+	assert.Equal(t, rs.ScopeSpans[0].Scope.Name, scopeName)
+	spans := rs.ScopeSpans[0].Spans
+	assert.Equal(t, len(spans), 3)
+
+	conn := spans[0]
+	assert.Equal(t, conn.Name, "backend")
+	assert.Equal(t, conn.Kind, spanKindInternal)
+	assert.Equal(t, len(conn.TraceID), 32)
+	assert.Equal(t, len(conn.SpanID), 16)
+	assert.Equal(t, conn.StartTimeUnixNano, "1717236000000000000")
+	assert.Equal(t, conn.EndTimeUnixNano, "1717236001000000000")
+	assert.DeepEqual(t, conn.Status, status{Code: statusCodeError, Message: "connector: connection refused"})
+	assert.DeepEqual(t, attrMap(conn.Attributes), map[string]string{
+		"skupper.flow.id":             "flow:1",
+		"skupper.routing_key":         "backend",
+		"network.protocol.name":       "tcp",
+		"skupper.listener.id":         "listener:1",
+		"skupper.connector.id":        "connector:1",
+		"server.address":              "10.0.0.5",
+		"server.port":                 "8080",
+		"client.address":              "10.0.0.1",
+		"client.port":                 "54321",
+		"skupper.source.process.id":   "p1",
+		"skupper.source.process.name": "frontend",
+		"skupper.source.site.id":      "site-a",
+		"skupper.source.site.name":    "east",
+		"skupper.source.router.name":  "east-router",
+		"skupper.dest.process.id":     "p2",
+		"skupper.dest.process.name":   "backend-pod",
+		"skupper.dest.site.id":        "site-b",
+		"skupper.dest.site.name":      "west",
+		"skupper.dest.router.name":    "west-router",
+		"skupper.octets":              "100",
+		"skupper.octets_reverse":      "2000",
+	})
+
+	// requests are children of the span for their connection
+	req := spans[1]
+	assert.Equal(t, req.Name, "GET backend")
+	assert.Equal(t, req.Kind, spanKindServer)
+	assert.Equal(t, req.TraceID, conn.TraceID)
+	assert.Equal(t, req.ParentSpanID, conn.SpanID)
+	assert.Assert(t, req.SpanID != conn.SpanID)
+	assert.Equal(t, req.Status.Code, statusCodeError)
+	reqAttrs := attrMap(req.Attributes)
+	assert.Equal(t, reqAttrs["http.request.method"], "GET")
+	assert.Equal(t, reqAttrs["http.response.status_code"], "503")
+	assert.Equal(t, reqAttrs["skupper.connection.id"], "flow:1")
+
+	metrics := collectorStandIn.metrics[0].ResourceMetrics[0].ScopeMetrics[0].Metrics
+	values := map[string]string{}
+	for _, m := range metrics {
+		assert.Equal(t, m.Sum.AggregationTemporality, temporalityCumulative)
+		assert.Assert(t, m.Sum.IsMonotonic)
+		assert.Equal(t, len(m.Sum.DataPoints), 1)
+		point := m.Sum.DataPoints[0]
+		values[m.Name] = point.AsInt
+		attrs := attrMap(point.Attributes)
+		assert.Equal(t, attrs["skupper.source.site.name"], "east")
+		assert.Equal(t, attrs["skupper.dest.process.name"], "backend-pod")
+		assert.Equal(t, attrs["skupper.flow.id"], "")
+	}
+	assert.DeepEqual(t, values, map[string]string{
+		"skupper.connections":                "2",
+		"skupper.connection.octets.sent":     "200",
+		"skupper.connection.octets.received": "4000",
+		"skupper.requests":                   "1",
+	})
+
+	// metrics are cumulative, spans are only sent once
+	exporter.flush(context.TODO())
+	assert.Equal(t, len(collectorStandIn.traces), 1)
+	assert.Equal(t, len(collectorStandIn.metrics), 2)
+}
+
+func TestExporterRun(t *testing.T) {
+	collectorStandIn := &receiver{}
+	srv := httptest.NewServer(collectorStandIn)
+	defer srv.Close()
+
+	exporter := New(slog.Default(), Config{Endpoint: srv.URL, Interval: time.Hour})
+	connection, transport, _, _ := testRecords()
+	exporter.ExportConnection(connection, transport)
+
+	// remaining spans are exported on shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- exporter.Run(ctx) }()
+	cancel()
+	assert.NilError(t, <-done)
+	assert.Equal(t, len(collectorStandIn.traces), 1)
+	assert.Equal(t, len(collectorStandIn.traces[0].ResourceSpans[0].ScopeSpans[0].Spans), 1)
+}
+
+func TestExporterQueueLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	exporter := New(slog.Default(), Config{Endpoint: srv.URL})
+	connection, transport, _, _ := testRecords()
+	for i := 0; i < maxQueuedSpans+5; i++ {
+		exporter.ExportConnection(connection, transport)
+	}
+	assert.Equal(t, len(exporter.spans), maxQueuedSpans)
+	assert.Equal(t, exporter.dropped, 5)
+	// failed exports are not retried
+	exporter.flush(context.TODO())
+	assert.Equal(t, len(exporter.spans), 0)
+	assert.Equal(t, exporter.dropped, 0)
+	assert.Equal(t, exporter.interval, 10*time.Second)
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders("Authorization=Bearer abc, x-tenant = blue,")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, headers, map[string]string{"Authorization": "Bearer abc", "x-tenant": "blue"})
+	headers, err = ParseHeaders("")
+	assert.NilError(t, err)
+	assert.Equal(t, len(headers), 0)
+	_, err = ParseHeaders("novalue")
+	assert.Error(t, err, `invalid header "novalue": expected key=value`)
+}
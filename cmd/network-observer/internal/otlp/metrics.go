@@ -0,0 +1,130 @@
+package otlp
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+)
+
+type metricDefinition struct {
+	Name        string
+	Description string
+	Unit        string
+}
+
+var (
+	metricConnections = metricDefinition{
+		Name:        "skupper.connections",
+		Description: "Connections that have terminated",
+		Unit:        "{connection}",
+	}
+	metricOctetsSent = metricDefinition{
+		Name:        "skupper.connection.octets.sent",
+		Description: "Octets sent from the source to the destination of terminated connections",
+		Unit:        "By",
+	}
+	metricOctetsReceived = metricDefinition{
+		Name:        "skupper.connection.octets.received",
+		Description: "Octets received by the source from the destination of terminated connections",
+		Unit:        "By",
+	}
+	metricRequests = metricDefinition{
+		Name:        "skupper.requests",
+		Description: "Requests that have completed",
+		Unit:        "{request}",
+	}
+	metricDefinitions = []metricDefinition{metricConnections, metricOctetsSent, metricOctetsReceived, metricRequests}
+)
+
+// series is the set of attributes a metric is recorded against. It leaves
+// out those, like flow IDs and client ports, that would be unbounded.
+type series struct {
+	RoutingKey string
+	Protocol   string
+	SourceSite string
+	DestSite   string
+	SourceProc string
+	DestProc   string
+	Method     string
+	Status     string
+}
+
+type seriesKey struct {
+	Metric string
+	series
+}
+
+func seriesFor(routingKey, protocol string, sourceSite, destSite, source, dest collector.NamedReference) series {
+	return series{
+		RoutingKey: routingKey,
+		Protocol:   protocol,
+		SourceSite: sourceSite.Name,
+		DestSite:   destSite.Name,
+		SourceProc: source.Name,
+		DestProc:   dest.Name,
+	}
+}
+
+func (s series) metric(def metricDefinition) seriesKey {
+	return seriesKey{Metric: def.Name, series: s}
+}
+
+func (s series) attributes() []keyValue {
+	attrs := newAttributes()
+	attrs.string("skupper.routing_key", s.RoutingKey)
+	attrs.string("network.protocol.name", s.Protocol)
+	attrs.string("skupper.source.site.name", s.SourceSite)
+	attrs.string("skupper.dest.site.name", s.DestSite)
+	attrs.string("skupper.source.process.name", s.SourceProc)
+	attrs.string("skupper.dest.process.name", s.DestProc)
+	attrs.string("http.request.method", s.Method)
+	attrs.string("http.response.status_code", s.Status)
+	return attrs.values
+}
+
+// metrics returns the cumulative sums accumulated since the Exporter was
+// created. It must be called with the lock held.
+func (e *Exporter) metrics(now time.Time) []metric {
+	points := map[string][]numberDataPoint{}
+	keys := make([]seriesKey, 0, len(e.sums))
+	for key := range e.sums {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+	for _, key := range keys {
+		points[key.Metric] = append(points[key.Metric], numberDataPoint{
+			Attributes:        key.attributes(),
+			StartTimeUnixNano: unixNano(e.start),
+			TimeUnixNano:      unixNano(now),
+			AsInt:             strconv.FormatInt(e.sums[key], 10),
+		})
+	}
+	var metrics []metric
+	for _, def := range metricDefinitions {
+		if len(points[def.Name]) == 0 {
+			continue
+		}
+		metrics = append(metrics, metric{
+			Name:        def.Name,
+			Description: def.Description,
+			Unit:        def.Unit,
+			Sum: &sum{
+				AggregationTemporality: temporalityCumulative,
+				IsMonotonic:            true,
+				DataPoints:             points[def.Name],
+			},
+		})
+	}
+	return metrics
+}
+
+func (k seriesKey) less(o seriesKey) bool {
+	a := []string{k.Metric, k.RoutingKey, k.Protocol, k.SourceSite, k.DestSite, k.SourceProc, k.DestProc, k.Method, k.Status}
+	b := []string{o.Metric, o.RoutingKey, o.Protocol, o.SourceSite, o.DestSite, o.SourceProc, o.DestProc, o.Method, o.Status}
+	return slices.Compare(a, b) < 0
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/topology"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+type siteScopeKey struct{}
+
+// siteScope is the set of IDs of the sites a request may see, along with
+// the records and graph used to find the sites of records that do not name
+// one. A nil siteScope includes every site.
+type siteScope struct {
+	sites   map[string]struct{}
+	records store.Interface
+	graph   collector.Graph
+	// groups holds the names of the process groups with a process in one of
+	// the sites, found on first use
+	groups map[string]struct{}
+}
+
+// Authorize restricts the records and topology history served for a
+// request to those of the sites the policy allows the authenticated user
+// to see. Records are placed in a site through their parent: routers,
+// links, listeners, connectors and router access by the site they belong
+// to, addresses by the sites of their listeners and connectors and
+// process groups by the sites of their processes. Connections, application
+// flows and pairs are included when either their source or destination is.
+func Authorize(records store.Interface, graph collector.Graph, policy *auth.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if policy == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := auth.UserFromContext(r.Context())
+			scope := func() *siteScope {
+				return &siteScope{
+					sites:   allowedSites(records, policy, user),
+					records: records,
+					graph:   graph,
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), siteScopeKey{}, scope)))
+		})
+	}
+}
+
+// RequireAllSites forbids requests from users the policy does not allow to
+// see every site, for handlers serving data that cannot be restricted to
+// the sites of a user, such as the Prometheus proxy.
+func RequireAllSites(policy *auth.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if policy == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := auth.UserFromContext(r.Context())
+			if !policy.AllowsAll(user) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func allowedSites(records store.Interface, policy *auth.Policy, user *auth.User) map[string]struct{} {
+	sites := map[string]struct{}{}
+	for _, entry := range listByType[vanflow.SiteRecord](records) {
+		site, ok := entry.Record.(vanflow.SiteRecord)
+		if !ok {
+			continue
+		}
+		var name, namespace string
+		if site.Name != nil {
+			name = *site.Name
+		}
+		if site.Namespace != nil {
+			namespace = *site.Namespace
+		}
+		if policy.Allows(user, name, namespace) {
+			sites[site.ID] = struct{}{}
+		}
+	}
+	return sites
+}
+
+// scopeFromRequest returns the sites the request may currently see.
+func scopeFromRequest(r *http.Request) *siteScope {
+	scope, ok := r.Context().Value(siteScopeKey{}).(func() *siteScope)
+	if !ok {
+		return nil
+	}
+	return scope()
+}
+
+// includes returns true if the record belongs to one of the sites in scope.
+// Records of any other type are never included.
+func (s *siteScope) includes(record any) bool {
+	if s == nil {
+		return true
+	}
+	switch record := record.(type) {
+	case api.SiteRecord:
+		return s.has(record.Identity)
+	case api.RouterRecord:
+		return s.has(record.Parent)
+	case api.LinkRecord:
+		return s.has(record.SourceSiteId)
+	case api.RouterLinkRecord:
+		return s.has(record.SourceSiteId)
+	case api.ListenerRecord:
+		return s.has(record.SiteId)
+	case api.ConnectorRecord:
+		return s.has(record.SiteId)
+	case api.RouterAccessRecord:
+		return s.has(s.graph.RouterAccess(record.Identity).Parent().Parent().ID())
+	case api.AddressRecord:
+		return s.hasAddress(record.Identity)
+	case api.ProcessRecord:
+		return s.has(record.Parent)
+	case api.ProcessGroupRecord:
+		return s.hasGroup(record.Name)
+	case api.FlowAggregateRecord:
+		switch record.PairType {
+		case api.SITE:
+			return s.has(record.SourceId) || s.has(record.DestinationId)
+		case api.PROCESS:
+			return s.hasProcess(record.SourceId) || s.hasProcess(record.DestinationId)
+		case api.PROCESSGROUP:
+			return s.hasGroup(record.SourceName) || s.hasGroup(record.DestinationName)
+		}
+		return false
+	case api.ConnectionRecord:
+		return s.has(record.SourceSiteId) || s.has(record.DestSiteId)
+	case api.ApplicationFlowRecord:
+		return s.has(record.SourceSiteId) || s.has(record.DestSiteId)
+	case topology.Event:
+		return s.has(record.Element.SiteID)
+	case topology.Element:
+		return s.has(record.SiteID)
+	default:
+		return false
+	}
+}
+
+func (s *siteScope) has(id string) bool {
+	if id == "" {
+		return false
+	}
+	_, ok := s.sites[id]
+	return ok
+}
+
+func (s *siteScope) hasProcess(id string) bool {
+	return s.has(s.graph.Process(id).Parent().ID())
+}
+
+// hasAddress returns true if a listener or connector of the address is in
+// one of the sites.
+func (s *siteScope) hasAddress(id string) bool {
+	routingKey := s.graph.Address(id).RoutingKey()
+	for _, listener := range routingKey.Listeners() {
+		if s.has(listener.Parent().Parent().ID()) {
+			return true
+		}
+	}
+	for _, connector := range routingKey.Connectors() {
+		if s.has(connector.Parent().Parent().ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroup returns true if a process of the named process group is in one
+// of the sites.
+func (s *siteScope) hasGroup(name string) bool {
+	if s.groups == nil {
+		s.groups = map[string]struct{}{}
+		for _, entry := range listByType[vanflow.ProcessRecord](s.records) {
+			process, ok := entry.Record.(vanflow.ProcessRecord)
+			if !ok || process.Group == nil || process.Parent == nil {
+				continue
+			}
+			if s.has(*process.Parent) {
+				s.groups[*process.Group] = struct{}{}
+			}
+		}
+	}
+	_, ok := s.groups[name]
+	return ok
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/history"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	flowHistory, err := history.NewFileStore(t.TempDir(), 24*time.Hour)
+	assert.NilError(t, err)
+	policy := &auth.Policy{Rules: []auth.Rule{
+		{Users: []string{"alice"}, Namespaces: []string{"west"}},
+	}}
+	authorized := Authorize(stor, graph, policy)(api.Handler(New(tlog, stor, graph, flowHistory)))
+	htsrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), &auth.User{Name: "alice"})))
+	}))
+	defer htsrv.Close()
+	c, err := api.NewClientWithResponses(htsrv.URL, api.WithHTTPClient(htsrv.Client()))
+	assert.NilError(t, err)
+
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("west"), Namespace: ptrTo("west")},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s2"), Name: ptrTo("east"), Namespace: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r1"), Parent: ptrTo("s1")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r2"), Parent: ptrTo("s2")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("ra1"), Parent: ptrTo("r1")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("ra2"), Parent: ptrTo("r2")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("l1"), Parent: ptrTo("r1"), Address: ptrTo("pizza"), Protocol: ptrTo("tcp")},
+		vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("c2"), Parent: ptrTo("r2"), Address: ptrTo("icecream"), Protocol: ptrTo("tcp")},
+		collector.AddressRecord{ID: "a1", Name: "pizza", Protocol: "tcp", Start: time.Now()},
+		collector.AddressRecord{ID: "a2", Name: "icecream", Protocol: "tcp", Start: time.Now()},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("s1"), Group: ptrTo("west-group")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p2"), Parent: ptrTo("s2"), Group: ptrTo("east-group")},
+		collector.ProcessGroupRecord{ID: "g1", Name: "west-group", Start: time.Now()},
+		collector.ProcessGroupRecord{ID: "g2", Name: "east-group", Start: time.Now()},
+		collector.SitePairRecord{ID: "sp-west-east", Source: "s1", Dest: "s2", Protocol: "tcp", Start: time.Now()},
+		collector.SitePairRecord{ID: "sp-east-east", Source: "s2", Dest: "s2", Protocol: "tcp", Start: time.Now()},
+	))
+	graph.(reset).Reset()
+	now := time.Now()
+	start, end := uint64(now.Add(-2*time.Minute).UnixMicro()), uint64(now.Add(-time.Minute).UnixMicro())
+	assert.NilError(t, flowHistory.Archive([]api.ConnectionRecord{
+		{Identity: "west-west", SourceSiteId: "s1", DestSiteId: "s1", StartTime: start, EndTime: end},
+		{Identity: "west-east", SourceSiteId: "s1", DestSiteId: "s2", StartTime: start, EndTime: end},
+		{Identity: "east-west", SourceSiteId: "s2", DestSiteId: "s1", StartTime: start, EndTime: end},
+		{Identity: "east-east", SourceSiteId: "s2", DestSiteId: "s2", StartTime: start, EndTime: end},
+	}, nil))
+
+	sites, err := c.SitesWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, sites.StatusCode(), 200)
+	assert.Equal(t, sites.JSON200.Count, int64(1))
+	assert.Equal(t, sites.JSON200.Results[0].Identity, "s1")
+
+	processes, err := c.ProcessesWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, processes.StatusCode(), 200)
+	assert.Equal(t, processes.JSON200.Count, int64(1))
+	assert.Equal(t, processes.JSON200.Results[0].Identity, "p1")
+
+	connections, err := c.ConnectionsWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, connections.StatusCode(), 200)
+	var identities []string
+	for _, connection := range connections.JSON200.Results {
+		identities = append(identities, connection.Identity)
+	}
+	slices.Sort(identities)
+	assert.DeepEqual(t, identities, []string{"east-west", "west-east", "west-west"})
+
+	routers, err := c.RoutersWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, routers.JSON200.Count, int64(1))
+	assert.Equal(t, routers.JSON200.Results[0].Identity, "r1")
+
+	routerAccess, err := c.RouteraccessWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, routerAccess.JSON200.Count, int64(1))
+	assert.Equal(t, routerAccess.JSON200.Results[0].Identity, "ra1")
+
+	listeners, err := c.ListenersWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, listeners.JSON200.Count, int64(1))
+	connectors, err := c.ConnectorsWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, connectors.JSON200.Count, int64(0))
+
+	addresses, err := c.AddressesWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, addresses.JSON200.Count, int64(1))
+	assert.Equal(t, addresses.JSON200.Results[0].Identity, "a1")
+
+	groups, err := c.ProcessgroupsWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, groups.JSON200.Count, int64(1))
+	assert.Equal(t, groups.JSON200.Results[0].Identity, "g1")
+
+	sitePairs, err := c.SitepairsWithResponse(context.TODO())
+	assert.NilError(t, err)
+	assert.Equal(t, sitePairs.JSON200.Count, int64(1))
+	assert.Equal(t, sitePairs.JSON200.Results[0].Identity, "sp-west-east")
+
+	site, err := c.SiteByIdWithResponse(context.TODO(), "s1")
+	assert.NilError(t, err)
+	assert.Equal(t, site.StatusCode(), 200)
+	site, err = c.SiteByIdWithResponse(context.TODO(), "s2")
+	assert.NilError(t, err)
+	assert.Equal(t, site.StatusCode(), 404)
+}
+
+func TestRequireAllSites(t *testing.T) {
+	policy := &auth.Policy{Rules: []auth.Rule{
+		{Users: []string{"alice"}, Namespaces: []string{"west"}},
+		{Users: []string{"admin"}, Namespaces: []string{"*"}},
+	}}
+	handler := RequireAllSites(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for user, expected := range map[string]int{
+		"alice": http.StatusForbidden,
+		"admin": http.StatusNoContent,
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1alpha1/internal/prom/query", nil)
+		handler.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), &auth.User{Name: user})))
+		assert.Equal(t, w.Code, expected, user)
+	}
+}
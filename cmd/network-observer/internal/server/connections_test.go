@@ -9,6 +9,7 @@ import (
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/history"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 	"gotest.tools/v3/assert"
@@ -19,7 +20,7 @@ func TestConnections(t *testing.T) {
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil))
 	defer srv.Close()
 
 	van := []vanflow.Record{
@@ -167,3 +168,46 @@ func TestConnections(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectionsWithHistory(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	flowHistory, err := history.NewFileStore(t.TempDir(), 24*time.Hour)
+	assert.NilError(t, err)
+	srv, c := requireTestClient(t, New(tlog, stor, graph, flowHistory))
+	defer srv.Close()
+
+	now := time.Now()
+	stor.Add(collector.ConnectionRecord{ID: "flow:1", FlowStore: flowStor}, store.SourceRef{})
+	flowStor.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1", now.Add(-time.Minute))}, store.SourceRef{})
+	assert.NilError(t, flowHistory.Archive([]api.ConnectionRecord{
+		// still current, so served from memory
+		{Identity: "flow:1", StartTime: uint64(now.Add(-time.Minute).UnixMicro()), EndTime: uint64(now.UnixMicro())},
+		{Identity: "flow:2", StartTime: uint64(now.Add(-3 * time.Hour).UnixMicro()), EndTime: uint64(now.Add(-2 * time.Hour).UnixMicro())},
+		{Identity: "flow:3", StartTime: uint64(now.Add(-10 * time.Minute).UnixMicro()), EndTime: uint64(now.Add(-5 * time.Minute).UnixMicro())},
+	}, nil))
+
+	identities := func(results []api.ConnectionRecord) []string {
+		ids := []string{}
+		for _, r := range results {
+			ids = append(ids, r.Identity)
+		}
+		return ids
+	}
+
+	resp, err := c.ConnectionsWithResponse(context.TODO(), withParameters(nil))
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.DeepEqual(t, identities(resp.JSON200.Results), []string{"flow:1", "flow:3"})
+	assert.Equal(t, resp.JSON200.Results[0].EndTime, uint64(0))
+
+	resp, err = c.ConnectionsWithResponse(context.TODO(), withParameters(map[string][]string{
+		"timeRangeStart": {fmt.Sprint(now.Add(-4 * time.Hour).UnixMicro())},
+		"timeRangeEnd":   {fmt.Sprint(now.Add(-90 * time.Minute).UnixMicro())},
+	}))
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode(), 200)
+	assert.DeepEqual(t, identities(resp.JSON200.Results), []string{"flow:2"})
+}
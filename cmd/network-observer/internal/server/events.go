@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// eventStreamKeepAlive is how often a comment is written to idle streams
+// so that proxies do not close them.
+const eventStreamKeepAlive = 30 * time.Second
+
+// RecordEvents is a source of changes to the records in the store.
+type RecordEvents interface {
+	Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan collector.RecordEvent
+}
+
+// NewEventStream creates a handler streaming changes to the records of the
+// collection named by the last element of the request path, such as
+// /api/v1alpha1/stream/connections, as server-sent events.
+//
+// The stream starts with an ADDED event for each record matching the
+// request's filters, followed by ADDED, MODIFIED and DELETED events as
+// records start matching, change, and stop matching or are removed. The
+// filters are those accepted by the collection endpoints. Pagination,
+// sorting and time range parameters do not apply to streams.
+func NewEventStream(logger *slog.Logger, records store.Interface, graph collector.Graph, events RecordEvents) http.Handler {
+	return &eventStream{
+		logger:  logger,
+		records: records,
+		graph:   graph,
+		events:  events,
+	}
+}
+
+type eventStream struct {
+	logger  *slog.Logger
+	records store.Interface
+	graph   collector.Graph
+	events  RecordEvents
+}
+
+// eventMessage is the data of each event in a stream. Record is omitted for
+// DELETED events.
+type eventMessage[T api.Record] struct {
+	Type     collector.RecordEventType `json:"type"`
+	Identity string                    `json:"identity"`
+	Record   *T                        `json:"record,omitempty"`
+}
+
+func (s *eventStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+	switch collection := path.Base(strings.TrimSuffix(r.URL.Path, "/")); collection {
+	case "sites":
+		err = streamEvents[vanflow.SiteRecord](s, w, r, views.NewSiteSliceProvider(s.graph))
+	case "routers":
+		err = streamEvents[vanflow.RouterRecord](s, w, r, views.Routers)
+	case "links":
+		err = streamEvents[vanflow.LinkRecord](s, w, r, views.NewLinkSliceProvider(s.graph))
+	case "routerlinks":
+		err = streamEvents[vanflow.LinkRecord](s, w, r, views.NewRotuerLinkSliceProvider(s.graph))
+	case "listeners":
+		err = streamEvents[vanflow.ListenerRecord](s, w, r, views.NewListenerSliceProvider(s.graph))
+	case "connectors":
+		err = streamEvents[vanflow.ConnectorRecord](s, w, r, views.NewConnectorSliceProvider(s.graph))
+	case "addresses":
+		err = streamEvents[collector.AddressRecord](s, w, r, views.NewAddressSliceProvider(s.records, s.graph))
+	case "processes":
+		err = streamEvents[vanflow.ProcessRecord](s, w, r, views.NewProcessSliceProvider(s.records, s.graph))
+	case "processgroups":
+		err = streamEvents[collector.ProcessGroupRecord](s, w, r, views.NewProcessGroupSliceProvider(s.records))
+	case "connections":
+		err = streamEvents[collector.ConnectionRecord](s, w, r, views.NewConnectionsSliceProvider(s.records))
+	case "applicationflows":
+		err = streamEvents[collector.RequestRecord](s, w, r, views.NewRequestSliceProvider(s.records))
+	default:
+		err = encodeResponse(w, http.StatusNotFound, api.ErrorNotFound{
+			Code:    "ErrNotFound",
+			Message: fmt.Sprintf("no event stream for %q", collection),
+		})
+	}
+	if err != nil {
+		requestLogger(s.logger, r).Error("failed to write event stream", slog.Any("error", err))
+	}
+}
+
+// streamEvents streams changes to records of type V, presented by the
+// provider as records of type T.
+func streamEvents[V vanflow.Record, T api.Record](s *eventStream, w http.ResponseWriter, r *http.Request, provider func([]store.Entry) []T) error {
+	filter, err := newRecordFilter[T](r, scopeFromRequest(r))
+	if err != nil {
+		return encodeResponse(w, http.StatusBadRequest, api.ErrorBadRequest{
+			Message: err.Error(),
+		})
+	}
+	rc := http.NewResponseController(w)
+	// streams outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("error clearing write deadline: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	// only changes to the records of the collection are subscribed to, so
+	// that low volume streams do not fall behind on those of other types
+	var exemplar V
+	events := s.events.Subscribe(ctx, exemplar.GetTypeMeta())
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	visible := make(map[string]struct{})
+	handle := func(eventType collector.RecordEventType, entry store.Entry) error {
+		if _, ok := entry.Record.(V); !ok {
+			return nil
+		}
+		id := entry.Record.Identity()
+		_, wasVisible := visible[id]
+		var (
+			record  T
+			matches bool
+		)
+		if eventType != collector.RecordDeleted {
+			if results := provider([]store.Entry{entry}); len(results) == 1 {
+				record, matches = results[0], filter(results[0])
+			}
+		}
+		message := eventMessage[T]{Identity: id}
+		switch {
+		case matches && wasVisible:
+			message.Type, message.Record = collector.RecordModified, &record
+		case matches:
+			visible[id] = struct{}{}
+			message.Type, message.Record = collector.RecordAdded, &record
+		case wasVisible:
+			delete(visible, id)
+			message.Type = collector.RecordDeleted
+		default:
+			return nil
+		}
+		return writeEvent(w, message)
+	}
+
+	for _, entry := range listByType[V](s.records) {
+		if err := handle(collector.RecordAdded, entry); err != nil {
+			return err
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				// the stream fell behind. Clients reconnect and start over
+				// from the current state.
+				requestLogger(s.logger, r).Info("closing event stream that fell behind")
+				return nil
+			}
+			if _, ok := event.Entry.Record.(vanflow.SiteRecord); ok {
+				// the sites the request may see can change with the sites
+				if filter, err = newRecordFilter[T](r, scopeFromRequest(r)); err != nil {
+					return err
+				}
+			}
+			if err := handle(event.Type, event.Entry); err != nil {
+				return err
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+func writeEvent[T api.Record](w http.ResponseWriter, message eventMessage[T]) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("json encoding error: %s", err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, data)
+	return err
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+// testRecordEvents passes on the events sent to it, recording the types
+// subscribed to.
+type testRecordEvents struct {
+	events chan collector.RecordEvent
+	types  chan []vanflow.TypeMeta
+}
+
+func (e testRecordEvents) Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan collector.RecordEvent {
+	e.types <- types
+	return e.events
+}
+
+type testEvent struct {
+	Name string
+	eventMessage[api.ProcessRecord]
+}
+
+func readEvent(t *testing.T, stream *bufio.Reader) testEvent {
+	t.Helper()
+	var event testEvent
+	for {
+		line, err := stream.ReadString('\n')
+		assert.NilError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.Name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.NilError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.eventMessage))
+		}
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	events := testRecordEvents{
+		events: make(chan collector.RecordEvent, 8),
+		types:  make(chan []vanflow.TypeMeta, 1),
+	}
+	srv := httptest.NewServer(NewEventStream(tlog, stor, graph, events))
+	defer srv.Close()
+
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1")},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s2")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("s1")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p2"), Parent: ptrTo("s2")},
+	))
+	graph.(reset).Reset()
+
+	resp, err := http.Get(srv.URL + "/api/v1alpha1/stream/processes?parent=s1")
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+	stream := bufio.NewReader(resp.Body)
+
+	event := readEvent(t, stream)
+	assert.Equal(t, event.Name, "ADDED")
+	assert.Equal(t, event.Identity, "p1")
+	assert.Equal(t, event.Record.Parent, "s1")
+	// only changes to the records of the collection are subscribed to
+	assert.DeepEqual(t, <-events.types, []vanflow.TypeMeta{vanflow.ProcessRecord{}.GetTypeMeta()})
+
+	publish := func(eventType collector.RecordEventType, record vanflow.Record) {
+		switch eventType {
+		case collector.RecordDeleted:
+			stor.Delete(record.Identity())
+		default:
+			stor.Add(record, store.SourceRef{})
+			stor.Update(record)
+		}
+		events.events <- collector.RecordEvent{Type: eventType, Entry: store.Entry{Record: record}}
+	}
+
+	// records of other types and processes not matching the filter are not
+	// streamed
+	publish(collector.RecordAdded, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s3")})
+	publish(collector.RecordAdded, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p3"), Parent: ptrTo("s2")})
+	publish(collector.RecordAdded, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p4"), Parent: ptrTo("s1")})
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "ADDED")
+	assert.Equal(t, event.Identity, "p4")
+
+	publish(collector.RecordModified, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p4"), Parent: ptrTo("s1"), Name: ptrTo("renamed")})
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "MODIFIED")
+	assert.Equal(t, event.Identity, "p4")
+	assert.Equal(t, event.Record.Name, "renamed")
+
+	// processes that stop matching the filter are deleted from the stream
+	publish(collector.RecordModified, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("s2")})
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "DELETED")
+	assert.Equal(t, event.Identity, "p1")
+	assert.Assert(t, event.Record == nil)
+
+	publish(collector.RecordDeleted, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p2")})
+	publish(collector.RecordDeleted, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p4")})
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "DELETED")
+	assert.Equal(t, event.Identity, "p4")
+
+	for path, status := range map[string]int{
+		"/api/v1alpha1/stream/unknown":            http.StatusNotFound,
+		"/api/v1alpha1/stream/processes?fizz=buz": http.StatusBadRequest,
+	} {
+		resp, err := http.Get(srv.URL + path)
+		assert.NilError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, status, path)
+	}
+}
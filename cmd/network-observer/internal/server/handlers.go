@@ -15,6 +15,9 @@ var _ api.ServerInterface = (*server)(nil)
 // (GET /api/v1alpha1/connections/)
 func (s *server) Connections(w http.ResponseWriter, r *http.Request) {
 	results := views.NewConnectionsSliceProvider(s.records)(listByType[collector.ConnectionRecord](s.records))
+	if s.history != nil {
+		results = withHistory(s, r, results, s.history.Connections, func(c api.ConnectionRecord) string { return c.Identity })
+	}
 	if err := handleCollection(w, r, &api.ConnectionListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -34,6 +37,9 @@ func (s *server) ConnectionsByAddress(w http.ResponseWriter, r *http.Request, id
 
 func (s *server) Applicationflows(w http.ResponseWriter, r *http.Request) {
 	results := views.NewRequestSliceProvider(s.records)(listByType[collector.RequestRecord](s.records))
+	if s.history != nil {
+		results = withHistory(s, r, results, s.history.ApplicationFlows, func(f api.ApplicationFlowRecord) string { return f.Identity })
+	}
 	if err := handleCollection(w, r, &api.ApplicationFlowResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
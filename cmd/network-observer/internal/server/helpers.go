@@ -1,9 +1,12 @@
 package server
 
 import (
+	"log/slog"
+	"net/http"
 	"sort"
 	"strings"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
@@ -53,3 +56,29 @@ func ordered(entries []store.Entry) []store.Entry {
 	})
 	return entries
 }
+
+// withHistory adds the records retained in history for the time range of
+// the request to the current results, omitting any that are still current.
+func withHistory[T api.Record](s *server, r *http.Request, current []T, query func(start, end uint64) ([]T, error), identity func(T) string) []T {
+	qp := getQueryParams(r)
+	archived, err := query(qp.TimeRangeStart, qp.TimeRangeEnd)
+	if err != nil {
+		requestLogger(s.logger, r).Error("failed to read flow history", slog.Any("error", err))
+		return current
+	}
+	if len(archived) == 0 {
+		return current
+	}
+	seen := make(map[string]struct{}, len(current))
+	for _, record := range current {
+		seen[identity(record)] = struct{}{}
+	}
+	for _, record := range archived {
+		if _, ok := seen[identity(record)]; ok {
+			continue
+		}
+		seen[identity(record)] = struct{}{}
+		current = append(current, record)
+	}
+	return current
+}
@@ -23,23 +23,12 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 
 	qp := getQueryParams(r)
 
-	filterFields := make(map[string]fieldIndex[T], len(qp.FilterFields))
-	for path := range qp.FilterFields {
-		m, err := indexerForField[T](path)
-		if err != nil {
-			return nil, 0, fmt.Errorf("invalid filter parameter %q for record type %T", path, results)
-		}
-		filterFields[path] = m
+	filter, err := newRecordFilter[T](r, scopeFromRequest(r))
+	if err != nil {
+		return nil, 0, err
 	}
-
 	for i, item := range results {
-		matches := true
-		for path, values := range qp.FilterFields {
-			if !filterFields[path].MatchesFilter(item, values) {
-				matches = false
-				break
-			}
-		}
+		matches := filter(item)
 		switch {
 		case matches && !isCopy:
 			continue
@@ -82,6 +71,31 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 	return out, timeRangeCount, nil
 }
 
+// newRecordFilter returns a function reporting whether a record is within
+// the site scope and matches the field filters of the request.
+func newRecordFilter[T api.Record](r *http.Request, scope *siteScope) (func(T) bool, error) {
+	qp := getQueryParams(r)
+	filterFields := make(map[string]fieldIndex[T], len(qp.FilterFields))
+	for path := range qp.FilterFields {
+		m, err := indexerForField[T](path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter parameter %q for record type %T", path, []T(nil))
+		}
+		filterFields[path] = m
+	}
+	return func(item T) bool {
+		if !scope.includes(item) {
+			return false
+		}
+		for path, values := range qp.FilterFields {
+			if !filterFields[path].MatchesFilter(item, values) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
 func filterTime[T api.Record](all []T, state timeRangeState, op timeRangeRelation, rangeStart, rangeEnd uint64) []T {
 	var (
 		out    = all
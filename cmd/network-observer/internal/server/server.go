@@ -9,14 +9,19 @@ import (
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/history"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
 
-func New(logger *slog.Logger, records store.Interface, graph collector.Graph) api.ServerInterface {
+// New creates an api.ServerInterface serving records from the store. When
+// flowHistory is not nil, the connections and application flows it retains
+// are included in the results for the requested time range.
+func New(logger *slog.Logger, records store.Interface, graph collector.Graph, flowHistory history.Store) api.ServerInterface {
 	return &server{
 		logger:  logger,
 		records: records,
 		graph:   graph,
+		history: flowHistory,
 	}
 }
 
@@ -24,6 +29,7 @@ type server struct {
 	logger  *slog.Logger
 	records store.Interface
 	graph   collector.Graph
+	history history.Store
 }
 
 func (c *server) logWriteError(r *http.Request, err error) {
@@ -80,13 +86,13 @@ func handleSubCollection[T api.Record](w http.ResponseWriter, r *http.Request, r
 	}
 	return nil
 }
-func handleSingle[T any](w http.ResponseWriter, _ *http.Request, response api.ResponseSetter[T], getter func() (T, bool)) error {
+func handleSingle[T any](w http.ResponseWriter, r *http.Request, response api.ResponseSetter[T], getter func() (T, bool)) error {
 	var (
 		out    any = response
 		status     = http.StatusOK
 	)
 
-	if record, ok := getter(); ok {
+	if record, ok := getter(); ok && scopeFromRequest(r).includes(record) {
 		response.SetResults(record)
 	} else {
 		status = http.StatusNotFound
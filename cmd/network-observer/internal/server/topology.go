@@ -0,0 +1,96 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/topology"
+)
+
+// listResponse is a collection response for records outside of the API
+// specification.
+type listResponse[T any] struct {
+	Count          int64 `json:"count"`
+	Results        []T   `json:"results"`
+	TimeRangeCount int64 `json:"timeRangeCount"`
+}
+
+func (r *listResponse[T]) SetCount(v int64) {
+	r.Count = v
+}
+
+func (r *listResponse[T]) SetResults(v []T) {
+	r.Results = v
+}
+
+func (r *listResponse[T]) SetTimeRangeCount(v int64) {
+	r.TimeRangeCount = v
+}
+
+// topologyResponse is the topology of the network at a point in time.
+type topologyResponse struct {
+	// Timestamp in microseconds since the Unix epoch
+	Timestamp uint64             `json:"timestamp"`
+	Count     int64              `json:"count"`
+	Results   []topology.Element `json:"results"`
+}
+
+// NewTopologyEvents creates a handler listing the changes made to the
+// topology. It accepts the same filter, time range, sorting and pagination
+// parameters as the collection endpoints, for example
+// ?type=DOWN&element.kind=link. Events are listed oldest first unless
+// sorted otherwise.
+func NewTopologyEvents(logger *slog.Logger, history *topology.History) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.Query(); !query.Has("sortBy") {
+			query.Set("sortBy", "timestamp.asc")
+			r.URL.RawQuery = query.Encode()
+		}
+		if err := handleCollection(w, r, &listResponse[topology.Event]{}, history.Events()); err != nil {
+			requestLogger(logger, r).Error("failed to write response", slog.Any("error", err))
+		}
+	})
+}
+
+// NewTopologySnapshot creates a handler returning the topology at the time
+// given by the time query parameter, in microseconds since the Unix epoch.
+// The current topology is returned when no time is given.
+func NewTopologySnapshot(logger *slog.Logger, history *topology.History) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			out    any
+			status = http.StatusOK
+		)
+		timestamp := uint64(time.Now().UnixMicro())
+		if value := r.URL.Query().Get("time"); value != "" {
+			var err error
+			if timestamp, err = strconv.ParseUint(value, 10, 64); err != nil {
+				status = http.StatusBadRequest
+				out = api.ErrorBadRequest{Message: fmt.Sprintf("invalid time parameter %q: expected microseconds since the Unix epoch", value)}
+			}
+		}
+		if status == http.StatusOK {
+			elements, err := history.At(timestamp)
+			if err != nil {
+				status = http.StatusBadRequest
+				out = api.ErrorBadRequest{Message: err.Error()}
+			} else {
+				scope := scopeFromRequest(r)
+				results := make([]topology.Element, 0, len(elements))
+				for _, element := range elements {
+					if scope.includes(element) {
+						results = append(results, element)
+					}
+				}
+				out = topologyResponse{Timestamp: timestamp, Count: int64(len(results)), Results: results}
+			}
+		}
+		if err := encodeResponse(w, status, out); err != nil {
+			requestLogger(logger, r).Error("failed to write response", slog.Any("error", err))
+		}
+	})
+}
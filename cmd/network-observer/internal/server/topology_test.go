@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/topology"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type idleRecordEvents struct{}
+
+func (idleRecordEvents) Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan collector.RecordEvent {
+	events := make(chan collector.RecordEvent)
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events
+}
+
+func TestTopology(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s1"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("r1"), Parent: ptrTo("s1")},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("l1"), Parent: ptrTo("r1"), Status: ptrTo("up")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p1"), Parent: ptrTo("s1")},
+	))
+	history, err := topology.NewHistory(tlog, stor, time.Hour)
+	assert.NilError(t, err)
+	// record the topology in the store
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.NilError(t, history.Run(ctx, idleRecordEvents{}))
+	synced := uint64(time.Now().UnixMicro())
+
+	mux := http.NewServeMux()
+	mux.Handle("/events", NewTopologyEvents(tlog, history))
+	mux.Handle("/topology", NewTopologySnapshot(tlog, history))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	get := func(path string, into any) int {
+		resp, err := http.Get(srv.URL + path)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		assert.NilError(t, json.NewDecoder(resp.Body).Decode(into))
+		return resp.StatusCode
+	}
+
+	var events listResponse[topology.Event]
+	assert.Equal(t, get("/events", &events), http.StatusOK)
+	assert.Equal(t, events.Count, int64(3))
+	assert.Equal(t, events.Results[0].Type, topology.Added)
+
+	events = listResponse[topology.Event]{}
+	assert.Equal(t, get("/events?element.kind=link", &events), http.StatusOK)
+	assert.Equal(t, events.Count, int64(1))
+	assert.Equal(t, events.Results[0].Element.Identity, "l1")
+	assert.Equal(t, events.Results[0].Element.SiteID, "s1")
+
+	var snapshot topologyResponse
+	assert.Equal(t, get(fmt.Sprintf("/topology?time=%d", synced), &snapshot), http.StatusOK)
+	assert.Equal(t, snapshot.Count, int64(3))
+	assert.Equal(t, snapshot.Results[0].Kind, topology.KindLink)
+
+	var errorResponse struct{ Message string }
+	assert.Equal(t, get("/topology?time=yesterday", &errorResponse), http.StatusBadRequest)
+	assert.Equal(t, get(fmt.Sprintf("/topology?time=%d", synced-uint64(time.Hour/time.Microsecond)), &errorResponse), http.StatusBadRequest)
+	assert.Equal(t, errorResponse.Message, topology.ErrBeforeHistory.Error())
+}
@@ -0,0 +1,247 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// maxEvents bounds the number of events retained regardless of their age.
+const maxEvents = 100000
+
+// ErrBeforeHistory is returned when asked for the topology at a time
+// earlier than the history retained.
+var ErrBeforeHistory = errors.New("time is before the start of the retained topology history")
+
+type EventType string
+
+const (
+	Added   EventType = "ADDED"
+	Removed EventType = "REMOVED"
+	Changed EventType = "CHANGED"
+	// LinkUp and LinkDown are changes to the status of a link.
+	LinkUp   EventType = "UP"
+	LinkDown EventType = "DOWN"
+)
+
+// Event is a change to the topology.
+type Event struct {
+	// Timestamp is when the change was observed, in microseconds since the
+	// Unix epoch.
+	Timestamp uint64    `json:"timestamp"`
+	Type      EventType `json:"type"`
+	Element   Element   `json:"element"`
+	// Previous is the state of the element before a CHANGED, UP or DOWN
+	// event.
+	Previous *Element `json:"previous,omitempty"`
+}
+
+func (e Event) GetStartTime() uint64 {
+	return e.Timestamp
+}
+
+func (e Event) GetEndTime() uint64 {
+	return e.Timestamp
+}
+
+// Subscriber is a source of changes to the records of the given types in a
+// store.
+type Subscriber interface {
+	Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan collector.RecordEvent
+}
+
+// History records the changes made to the topology found in a records
+// store, keeping them for the retention period.
+type History struct {
+	logger    *slog.Logger
+	records   store.Interface
+	retention time.Duration
+	now       func() time.Time
+
+	mu sync.Mutex
+	// current is the topology as of the latest event
+	current map[string]Element
+	// baseline is the topology as of horizon, before the retained events
+	baseline map[string]Element
+	horizon  uint64
+	events   []Event
+}
+
+func NewHistory(logger *slog.Logger, records store.Interface, retention time.Duration) (*History, error) {
+	if retention <= 0 {
+		return nil, fmt.Errorf("topology history retention must be positive, got %s", retention)
+	}
+	return &History{
+		logger:    logger,
+		records:   records,
+		retention: retention,
+		now:       time.Now,
+		current:   map[string]Element{},
+		baseline:  map[string]Element{},
+		horizon:   uint64(time.Now().UnixMicro()),
+	}, nil
+}
+
+// Run records changes to the topology until the context is cancelled.
+func (h *History) Run(ctx context.Context, subscriber Subscriber) error {
+	for {
+		subCtx, cancel := context.WithCancel(ctx)
+		changes := subscriber.Subscribe(subCtx, recordTypes()...)
+		h.sync()
+		for change := range changes {
+			h.observe(change)
+		}
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		h.logger.Info("topology history fell behind record changes, resynchronizing")
+	}
+}
+
+// Events returns the retained events, oldest first.
+func (h *History) Events() []Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := make([]Event, len(h.events))
+	copy(events, h.events)
+	return events
+}
+
+// At returns the elements of the topology at the given time, in
+// microseconds since the Unix epoch, ordered by kind and identity.
+func (h *History) At(timestamp uint64) ([]Element, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if timestamp < h.horizon {
+		return nil, ErrBeforeHistory
+	}
+	state := maps.Clone(h.baseline)
+	for _, event := range h.events {
+		if event.Timestamp > timestamp {
+			break
+		}
+		applyEvent(state, event)
+	}
+	elements := make([]Element, 0, len(state))
+	for _, element := range state {
+		elements = append(elements, element)
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		if elements[i].Kind != elements[j].Kind {
+			return elements[i].Kind < elements[j].Kind
+		}
+		return elements[i].Identity < elements[j].Identity
+	})
+	return elements, nil
+}
+
+// sync records the differences between the topology in the store and the
+// current topology.
+func (h *History) sync() {
+	elements := map[string]Element{}
+	for _, exemplar := range exemplars {
+		for _, entry := range h.records.Index(store.TypeIndex, store.Entry{Record: exemplar}) {
+			if element, ok := elementOf(h.records, entry.Record); ok {
+				elements[element.Identity] = element
+			}
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var removed []string
+	for id := range h.current {
+		if _, ok := elements[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		h.update(id, nil)
+	}
+	ids := make([]string, 0, len(elements))
+	for id := range elements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		element := elements[id]
+		h.update(id, &element)
+	}
+}
+
+func (h *History) observe(change collector.RecordEvent) {
+	element, ok := elementOf(h.records, change.Entry.Record)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if change.Type == collector.RecordDeleted {
+		h.update(element.Identity, nil)
+		return
+	}
+	h.update(element.Identity, &element)
+}
+
+// update records an event when the element differs from the current one.
+// A nil element removes it. Must be called with the lock held.
+func (h *History) update(id string, element *Element) {
+	previous, existed := h.current[id]
+	event := Event{}
+	switch {
+	case element == nil && existed:
+		event.Type, event.Element = Removed, previous
+	case element == nil:
+		return
+	case !existed:
+		event.Type, event.Element = Added, *element
+	case *element == previous:
+		return
+	default:
+		event.Type, event.Element, event.Previous = Changed, *element, &previous
+		if element.Kind == KindLink && element.Status != previous.Status {
+			switch element.Status {
+			case "up":
+				event.Type = LinkUp
+			case "down":
+				event.Type = LinkDown
+			}
+		}
+	}
+	now := h.now()
+	event.Timestamp = uint64(now.UnixMicro())
+	applyEvent(h.current, event)
+	h.events = append(h.events, event)
+	h.prune(now)
+}
+
+// prune folds events older than the retention period, and any beyond
+// maxEvents, into the baseline. Must be called with the lock held.
+func (h *History) prune(now time.Time) {
+	cutoff := uint64(now.Add(-h.retention).UnixMicro())
+	n := 0
+	for n < len(h.events) && (h.events[n].Timestamp < cutoff || len(h.events)-n > maxEvents) {
+		applyEvent(h.baseline, h.events[n])
+		h.horizon = max(h.horizon, h.events[n].Timestamp)
+		n++
+	}
+	h.events = h.events[n:]
+}
+
+func applyEvent(state map[string]Element, event Event) {
+	if event.Type == Removed {
+		delete(state, event.Element.Identity)
+		return
+	}
+	state[event.Element.Identity] = event.Element
+}
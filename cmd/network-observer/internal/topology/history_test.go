@@ -0,0 +1,187 @@
+package topology
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type testSubscription struct {
+	events chan collector.RecordEvent
+	// closing drop ends the subscription as if the subscriber fell behind
+	drop chan struct{}
+}
+
+type testSubscriber chan testSubscription
+
+func (s testSubscriber) Subscribe(ctx context.Context, types ...vanflow.TypeMeta) <-chan collector.RecordEvent {
+	sub := testSubscription{
+		events: make(chan collector.RecordEvent),
+		drop:   make(chan struct{}),
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.drop:
+		}
+		close(sub.events)
+	}()
+	s <- sub
+	return sub.events
+}
+
+// settle waits for the history to finish handling earlier events, by
+// sending an event it ignores.
+func (sub testSubscription) settle() {
+	sub.events <- collector.RecordEvent{Type: collector.RecordModified, Entry: store.Entry{Record: vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("ignored")}}}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func TestHistory(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	history, err := NewHistory(slog.Default(), stor, time.Hour)
+	assert.NilError(t, err)
+	start := time.Now()
+	now := start
+	history.now = func() time.Time { return now }
+	at := func(t time.Time) uint64 { return uint64(t.UnixMicro()) }
+
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")}, store.SourceRef{})
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1")}, store.SourceRef{})
+	stor.Add(vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("process-1")}, store.SourceRef{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscriber := make(testSubscriber)
+	done := make(chan error)
+	go func() { done <- history.Run(ctx, subscriber) }()
+	sub := <-subscriber
+	sub.settle()
+
+	publish := func(eventType collector.RecordEventType, record vanflow.Record) {
+		switch eventType {
+		case collector.RecordDeleted:
+			stor.Delete(record.Identity())
+		case collector.RecordAdded:
+			stor.Add(record, store.SourceRef{})
+		default:
+			stor.Update(record)
+		}
+		sub.events <- collector.RecordEvent{Type: eventType, Entry: store.Entry{Record: record}}
+		sub.settle()
+	}
+
+	now = start.Add(time.Minute)
+	link := vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-1"), Parent: ptrTo("router-1"), Peer: ptrTo("ap-2"), Status: ptrTo("up")}
+	publish(collector.RecordAdded, link)
+	// counters are not part of the topology
+	link.Octets = ptrTo(uint64(1024))
+	publish(collector.RecordModified, link)
+	publish(collector.RecordModified, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("process-1"), Name: ptrTo("renamed")})
+
+	now = start.Add(2 * time.Minute)
+	link.Status = ptrTo("down")
+	publish(collector.RecordModified, link)
+	publish(collector.RecordAdded, vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1"), Parent: ptrTo("router-1"), Address: ptrTo("backend")})
+
+	now = start.Add(3 * time.Minute)
+	publish(collector.RecordDeleted, vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1")})
+	cancel()
+	assert.NilError(t, <-done)
+
+	type summary struct {
+		Type     EventType
+		Identity string
+		Time     uint64
+	}
+	var summaries []summary
+	for _, event := range history.Events() {
+		summaries = append(summaries, summary{event.Type, event.Element.Identity, event.Timestamp})
+	}
+	assert.DeepEqual(t, summaries, []summary{
+		{Added, "router-1", at(start)},
+		{Added, "site-1", at(start)},
+		{Added, "link-1", at(start.Add(time.Minute))},
+		{LinkDown, "link-1", at(start.Add(2 * time.Minute))},
+		{Added, "listener-1", at(start.Add(2 * time.Minute))},
+		{Removed, "listener-1", at(start.Add(3 * time.Minute))},
+	})
+	events := history.Events()
+	assert.Equal(t, events[3].Previous.Status, "up")
+	assert.Equal(t, events[3].Element.SiteID, "site-1")
+	assert.Equal(t, events[5].Element.Address, "backend")
+
+	identities := func(elements []Element) []string {
+		var ids []string
+		for _, element := range elements {
+			ids = append(ids, element.Identity)
+		}
+		return ids
+	}
+	elements, err := history.At(at(start.Add(150 * time.Second)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, identities(elements), []string{"link-1", "listener-1", "router-1", "site-1"})
+	assert.Equal(t, elements[0].Status, "down")
+	elements, err = history.At(at(start.Add(90 * time.Second)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, identities(elements), []string{"link-1", "router-1", "site-1"})
+	assert.Equal(t, elements[0].Status, "up")
+	_, err = history.At(at(start.Add(-time.Hour)))
+	assert.ErrorIs(t, err, ErrBeforeHistory)
+
+	// events past retention are folded into the baseline
+	now = start.Add(time.Hour + 150*time.Second)
+	history.mu.Lock()
+	history.update("site-2", &Element{Kind: KindSite, Identity: "site-2", SiteID: "site-2"})
+	history.mu.Unlock()
+	assert.Equal(t, len(history.Events()), 2)
+	_, err = history.At(at(start.Add(90 * time.Second)))
+	assert.ErrorIs(t, err, ErrBeforeHistory)
+	elements, err = history.At(at(start.Add(150 * time.Second)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, identities(elements), []string{"link-1", "listener-1", "router-1", "site-1"})
+	elements, err = history.At(at(now))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, identities(elements), []string{"link-1", "router-1", "site-1", "site-2"})
+
+	_, err = NewHistory(slog.Default(), stor, 0)
+	assert.Error(t, err, "topology history retention must be positive, got 0s")
+}
+
+func TestHistoryResync(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	history, err := NewHistory(slog.Default(), stor, time.Hour)
+	assert.NilError(t, err)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}, store.SourceRef{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subscriber := make(testSubscriber)
+	done := make(chan error)
+	go func() { done <- history.Run(ctx, subscriber) }()
+	sub := <-subscriber
+	sub.settle()
+
+	// changes missed while falling behind are found when resynchronizing
+	stor.Delete("site-1")
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-2")}, store.SourceRef{})
+	close(sub.drop)
+	sub = <-subscriber
+	sub.settle()
+	cancel()
+	assert.NilError(t, <-done)
+
+	var summary []string
+	for _, event := range history.Events() {
+		summary = append(summary, string(event.Type)+" "+event.Element.Identity)
+	}
+	assert.DeepEqual(t, summary, []string{"ADDED site-1", "REMOVED site-1", "ADDED site-2"})
+}
@@ -0,0 +1,147 @@
+// Package topology keeps a history of changes to the network's topology: its
+// sites, routers, links, listeners and connectors.
+package topology
+
+import (
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+type Kind string
+
+const (
+	KindSite      Kind = "site"
+	KindRouter    Kind = "router"
+	KindLink      Kind = "link"
+	KindListener  Kind = "listener"
+	KindConnector Kind = "connector"
+)
+
+// Element is the topologically significant state of a site, router, link,
+// listener or connector. Fields that change in the normal course of
+// operation, such as counters, are left out so that any change to an
+// Element is a change to the topology.
+type Element struct {
+	Kind     Kind   `json:"kind"`
+	Identity string `json:"identity"`
+	Name     string `json:"name,omitempty"`
+	// Parent is the site of a router, and the router of a link, listener
+	// or connector.
+	Parent string `json:"parent,omitempty"`
+	// SiteID is the site the element belongs to.
+	SiteID    string `json:"siteId,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Peer is the router access point a link connects to.
+	Peer string `json:"peer,omitempty"`
+	// Status is the up or down status of a link.
+	Status   string `json:"status,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Port     string `json:"port,omitempty"`
+}
+
+// exemplars of the record types that make up the topology
+var exemplars = []vanflow.Record{
+	vanflow.SiteRecord{},
+	vanflow.RouterRecord{},
+	vanflow.LinkRecord{},
+	vanflow.ListenerRecord{},
+	vanflow.ConnectorRecord{},
+}
+
+// recordTypes returns the types of the records that make up the topology.
+func recordTypes() []vanflow.TypeMeta {
+	types := make([]vanflow.TypeMeta, len(exemplars))
+	for i, exemplar := range exemplars {
+		types[i] = exemplar.GetTypeMeta()
+	}
+	return types
+}
+
+// elementOf returns the Element for a record, and false when the record is
+// not part of the topology. The records store is used to find the site of
+// records belonging to a router.
+func elementOf(records store.Interface, record vanflow.Record) (Element, bool) {
+	switch record := record.(type) {
+	case vanflow.SiteRecord:
+		return Element{
+			Kind:      KindSite,
+			Identity:  record.ID,
+			Name:      dref(record.Name),
+			SiteID:    record.ID,
+			Namespace: dref(record.Namespace),
+		}, true
+	case vanflow.RouterRecord:
+		return Element{
+			Kind:      KindRouter,
+			Identity:  record.ID,
+			Name:      dref(record.Name),
+			Parent:    dref(record.Parent),
+			SiteID:    dref(record.Parent),
+			Namespace: dref(record.Namespace),
+		}, true
+	case vanflow.LinkRecord:
+		return Element{
+			Kind:     KindLink,
+			Identity: record.ID,
+			Name:     dref(record.Name),
+			Parent:   dref(record.Parent),
+			SiteID:   routerSite(records, dref(record.Parent)),
+			Peer:     dref(record.Peer),
+			Status:   dref(record.Status),
+			Protocol: dref(record.Protocol),
+			Host:     dref(record.DestHost),
+			Port:     dref(record.DestPort),
+		}, true
+	case vanflow.ListenerRecord:
+		return Element{
+			Kind:     KindListener,
+			Identity: record.ID,
+			Name:     dref(record.Name),
+			Parent:   dref(record.Parent),
+			SiteID:   routerSite(records, dref(record.Parent)),
+			Address:  dref(record.Address),
+			Protocol: dref(record.Protocol),
+			Host:     dref(record.DestHost),
+			Port:     dref(record.DestPort),
+		}, true
+	case vanflow.ConnectorRecord:
+		return Element{
+			Kind:     KindConnector,
+			Identity: record.ID,
+			Name:     dref(record.Name),
+			Parent:   dref(record.Parent),
+			SiteID:   routerSite(records, dref(record.Parent)),
+			Address:  dref(record.Address),
+			Protocol: dref(record.Protocol),
+			Host:     dref(record.DestHost),
+			Port:     dref(record.DestPort),
+		}, true
+	default:
+		return Element{}, false
+	}
+}
+
+func routerSite(records store.Interface, routerID string) string {
+	if routerID == "" {
+		return ""
+	}
+	entry, ok := records.Get(routerID)
+	if !ok {
+		return ""
+	}
+	router, ok := entry.Record.(vanflow.RouterRecord)
+	if !ok {
+		return ""
+	}
+	return dref(router.Parent)
+}
+
+func dref[T any](ptr *T) T {
+	var out T
+	if ptr != nil {
+		out = *ptr
+	}
+	return out
+}
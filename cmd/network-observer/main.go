@@ -18,9 +18,13 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/history"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/otlp"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/server"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/topology"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
 	"github.com/skupperproject/skupper/pkg/version"
@@ -48,8 +52,20 @@ func run(cfg Config) error {
 
 	flowLogger := func(vanflow.RecordMessage) {}
 	vanflowSLog := logger.With(slog.String("component", "vanflow"))
+	if cfg.VanflowLoggingRules != "" && cfg.VanflowLoggingProfile != "silent" {
+		return fmt.Errorf("vanflow-logging-rules cannot be used with a vanflow-logging-profile other than silent")
+	}
+	var flowLogHandler *flowlog.Handler
 	switch cfg.VanflowLoggingProfile {
 	case "silent":
+		if cfg.VanflowLoggingRules != "" {
+			rules, err := flowlog.LoadRules(cfg.VanflowLoggingRules)
+			if err != nil {
+				return err
+			}
+			flowLogHandler = flowlog.NewHandler(ctx, vanflowSLog.Info, rules)
+			flowLogger = flowLogHandler.Handle
+		}
 	case "minimal":
 		flowLogger = flowlog.New(ctx, vanflowSLog.Info, loggingProfileMinimal)
 	case "moderate":
@@ -60,6 +76,24 @@ func run(cfg Config) error {
 		return fmt.Errorf("unknown logging profile: %s", cfg.VanflowLoggingProfile)
 	}
 
+	var flowLogSinks flowlog.Sinks
+	if cfg.VanflowLoggingSinks != "" {
+		sinksConfig, err := flowlog.LoadSinks(cfg.VanflowLoggingSinks)
+		if err != nil {
+			return err
+		}
+		flowLogSinks, err = flowlog.OpenSinks(ctx, vanflowSLog, sinksConfig)
+		if err != nil {
+			return err
+		}
+		defer flowLogSinks.Close()
+		logMessage := flowLogger
+		flowLogger = func(msg vanflow.RecordMessage) {
+			logMessage(msg)
+			flowLogSinks.Handle(msg)
+		}
+	}
+
 	collector := collector.New(
 		logger.With(slog.String("component", "collector")),
 		session.NewContainerFactory(cfg.RouterURL, sessionConfig),
@@ -67,13 +101,78 @@ func run(cfg Config) error {
 		cfg.FlowRecordTTL,
 		flowLogger,
 	)
+	if flowLogHandler != nil {
+		flowLogHandler.SetResolver(flowlog.StoreResolver(collector.GetRecord))
+	}
+	flowLogSinks.SetResolver(flowlog.StoreResolver(collector.GetRecord))
+
+	var flowHistory history.Store
+	if cfg.FlowHistoryDir != "" {
+		fileStore, err := history.NewFileStore(cfg.FlowHistoryDir, cfg.FlowHistoryRetention)
+		if err != nil {
+			return fmt.Errorf("error opening flow history: %s", err)
+		}
+		flowHistory = fileStore
+		collector.SetFlowArchive(history.NewArchiver(
+			logger.With(slog.String("component", "history")),
+			collector.Records,
+			flowHistory,
+		))
+	}
+
+	topologyHistory, err := topology.NewHistory(
+		logger.With(slog.String("component", "topology")),
+		collector.Records,
+		cfg.TopologyHistoryRetention,
+	)
+	if err != nil {
+		return err
+	}
+
+	var otlpExporter *otlp.Exporter
+	if cfg.OTLPEndpoint != "" {
+		otlpCfg := otlp.Config{
+			Endpoint: cfg.OTLPEndpoint,
+			Interval: cfg.OTLPInterval,
+		}
+		otlpCfg.Headers, err = otlp.ParseHeaders(cfg.OTLPHeaders)
+		if err != nil {
+			return fmt.Errorf("error parsing otlp-headers: %s", err)
+		}
+		otlpCfg.TLSConfig, err = cfg.OTLPTLS.config()
+		if err != nil {
+			return fmt.Errorf("could not set up certs for otlp exporter: %s", err)
+		}
+		otlpExporter = otlp.New(logger.With(slog.String("component", "otlp")), otlpCfg)
+		collector.SetFlowExporter(otlpExporter)
+	}
 
 	collectorAPI := server.New(
 		logger.With(slog.String("component", "api")),
 		collector.Records,
 		collector.GetGraph(),
+		flowHistory,
 	)
 
+	authn, err := configureAuth(cfg)
+	if err != nil {
+		return fmt.Errorf("error configuring authentication: %s", err)
+	}
+	var policy *auth.Policy
+	if cfg.AuthPolicyFile != "" {
+		if authn == nil {
+			return fmt.Errorf("auth-policy requires an auth-mode other than %s", authModeUnsecured)
+		}
+		policy, err = auth.LoadPolicy(cfg.AuthPolicyFile)
+		if err != nil {
+			return err
+		}
+	}
+	authenticated := func(next http.Handler) http.Handler { return next }
+	if authn != nil {
+		authenticated = auth.Middleware(logger.With(slog.String("component", "auth")), authn)
+	}
+
 	var mux = mux.NewRouter().StrictSlash(true)
 	promSubrouter := mux.PathPrefix("/api/v1alpha1/internal/prom")
 	mux.Handle("/metrics", handleMetrics(reg))
@@ -82,9 +181,18 @@ func run(cfg Config) error {
 	if cfg.CORSAllowAll {
 		apiMux.Use(handlers.CORS())
 	}
+	apiMux.Use(authenticated, server.Authorize(collector.Records, collector.GetGraph(), policy))
 	api.HandlerWithOptions(collectorAPI, api.GorillaServerOptions{
 		BaseRouter: apiMux,
 	})
+	apiMux.Path("/api/v1alpha1/events").Handler(server.NewTopologyEvents(logger.With(slog.String("component", "api")), topologyHistory))
+	apiMux.Path("/api/v1alpha1/topology").Handler(server.NewTopologySnapshot(logger.With(slog.String("component", "api")), topologyHistory))
+	apiMux.Path("/api/v1alpha1/stream/{collection}").Handler(server.NewEventStream(
+		logger.With(slog.String("component", "events")),
+		collector.Records,
+		collector.GetGraph(),
+		collector,
+	))
 
 	if cfg.EnableConsole {
 		promAPI, err := parsePrometheusAPI(cfg.PrometheusAPI)
@@ -92,9 +200,13 @@ func run(cfg Config) error {
 			return fmt.Errorf("error parsing prometheus-api as URL: %s", err)
 		}
 		// add unspec'd api routes
-		apiMux.Path("/api/v1alpha1/user").Handler(handleNoContent())
-		apiMux.Path("/api/v1alpha1/logout").Handler(handleNoContent())
-		promSubrouter.Handler(handleProxyPrometheusAPI("/api/v1alpha1/internal/prom", promAPI))
+		apiMux.Path("/api/v1alpha1/user").Handler(handleUser())
+		if authn != nil {
+			apiMux.Path("/api/v1alpha1/logout").Handler(handleLogout(authn))
+		} else {
+			apiMux.Path("/api/v1alpha1/logout").Handler(handleNoContent())
+		}
+		promSubrouter.Handler(authenticated(server.RequireAllSites(policy)(handleProxyPrometheusAPI("/api/v1alpha1/internal/prom", promAPI))))
 
 		apiMux.PathPrefix("/").Handler(handleConsoleAssets(cfg.ConsoleLocation))
 	}
@@ -177,6 +289,28 @@ func run(cfg Config) error {
 		})
 	}
 
+	g.Go(func() error {
+		return topologyHistory.Run(runCtx, collector)
+	})
+	if flowLogHandler != nil {
+		g.Go(func() error {
+			flowlog.WatchRules(runCtx, vanflowSLog, cfg.VanflowLoggingRules, 10*time.Second, flowLogHandler)
+			return nil
+		})
+	}
+	if flowLogSinks != nil {
+		g.Go(func() error {
+			flowlog.WatchSinks(runCtx, vanflowSLog, cfg.VanflowLoggingSinks, 10*time.Second, flowLogSinks)
+			return nil
+		})
+	}
+	if otlpExporter != nil {
+		g.Go(func() error {
+			logger.Info("Starting OTLP Exporter", slog.String("endpoint", cfg.OTLPEndpoint))
+			return otlpExporter.Run(runCtx)
+		})
+	}
+
 	g.Go(func() error {
 		logger.Debug("Starting Network Observer Collector")
 		if err := collector.Run(runCtx); err != nil {
@@ -213,10 +347,32 @@ func main() {
 	flags.StringVar(&cfg.PrometheusAPI, "prometheus-api", "http://network-observer-prometheus:9090", "Prometheus API HTTP endpoint for console")
 
 	flags.DurationVar(&cfg.FlowRecordTTL, "flow-record-ttl", 15*time.Minute, "How long to retain flow records in memory")
+	flags.StringVar(&cfg.FlowHistoryDir, "flow-history-dir", "", "Directory in which to retain connection and request history once flow records leave memory. History is not retained when unset")
+	flags.DurationVar(&cfg.FlowHistoryRetention, "flow-history-retention", 7*24*time.Hour, "How long to retain connection and request history in flow-history-dir")
+	flags.DurationVar(&cfg.TopologyHistoryRetention, "topology-history-retention", 24*time.Hour, "How long to retain the history of changes to the network topology. The history is kept in memory and starts over when the observer restarts")
+	flags.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "Base URL of an OTLP/HTTP receiver, such as http://otel-collector:4318, to export connections and requests to as spans and metrics. Not exported when unset")
+	flags.StringVar(&cfg.OTLPHeaders, "otlp-headers", "", "Comma separated key=value headers to send with OTLP exports")
+	flags.DurationVar(&cfg.OTLPInterval, "otlp-export-interval", 10*time.Second, "Interval between OTLP exports")
+	flags.StringVar(&cfg.OTLPTLS.CA, "otlp-tls-ca", "", "Path to the CA certificate file for the OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.Cert, "otlp-tls-cert", "", "Path to the client certificate for the OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.Key, "otlp-tls-key", "", "Path to the client key for the OTLP endpoint")
+	flags.BoolVar(&cfg.OTLPTLS.SkipVerify, "otlp-tls-insecure", false, "Set to skip verification of the OTLP endpoint certificate and host name")
+	flags.StringVar(&cfg.AuthMode, "auth-mode", authModeUnsecured, "How API requests are authenticated. Options are unsecured, basic (using auth-htpasswd-file), openshift (using OpenShift OAuth tokens) and oidc (using OpenID Connect ID tokens)")
+	flags.StringVar(&cfg.AuthHtpasswdFile, "auth-htpasswd-file", "/etc/console-users/htpasswd", "Path to an htpasswd file of users for basic auth, with bcrypt or SHA1 hashed passwords")
+	flags.StringVar(&cfg.AuthOpenShiftAPI, "auth-openshift-api", "https://kubernetes.default.svc", "URL of the OpenShift API server that validates tokens for openshift auth")
+	flags.StringVar(&cfg.AuthOpenShiftTLS.CA, "auth-openshift-ca", "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt", "Path to the CA certificate file for the OpenShift API server")
+	flags.StringVar(&cfg.AuthOIDCIssuer, "auth-oidc-issuer", "", "Issuer URL of the OpenID Connect provider for oidc auth")
+	flags.StringVar(&cfg.AuthOIDCClientID, "auth-oidc-client-id", "", "Client ID that ID tokens must be issued for in oidc auth")
+	flags.StringVar(&cfg.AuthOIDCUserClaim, "auth-oidc-username-claim", "", "ID token claim naming the user in oidc auth. Defaults to preferred_username, falling back to sub")
+	flags.StringVar(&cfg.AuthOIDCGroupsClaim, "auth-oidc-groups-claim", "groups", "ID token claim listing the groups of the user in oidc auth")
+	flags.StringVar(&cfg.AuthOIDCTLS.CA, "auth-oidc-ca", "", "Path to the CA certificate file for the OpenID Connect provider")
+	flags.StringVar(&cfg.AuthPolicyFile, "auth-policy", "", "Path to a YAML file of rules granting users and groups access to sites by name or namespace. When unset, authenticated users see all sites")
 	flags.BoolVar(&cfg.CORSAllowAll, "cors-allow-all", false, "Development option to allow all origins")
 	flags.BoolVar(&cfg.EnableProfile, "profile", false, "Exposes the runtime profiling facilities from net/http/pprof on http://localhost:9970")
 
 	flags.StringVar(&cfg.VanflowLoggingProfile, "vanflow-logging-profile", "silent", "Controls low level vanflow record logging. Options are silent, minimal, moderate and all")
+	flags.StringVar(&cfg.VanflowLoggingRules, "vanflow-logging-rules", "", "Path to a YAML file of rules controlling low level vanflow record logging by record type, routing key, site, protocol and error status. Reloaded when modified. Cannot be used with a vanflow-logging-profile other than silent")
+	flags.StringVar(&cfg.VanflowLoggingSinks, "vanflow-logging-sinks", "", "Path to a YAML file of sinks, such as rotating files, syslog servers and unix sockets, that vanflow records are logged to as JSON, each with its own rules. Sink rules are reloaded when modified")
 
 	flags.Parse(os.Args[1:])
 	if *isVersion {
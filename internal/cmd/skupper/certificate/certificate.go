@@ -0,0 +1,164 @@
+package certificate
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate/nonkube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdCertificate() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "certificate",
+		Short: "Issues TLS certificates signed by a CA of the local site.",
+		Long:  `A certificate is a TLS credential issued by a CA of the local site, usable for client or server authentication or to sign other certificates`,
+		Example: `skupper certificate create my-cert --ca skupper-site-ca --server --hosts backend.example.com
+skupper certificate status my-cert`,
+	}
+
+	cmd.AddCommand(CmdCertificateCreateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdCertificateStatusFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdCertificateUpdateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdCertificateDeleteFactory(config.GetPlatform()))
+
+	return cmd
+}
+
+func CmdCertificateCreateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateCreate()
+	nonKubeCommand := nonkube.NewCmdCertificateCreate()
+
+	cmdCertificateCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name>",
+		Short: "create a certificate",
+		Long: `Issue a certificate signed by the given CA.
+	The subject defaults to the certificate name.`,
+		Example: "skupper certificate create my-cert --ca skupper-site-ca --client",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Ca, common.FlagNameCa, "", common.FlagDescCa)
+	cmd.Flags().StringVar(&cmdFlags.Subject, common.FlagNameSubject, "", common.FlagDescSubject)
+	cmd.Flags().StringSliceVar(&cmdFlags.Hosts, common.FlagNameHosts, []string{}, common.FlagDescHosts)
+	cmd.Flags().BoolVar(&cmdFlags.Client, common.FlagNameClient, false, common.FlagDescClient)
+	cmd.Flags().BoolVar(&cmdFlags.Server, common.FlagNameServer, false, common.FlagDescServer)
+	cmd.Flags().BoolVar(&cmdFlags.Signing, common.FlagNameSigning, false, common.FlagDescSigning)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdCertificateUpdateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateUpdate()
+	nonKubeCommand := nonkube.NewCmdCertificateUpdate()
+
+	cmdCertificateUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a certificate",
+		Long: `Change the definition of a certificate, which is then reissued.
+	The user can change CA, subject, hosts and usages`,
+		Example: "skupper certificate update my-cert --hosts backend.example.com,10.0.0.1",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateUpdateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Ca, common.FlagNameCa, "", common.FlagDescCa)
+	cmd.Flags().StringVar(&cmdFlags.Subject, common.FlagNameSubject, "", common.FlagDescSubject)
+	cmd.Flags().StringSliceVar(&cmdFlags.Hosts, common.FlagNameHosts, []string{}, common.FlagDescHosts)
+	cmd.Flags().BoolVar(&cmdFlags.Client, common.FlagNameClient, false, common.FlagDescClient)
+	cmd.Flags().BoolVar(&cmdFlags.Server, common.FlagNameServer, false, common.FlagDescServer)
+	cmd.Flags().BoolVar(&cmdFlags.Signing, common.FlagNameSigning, false, common.FlagDescSigning)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "ready", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdCertificateStatusFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateStatus()
+	nonKubeCommand := nonkube.NewCmdCertificateStatus()
+
+	cmdCertificateStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of certificates",
+		Long:    "Display status of all certificates or a specific certificate",
+		Example: "skupper certificate status my-cert",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdCertificateDeleteFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdCertificateDelete()
+	nonKubeCommand := nonkube.NewCmdCertificateDelete()
+
+	cmdCertificateDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a certificate",
+		Long:    "Delete a certificate <name>",
+		Example: "skupper certificate delete my-cert",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdCertificateDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandCertificateDeleteFlags{}
+
+	cmd.Flags().DurationVarP(&cmdFlags.Timeout, common.FlagNameTimeout, "t", 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
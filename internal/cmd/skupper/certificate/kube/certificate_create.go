@@ -0,0 +1,219 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateCreate struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandCertificateCreateFlags
+	namespace string
+	name      string
+	ca        string
+	subject   string
+	hosts     []string
+	isClient  bool
+	isServer  bool
+	isSigning bool
+	timeout   time.Duration
+	output    string
+	status    string
+}
+
+func NewCmdCertificateCreate() *CmdCertificateCreate {
+
+	return &CmdCertificateCreate{}
+}
+
+func (cmd *CmdCertificateCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdCertificateCreate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate if there is already a certificate with this name in the namespace
+	if cmd.name != "" {
+		certificate, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if certificate != nil && !errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already a certificate %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if cmd.Flags.Ca == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("ca must be configured"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+			}
+		}
+
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("hosts are not valid: a valid IP address or hostname is expected"))
+				break
+			}
+		}
+
+		if !cmd.Flags.Client && !cmd.Flags.Server && !cmd.Flags.Signing {
+			validationErrors = append(validationErrors, fmt.Errorf("at least one of client, server or signing must be set"))
+		}
+
+		if cmd.Flags.Timeout.String() != "" {
+			ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+			}
+		}
+
+		if cmd.Flags.Output != "" {
+			ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+			}
+		}
+
+		if cmd.Flags.Wait != "" {
+			ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdCertificateCreate) InputToOptions() {
+	cmd.ca = cmd.Flags.Ca
+	if cmd.Flags.Subject == "" {
+		cmd.subject = cmd.name
+	} else {
+		cmd.subject = cmd.Flags.Subject
+	}
+	cmd.hosts = cmd.Flags.Hosts
+	cmd.isClient = cmd.Flags.Client
+	cmd.isServer = cmd.Flags.Server
+	cmd.isSigning = cmd.Flags.Signing
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.output = cmd.Flags.Output
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdCertificateCreate) Run() error {
+
+	resource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      cmd.ca,
+			Subject: cmd.subject,
+			Hosts:   cmd.hosts,
+			Client:  cmd.isClient,
+			Server:  cmd.isServer,
+			Signing: cmd.isSigning,
+		},
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, resource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		_, err := cmd.client.Certificates(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+		return err
+	}
+}
+
+func (cmd *CmdCertificateCreate) WaitUntil() error {
+	// the certificate resource was not created
+	if cmd.output != "" {
+		return nil
+	}
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var certificateCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		// a certificate only reports whether it is ready
+		certificateCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+
+		if certificateCondition != nil && certificateCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		if certificateCondition != nil {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && certificateCondition == nil {
+		return fmt.Errorf("Certificate %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && certificateCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("Certificate %q is not yet %s: %s\n", cmd.name, cmd.status, certificateCondition.Message)
+	}
+
+	fmt.Printf("Certificate %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
@@ -0,0 +1,315 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateCreateFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:  "certificate is not created because there is already the same certificate in the namespace",
+			args:  []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{"there is already a certificate my-cert created for namespace test"},
+		},
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: time.Minute},
+			expectedErrors: []string{"certificate name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-cert", "other"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: time.Minute},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "ca is not specified",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Client: true, Timeout: time.Minute},
+			expectedErrors: []string{"ca must be configured"},
+		},
+		{
+			name:           "ca is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "my_ca", Client: true, Timeout: time.Minute},
+			expectedErrors: []string{"ca is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "hosts are not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Server: true, Hosts: []string{"not valid"}, Timeout: time.Minute},
+			expectedErrors: []string{"hosts are not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "no usage is specified",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Timeout: time.Minute},
+			expectedErrors: []string{"at least one of client, server or signing must be set"},
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: 0},
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: time.Minute, Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Timeout: time.Minute, Wait: "created"},
+			expectedErrors: []string{"status is not valid: value created not allowed. It should be one of this options: [ready configured none]"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-cert"},
+			flags: common.CommandCertificateCreateFlags{
+				Ca:      "skupper-site-ca",
+				Subject: "backend",
+				Hosts:   []string{"backend.example.com", "10.0.0.1"},
+				Server:  true,
+				Timeout: time.Minute,
+				Output:  "yaml",
+				Wait:    "ready",
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateCreateWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdCertificateCreate_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name            string
+		flags           common.CommandCertificateCreateFlags
+		expectedSubject string
+	}
+
+	testTable := []test{
+		{
+			name:            "subject defaults to the certificate name",
+			flags:           common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true},
+			expectedSubject: "my-cert",
+		},
+		{
+			name:            "subject is supplied",
+			flags:           common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Subject: "backend", Server: true},
+			expectedSubject: "backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd, err := newCmdCertificateCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.Flags = &test.flags
+			cmd.name = "my-cert"
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.ca == test.flags.Ca)
+			assert.Check(t, cmd.subject == test.expectedSubject)
+			assert.Check(t, cmd.isClient == test.flags.Client)
+			assert.Check(t, cmd.isServer == test.flags.Server)
+		})
+	}
+}
+
+func TestCmdCertificateCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output json",
+			output: "json",
+		},
+		{
+			name:                "creation fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.ca = "skupper-site-ca"
+		cmd.subject = "my-cert"
+		cmd.isClient = true
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	readyCertificate := []runtime.Object{
+		&v2alpha1.Certificate{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-cert",
+				Namespace: "test",
+			},
+			Status: v2alpha1.CertificateStatus{
+				Status: v2alpha1.Status{
+					Conditions: []v1.Condition{
+						{
+							Type:   "Ready",
+							Status: "True",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:   "certificate is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "certificate is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:           "certificate is ready",
+			status:         "ready",
+			skupperObjects: readyCertificate,
+			expectError:    false,
+		},
+		{
+			name:           "configured waits for the certificate to be ready",
+			status:         "configured",
+			skupperObjects: readyCertificate,
+			expectError:    false,
+		},
+		{
+			name:        "output yaml is not waited for",
+			output:      "yaml",
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.output = test.output
+		cmd.status = test.status
+		cmd.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateCreate := &CmdCertificateCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateCreate, nil
+}
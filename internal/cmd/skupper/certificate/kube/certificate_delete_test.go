@@ -0,0 +1,201 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateDeleteFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			flags:          common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			expectedErrors: []string{"certificate name must be specified"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-cert", "other"},
+			flags:          common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "certificate does not exist",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			expectedErrors: []string{"certificate my-cert does not exist in namespace test"},
+		},
+		{
+			name:  "timeout is not valid",
+			args:  []string{"my-cert"},
+			flags: common.CommandCertificateDeleteFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-cert"},
+			flags: common.CommandCertificateDeleteFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdCertificateDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name: "certificate is not deleted",
+			wait: true,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "certificate is deleted",
+			wait:        true,
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			wait:        false,
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.Flags = &common.CommandCertificateDeleteFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateDelete := &CmdCertificateDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateDelete, nil
+}
@@ -0,0 +1,160 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateStatusFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-cert", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "certificate does not exist",
+			args:           []string{"my-cert"},
+			expectedErrors: []string{"certificate my-cert does not exist in namespace test"},
+		},
+		{
+			name: "output format is not valid",
+			args: []string{"my-cert"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+			flags:          common.CommandCertificateStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "all certificates",
+			args:           []string{},
+			flags:          common.CommandCertificateStatusFlags{Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateStatusWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdCertificateStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		certificate    string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	certificatees := []runtime.Object{
+		&v2alpha1.Certificate{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-cert",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"backend.example.com"},
+				Server:  true,
+			},
+			Status: v2alpha1.CertificateStatus{
+				Status:     v2alpha1.Status{StatusType: "Ready"},
+				Expiration: "2030-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "all certificates",
+			skupperObjects: certificatees,
+		},
+		{
+			name:           "all certificates yaml",
+			output:         "yaml",
+			skupperObjects: certificatees,
+		},
+		{
+			name:           "one certificate",
+			certificate:    "my-cert",
+			skupperObjects: certificatees,
+		},
+		{
+			name:           "one certificate json",
+			certificate:    "my-cert",
+			output:         "json",
+			skupperObjects: certificatees,
+		},
+		{
+			name:         "certificate does not exist",
+			certificate:  "my-cert",
+			errorMessage: "certificates.skupper.io \"my-cert\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateStatusWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = test.certificate
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateStatus := &CmdCertificateStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateStatus, nil
+}
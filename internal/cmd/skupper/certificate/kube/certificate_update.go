@@ -0,0 +1,240 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CertificateUpdates struct {
+	ca        string
+	subject   string
+	hosts     []string
+	isClient  bool
+	isServer  bool
+	isSigning bool
+	settings  map[string]string
+	timeout   time.Duration
+	output    string
+}
+
+type CmdCertificateUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandCertificateUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     CertificateUpdates
+	status          string
+}
+
+func NewCmdCertificateUpdate() *CmdCertificateUpdate {
+
+	return &CmdCertificateUpdate{}
+}
+
+func (cmd *CmdCertificateUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdCertificateUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a certificate with this name in the namespace
+	if cmd.name != "" {
+		certificate, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if certificate == nil || errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = certificate.ResourceVersion
+			cmd.newSettings.ca = certificate.Spec.Ca
+			cmd.newSettings.subject = certificate.Spec.Subject
+			cmd.newSettings.hosts = certificate.Spec.Hosts
+			cmd.newSettings.isClient = certificate.Spec.Client
+			cmd.newSettings.isServer = certificate.Spec.Server
+			cmd.newSettings.isSigning = certificate.Spec.Signing
+			cmd.newSettings.settings = certificate.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.Ca != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		} else {
+			cmd.newSettings.ca = cmd.Flags.Ca
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Subject != "" {
+		cmd.newSettings.subject = cmd.Flags.Subject
+	}
+	if cmd.Flags != nil && len(cmd.Flags.Hosts) > 0 {
+		valid := true
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("hosts are not valid: a valid IP address or hostname is expected"))
+				valid = false
+				break
+			}
+		}
+		if valid {
+			cmd.newSettings.hosts = cmd.Flags.Hosts
+		}
+	}
+	// usages can only be changed when explicitly set on the command line
+	if cmd.Flags != nil && cmd.CobraCmd != nil {
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameClient) {
+			cmd.newSettings.isClient = cmd.Flags.Client
+		}
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameServer) {
+			cmd.newSettings.isServer = cmd.Flags.Server
+		}
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameSigning) {
+			cmd.newSettings.isSigning = cmd.Flags.Signing
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdCertificateUpdate) InputToOptions() {
+	cmd.newSettings.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdCertificateUpdate) Run() error {
+
+	resource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:       cmd.newSettings.ca,
+			Subject:  cmd.newSettings.subject,
+			Hosts:    cmd.newSettings.hosts,
+			Client:   cmd.newSettings.isClient,
+			Server:   cmd.newSettings.isServer,
+			Signing:  cmd.newSettings.isSigning,
+			Settings: cmd.newSettings.settings,
+		},
+	}
+
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, resource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		_, err := cmd.client.Certificates(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (cmd *CmdCertificateUpdate) WaitUntil() error {
+
+	// the certificate resource was not updated
+	if cmd.newSettings.output != "" {
+		return nil
+	}
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.newSettings.timeout.Seconds())
+	var certificateCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.Certificates(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		// a certificate only reports whether it is ready
+		certificateCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+
+		if certificateCondition != nil && certificateCondition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		if certificateCondition != nil {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && certificateCondition == nil {
+		return fmt.Errorf("Certificate %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && certificateCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("Certificate %q is not yet %s: %s\n", cmd.name, cmd.status, certificateCondition.Message)
+	}
+
+	fmt.Printf("Certificate %q is updated\n", cmd.name)
+	return nil
+}
@@ -0,0 +1,246 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdCertificateUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandCertificateUpdateFlags
+		changedFlags   map[string]string
+		skupperObjects []runtime.Object
+		expectedErrors []string
+		expectedSpec   *v2alpha1.CertificateSpec
+	}
+
+	existing := []runtime.Object{
+		&v2alpha1.Certificate{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-cert",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"backend.example.com"},
+				Server:  true,
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate does not exist",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"certificate my-cert must exist in namespace test to be updated"},
+		},
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			flags:          common.CommandCertificateUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"certificate name must be configured"},
+		},
+		{
+			name:           "hosts are not valid",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Hosts: []string{"not valid"}, Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"hosts are not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "existing values are kept",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{},
+			expectedSpec: &v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"backend.example.com"},
+				Server:  true,
+			},
+		},
+		{
+			name:           "flags override existing values",
+			args:           []string{"my-cert"},
+			flags:          common.CommandCertificateUpdateFlags{Hosts: []string{"10.0.0.1"}, Client: true, Timeout: time.Minute},
+			changedFlags:   map[string]string{common.FlagNameClient: "true", common.FlagNameServer: "false"},
+			skupperObjects: existing,
+			expectedErrors: []string{},
+			expectedSpec: &v2alpha1.CertificateSpec{
+				Ca:      "skupper-site-ca",
+				Subject: "my-cert",
+				Hosts:   []string{"10.0.0.1"},
+				Client:  true,
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdCertificateUpdateWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			for name, value := range test.changedFlags {
+				command.CobraCmd.Flags().Bool(name, false, "")
+				assert.Assert(t, command.CobraCmd.Flags().Set(name, value))
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+			if test.expectedSpec != nil {
+				assert.Equal(t, command.newSettings.ca, test.expectedSpec.Ca)
+				assert.Equal(t, command.newSettings.subject, test.expectedSpec.Subject)
+				assert.DeepEqual(t, command.newSettings.hosts, test.expectedSpec.Hosts)
+				assert.Equal(t, command.newSettings.isClient, test.expectedSpec.Client)
+				assert.Equal(t, command.newSettings.isServer, test.expectedSpec.Server)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateUpdate_Run(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:         "certificate does not exist",
+			errorMessage: "certificates.skupper.io \"my-cert\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.newSettings.ca = "skupper-site-ca"
+		cmd.newSettings.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdCertificateUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "certificate is not returned",
+			status:      "ready",
+			expectError: true,
+		},
+		{
+			name:   "certificate is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Certificate{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-cert",
+						Namespace: "test",
+					},
+					Status: v2alpha1.CertificateStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdCertificateUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-cert"
+		cmd.status = test.status
+		cmd.newSettings.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdCertificateUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdCertificateUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdCertificateUpdate := &CmdCertificateUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdCertificateUpdate, nil
+}
@@ -0,0 +1,172 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdCertificateCreate struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateCreateFlags
+	namespace          string
+	certificateName    string
+	ca                 string
+	subject            string
+	hosts              []string
+	isClient           bool
+	isServer           bool
+	isSigning          bool
+	output             string
+}
+
+func NewCmdCertificateCreate() *CmdCertificateCreate {
+	return &CmdCertificateCreate{}
+}
+
+func (cmd *CmdCertificateCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateCreate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.certificateName = args[0]
+		}
+	}
+
+	// Validate if there is already a certificate with this name
+	if cmd.certificateName != "" {
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, opts)
+		if certificate != nil && err == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already a certificate %s created for namespace %s", cmd.certificateName, cmd.getNamespace()))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags.Ca == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("ca must be configured"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		}
+	}
+
+	for _, host := range cmd.Flags.Hosts {
+		ok, _ := hostStringValidator.Evaluate(host)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("hosts are not valid: a valid IP address or hostname is expected"))
+			break
+		}
+	}
+
+	if !cmd.Flags.Client && !cmd.Flags.Server && !cmd.Flags.Signing {
+		validationErrors = append(validationErrors, fmt.Errorf("at least one of client, server or signing must be set"))
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdCertificateCreate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	cmd.ca = cmd.Flags.Ca
+	if cmd.Flags.Subject == "" {
+		cmd.subject = cmd.certificateName
+	} else {
+		cmd.subject = cmd.Flags.Subject
+	}
+	cmd.hosts = cmd.Flags.Hosts
+	cmd.isClient = cmd.Flags.Client
+	cmd.isServer = cmd.Flags.Server
+	cmd.isSigning = cmd.Flags.Signing
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdCertificateCreate) Run() error {
+	certificateResource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.certificateName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      cmd.ca,
+			Subject: cmd.subject,
+			Hosts:   cmd.hosts,
+			Client:  cmd.isClient,
+			Server:  cmd.isServer,
+			Signing: cmd.isSigning,
+		},
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, certificateResource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		err := cmd.certificateHandler.Add(certificateResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdCertificateCreate) WaitUntil() error { return nil }
+
+func (cmd *CmdCertificateCreate) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
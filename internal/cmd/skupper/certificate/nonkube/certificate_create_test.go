@@ -0,0 +1,139 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNonKubeCmdCertificateCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandCertificateCreateFlags
+		existing          bool
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true},
+			expectedErrors: []string{"certificate name must be configured"},
+		},
+		{
+			name:           "certificate already exists",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true},
+			existing:       true,
+			expectedErrors: []string{"there is already a certificate my-cert created for namespace default"},
+		},
+		{
+			name:           "ca and usage are not specified",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{},
+			expectedErrors: []string{"ca must be configured", "at least one of client, server or signing must be set"},
+		},
+		{
+			name:           "hosts are not valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Server: true, Hosts: []string{"not valid"}},
+			expectedErrors: []string{"hosts are not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true, Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Client: true},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Hosts: []string{"backend.example.com"}, Server: true, Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdCertificateCreate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.certificateHandler = fs.NewCertificateHandler("")
+
+			if test.existing {
+				assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdCertificateCreate_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdCertificateCreate{Flags: &common.CommandCertificateCreateFlags{Ca: "skupper-site-ca", Hosts: []string{"backend.example.com"}, Server: true}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.certificateHandler = fs.NewCertificateHandler("")
+
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"my-cert"})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	certificate, err := command.certificateHandler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, certificate.Namespace, "default")
+	assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+	assert.Equal(t, certificate.Spec.Subject, "my-cert")
+	assert.DeepEqual(t, certificate.Spec.Hosts, []string{"backend.example.com"})
+	assert.Assert(t, certificate.Spec.Server)
+	assert.Assert(t, !certificate.Spec.Client)
+}
+
+// --- helper methods
+
+func newCertificate(name string) v2alpha1.Certificate {
+	return v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:      "skupper-site-ca",
+			Subject: name,
+			Hosts:   []string{"backend.example.com"},
+			Server:  true,
+		},
+	}
+}
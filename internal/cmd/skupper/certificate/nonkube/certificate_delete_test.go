@@ -0,0 +1,86 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdCertificateDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			expectedErrors: []string{"certificate name must be specified"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-cert", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "certificate name is empty",
+			args:           []string{""},
+			expectedErrors: []string{"certificate name must not be empty"},
+		},
+		{
+			name:           "certificate does not exist",
+			args:           []string{"other"},
+			expectedErrors: []string{"certificate other does not exist"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-cert"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdCertificateDelete{Flags: &common.CommandCertificateDeleteFlags{}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.certificateHandler = fs.NewCertificateHandler("")
+			assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdCertificateDelete_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdCertificateDelete{}
+	command.certificateName = "my-cert"
+	command.certificateHandler = fs.NewCertificateHandler("")
+	assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	_, err := command.certificateHandler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,144 @@
+package nonkube
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdCertificateStatus struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateStatusFlags
+	namespace          string
+	certificateName    string
+	output             string
+}
+
+func NewCmdCertificateStatus() *CmdCertificateStatus {
+	return &CmdCertificateStatus{}
+}
+
+func (cmd *CmdCertificateStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+			} else {
+				cmd.certificateName = args[0]
+			}
+		}
+	}
+	// Validate that there is a certificate with this name in the namespace
+	if cmd.certificateName != "" {
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, opts)
+		if certificate == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s does not exist in namespace %s", cmd.certificateName, cmd.getNamespace()))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdCertificateStatus) Run() error {
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: true}
+	if cmd.certificateName == "" {
+		certificatees, err := cmd.certificateHandler.List()
+		if certificatees == nil || err != nil {
+			fmt.Println("No certificates found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, certificate := range certificatees {
+				encodedOutput, err := utils.Encode(cmd.output, certificate)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "CA", "SUBJECT", "HOSTS"))
+			for _, certificate := range certificatees {
+				status := "Not Ready"
+				if certificate.IsReady() {
+					status = "Ok"
+				}
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+					certificate.Name, status, certificate.Spec.Ca, certificate.Spec.Subject,
+					strings.Join(certificate.Spec.Hosts, ",")))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, opts)
+		if certificate == nil || err != nil {
+			fmt.Println("No certificates found:", err)
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, certificate)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			status := "Not Ready"
+			if certificate.IsReady() {
+				status = "Ok"
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nCA:\t%s\nSubject:\t%s\nHosts:\t%s\nClient:\t%t\nServer:\t%t\nSigning:\t%t\nExpiration:\t%s\n",
+				certificate.Name, status, certificate.Spec.Ca, certificate.Spec.Subject, strings.Join(certificate.Spec.Hosts, ","),
+				certificate.Spec.Client, certificate.Spec.Server, certificate.Spec.Signing, certificate.Status.Expiration))
+			_ = tw.Flush()
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdCertificateStatus) InputToOptions()  {}
+func (cmd *CmdCertificateStatus) WaitUntil() error { return nil }
+
+func (cmd *CmdCertificateStatus) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
@@ -0,0 +1,118 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdCertificateStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          *common.CommandCertificateStatusFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-cert", "other"},
+			flags:          &common.CommandCertificateStatusFlags{},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "certificate does not exist",
+			args:           []string{"other"},
+			flags:          &common.CommandCertificateStatusFlags{},
+			expectedErrors: []string{"certificate other does not exist in namespace default"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateStatusFlags{Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdCertificateStatus{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.certificateHandler = fs.NewCertificateHandler("")
+			assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdCertificateStatus_Run(t *testing.T) {
+	type test struct {
+		name            string
+		certificateName string
+		output          string
+		withoutExisting bool
+		expectError     bool
+	}
+
+	testTable := []test{
+		{
+			name: "all certificates",
+		},
+		{
+			name:   "all certificates yaml",
+			output: "yaml",
+		},
+		{
+			name:            "one certificate",
+			certificateName: "my-cert",
+		},
+		{
+			name:            "one certificate json",
+			certificateName: "my-cert",
+			output:          "json",
+		},
+		{
+			name:            "certificate does not exist",
+			certificateName: "my-cert",
+			withoutExisting: true,
+			expectError:     true,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdCertificateStatus{}
+			command.certificateName = test.certificateName
+			command.output = test.output
+			command.certificateHandler = fs.NewCertificateHandler("")
+			if !test.withoutExisting {
+				assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+			}
+
+			err := command.Run()
+			if test.expectError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CertificateUpdates struct {
+	ca        string
+	subject   string
+	hosts     []string
+	isClient  bool
+	isServer  bool
+	isSigning bool
+	settings  map[string]string
+	output    string
+}
+
+type CmdCertificateUpdate struct {
+	certificateHandler *fs.CertificateHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandCertificateUpdateFlags
+	namespace          string
+	certificateName    string
+	newSettings        CertificateUpdates
+}
+
+func NewCmdCertificateUpdate() *CmdCertificateUpdate {
+	return &CmdCertificateUpdate{}
+}
+
+func (cmd *CmdCertificateUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.certificateHandler = fs.NewCertificateHandler(cmd.namespace)
+}
+
+func (cmd *CmdCertificateUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("certificate name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate name is not valid: %s", err))
+		} else {
+			cmd.certificateName = args[0]
+		}
+	}
+
+	// Validate that there is already a certificate with this name in the namespace
+	if cmd.certificateName != "" {
+		certificate, err := cmd.certificateHandler.Get(cmd.certificateName, opts)
+		if certificate == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate %s must exist in namespace %s to be updated", cmd.certificateName, cmd.getNamespace()))
+		} else {
+			// save existing values
+			cmd.newSettings.ca = certificate.Spec.Ca
+			cmd.newSettings.subject = certificate.Spec.Subject
+			cmd.newSettings.hosts = certificate.Spec.Hosts
+			cmd.newSettings.isClient = certificate.Spec.Client
+			cmd.newSettings.isServer = certificate.Spec.Server
+			cmd.newSettings.isSigning = certificate.Spec.Signing
+			cmd.newSettings.settings = certificate.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags.Ca != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Ca)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("ca is not valid: %s", err))
+		} else {
+			cmd.newSettings.ca = cmd.Flags.Ca
+		}
+	}
+	if cmd.Flags.Subject != "" {
+		cmd.newSettings.subject = cmd.Flags.Subject
+	}
+	if len(cmd.Flags.Hosts) > 0 {
+		valid := true
+		for _, host := range cmd.Flags.Hosts {
+			ok, _ := hostStringValidator.Evaluate(host)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("hosts are not valid: a valid IP address or hostname is expected"))
+				valid = false
+				break
+			}
+		}
+		if valid {
+			cmd.newSettings.hosts = cmd.Flags.Hosts
+		}
+	}
+	// usages can only be changed when explicitly set on the command line
+	if cmd.CobraCmd != nil {
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameClient) {
+			cmd.newSettings.isClient = cmd.Flags.Client
+		}
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameServer) {
+			cmd.newSettings.isServer = cmd.Flags.Server
+		}
+		if cmd.CobraCmd.Flags().Changed(common.FlagNameSigning) {
+			cmd.newSettings.isSigning = cmd.Flags.Signing
+		}
+	}
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdCertificateUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdCertificateUpdate) Run() error {
+	certificateResource := v2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.certificateName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.CertificateSpec{
+			Ca:       cmd.newSettings.ca,
+			Subject:  cmd.newSettings.subject,
+			Hosts:    cmd.newSettings.hosts,
+			Client:   cmd.newSettings.isClient,
+			Server:   cmd.newSettings.isServer,
+			Signing:  cmd.newSettings.isSigning,
+			Settings: cmd.newSettings.settings,
+		},
+	}
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, certificateResource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		err := cmd.certificateHandler.Add(certificateResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdCertificateUpdate) WaitUntil() error { return nil }
+
+func (cmd *CmdCertificateUpdate) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
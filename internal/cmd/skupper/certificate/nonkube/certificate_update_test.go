@@ -0,0 +1,90 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdCertificateUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name            string
+		args            []string
+		flags           *common.CommandCertificateUpdateFlags
+		withoutExisting bool
+		expectedErrors  []string
+	}
+
+	testTable := []test{
+		{
+			name:           "certificate name is not specified",
+			args:           []string{},
+			flags:          &common.CommandCertificateUpdateFlags{},
+			expectedErrors: []string{"certificate name must be configured"},
+		},
+		{
+			name:            "certificate does not exist",
+			args:            []string{"my-cert"},
+			flags:           &common.CommandCertificateUpdateFlags{},
+			withoutExisting: true,
+			expectedErrors:  []string{"certificate my-cert must exist in namespace default to be updated"},
+		},
+		{
+			name:           "ca is not valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateUpdateFlags{Ca: "my_ca"},
+			expectedErrors: []string{"ca is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-cert"},
+			flags:          &common.CommandCertificateUpdateFlags{Hosts: []string{"10.0.0.1"}, Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdCertificateUpdate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.certificateHandler = fs.NewCertificateHandler("")
+
+			if !test.withoutExisting {
+				assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdCertificateUpdate_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdCertificateUpdate{Flags: &common.CommandCertificateUpdateFlags{Hosts: []string{"10.0.0.1"}, Client: true}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.CobraCmd.Flags().Bool(common.FlagNameClient, false, "")
+	assert.Assert(t, command.CobraCmd.Flags().Set(common.FlagNameClient, "true"))
+	command.certificateHandler = fs.NewCertificateHandler("")
+	assert.Assert(t, command.certificateHandler.Add(newCertificate("my-cert")))
+
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"my-cert"})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	certificate, err := command.certificateHandler.Get("my-cert", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, certificate.Spec.Ca, "skupper-site-ca")
+	assert.DeepEqual(t, certificate.Spec.Hosts, []string{"10.0.0.1"})
+	assert.Assert(t, certificate.Spec.Client)
+	assert.Assert(t, certificate.Spec.Server)
+}
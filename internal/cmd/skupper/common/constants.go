@@ -1,19 +1,29 @@
 package common
 
 var (
-	LinkAccessTypes = []string{"route", "loadbalancer", "default"}
-	OutputTypes     = []string{"json", "yaml"}
-	ListenerTypes   = []string{"tcp"}
-	ConnectorTypes  = []string{"tcp"}
-	WorkloadTypes   = []string{"deployment", "service", "daemonset", "statefulset"}
-	WaitStatusTypes = []string{"ready", "configured", "none"}
+	LinkAccessTypes          = []string{"route", "loadbalancer", "default"}
+	OutputTypes              = []string{"json", "yaml"}
+	ListenerTypes            = []string{"tcp", "udp"}
+	ConnectorTypes           = []string{"tcp", "udp"}
+	WorkloadTypes            = []string{"deployment", "service", "daemonset", "statefulset"}
+	WaitStatusTypes          = []string{"ready", "configured", "none"}
+	AccessTypes              = []string{"local", "loadbalancer", "route", "nodeport", "ingress-nginx", "contour-http-proxy", "gateway"}
+	RouterAccessRoles        = []string{"inter-router", "edge"}
+	NetworkStatusOutputTypes = []string{"table", "tree", "json", "yaml"}
+	// SkmanageEntities are the router entities queried with skmanage for debug dumps
+	SkmanageEntities = []string{"router", "connection", "listener", "connector", "tcpListener", "tcpConnector", "udpListener", "udpConnector", "address", "router.node", "sslProfile"}
 )
 
 const (
-	Connectors     string = "connectors"
-	Listeners      string = "listeners"
-	Sites          string = "sites"
-	RouterAccesses string = "routerAccesses"
+	Connectors      string = "connectors"
+	Listeners       string = "listeners"
+	Sites           string = "sites"
+	RouterAccesses  string = "routerAccesses"
+	AccessGrants    string = "accessGrants"
+	Links           string = "links"
+	Secrets         string = "secrets"
+	Certificates    string = "certificates"
+	SecuredAccesses string = "securedAccesses"
 )
 
 const (
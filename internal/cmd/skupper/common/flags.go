@@ -51,7 +51,7 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagNameHost                = "host"
 	FlagDescHost                = "The hostname or IP address of the local connector"
 	FlagNameConnectorType       = "type"
-	FlagDescConnectorType       = "The connector type. Choices: [tcp]."
+	FlagDescConnectorType       = "The connector type. Choices: [tcp|udp]."
 	FlagNameIncludeNotReadyPods = "include-not-ready"
 	FlagDescIncludeNotRead      = "If true, include server pods that are not in the ready state."
 	FlagNameSelector            = "selector"
@@ -65,19 +65,63 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagNameConnectorStatusOutput = "output"
 	FlagDescConnectorStatusOutput = "print status of connectors Choices: json, yaml"
 
+	FlagNameNetworkStatusOutput = "output"
+	FlagDescNetworkStatusOutput = "print status of the network Choices: table, tree, json, yaml"
+
+	FlagNameSiteNamespace      = "site-namespace"
+	FlagDescSiteNamespace      = "The namespace of the site the attached connector is exposed through."
+	FlagNameConnectorNamespace = "connector-namespace"
+	FlagDescConnectorNamespace = "The namespace where the attached connector is defined."
+	FlagNameUseClientCert      = "use-client-cert"
+	FlagDescUseClientCert      = "If true, send the client certificate from tls-credentials when connecting to the target pods."
+	FlagNameExposePodsByName   = "expose-pods-by-name"
+	FlagDescExposePodsByName   = "If true, expose each selected pod as a separate service, identified by pod name."
+
 	FlagNameListenerType = "type"
-	FlagDescListenerType = "The listener type. Choices: [tcp]."
+	FlagDescListenerType = "The listener type. Choices: [tcp|udp]."
 	FlagNameListenerPort = "port"
 	FlagDescListenerPort = "The port of the local listener"
 	FlagNameListenerHost = "host"
 	FlagDescListenerHost = "The hostname or IP address of the local listener. Clients at this site use the listener host and port to establish connections to the remote service."
 
-	FlagNamePath     = "path"
-	FlagDescPath     = "Custom resources location on the file system"
-	FlagNameStrategy = "strategy"
-	FlagDescStrategy = "The bundle strategy to be produced. Choices: bundle, tarball"
-	FlagNameForce    = "force"
-	FlagDescForce    = "Forces to overwrite an existing namespace"
+	FlagNameRoles                  = "roles"
+	FlagDescRoles                  = "The roles the router access is used for, expressed as role[:port]. Choices: inter-router, edge"
+	FlagNameGenerateTlsCredentials = "generate-tls-credentials"
+	FlagDescGenerateTlsCredentials = "generate the TLS credentials, signed by the issuer, when they are not supplied externally"
+	FlagNameIssuer                 = "issuer"
+	FlagDescIssuer                 = "the name of the CA used to sign the generated TLS credentials"
+	FlagNameAccessType             = "access-type"
+	FlagDescAccessType             = `configure how the access is exposed outside the site.
+Choices: [local|loadbalancer|route|nodeport|ingress-nginx|contour-http-proxy|gateway].
+When not set, the default access type of the controller is used.`
+	FlagNamePorts       = "ports"
+	FlagDescPorts       = "The ports to expose, expressed as name:port[:targetPort]"
+	FlagNameCertificate = "certificate"
+	FlagDescCertificate = "the name of the secret holding the certificate used to secure the access"
+
+	FlagNameCa      = "ca"
+	FlagDescCa      = "the name of the CA used to sign the certificate"
+	FlagNameSubject = "subject"
+	FlagDescSubject = "the subject of the certificate (defaults to the certificate name)"
+	FlagNameHosts   = "hosts"
+	FlagDescHosts   = "the hostnames and IP addresses the certificate is valid for"
+	FlagNameClient  = "client"
+	FlagDescClient  = "the certificate can be used for client authentication"
+	FlagNameServer  = "server"
+	FlagDescServer  = "the certificate can be used for server authentication"
+	FlagNameSigning = "signing"
+	FlagDescSigning = "the certificate is a CA, able to sign other certificates"
+
+	FlagNamePath            = "path"
+	FlagDescPath            = "Custom resources location on the file system"
+	FlagNameGrantServerHost = "host"
+	FlagDescGrantServerHost = "The hostname or IP address remote sites use to reach the grant server (defaults to the first non-loopback address of the site certificate)"
+	FlagNameGrantServerPort = "port"
+	FlagDescGrantServerPort = "The port the grant server listens on"
+	FlagNameStrategy        = "strategy"
+	FlagDescStrategy        = "The bundle strategy to be produced. Choices: bundle, tarball"
+	FlagNameForce           = "force"
+	FlagDescForce           = "Forces to overwrite an existing namespace"
 
 	FlagNameWait       = "wait"
 	FlagDescWait       = "Wait for the given status before exiting. Choices: configured, ready, none"
@@ -194,6 +238,41 @@ type CommandConnectorStatusFlags struct {
 	Output string
 }
 
+type CommandNetworkStatusFlags struct {
+	Output string
+}
+
+type CommandConnectorAttachFlags struct {
+	SiteNamespace       string
+	RoutingKey          string
+	Selector            string
+	TlsCredentials      string
+	UseClientCert       bool
+	ConnectorType       string
+	IncludeNotReadyPods bool
+	ExposePodsByName    bool
+	Timeout             time.Duration
+	Output              string
+	Wait                string
+}
+
+type CommandConnectorBindFlags struct {
+	ConnectorNamespace string
+	RoutingKey         string
+	ExposePodsByName   bool
+	Output             string
+}
+
+type CommandConnectorDetachFlags struct {
+	SiteNamespace string
+	Timeout       time.Duration
+	Wait          bool
+}
+
+type CommandConnectorAttachStatusFlags struct {
+	Output string
+}
+
 type CommandListenerCreateFlags struct {
 	RoutingKey     string
 	Host           string
@@ -224,15 +303,119 @@ type CommandListenerDeleteFlags struct {
 	Wait    bool
 }
 
+type CommandRouterAccessCreateFlags struct {
+	Roles                   []string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	AccessType              string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Output                  string
+	Wait                    string
+}
+
+type CommandRouterAccessUpdateFlags struct {
+	Roles                   []string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	AccessType              string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Output                  string
+	Wait                    string
+}
+
+type CommandRouterAccessStatusFlags struct {
+	Output string
+}
+
+type CommandRouterAccessDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandSecuredAccessCreateFlags struct {
+	Selector    string
+	Ports       []string
+	AccessType  string
+	Certificate string
+	Issuer      string
+	Timeout     time.Duration
+	Output      string
+	Wait        string
+}
+
+type CommandSecuredAccessUpdateFlags struct {
+	Selector    string
+	Ports       []string
+	AccessType  string
+	Certificate string
+	Issuer      string
+	Timeout     time.Duration
+	Output      string
+	Wait        string
+}
+
+type CommandSecuredAccessStatusFlags struct {
+	Output string
+}
+
+type CommandSecuredAccessDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandCertificateCreateFlags struct {
+	Ca      string
+	Subject string
+	Hosts   []string
+	Client  bool
+	Server  bool
+	Signing bool
+	Timeout time.Duration
+	Output  string
+	Wait    string
+}
+
+type CommandCertificateUpdateFlags struct {
+	Ca      string
+	Subject string
+	Hosts   []string
+	Client  bool
+	Server  bool
+	Signing bool
+	Timeout time.Duration
+	Output  string
+	Wait    string
+}
+
+type CommandCertificateStatusFlags struct {
+	Output string
+}
+
+type CommandCertificateDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
 type CommandSystemSetupFlags struct {
 	Path     string
 	Strategy string
 	Force    bool
 }
 
+type CommandSystemGrantServerFlags struct {
+	Host string
+	Port int
+}
+
 type CommandVersionFlags struct {
 	Output string
 }
 
-type CommandDebugFlags struct {
+type CommandDebugDumpFlags struct {
 }
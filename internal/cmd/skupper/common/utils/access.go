@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ParseRouterAccessRoles converts values expressed as role[:port] into
+// the roles of a RouterAccess; a missing port is left to the router default
+func ParseRouterAccessRoles(values []string, allowedRoles []string) ([]v2alpha1.RouterAccessRole, error) {
+	var roles []v2alpha1.RouterAccessRole
+	for _, value := range values {
+		name, portValue, hasPort := strings.Cut(value, ":")
+		if !slices.Contains(allowedRoles, name) {
+			return nil, fmt.Errorf("role %s not allowed. It should be one of this options: %v", name, allowedRoles)
+		}
+		role := v2alpha1.RouterAccessRole{Name: name}
+		if hasPort {
+			port, err := parsePort(portValue)
+			if err != nil {
+				return nil, fmt.Errorf("port of role %s is not valid: %s", name, err)
+			}
+			role.Port = port
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// ParseSecuredAccessPorts converts values expressed as name:port[:targetPort]
+// into the ports of a SecuredAccess
+func ParseSecuredAccessPorts(values []string) ([]v2alpha1.SecuredAccessPort, error) {
+	var ports []v2alpha1.SecuredAccessPort
+	for _, value := range values {
+		parts := strings.Split(value, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("port %s is not valid: expected name:port[:targetPort]", value)
+		}
+		port, err := parsePort(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("port %s is not valid: %s", value, err)
+		}
+		securedAccessPort := v2alpha1.SecuredAccessPort{
+			Name:     parts[0],
+			Port:     port,
+			Protocol: "TCP",
+		}
+		if len(parts) == 3 {
+			targetPort, err := parsePort(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("target port %s is not valid: %s", value, err)
+			}
+			securedAccessPort.TargetPort = targetPort
+		}
+		ports = append(ports, securedAccessPort)
+	}
+	return ports, nil
+}
+
+// ParseSelector converts a label selector such as app=backend,tier=db
+// into the map used by a SecuredAccess
+func ParseSelector(selector string) (map[string]string, error) {
+	selectorMap, err := labels.ConvertSelectorToLabelsMap(selector)
+	if err != nil {
+		return nil, err
+	}
+	return selectorMap, nil
+}
+
+func FormatSelector(selector map[string]string) string {
+	return labels.Set(selector).String()
+}
+
+func FormatRouterAccessRoles(roles []v2alpha1.RouterAccessRole) string {
+	var values []string
+	for _, role := range roles {
+		values = append(values, fmt.Sprintf("%s:%d", role.Name, role.GetPort()))
+	}
+	return strings.Join(values, ",")
+}
+
+func FormatSecuredAccessPorts(ports []v2alpha1.SecuredAccessPort) string {
+	var values []string
+	for _, port := range ports {
+		if port.TargetPort != 0 {
+			values = append(values, fmt.Sprintf("%s:%d:%d", port.Name, port.Port, port.TargetPort))
+		} else {
+			values = append(values, fmt.Sprintf("%s:%d", port.Name, port.Port))
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func FormatEndpoints(endpoints []v2alpha1.Endpoint) string {
+	var values []string
+	for _, endpoint := range endpoints {
+		values = append(values, fmt.Sprintf("%s:%s", endpoint.Host, endpoint.Port))
+	}
+	return strings.Join(values, ",")
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("value %d is out of range", port)
+	}
+	return port, nil
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/skupperproject/skupper/pkg/utils/formatter"
+)
+
+// PrintNetworkStatus renders the network status in the given output format:
+// "json" and "yaml" encode it, "tree" shows the sites and their links as a
+// nested list and anything else shows it as tables.
+func PrintNetworkStatus(output string, currentSite string, networkStatus *network.NetworkStatusInfo) error {
+	switch output {
+	case "json", "yaml":
+		encodedOutput, err := Encode(output, networkStatus)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+		return nil
+	case "tree":
+		if err := formatter.PrintNetworkStatus(currentSite, networkStatus, "", true); err != nil {
+			return err
+		}
+	default:
+		if err := formatter.PrintNetworkStatusTable(currentSite, networkStatus); err != nil {
+			return err
+		}
+	}
+	formatter.PrintNetworkStatusWarnings(networkStatus)
+	return nil
+}
@@ -25,6 +25,10 @@ skupper connector status my-connector`,
 	cmd.AddCommand(CmdConnectorStatusFactory(config.GetPlatform()))
 	cmd.AddCommand(CmdConnectorUpdateFactory(config.GetPlatform()))
 	cmd.AddCommand(CmdConnectorDeleteFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdConnectorAttachFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdConnectorBindFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdConnectorDetachFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdConnectorAttachStatusFactory(config.GetPlatform()))
 
 	return cmd
 }
@@ -164,3 +168,134 @@ func CmdConnectorDeleteFactory(configuredPlatform types.Platform) *cobra.Command
 
 	return cmd
 }
+
+func CmdConnectorAttachFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorAttach()
+	nonKubeCommand := nonkube.NewCmdConnectorAttach()
+
+	cmdConnectorAttachDesc := common.SkupperCmdDescription{
+		Use:   "attach <name> <port>",
+		Short: "attach workloads in this namespace to a site in another namespace",
+		Long: `Create an AttachedConnector in the current namespace and the matching AttachedConnectorBinding
+in the site namespace, so that pods selected here are exposed through the site there.`,
+		Example: "skupper connector attach backend 8080 --site-namespace shared-site --selector app=backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorAttachDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorAttachFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().StringVarP(&cmdFlags.Selector, common.FlagNameSelector, "s", "", common.FlagDescSelector)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.UseClientCert, common.FlagNameUseClientCert, false, common.FlagDescUseClientCert)
+	cmd.Flags().StringVar(&cmdFlags.ConnectorType, common.FlagNameConnectorType, "tcp", common.FlagDescConnectorType)
+	cmd.Flags().BoolVarP(&cmdFlags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, "i", false, common.FlagDescIncludeNotRead)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Hidden = true
+	}
+
+	return cmd
+}
+
+func CmdConnectorBindFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorBind()
+	nonKubeCommand := nonkube.NewCmdConnectorBind()
+
+	cmdConnectorBindDesc := common.SkupperCmdDescription{
+		Use:   "bind <name>",
+		Short: "bind an attached connector from another namespace to the site in this namespace",
+		Long: `Create an AttachedConnectorBinding in the current (site) namespace for an AttachedConnector
+that is managed in the connector namespace.`,
+		Example: "skupper connector bind backend --connector-namespace app-team --routing-key backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorBindDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorBindFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.ConnectorNamespace, common.FlagNameConnectorNamespace, "", common.FlagDescConnectorNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Hidden = true
+	}
+
+	return cmd
+}
+
+func CmdConnectorDetachFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorDetach()
+	nonKubeCommand := nonkube.NewCmdConnectorDetach()
+
+	cmdConnectorDetachDesc := common.SkupperCmdDescription{
+		Use:     "detach <name>",
+		Short:   "delete an attached connector",
+		Long:    "Delete the AttachedConnector <name> in the current namespace and its binding in the site namespace",
+		Example: "skupper connector detach backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorDetachDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorDetachFlags{}
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().DurationVarP(&cmdFlags.Timeout, common.FlagNameTimeout, "t", 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Hidden = true
+	}
+
+	return cmd
+}
+
+func CmdConnectorAttachStatusFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorAttachStatus()
+	nonKubeCommand := nonkube.NewCmdConnectorAttachStatus()
+
+	cmdConnectorAttachStatusDesc := common.SkupperCmdDescription{
+		Use:     "attach-status <name>",
+		Short:   "get status of attached connectors",
+		Long:    "Display status of all attached connectors in the current namespace or a specific one, combined with their bindings",
+		Example: "skupper connector attach-status backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorAttachStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorAttachStatusFlags{}
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Hidden = true
+	}
+
+	return cmd
+}
@@ -68,6 +68,49 @@ func TestCmdConnectorFactory(t *testing.T) {
 			},
 			command: CmdConnectorDeleteFactory(types.PlatformKubernetes),
 		},
+		{
+			name: "CmdConnectorAttachFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace:       "",
+				common.FlagNameRoutingKey:          "",
+				common.FlagNameSelector:            "",
+				common.FlagNameTlsCredentials:      "",
+				common.FlagNameUseClientCert:       "false",
+				common.FlagNameConnectorType:       "tcp",
+				common.FlagNameIncludeNotReadyPods: "false",
+				common.FlagNameExposePodsByName:    "false",
+				common.FlagNameOutput:              "",
+				common.FlagNameTimeout:             "1m0s",
+				common.FlagNameWait:                "configured",
+			},
+			command: CmdConnectorAttachFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorBindFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameConnectorNamespace: "",
+				common.FlagNameRoutingKey:         "",
+				common.FlagNameExposePodsByName:   "false",
+				common.FlagNameOutput:             "",
+			},
+			command: CmdConnectorBindFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorDetachFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace: "",
+				common.FlagNameTimeout:       "1m0s",
+				common.FlagNameWait:          "true",
+			},
+			command: CmdConnectorDetachFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorAttachStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdConnectorAttachStatusFactory(types.PlatformKubernetes),
+		},
 	}
 
 	for _, test := range testTable {
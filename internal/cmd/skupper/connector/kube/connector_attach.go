@@ -0,0 +1,320 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdConnectorAttach struct {
+	client              skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandConnectorAttachFlags
+	namespace           string
+	name                string
+	port                int
+	siteNamespace       string
+	selector            string
+	routingKey          string
+	tlsCredentials      string
+	useClientCert       bool
+	connectorType       string
+	includeNotReadyPods bool
+	exposePodsByName    bool
+	output              string
+	timeout             time.Duration
+	KubeClient          kubernetes.Interface
+	status              string
+}
+
+func NewCmdConnectorAttach() *CmdConnectorAttach {
+
+	return &CmdConnectorAttach{}
+}
+
+func (cmd *CmdConnectorAttach) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdConnectorAttach) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	connectorTypeValidator := validator.NewOptionValidator(common.ConnectorTypes)
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	selectorStringValidator := validator.NewSelectorStringValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("connector port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector port is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags == nil || cmd.Flags.SiteNamespace == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("site namespace must be configured"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+		} else if cmd.Flags.SiteNamespace == cmd.namespace {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace must be different from the connector namespace %s; use 'skupper connector create' instead", cmd.namespace))
+		} else {
+			cmd.siteNamespace = cmd.Flags.SiteNamespace
+		}
+	}
+
+	// Validate that neither half of the pair exists already, and that an existing
+	// binding in the site namespace does not refer to a different connector namespace
+	if cmd.name != "" {
+		attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if attachedConnector != nil && !errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already an attached connector %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+		if cmd.siteNamespace != "" {
+			binding, err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if binding != nil && !errors.IsNotFound(err) {
+				if binding.Spec.ConnectorNamespace != cmd.namespace {
+					validationErrors = append(validationErrors, fmt.Errorf("attached connector binding %s in namespace %s is bound to connector namespace %s", cmd.name, cmd.siteNamespace, binding.Spec.ConnectorNamespace))
+				} else {
+					validationErrors = append(validationErrors, fmt.Errorf("there is already an attached connector binding %s created for namespace %s", cmd.name, cmd.siteNamespace))
+				}
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.RoutingKey != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.RoutingKey)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routing key is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		// the secret is read by the site controller from the connector namespace
+		_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-secret is not valid: does not exist"))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.UseClientCert && cmd.Flags.TlsCredentials == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("use-client-cert requires tls-credentials to be configured"))
+	}
+	if cmd.Flags != nil && cmd.Flags.ConnectorType != "" {
+		ok, err := connectorTypeValidator.Evaluate(cmd.Flags.ConnectorType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector type is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		ok, err := selectorStringValidator.Evaluate(cmd.Flags.Selector)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdConnectorAttach) InputToOptions() {
+
+	// default selector to name of connector
+	if cmd.Flags.Selector == "" {
+		cmd.selector = "app=" + cmd.name
+	} else {
+		cmd.selector = cmd.Flags.Selector
+	}
+
+	// default routingkey to name of connector
+	if cmd.Flags.RoutingKey == "" {
+		cmd.routingKey = cmd.name
+	} else {
+		cmd.routingKey = cmd.Flags.RoutingKey
+	}
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.useClientCert = cmd.Flags.UseClientCert
+	cmd.connectorType = cmd.Flags.ConnectorType
+	cmd.includeNotReadyPods = cmd.Flags.IncludeNotReadyPods
+	cmd.exposePodsByName = cmd.Flags.ExposePodsByName
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.output = cmd.Flags.Output
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdConnectorAttach) Run() error {
+
+	attachedConnector := v2alpha1.AttachedConnector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorSpec{
+			SiteNamespace:       cmd.siteNamespace,
+			Selector:            cmd.selector,
+			Port:                cmd.port,
+			TlsCredentials:      cmd.tlsCredentials,
+			UseClientCert:       cmd.useClientCert,
+			Type:                cmd.connectorType,
+			IncludeNotReadyPods: cmd.includeNotReadyPods,
+		},
+	}
+
+	binding := v2alpha1.AttachedConnectorBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnectorBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.siteNamespace,
+		},
+		Spec: v2alpha1.AttachedConnectorBindingSpec{
+			ConnectorNamespace: cmd.namespace,
+			RoutingKey:         cmd.routingKey,
+			ExposePodsByName:   cmd.exposePodsByName,
+		},
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, attachedConnector)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+		encodedOutput, err = utils.Encode(cmd.output, binding)
+		fmt.Println(encodedOutput)
+		return err
+	}
+
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).Create(context.TODO(), &attachedConnector, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Create(context.TODO(), &binding, metav1.CreateOptions{})
+	if err != nil {
+		// do not leave half of the pair behind
+		_ = cmd.client.AttachedConnectors(cmd.namespace).Delete(context.TODO(), cmd.name, metav1.DeleteOptions{})
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdConnectorAttach) WaitUntil() error {
+	// the resources were not created
+	if cmd.output != "" {
+		return nil
+	}
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+
+	var condition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		// the attached connector is configured once the site controller has
+		// accepted it; it is ready once its binding has found a matching listener
+		var conditions []metav1.Condition
+		switch cmd.status {
+		case "ready":
+			binding, err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			conditions = binding.Status.Conditions
+			condition = meta.FindStatusCondition(conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			conditions = attachedConnector.Status.Conditions
+			condition = meta.FindStatusCondition(conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if condition != nil && condition.Status == metav1.ConditionTrue {
+			return nil
+		}
+
+		if condition != nil {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && condition == nil {
+		return fmt.Errorf("AttachedConnector %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && condition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnector %q is not yet %s: %s\n", cmd.name, cmd.status, condition.Message)
+	} else if err != nil {
+		return fmt.Errorf("AttachedConnector %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	}
+
+	fmt.Printf("AttachedConnector %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
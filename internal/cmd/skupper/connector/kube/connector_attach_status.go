@@ -0,0 +1,192 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AttachedConnectorStatus combines an AttachedConnector with the binding for
+// it in the site namespace, if any.
+type AttachedConnectorStatus struct {
+	AttachedConnector *v2alpha1.AttachedConnector        `json:"attachedConnector"`
+	Binding           *v2alpha1.AttachedConnectorBinding `json:"binding,omitempty"`
+}
+
+type CmdConnectorAttachStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandConnectorAttachStatusFlags
+	namespace string
+	name      string
+	output    string
+}
+
+func NewCmdConnectorAttachStatus() *CmdConnectorAttachStatus {
+	return &CmdConnectorAttachStatus{}
+}
+
+func (cmd *CmdConnectorAttachStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorAttachStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+			} else {
+				cmd.name = args[0]
+			}
+		}
+	}
+
+	if cmd.name != "" {
+		attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if attachedConnector == nil || errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdConnectorAttachStatus) Run() error {
+	var attachedConnectors []v2alpha1.AttachedConnector
+	if cmd.name == "" {
+		resources, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || resources == nil || len(resources.Items) == 0 {
+			fmt.Println("No attached connectors found")
+			return err
+		}
+		attachedConnectors = resources.Items
+	} else {
+		resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || resource == nil {
+			fmt.Println("No attached connectors found")
+			return err
+		}
+		attachedConnectors = append(attachedConnectors, *resource)
+	}
+
+	var statuses []AttachedConnectorStatus
+	for i := range attachedConnectors {
+		status := AttachedConnectorStatus{AttachedConnector: &attachedConnectors[i]}
+		binding, err := cmd.client.AttachedConnectorBindings(attachedConnectors[i].Spec.SiteNamespace).Get(context.TODO(), attachedConnectors[i].Name, metav1.GetOptions{})
+		if err == nil && binding != nil && binding.Spec.ConnectorNamespace == cmd.namespace {
+			status.Binding = binding
+		}
+		statuses = append(statuses, status)
+	}
+
+	if cmd.output != "" {
+		for _, status := range statuses {
+			encodedOutput, err := utils.Encode(cmd.output, status)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+	if cmd.name == "" {
+		_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
+			"NAME", "STATUS", "SITE-NAMESPACE", "ROUTING-KEY", "SELECTOR", "PORT", "SELECTED PODS", "HAS MATCHING LISTENER"))
+		for _, status := range statuses {
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%d\t%d\t%t",
+				status.AttachedConnector.Name, status.statusType(), status.AttachedConnector.Spec.SiteNamespace,
+				status.routingKey(), status.AttachedConnector.Spec.Selector, status.AttachedConnector.Spec.Port,
+				len(status.AttachedConnector.Status.SelectedPods), status.hasMatchingListener()))
+		}
+	} else {
+		status := statuses[0]
+		_, _ = fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nSite namespace:\t%s\nBound:\t%t\nRouting key:\t%s\nSelector:\t%s\nPort:\t%d\nSelected pods:\t%s\nHas Matching Listener:\t%t\nMessage:\t%s\n",
+			status.AttachedConnector.Name, status.statusType(), status.AttachedConnector.Spec.SiteNamespace, status.Binding != nil,
+			status.routingKey(), status.AttachedConnector.Spec.Selector, status.AttachedConnector.Spec.Port,
+			formatPods(status.AttachedConnector.Status.SelectedPods), status.hasMatchingListener(), status.message()))
+	}
+	_ = tw.Flush()
+
+	return nil
+}
+
+func (cmd *CmdConnectorAttachStatus) InputToOptions()  {}
+func (cmd *CmdConnectorAttachStatus) WaitUntil() error { return nil }
+
+// statusType reports the binding status once the pair is complete, as only
+// the binding knows whether a matching listener exists.
+func (s AttachedConnectorStatus) statusType() string {
+	if s.Binding == nil {
+		if s.AttachedConnector.Status.StatusType == "" {
+			return "Unbound"
+		}
+		return string(s.AttachedConnector.Status.StatusType) + " (unbound)"
+	}
+	if s.AttachedConnector.Status.StatusType != v2alpha1.StatusReady {
+		return string(s.AttachedConnector.Status.StatusType)
+	}
+	return string(s.Binding.Status.StatusType)
+}
+
+func (s AttachedConnectorStatus) routingKey() string {
+	if s.Binding == nil {
+		return ""
+	}
+	return s.Binding.Spec.RoutingKey
+}
+
+func (s AttachedConnectorStatus) hasMatchingListener() bool {
+	return s.Binding != nil && s.Binding.Status.HasMatchingListener
+}
+
+func (s AttachedConnectorStatus) message() string {
+	if s.AttachedConnector.Status.Message != "" || s.Binding == nil {
+		return s.AttachedConnector.Status.Message
+	}
+	return s.Binding.Status.Message
+}
+
+func formatPods(pods []v2alpha1.PodDetails) string {
+	var values []string
+	for _, pod := range pods {
+		values = append(values, pod.Name+"("+pod.IP+")")
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,191 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorAttachStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorAttachStatusFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument",
+			args:           []string{"backend", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "attached connector does not exist",
+			args:           []string{"backend"},
+			expectedErrors: []string{"attached connector backend does not exist in namespace test"},
+		},
+		{
+			name:           "output is not valid",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorAttachStatusFlags{Output: "xml"},
+			skupperObjects: attachedConnectorPair("site"),
+			expectedErrors: []string{"output type is not valid: value xml not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "no name lists all",
+			args:           []string{},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorAttachStatusWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdConnectorAttachStatus_Run(t *testing.T) {
+	type test struct {
+		name                string
+		connectorName       string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "lists attached connectors",
+			skupperObjects: attachedConnectorPair("site"),
+		},
+		{
+			name:           "shows one attached connector",
+			connectorName:  "backend",
+			skupperObjects: attachedConnectorPair("site"),
+		},
+		{
+			name:           "shows one attached connector as yaml",
+			connectorName:  "backend",
+			output:         "yaml",
+			skupperObjects: attachedConnectorPair("site"),
+		},
+		{
+			name:                "list fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorAttachStatusWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = test.connectorName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestAttachedConnectorStatus(t *testing.T) {
+	ready := v2alpha1.Status{StatusType: v2alpha1.StatusReady}
+	pending := v2alpha1.Status{StatusType: v2alpha1.StatusPending, Message: "No matching listener"}
+
+	type test struct {
+		name                        string
+		status                      AttachedConnectorStatus
+		expectedStatus              string
+		expectedHasMatchingListener bool
+		expectedMessage             string
+	}
+
+	testTable := []test{
+		{
+			name: "unbound",
+			status: AttachedConnectorStatus{
+				AttachedConnector: &v2alpha1.AttachedConnector{},
+			},
+			expectedStatus: "Unbound",
+		},
+		{
+			name: "bound and waiting for a listener",
+			status: AttachedConnectorStatus{
+				AttachedConnector: &v2alpha1.AttachedConnector{
+					Status: v2alpha1.AttachedConnectorStatus{Status: ready},
+				},
+				Binding: &v2alpha1.AttachedConnectorBinding{
+					Status: v2alpha1.AttachedConnectorBindingStatus{Status: pending},
+				},
+			},
+			expectedStatus:  "Pending",
+			expectedMessage: "No matching listener",
+		},
+		{
+			name: "bound with a matching listener",
+			status: AttachedConnectorStatus{
+				AttachedConnector: &v2alpha1.AttachedConnector{
+					Status: v2alpha1.AttachedConnectorStatus{Status: ready},
+				},
+				Binding: &v2alpha1.AttachedConnectorBinding{
+					Status: v2alpha1.AttachedConnectorBindingStatus{Status: ready, HasMatchingListener: true},
+				},
+			},
+			expectedStatus:              "Ready",
+			expectedHasMatchingListener: true,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.status.statusType(), test.expectedStatus)
+			assert.Equal(t, test.status.hasMatchingListener(), test.expectedHasMatchingListener)
+			assert.Equal(t, test.status.message(), test.expectedMessage)
+		})
+	}
+}
+
+func TestFormatPods(t *testing.T) {
+	pods := []v2alpha1.PodDetails{{Name: "backend-1", IP: "10.0.0.1"}, {Name: "backend-2", IP: "10.0.0.2"}}
+	assert.Equal(t, formatPods(pods), "backend-1(10.0.0.1), backend-2(10.0.0.2)")
+	assert.Equal(t, formatPods(nil), "")
+}
+
+// --- helper methods
+
+func newCmdConnectorAttachStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorAttachStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorAttachStatus := &CmdConnectorAttachStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorAttachStatus, nil
+}
@@ -0,0 +1,365 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorAttach_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorAttachFlags
+		k8sObjects     []runtime.Object
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "name and port are not specified",
+			args:           []string{},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			expectedErrors: []string{"connector name and port must be configured"},
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"backend", "abc"},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			expectedErrors: []string{"connector port is not valid: strconv.Atoi: parsing \"abc\": invalid syntax"},
+		},
+		{
+			name:           "site namespace is not specified",
+			args:           []string{"backend", "8080"},
+			flags:          common.CommandConnectorAttachFlags{Timeout: time.Minute},
+			expectedErrors: []string{"site namespace must be configured"},
+		},
+		{
+			name:           "site namespace is the connector namespace",
+			args:           []string{"backend", "8080"},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "test", Timeout: time.Minute},
+			expectedErrors: []string{"site namespace must be different from the connector namespace test; use 'skupper connector create' instead"},
+		},
+		{
+			name:  "attached connector already exists",
+			args:  []string{"backend", "8080"},
+			flags: common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+				},
+			},
+			expectedErrors: []string{"there is already an attached connector backend created for namespace test"},
+		},
+		{
+			name:  "binding in site namespace refers to another connector namespace",
+			args:  []string{"backend", "8080"},
+			flags: common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "site"},
+					Spec:       v2alpha1.AttachedConnectorBindingSpec{ConnectorNamespace: "other"},
+				},
+			},
+			expectedErrors: []string{"attached connector binding backend in namespace site is bound to connector namespace other"},
+		},
+		{
+			name:  "binding in site namespace already exists",
+			args:  []string{"backend", "8080"},
+			flags: common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "site"},
+					Spec:       v2alpha1.AttachedConnectorBindingSpec{ConnectorNamespace: "test"},
+				},
+			},
+			expectedErrors: []string{"there is already an attached connector binding backend created for namespace site"},
+		},
+		{
+			name:           "use client cert without tls credentials",
+			args:           []string{"backend", "8080"},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "site", UseClientCert: true, Timeout: time.Minute},
+			expectedErrors: []string{"use-client-cert requires tls-credentials to be configured"},
+		},
+		{
+			name:           "tls credentials do not exist",
+			args:           []string{"backend", "8080"},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "site", TlsCredentials: "secret", Timeout: time.Minute},
+			expectedErrors: []string{"tls-secret is not valid: does not exist"},
+		},
+		{
+			name:  "selector, type, output and wait are not valid",
+			args:  []string{"backend", "8080"},
+			flags: common.CommandConnectorAttachFlags{SiteNamespace: "site", Selector: "@#$%", ConnectorType: "sctp", Output: "xml", Wait: "created", Timeout: time.Minute},
+			expectedErrors: []string{
+				"connector type is not valid: value sctp not allowed. It should be one of this options: [tcp udp]",
+				"selector is not valid: value does not match this regular expression: ^[A-Za-z0-9=:./-]+$",
+				"output type is not valid: value xml not allowed. It should be one of this options: [json yaml]",
+				"status is not valid: value created not allowed. It should be one of this options: [ready configured none]",
+			},
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"backend", "8080"},
+			flags:          common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: 0},
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"backend", "8080"},
+			flags: common.CommandConnectorAttachFlags{
+				SiteNamespace:  "site",
+				RoutingKey:     "backend-key",
+				Selector:       "app=backend",
+				TlsCredentials: "secret",
+				UseClientCert:  true,
+				ConnectorType:  "tcp",
+				Timeout:        time.Minute,
+				Output:         "yaml",
+				Wait:           "ready",
+			},
+			k8sObjects: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: v1.ObjectMeta{Name: "secret", Namespace: "test"},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorAttachWithMocks("test", test.k8sObjects, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdConnectorAttach_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name               string
+		flags              common.CommandConnectorAttachFlags
+		expectedSelector   string
+		expectedRoutingKey string
+	}
+
+	testTable := []test{
+		{
+			name:               "selector and routing key default to the connector name",
+			flags:              common.CommandConnectorAttachFlags{},
+			expectedSelector:   "app=backend",
+			expectedRoutingKey: "backend",
+		},
+		{
+			name:               "selector and routing key are supplied",
+			flags:              common.CommandConnectorAttachFlags{Selector: "tier=api", RoutingKey: "api"},
+			expectedSelector:   "tier=api",
+			expectedRoutingKey: "api",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd, err := newCmdConnectorAttachWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.Flags = &test.flags
+			cmd.name = "backend"
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.selector == test.expectedSelector)
+			assert.Check(t, cmd.routingKey == test.expectedRoutingKey)
+		})
+	}
+}
+
+func TestCmdConnectorAttach_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:                "creation fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorAttachWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "backend"
+		cmd.port = 8080
+		cmd.siteNamespace = "site"
+		cmd.selector = "app=backend"
+		cmd.routingKey = "backend"
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+				return
+			}
+			assert.Check(t, err == nil)
+			if test.output == "" {
+				binding, err := cmd.client.AttachedConnectorBindings("site").Get(context.TODO(), "backend", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, binding.Spec.ConnectorNamespace, "test")
+				assert.Equal(t, binding.Spec.RoutingKey, "backend")
+				attachedConnector, err := cmd.client.AttachedConnectors("test").Get(context.TODO(), "backend", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, attachedConnector.Spec.SiteNamespace, "site")
+				assert.Equal(t, attachedConnector.Spec.Port, 8080)
+			}
+		})
+	}
+}
+
+func TestCmdConnectorAttach_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		output         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "attached connector is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+					Status: v2alpha1.AttachedConnectorStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{{Type: "Configured", Status: "True"}},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:   "attached connector is not configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:   "binding is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "site"},
+					Status: v2alpha1.AttachedConnectorBindingStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{{Type: "Ready", Status: "True"}},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:   "binding has no matching listener",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "site"},
+					Status: v2alpha1.AttachedConnectorBindingStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{{Type: "Ready", Status: "False", Message: "No matching listener"}},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "output yaml is not waited for",
+			output:      "yaml",
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorAttachWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "backend"
+		cmd.siteNamespace = "site"
+		cmd.output = test.output
+		cmd.status = test.status
+		cmd.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorAttachWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorAttach, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorAttach := &CmdConnectorAttach{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdConnectorAttach, nil
+}
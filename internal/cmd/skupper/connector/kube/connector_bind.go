@@ -0,0 +1,149 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CmdConnectorBind creates the site side of an attached connector, for
+// when the AttachedConnector is managed by the owner of the workload namespace.
+type CmdConnectorBind struct {
+	client             skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandConnectorBindFlags
+	namespace          string
+	name               string
+	connectorNamespace string
+	routingKey         string
+	exposePodsByName   bool
+	output             string
+}
+
+func NewCmdConnectorBind() *CmdConnectorBind {
+
+	return &CmdConnectorBind{}
+}
+
+func (cmd *CmdConnectorBind) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorBind) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	if cmd.Flags == nil || cmd.Flags.ConnectorNamespace == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector namespace must be configured"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.ConnectorNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector namespace is not valid: %s", err))
+		} else if cmd.Flags.ConnectorNamespace == cmd.namespace {
+			validationErrors = append(validationErrors, fmt.Errorf("connector namespace must be different from the site namespace %s; use 'skupper connector create' instead", cmd.namespace))
+		} else {
+			cmd.connectorNamespace = cmd.Flags.ConnectorNamespace
+		}
+	}
+
+	if cmd.name != "" {
+		binding, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if binding != nil && !errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already an attached connector binding %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+		// The attached connector may not have been created yet, but if it
+		// has been it must point back at this namespace
+		if cmd.connectorNamespace != "" {
+			attachedConnector, err := cmd.client.AttachedConnectors(cmd.connectorNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err == nil && attachedConnector != nil && attachedConnector.Spec.SiteNamespace != cmd.namespace {
+				validationErrors = append(validationErrors, fmt.Errorf("attached connector %s in namespace %s refers to site namespace %s", cmd.name, cmd.connectorNamespace, attachedConnector.Spec.SiteNamespace))
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.RoutingKey != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.RoutingKey)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routing key is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdConnectorBind) InputToOptions() {
+	// default routingkey to name of connector
+	if cmd.Flags.RoutingKey == "" {
+		cmd.routingKey = cmd.name
+	} else {
+		cmd.routingKey = cmd.Flags.RoutingKey
+	}
+	cmd.exposePodsByName = cmd.Flags.ExposePodsByName
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdConnectorBind) Run() error {
+
+	resource := v2alpha1.AttachedConnectorBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnectorBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorBindingSpec{
+			ConnectorNamespace: cmd.connectorNamespace,
+			RoutingKey:         cmd.routingKey,
+			ExposePodsByName:   cmd.exposePodsByName,
+		},
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, resource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		_, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+		return err
+	}
+}
+
+func (cmd *CmdConnectorBind) WaitUntil() error { return nil }
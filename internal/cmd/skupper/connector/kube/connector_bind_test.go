@@ -0,0 +1,165 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorBind_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorBindFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "name is not specified",
+			args:           []string{},
+			flags:          common.CommandConnectorBindFlags{ConnectorNamespace: "app"},
+			expectedErrors: []string{"connector name must be configured"},
+		},
+		{
+			name:           "connector namespace is not specified",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorBindFlags{},
+			expectedErrors: []string{"connector namespace must be configured"},
+		},
+		{
+			name:           "connector namespace is the site namespace",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorBindFlags{ConnectorNamespace: "test"},
+			expectedErrors: []string{"connector namespace must be different from the site namespace test; use 'skupper connector create' instead"},
+		},
+		{
+			name:  "binding already exists",
+			args:  []string{"backend"},
+			flags: common.CommandConnectorBindFlags{ConnectorNamespace: "app"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+				},
+			},
+			expectedErrors: []string{"there is already an attached connector binding backend created for namespace test"},
+		},
+		{
+			name:  "attached connector refers to another site namespace",
+			args:  []string{"backend"},
+			flags: common.CommandConnectorBindFlags{ConnectorNamespace: "app"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "app"},
+					Spec:       v2alpha1.AttachedConnectorSpec{SiteNamespace: "other"},
+				},
+			},
+			expectedErrors: []string{"attached connector backend in namespace app refers to site namespace other"},
+		},
+		{
+			name:           "routing key and output are not valid",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorBindFlags{ConnectorNamespace: "app", RoutingKey: "not_valid", Output: "xml"},
+			expectedErrors: []string{"routing key is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$", "output type is not valid: value xml not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"backend"},
+			flags: common.CommandConnectorBindFlags{ConnectorNamespace: "app", RoutingKey: "backend", ExposePodsByName: true, Output: "json"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "app"},
+					Spec:       v2alpha1.AttachedConnectorSpec{SiteNamespace: "test"},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorBindWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdConnectorBind_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output json",
+			output: "json",
+		},
+		{
+			name:                "creation fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorBindWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.Flags = &common.CommandConnectorBindFlags{ConnectorNamespace: "app", Output: test.output}
+		cmd.name = "backend"
+		cmd.connectorNamespace = "app"
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+				return
+			}
+			assert.Check(t, err == nil)
+			if test.output == "" {
+				binding, err := cmd.client.AttachedConnectorBindings("test").Get(context.TODO(), "backend", v1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Equal(t, binding.Spec.ConnectorNamespace, "app")
+				assert.Equal(t, binding.Spec.RoutingKey, "backend")
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorBindWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorBind, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorBind := &CmdConnectorBind{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorBind, nil
+}
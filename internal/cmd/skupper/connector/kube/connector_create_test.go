@@ -149,7 +149,7 @@ func TestCmdConnectorCreate_ValidateInput(t *testing.T) {
 				Selector:      "backend",
 			},
 			expectedErrors: []string{
-				"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+				"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name: "routing key is not valid",
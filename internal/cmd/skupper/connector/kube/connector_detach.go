@@ -0,0 +1,120 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdConnectorDetach struct {
+	client        skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd      *cobra.Command
+	Flags         *common.CommandConnectorDetachFlags
+	namespace     string
+	name          string
+	siteNamespace string
+	wait          bool
+}
+
+func NewCmdConnectorDetach() *CmdConnectorDetach {
+
+	return &CmdConnectorDetach{}
+}
+
+func (cmd *CmdConnectorDetach) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorDetach) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	if cmd.name != "" {
+		attachedConnector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || attachedConnector == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		} else {
+			cmd.siteNamespace = attachedConnector.Spec.SiteNamespace
+			if cmd.Flags != nil && cmd.Flags.SiteNamespace != "" && cmd.Flags.SiteNamespace != cmd.siteNamespace {
+				validationErrors = append(validationErrors, fmt.Errorf("attached connector %s in namespace %s refers to site namespace %s, not %s", cmd.name, cmd.namespace, cmd.siteNamespace, cmd.Flags.SiteNamespace))
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdConnectorDetach) InputToOptions() {
+	cmd.wait = cmd.Flags.Wait
+}
+
+func (cmd *CmdConnectorDetach) Run() error {
+	// the binding may be owned by someone else and already removed
+	err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Delete(context.TODO(), cmd.name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return cmd.client.AttachedConnectors(cmd.namespace).Delete(context.TODO(), cmd.name, metav1.DeleteOptions{})
+}
+
+func (cmd *CmdConnectorDetach) WaitUntil() error {
+
+	if cmd.wait {
+		waitTime := int(cmd.Flags.Timeout.Seconds())
+		err := utils.NewSpinnerWithTimeout("Waiting for deletion to complete...", waitTime, func() error {
+
+			resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err == nil && resource != nil {
+				return fmt.Errorf("error deleting the resource")
+			}
+			binding, err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err == nil && binding != nil {
+				return fmt.Errorf("error deleting the resource")
+			}
+			return nil
+		})
+
+		if err != nil {
+			return fmt.Errorf("AttachedConnector %q not deleted yet, check the status for more information %s\n", cmd.name, err)
+		}
+
+		fmt.Printf("AttachedConnector %q deleted\n", cmd.name)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func attachedConnectorPair(siteNamespace string) []runtime.Object {
+	return []runtime.Object{
+		&v2alpha1.AttachedConnector{
+			ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+			Spec:       v2alpha1.AttachedConnectorSpec{SiteNamespace: siteNamespace, Port: 8080, Selector: "app=backend"},
+		},
+		&v2alpha1.AttachedConnectorBinding{
+			ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: siteNamespace},
+			Spec:       v2alpha1.AttachedConnectorBindingSpec{ConnectorNamespace: "test", RoutingKey: "backend"},
+		},
+	}
+}
+
+func TestCmdConnectorDetach_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorDetachFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "name is not specified",
+			args:           []string{},
+			flags:          common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			expectedErrors: []string{"connector name must be specified"},
+		},
+		{
+			name:           "attached connector does not exist",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			expectedErrors: []string{"attached connector backend does not exist in namespace test"},
+		},
+		{
+			name:           "site namespace does not match",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorDetachFlags{SiteNamespace: "other", Timeout: time.Minute},
+			skupperObjects: attachedConnectorPair("site"),
+			expectedErrors: []string{"attached connector backend in namespace test refers to site namespace site, not other"},
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorDetachFlags{Timeout: 0},
+			skupperObjects: attachedConnectorPair("site"),
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"backend"},
+			flags:          common.CommandConnectorDetachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: attachedConnectorPair("site"),
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorDetachWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdConnectorDetach_Run(t *testing.T) {
+	type test struct {
+		name           string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: attachedConnectorPair("site"),
+		},
+		{
+			name: "binding was already removed",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{Name: "backend", Namespace: "test"},
+					Spec:       v2alpha1.AttachedConnectorSpec{SiteNamespace: "site"},
+				},
+			},
+		},
+		{
+			name:         "attached connector does not exist",
+			errorMessage: "attachedconnectors.skupper.io \"backend\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorDetachWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "backend"
+		cmd.siteNamespace = "site"
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+				return
+			}
+			assert.Check(t, err == nil)
+			_, err = cmd.client.AttachedConnectorBindings("site").Get(context.TODO(), "backend", v1.GetOptions{})
+			assert.Check(t, err != nil)
+		})
+	}
+}
+
+func TestCmdConnectorDetach_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "pair is deleted",
+			wait:        true,
+			expectError: false,
+		},
+		{
+			name:           "pair is not deleted",
+			wait:           true,
+			skupperObjects: attachedConnectorPair("site"),
+			expectError:    true,
+		},
+		{
+			name:           "user does not wait",
+			wait:           false,
+			skupperObjects: attachedConnectorPair("site"),
+			expectError:    false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorDetachWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.Flags = &common.CommandConnectorDetachFlags{Timeout: time.Second, Wait: test.wait}
+		cmd.name = "backend"
+		cmd.siteNamespace = "site"
+		cmd.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorDetachWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorDetach, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorDetach := &CmdConnectorDetach{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorDetach, nil
+}
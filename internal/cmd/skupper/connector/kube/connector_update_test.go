@@ -83,7 +83,7 @@ func TestCmdConnectorUpdate_ValidateInput(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+				"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name: "routing key is not valid",
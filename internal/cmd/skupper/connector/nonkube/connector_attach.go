@@ -0,0 +1,81 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+// Attached connectors select pods in a namespace other than the site's, which
+// only exists on Kubernetes; these commands reject any input on other platforms.
+
+var errAttachedConnectorsNotSupported = fmt.Errorf("attached connectors are only supported on the kubernetes platform")
+
+type CmdConnectorAttach struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorAttachFlags
+}
+
+func NewCmdConnectorAttach() *CmdConnectorAttach {
+	return &CmdConnectorAttach{}
+}
+
+func (cmd *CmdConnectorAttach) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorAttach) ValidateInput(args []string) []error {
+	return []error{errAttachedConnectorsNotSupported}
+}
+func (cmd *CmdConnectorAttach) InputToOptions()  {}
+func (cmd *CmdConnectorAttach) Run() error       { return nil }
+func (cmd *CmdConnectorAttach) WaitUntil() error { return nil }
+
+type CmdConnectorBind struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorBindFlags
+}
+
+func NewCmdConnectorBind() *CmdConnectorBind {
+	return &CmdConnectorBind{}
+}
+
+func (cmd *CmdConnectorBind) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorBind) ValidateInput(args []string) []error {
+	return []error{errAttachedConnectorsNotSupported}
+}
+func (cmd *CmdConnectorBind) InputToOptions()  {}
+func (cmd *CmdConnectorBind) Run() error       { return nil }
+func (cmd *CmdConnectorBind) WaitUntil() error { return nil }
+
+type CmdConnectorDetach struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorDetachFlags
+}
+
+func NewCmdConnectorDetach() *CmdConnectorDetach {
+	return &CmdConnectorDetach{}
+}
+
+func (cmd *CmdConnectorDetach) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorDetach) ValidateInput(args []string) []error {
+	return []error{errAttachedConnectorsNotSupported}
+}
+func (cmd *CmdConnectorDetach) InputToOptions()  {}
+func (cmd *CmdConnectorDetach) Run() error       { return nil }
+func (cmd *CmdConnectorDetach) WaitUntil() error { return nil }
+
+type CmdConnectorAttachStatus struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorAttachStatusFlags
+}
+
+func NewCmdConnectorAttachStatus() *CmdConnectorAttachStatus {
+	return &CmdConnectorAttachStatus{}
+}
+
+func (cmd *CmdConnectorAttachStatus) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorAttachStatus) ValidateInput(args []string) []error {
+	return []error{errAttachedConnectorsNotSupported}
+}
+func (cmd *CmdConnectorAttachStatus) InputToOptions()  {}
+func (cmd *CmdConnectorAttachStatus) Run() error       { return nil }
+func (cmd *CmdConnectorAttachStatus) WaitUntil() error { return nil }
@@ -0,0 +1,26 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdConnectorAttach_NotSupported(t *testing.T) {
+	expectedErrors := []string{"attached connectors are only supported on the kubernetes platform"}
+
+	commands := map[string]common.SkupperCommand{
+		"attach":        NewCmdConnectorAttach(),
+		"bind":          NewCmdConnectorBind(),
+		"detach":        NewCmdConnectorDetach(),
+		"attach-status": NewCmdConnectorAttachStatus(),
+	}
+
+	for name, command := range commands {
+		t.Run(name, func(t *testing.T) {
+			assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"backend"})), expectedErrors)
+		})
+	}
+}
@@ -70,7 +70,7 @@ func TestNonKubeCmdConnectorCreate_ValidateInput(t *testing.T) {
 			name:           "type is not valid",
 			args:           []string{"my-connector", "8080"},
 			flags:          &common.CommandConnectorCreateFlags{ConnectorType: "not-valid", Host: "1.2.3.4"},
-			expectedErrors: []string{"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+			expectedErrors: []string{"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name:           "routing key is not valid",
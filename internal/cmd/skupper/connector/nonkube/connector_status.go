@@ -8,8 +8,12 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type CmdConnectorStatus struct {
@@ -19,6 +23,7 @@ type CmdConnectorStatus struct {
 	namespace        string
 	connectorName    string
 	output           string
+	routerStatus     func(namespace string) *router.Status
 }
 
 func NewCmdConnectorStatus() *CmdConnectorStatus {
@@ -31,6 +36,7 @@ func (cmd *CmdConnectorStatus) NewClient(cobraCommand *cobra.Command, args []str
 	}
 
 	cmd.connectorHandler = fs.NewConnectorHandler(cmd.namespace)
+	cmd.routerStatus = router.GetStatus
 }
 
 func (cmd *CmdConnectorStatus) ValidateInput(args []string) []error {
@@ -82,6 +88,12 @@ func (cmd *CmdConnectorStatus) Run() error {
 			fmt.Println("No connectors found:")
 			return err
 		}
+		if cmd.routerStatus != nil {
+			status := cmd.routerStatus(cmd.namespace)
+			for _, connector := range connectors {
+				status.UpdateConnector(connector)
+			}
+		}
 		if cmd.output != "" {
 			for _, connector := range connectors {
 				encodedOutput, err := utils.Encode(cmd.output, connector)
@@ -92,15 +104,15 @@ func (cmd *CmdConnectorStatus) Run() error {
 			}
 		} else {
 			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
-			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
-				"NAME", "STATUS", "ROUTING-KEY", "HOST", "PORT"))
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "MATCHED", "ROUTING-KEY", "HOST", "PORT"))
 			for _, connector := range connectors {
 				status := "Not Ready"
 				if connector.IsConfigured() {
 					status = "Ok"
 				}
-				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%d",
-					connector.Name, status, connector.Spec.RoutingKey, connector.Spec.Host, connector.Spec.Port))
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%d",
+					connector.Name, status, matched(connector.Status.Conditions), connector.Spec.RoutingKey, connector.Spec.Host, connector.Spec.Port))
 			}
 			_ = tw.Flush()
 		}
@@ -110,6 +122,9 @@ func (cmd *CmdConnectorStatus) Run() error {
 			fmt.Println("No connectors found:")
 			return err
 		}
+		if cmd.routerStatus != nil {
+			cmd.routerStatus(cmd.namespace).UpdateConnector(connector)
+		}
 		if cmd.output != "" {
 			encodedOutput, err := utils.Encode(cmd.output, connector)
 			if err != nil {
@@ -122,8 +137,8 @@ func (cmd *CmdConnectorStatus) Run() error {
 				status = "Ok"
 			}
 			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
-			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nRouting key:\t%s\nHost:\t%s\nPort:\t%d\nTlsCredentials:\t%s",
-				connector.Name, status, connector.Spec.RoutingKey, connector.Spec.Host, connector.Spec.Port, connector.Spec.TlsCredentials))
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nMatched:\t%s\nRouting key:\t%s\nHost:\t%s\nPort:\t%d\nTlsCredentials:\t%s",
+				connector.Name, status, matched(connector.Status.Conditions), connector.Spec.RoutingKey, connector.Spec.Host, connector.Spec.Port, connector.Spec.TlsCredentials))
 			_ = tw.Flush()
 		}
 	}
@@ -132,3 +147,16 @@ func (cmd *CmdConnectorStatus) Run() error {
 
 func (cmd *CmdConnectorStatus) InputToOptions()  {}
 func (cmd *CmdConnectorStatus) WaitUntil() error { return nil }
+
+// matched describes the Matched condition, which is only known once
+// the router of the site has been queried.
+func matched(conditions []metav1.Condition) string {
+	condition := meta.FindStatusCondition(conditions, v2alpha1.CONDITION_TYPE_MATCHED)
+	if condition == nil {
+		return "Unknown"
+	}
+	if condition.Status == metav1.ConditionTrue {
+		return "Yes"
+	}
+	return "No"
+}
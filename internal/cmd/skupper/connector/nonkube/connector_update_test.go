@@ -65,7 +65,7 @@ func TestCmdConnectorUpdate_ValidateInput(t *testing.T) {
 			name:           "connector type is not valid",
 			args:           []string{"my-connector"},
 			flags:          &common.CommandConnectorUpdateFlags{ConnectorType: "not-valid"},
-			expectedErrors: []string{"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+			expectedErrors: []string{"connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name:           "routing key is not valid",
@@ -12,26 +12,35 @@ import (
 
 func NewCmdDebug() *cobra.Command {
 
-	cmd := CmdDebugFactory(config.GetPlatform())
+	cmd := &cobra.Command{
+		Use:     "debug",
+		Short:   "Debug skupper installation",
+		Long:    "Collect details about the skupper installation in the current namespace for troubleshooting",
+		Example: "skupper debug dump my-dump",
+	}
+
+	cmd.AddCommand(CmdDebugDumpFactory(config.GetPlatform()))
 
 	return cmd
 }
 
-func CmdDebugFactory(configuredPlatform types.Platform) *cobra.Command {
-	kubeCommand := kube.NewCmdDebug()
-	nonKubeCommand := nonkube.NewCmdDebug()
-
-	cmdDebugDesc := common.SkupperCmdDescription{
-		Use:   "debug",
-		Short: "",
-		Long:  "",
+func CmdDebugDumpFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdDebugDump()
+	nonKubeCommand := nonkube.NewCmdDebugDump()
+
+	cmdDebugDumpDesc := common.SkupperCmdDescription{
+		Use:   "dump [filename]",
+		Short: "Collect and save skupper logs, config, and resources",
+		Long: `Collect the skupper resources and their status, the router configuration, logs and
+router management queries into a compressed archive (secrets are redacted), so it
+can be attached to a support request. The archive is named skupper-dump.tar.gz
+unless a file name is given.`,
+		Example: "skupper debug dump my-dump",
 	}
 
-	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdDebugDesc, kubeCommand, nonKubeCommand)
-
-	cmdFlags := common.CommandDebugFlags{}
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdDebugDumpDesc, kubeCommand, nonKubeCommand)
 
-	//Add flags if necessary
+	cmdFlags := common.CommandDebugDumpFlags{}
 
 	kubeCommand.CobraCmd = cmd
 	kubeCommand.Flags = &cmdFlags
@@ -0,0 +1,290 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	internalutils "github.com/skupperproject/skupper/internal/utils"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/images"
+	"github.com/skupperproject/skupper/pkg/qdr"
+	"github.com/skupperproject/skupper/pkg/utils/configs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+var (
+	skupperPodSelector = "application in (skupper-router, skupper-controller)"
+	routerPodSelector  = "application=skupper-router"
+)
+
+type CmdDebugDump struct {
+	Client     skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient kubernetes.Interface
+	Rest       *restclient.Config
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandDebugDumpFlags
+	Namespace  string
+	fileName   string
+}
+
+func NewCmdDebugDump() *CmdDebugDump {
+
+	skupperCmd := CmdDebugDump{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdDebugDump) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.Client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.Rest = cli.Rest
+	cmd.Namespace = cli.Namespace
+}
+
+func (cmd *CmdDebugDump) ValidateInput(args []string) []error {
+	var validationErrors []error
+	fileStringValidator := validator.NewFilePathStringValidator()
+
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("file name must not be empty"))
+		} else {
+			ok, err := fileStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("filename is not valid: %s", err))
+			} else {
+				cmd.fileName = args[0]
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdDebugDump) InputToOptions() {
+	if cmd.fileName == "" {
+		cmd.fileName = "skupper-dump"
+	}
+	if !strings.HasSuffix(cmd.fileName, ".tar.gz") {
+		cmd.fileName = cmd.fileName + ".tar.gz"
+	}
+}
+
+func (cmd *CmdDebugDump) Run() error {
+	tb := internalutils.NewTarball()
+	ctx := context.TODO()
+
+	if err := addEncoded(tb, "versions/manifest.yaml", cmd.getManifest(ctx)); err != nil {
+		return err
+	}
+
+	if err := cmd.addSkupperResources(ctx, tb); err != nil {
+		return err
+	}
+
+	configMaps, err := cmd.KubeClient.CoreV1().ConfigMaps(cmd.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing configmaps: %w", err)
+	}
+	for _, cm := range configMaps.Items {
+		if strings.HasPrefix(cm.Name, "skupper") {
+			if err := addEncoded(tb, path.Join("configmaps", cm.Name+".yaml"), cm); err != nil {
+				return err
+			}
+		}
+	}
+
+	secrets, err := cmd.KubeClient.CoreV1().Secrets(cmd.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		if err := addEncoded(tb, path.Join("secrets", secret.Name+".yaml"), redactSecret(secret)); err != nil {
+			return err
+		}
+	}
+
+	events, err := cmd.KubeClient.CoreV1().Events(cmd.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing events: %w", err)
+	}
+	if err := addEncoded(tb, "events.yaml", events); err != nil {
+		return err
+	}
+
+	if err := cmd.addPods(ctx, tb); err != nil {
+		return err
+	}
+
+	if err := tb.Save(cmd.fileName); err != nil {
+		return fmt.Errorf("unable to save skupper dump details: %w", err)
+	}
+	fmt.Println("Skupper dump details written to compressed archive: ", cmd.fileName)
+	return nil
+}
+
+// getManifest reports the images of the running skupper pods, falling back
+// to the configured defaults for any component that is not running
+func (cmd *CmdDebugDump) getManifest(ctx context.Context) configs.SkupperManifest {
+	runningPods := map[string]string{}
+	pods, err := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "app.kubernetes.io/part-of in (skupper, skupper-network-observer)"})
+	if err == nil {
+		for _, pod := range pods.Items {
+			for _, container := range pod.Status.ContainerStatuses {
+				runningPods[container.Name] = container.Image
+			}
+		}
+	}
+	manifest := configs.ManifestManager{Components: images.KubeComponents, EnableSHA: false, RunningPods: runningPods}
+	return manifest.GetConfiguredManifest()
+}
+
+func (cmd *CmdDebugDump) addSkupperResources(ctx context.Context, tb *internalutils.Tarball) error {
+	opts := metav1.ListOptions{}
+	resources := map[string]func() (interface{}, error){
+		"sites":      func() (interface{}, error) { return cmd.Client.Sites(cmd.Namespace).List(ctx, opts) },
+		"listeners":  func() (interface{}, error) { return cmd.Client.Listeners(cmd.Namespace).List(ctx, opts) },
+		"connectors": func() (interface{}, error) { return cmd.Client.Connectors(cmd.Namespace).List(ctx, opts) },
+		"links":      func() (interface{}, error) { return cmd.Client.Links(cmd.Namespace).List(ctx, opts) },
+		"accessgrants": func() (interface{}, error) {
+			grants, err := cmd.Client.AccessGrants(cmd.Namespace).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			for i := range grants.Items {
+				grants.Items[i].Status.Code = redactCode(grants.Items[i].Status.Code)
+			}
+			return grants, nil
+		},
+		"accesstokens": func() (interface{}, error) {
+			tokens, err := cmd.Client.AccessTokens(cmd.Namespace).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			for i := range tokens.Items {
+				tokens.Items[i].Spec.Code = redactCode(tokens.Items[i].Spec.Code)
+			}
+			return tokens, nil
+		},
+		"routeraccesses":     func() (interface{}, error) { return cmd.Client.RouterAccesses(cmd.Namespace).List(ctx, opts) },
+		"securedaccesses":    func() (interface{}, error) { return cmd.Client.SecuredAccesses(cmd.Namespace).List(ctx, opts) },
+		"certificates":       func() (interface{}, error) { return cmd.Client.Certificates(cmd.Namespace).List(ctx, opts) },
+		"attachedconnectors": func() (interface{}, error) { return cmd.Client.AttachedConnectors(cmd.Namespace).List(ctx, opts) },
+		"attachedconnectorbindings": func() (interface{}, error) {
+			return cmd.Client.AttachedConnectorBindings(cmd.Namespace).List(ctx, opts)
+		},
+	}
+	for name, list := range resources {
+		result, err := list()
+		if err != nil {
+			return fmt.Errorf("error listing %s: %w", name, err)
+		}
+		if err := addEncoded(tb, path.Join("resources", name+".yaml"), result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdDebugDump) addPods(ctx context.Context, tb *internalutils.Tarball) error {
+	pods, err := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).List(ctx, metav1.ListOptions{LabelSelector: skupperPodSelector})
+	if err != nil {
+		return fmt.Errorf("error listing pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		podPath := path.Join("pods", pod.Name)
+		if err := addEncoded(tb, path.Join(podPath, "pod.yaml"), pod); err != nil {
+			return err
+		}
+		for _, container := range pod.Spec.Containers {
+			logs, err := cmd.getLogs(ctx, pod.Name, container.Name)
+			if err != nil {
+				logs = []byte(err.Error())
+			}
+			if err := tb.AddFileData(path.Join(podPath, container.Name+".log"), 0644, time.Now(), logs); err != nil {
+				return err
+			}
+		}
+	}
+
+	routers, err := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).List(ctx, metav1.ListOptions{LabelSelector: routerPodSelector})
+	if err != nil {
+		return fmt.Errorf("error listing router pods: %w", err)
+	}
+	for _, pod := range routers.Items {
+		for _, entity := range common.SkmanageEntities {
+			var data []byte
+			out, err := client.ExecCommandInContainer(qdr.SkmanageQueryCommand(entity, "", false, ""), pod.Name, "router", cmd.Namespace, cmd.KubeClient, cmd.Rest)
+			if err != nil {
+				data = []byte(err.Error())
+			} else {
+				data = out.Bytes()
+			}
+			if err := tb.AddFileData(path.Join("pods", pod.Name, "skmanage", entity+".json"), 0644, time.Now(), data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdDebugDump) getLogs(ctx context.Context, podName string, containerName string) ([]byte, error) {
+	request := cmd.KubeClient.CoreV1().Pods(cmd.Namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerName})
+	stream, err := request.Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving logs for %s/%s: %w", podName, containerName, err)
+	}
+	defer stream.Close()
+	return io.ReadAll(stream)
+}
+
+func (cmd *CmdDebugDump) WaitUntil() error { return nil }
+
+func addEncoded(tb *internalutils.Tarball, fileName string, resource interface{}) error {
+	encoded, err := utils.Encode("yaml", resource)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", fileName, err)
+	}
+	return tb.AddFileData(fileName, 0644, time.Now(), []byte(encoded))
+}
+
+// redactCode hides the redemption code of an AccessGrant or AccessToken
+func redactCode(code string) string {
+	if code == "" {
+		return code
+	}
+	return "<redacted>"
+}
+
+// redactSecret returns a copy of the secret in which every value has been
+// replaced by a placeholder, so that only the keys are included in the dump
+func redactSecret(secret corev1.Secret) corev1.Secret {
+	redacted := *secret.DeepCopy()
+	redacted.StringData = map[string]string{}
+	for key, value := range secret.Data {
+		redacted.StringData[key] = fmt.Sprintf("<redacted: %d bytes>", len(value))
+	}
+	for key, value := range secret.StringData {
+		redacted.StringData[key] = fmt.Sprintf("<redacted: %d bytes>", len(value))
+	}
+	redacted.Data = nil
+	delete(redacted.Annotations, corev1.LastAppliedConfigAnnotation)
+	return redacted
+}
@@ -0,0 +1,242 @@
+package kube
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdDebugDump_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument",
+			args:           []string{"dump1", "dump2"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "empty file name",
+			args:           []string{""},
+			expectedErrors: []string{"file name must not be empty"},
+		},
+		{
+			name:           "invalid file name",
+			args:           []string{"dump!"},
+			expectedErrors: []string{"filename is not valid: value does not match this regular expression: ^[A-Za-z0-9./~-]+$"},
+		},
+		{
+			name:           "no arguments",
+			args:           []string{},
+			expectedErrors: []string{},
+		},
+		{
+			name:           "valid file name",
+			args:           []string{"my-dump"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdDebugDump{Namespace: "test", Flags: &common.CommandDebugDumpFlags{}}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdDebugDump_InputToOptions(t *testing.T) {
+	type test struct {
+		name             string
+		fileName         string
+		expectedFileName string
+	}
+
+	testTable := []test{
+		{
+			name:             "default",
+			expectedFileName: "skupper-dump.tar.gz",
+		},
+		{
+			name:             "without extension",
+			fileName:         "my-dump",
+			expectedFileName: "my-dump.tar.gz",
+		},
+		{
+			name:             "with extension",
+			fileName:         "my-dump.tar.gz",
+			expectedFileName: "my-dump.tar.gz",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdDebugDump{fileName: test.fileName}
+
+			command.InputToOptions()
+
+			assert.Equal(t, command.fileName, test.expectedFileName)
+		})
+	}
+}
+
+func TestCmdDebugDump_Run(t *testing.T) {
+	type test struct {
+		name                string
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedFiles       []string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			k8sObjects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "skupper-router", Namespace: "test"},
+					Data:       map[string]string{"skrouterd.json": "[]"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "skupper-site-server", Namespace: "test"},
+					Data:       map[string][]byte{"tls.key": []byte("very-secret-key")},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "skupper-router-1234",
+						Namespace: "test",
+						Labels:    map[string]string{"application": "skupper-router"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "router"}, {Name: "kube-adaptor"}},
+					},
+				},
+			},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Site{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "test"},
+				},
+				&v2alpha1.AccessGrant{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-grant", Namespace: "test"},
+					Status:     v2alpha1.AccessGrantStatus{Code: "very-secret-code"},
+				},
+				&v2alpha1.AccessToken{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-token", Namespace: "test"},
+					Spec:       v2alpha1.AccessTokenSpec{Code: "very-secret-code"},
+				},
+			},
+			expectedFiles: []string{
+				"versions/manifest.yaml",
+				"resources/sites.yaml",
+				"resources/listeners.yaml",
+				"resources/connectors.yaml",
+				"resources/accessgrants.yaml",
+				"resources/accesstokens.yaml",
+				"configmaps/skupper-router.yaml",
+				"secrets/skupper-site-server.yaml",
+				"events.yaml",
+				"pods/skupper-router-1234/pod.yaml",
+				"pods/skupper-router-1234/router.log",
+				"pods/skupper-router-1234/kube-adaptor.log",
+				"pods/skupper-router-1234/skmanage/router.json",
+			},
+		},
+		{
+			name:                "skupper resources cannot be listed",
+			skupperErrorMessage: "error listing",
+			errorMessage:        "error listing",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			command, err := newCmdDebugDumpWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+			command.fileName = path.Join(tempDir, "dump.tar.gz")
+
+			err = command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+
+			contents := readDump(t, command.fileName)
+			for _, file := range test.expectedFiles {
+				data, ok := contents[file]
+				assert.Assert(t, ok, "expected file %s not found", file)
+				assert.Assert(t, !strings.Contains(data, "very-secret-code"), "%s includes a redemption code", file)
+				if strings.HasPrefix(file, "secrets/") {
+					assert.Assert(t, !strings.Contains(data, "very-secret-key"))
+					assert.Assert(t, strings.Contains(data, "<redacted: 15 bytes>"))
+				}
+			}
+		})
+	}
+}
+
+func TestCmdDebugDump_WaitUntil(t *testing.T) {
+	command := &CmdDebugDump{}
+	assert.Assert(t, command.WaitUntil())
+}
+
+// --- helper methods
+
+func newCmdDebugDumpWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdDebugDump, error) {
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdDebugDump := &CmdDebugDump{
+		Client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		Namespace:  namespace,
+	}
+
+	return cmdDebugDump, nil
+}
+
+func readDump(t *testing.T, fileName string) map[string]string {
+	t.Helper()
+	f, err := os.Open(fileName)
+	assert.Assert(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.Assert(t, err)
+	tr := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return contents
+		}
+		assert.Assert(t, err)
+		data, err := io.ReadAll(tr)
+		assert.Assert(t, err)
+		contents[header.Name] = string(data)
+	}
+}
@@ -0,0 +1,261 @@
+package nonkube
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	internalclient "github.com/skupperproject/skupper/internal/nonkube/client/compat"
+	internalutils "github.com/skupperproject/skupper/internal/utils"
+	"github.com/skupperproject/skupper/pkg/images"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	nonkubecommon "github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/qdr"
+	"github.com/skupperproject/skupper/pkg/utils/configs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	// paths that only hold certificates and private keys are never added to the dump
+	excludedPaths = []api.InternalPath{api.InputCertificatesPath, api.InputIssuersPath, api.CertificatesPath, api.IssuersPath}
+)
+
+type CmdDebugDump struct {
+	CobraCmd       *cobra.Command
+	Flags          *common.CommandDebugDumpFlags
+	namespace      string
+	fileName       string
+	platform       string
+	namespaceHome  string
+	platformLoader *nonkubecommon.NamespacePlatformLoader
+}
+
+func NewCmdDebugDump() *CmdDebugDump {
+
+	skupperCmd := CmdDebugDump{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdDebugDump) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+	cmd.platformLoader = &nonkubecommon.NamespacePlatformLoader{}
+}
+
+func (cmd *CmdDebugDump) ValidateInput(args []string) []error {
+	var validationErrors []error
+	fileStringValidator := validator.NewFilePathStringValidator()
+
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("file name must not be empty"))
+		} else {
+			ok, err := fileStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("filename is not valid: %s", err))
+			} else {
+				cmd.fileName = args[0]
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdDebugDump) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+	if cmd.fileName == "" {
+		cmd.fileName = "skupper-dump"
+	}
+	if !strings.HasSuffix(cmd.fileName, ".tar.gz") {
+		cmd.fileName = cmd.fileName + ".tar.gz"
+	}
+	if cmd.namespaceHome == "" {
+		cmd.namespaceHome = api.GetHostNamespaceHome(cmd.namespace)
+	}
+	if cmd.platformLoader != nil {
+		// the platform is only known once the site has been rendered
+		cmd.platform, _ = cmd.platformLoader.Load(cmd.namespace)
+	}
+}
+
+func (cmd *CmdDebugDump) Run() error {
+	if _, err := os.Stat(cmd.namespaceHome); err != nil {
+		return fmt.Errorf("there is no definition for namespace %s: %w", cmd.namespace, err)
+	}
+	tb := internalutils.NewTarball()
+
+	manifest := configs.ManifestManager{Components: images.NonKubeComponents, EnableSHA: false}
+	encodedManifest, err := utils.Encode("yaml", manifest.GetConfiguredManifest())
+	if err != nil {
+		return err
+	}
+	if err := tb.AddFileData("versions/manifest.yaml", 0644, time.Now(), []byte(encodedManifest)); err != nil {
+		return err
+	}
+
+	if err := cmd.addNamespaceFiles(tb); err != nil {
+		return err
+	}
+
+	switch cmd.platform {
+	case "podman", "docker":
+		cmd.addContainerDetails(tb)
+	case "systemd":
+		cmd.addSystemdDetails(tb)
+	}
+
+	if err := tb.Save(cmd.fileName); err != nil {
+		return fmt.Errorf("unable to save skupper dump details: %w", err)
+	}
+	fmt.Println("Skupper dump details written to compressed archive: ", cmd.fileName)
+	return nil
+}
+
+func (cmd *CmdDebugDump) addNamespaceFiles(tb *internalutils.Tarball) error {
+	excluded := map[string]bool{}
+	for _, internalPath := range excludedPaths {
+		excluded[path.Join(cmd.namespaceHome, string(internalPath))] = true
+	}
+	return filepath.WalkDir(cmd.namespaceHome, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if excluded[filePath] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(filePath); ext == ".yaml" || ext == ".yml" {
+			data, err = redactSecrets(data)
+			if err != nil {
+				return fmt.Errorf("unable to redact %s: %w", filePath, err)
+			}
+		}
+		relPath, _ := filepath.Rel(cmd.namespaceHome, filePath)
+		return tb.AddFileData(path.Join("namespace", relPath), 0644, time.Now(), data)
+	})
+}
+
+func (cmd *CmdDebugDump) addContainerDetails(tb *internalutils.Tarball) {
+	containerName := cmd.namespace + "-skupper-router"
+	addOutput := func(fileName string, output string, err error) {
+		if err != nil {
+			output = err.Error()
+		}
+		_ = tb.AddFileData(path.Join("containers", containerName, fileName), 0644, time.Now(), []byte(output))
+	}
+	cli, err := internalclient.NewCompatClient(os.Getenv("CONTAINER_ENDPOINT"), "")
+	if err != nil {
+		addOutput("error.txt", "", fmt.Errorf("failed to create container client: %w", err))
+		return
+	}
+	if container, err := cli.ContainerInspect(containerName); err != nil {
+		addOutput("container.yaml", "", err)
+	} else {
+		encoded, err := utils.Encode("yaml", container)
+		addOutput("container.yaml", encoded, err)
+	}
+	logs, err := cli.ContainerLogs(containerName)
+	addOutput("router.log", logs, err)
+	for _, entity := range common.SkmanageEntities {
+		out, err := cli.ContainerExec(containerName, qdr.SkmanageQueryCommand(entity, "", false, ""))
+		addOutput(path.Join("skmanage", entity+".json"), out, err)
+	}
+}
+
+func (cmd *CmdDebugDump) addSystemdDetails(tb *internalutils.Tarball) {
+	serviceName := fmt.Sprintf("skupper-%s.service", cmd.namespace)
+	args := []string{"--no-pager", "-u", serviceName}
+	if os.Getuid() != 0 {
+		args = append([]string{"--user"}, args...)
+	}
+	out, err := exec.Command("journalctl", args...).CombinedOutput()
+	if err != nil {
+		out = append(out, []byte(err.Error())...)
+	}
+	_ = tb.AddFileData(path.Join("systemd", serviceName+".log"), 0644, time.Now(), out)
+}
+
+func (cmd *CmdDebugDump) WaitUntil() error { return nil }
+
+// redactedCodes are the fields holding redemption codes in the
+// documents of each kind
+var redactedCodes = map[string][2]string{
+	"AccessGrant": {"status", "code"},
+	"AccessToken": {"spec", "code"},
+}
+
+// redactSecrets replaces the values of any Secret documents, and the
+// redemption codes of AccessGrants and AccessTokens, found in the given
+// (possibly multi-document) yaml content
+func redactSecrets(data []byte) ([]byte, error) {
+	decoder := yamlv3.NewDecoder(bytes.NewReader(data))
+	var out bytes.Buffer
+	encoder := yamlv3.NewEncoder(&out)
+	encoder.SetIndent(2)
+	redacted := false
+	for {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if document == nil {
+			continue
+		}
+		if kind, _ := document["kind"].(string); kind == "Secret" {
+			for _, field := range []string{"data", "stringData"} {
+				if values, ok := document[field].(map[string]interface{}); ok {
+					for key := range values {
+						values[key] = "<redacted>"
+					}
+				}
+			}
+			redacted = true
+		} else if field, ok := redactedCodes[kind]; ok {
+			if values, ok := document[field[0]].(map[string]interface{}); ok && values[field[1]] != nil {
+				values[field[1]] = "<redacted>"
+				redacted = true
+			}
+		}
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+	if !redacted {
+		return data, nil
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
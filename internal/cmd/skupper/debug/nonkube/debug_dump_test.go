@@ -0,0 +1,217 @@
+package nonkube
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+)
+
+const secretYaml = `---
+apiVersion: skupper.io/v2alpha1
+kind: Site
+metadata:
+  name: my-site
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-secret
+data:
+  password: c2VjcmV0LXBhc3N3b3Jk
+stringData:
+  token: plain-secret-token
+`
+
+func TestCmdDebugDump_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument",
+			args:           []string{"dump1", "dump2"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "empty file name",
+			args:           []string{""},
+			expectedErrors: []string{"file name must not be empty"},
+		},
+		{
+			name:           "invalid file name",
+			args:           []string{"dump!"},
+			expectedErrors: []string{"filename is not valid: value does not match this regular expression: ^[A-Za-z0-9./~-]+$"},
+		},
+		{
+			name:           "valid file name",
+			args:           []string{"my-dump"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdDebugDump{Flags: &common.CommandDebugDumpFlags{}}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdDebugDump_InputToOptions(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+
+	type test struct {
+		name              string
+		namespace         string
+		fileName          string
+		expectedNamespace string
+		expectedFileName  string
+	}
+
+	testTable := []test{
+		{
+			name:              "defaults",
+			expectedNamespace: "default",
+			expectedFileName:  "skupper-dump.tar.gz",
+		},
+		{
+			name:              "custom values",
+			namespace:         "east",
+			fileName:          "east-dump",
+			expectedNamespace: "east",
+			expectedFileName:  "east-dump.tar.gz",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdDebugDump{namespace: test.namespace, fileName: test.fileName}
+
+			command.InputToOptions()
+
+			assert.Equal(t, command.namespace, test.expectedNamespace)
+			assert.Equal(t, command.fileName, test.expectedFileName)
+			assert.Equal(t, command.namespaceHome, api.GetHostNamespaceHome(test.expectedNamespace))
+		})
+	}
+}
+
+func TestCmdDebugDump_Run(t *testing.T) {
+	tempDir := t.TempDir()
+	namespaceHome := path.Join(tempDir, "namespaces", "test")
+	files := map[string]string{
+		path.Join(string(api.InputSiteStatePath), "resources.yaml"):   secretYaml,
+		path.Join(string(api.InputCertificatesPath), "ca", "tls.key"): "input-private-key",
+		path.Join(string(api.CertificatesPath), "ca", "tls.key"):      "runtime-private-key",
+		path.Join(string(api.RouterConfigPath), "skrouterd.json"):     "[]",
+	}
+	for name, content := range files {
+		fileName := path.Join(namespaceHome, name)
+		assert.Assert(t, os.MkdirAll(path.Dir(fileName), 0755))
+		assert.Assert(t, os.WriteFile(fileName, []byte(content), 0644))
+	}
+
+	t.Run("namespace does not exist", func(t *testing.T) {
+		command := &CmdDebugDump{
+			namespace:     "missing",
+			namespaceHome: path.Join(tempDir, "namespaces", "missing"),
+			fileName:      path.Join(tempDir, "missing.tar.gz"),
+		}
+		assert.ErrorContains(t, command.Run(), "there is no definition for namespace missing")
+	})
+
+	t.Run("runs ok", func(t *testing.T) {
+		command := &CmdDebugDump{
+			namespace:     "test",
+			namespaceHome: namespaceHome,
+			fileName:      path.Join(tempDir, "dump.tar.gz"),
+		}
+		assert.Assert(t, command.Run())
+
+		contents := readDump(t, command.fileName)
+		_, ok := contents["versions/manifest.yaml"]
+		assert.Assert(t, ok)
+		_, ok = contents[path.Join("namespace", string(api.RouterConfigPath), "skrouterd.json")]
+		assert.Assert(t, ok)
+		for name := range contents {
+			assert.Assert(t, !strings.HasPrefix(name, path.Join("namespace", string(api.InputCertificatesPath))), "%s must not be included", name)
+			assert.Assert(t, !strings.HasPrefix(name, path.Join("namespace", string(api.CertificatesPath))), "%s must not be included", name)
+		}
+
+		resources, ok := contents[path.Join("namespace", string(api.InputSiteStatePath), "resources.yaml")]
+		assert.Assert(t, ok)
+		assert.Assert(t, strings.Contains(resources, "name: my-site"))
+		assert.Assert(t, !strings.Contains(resources, "c2VjcmV0LXBhc3N3b3Jk"))
+		assert.Assert(t, !strings.Contains(resources, "plain-secret-token"))
+		assert.Assert(t, strings.Contains(resources, "password: <redacted>"))
+	})
+}
+
+func TestRedactSecrets(t *testing.T) {
+	notSecret := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm # comment preserved\n")
+	redacted, err := redactSecrets(notSecret)
+	assert.Assert(t, err)
+	assert.DeepEqual(t, redacted, notSecret)
+
+	codes := []byte(`apiVersion: skupper.io/v2alpha1
+kind: AccessGrant
+metadata:
+  name: my-grant
+status:
+  code: grant-code
+---
+apiVersion: skupper.io/v2alpha1
+kind: AccessToken
+metadata:
+  name: my-token
+spec:
+  code: token-code
+  url: https://10.0.0.1:9090/my-grant
+`)
+	redacted, err = redactSecrets(codes)
+	assert.Assert(t, err)
+	assert.Assert(t, !strings.Contains(string(redacted), "grant-code"))
+	assert.Assert(t, !strings.Contains(string(redacted), "token-code"))
+	assert.Assert(t, strings.Contains(string(redacted), "url: https://10.0.0.1:9090/my-grant"))
+
+	_, err = redactSecrets([]byte("kind: [invalid"))
+	assert.Assert(t, err != nil)
+}
+
+func readDump(t *testing.T, fileName string) map[string]string {
+	t.Helper()
+	f, err := os.Open(fileName)
+	assert.Assert(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.Assert(t, err)
+	tr := tar.NewReader(gz)
+	contents := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return contents
+		}
+		assert.Assert(t, err)
+		data, err := io.ReadAll(tr)
+		assert.Assert(t, err)
+		contents[header.Name] = string(data)
+	}
+}
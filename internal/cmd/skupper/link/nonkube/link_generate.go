@@ -5,15 +5,34 @@ package nonkube
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type CmdLinkGenerate struct {
-	CobraCmd  *cobra.Command
-	Namespace string
-	siteName  string
-	Flags     *common.CommandLinkGenerateFlags
+	CobraCmd           *cobra.Command
+	Namespace          string
+	siteName           string
+	Flags              *common.CommandLinkGenerateFlags
+	tokenPath          string
+	tlsCredentials     string
+	cost               int
+	output             string
+	generateCredential bool
+	generatedLink      *v2alpha1.Link
+	generatedSecret    *corev1.Secret
 }
 
 func NewCmdLinkGenerate() *CmdLinkGenerate {
@@ -21,12 +40,168 @@ func NewCmdLinkGenerate() *CmdLinkGenerate {
 }
 
 func (cmd *CmdLinkGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
-	//TODO
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.Namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdLinkGenerate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("arguments are not allowed in this command"))
+	}
+
+	if cmd.Flags.TlsCredentials == "" && !cmd.Flags.GenerateCredential {
+		validationErrors = append(validationErrors, fmt.Errorf("the TLS secret name was not specified"))
+	} else if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("the name of the tls secret is not valid: %s", err))
+		}
+	}
+
+	selectedCost, err := strconv.Atoi(cmd.Flags.Cost)
+	if err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("link cost is not valid: %s", err))
+	}
+	ok, err := numberValidator.Evaluate(selectedCost)
+	if !ok {
+		validationErrors = append(validationErrors, fmt.Errorf("link cost is not valid: %s", err))
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdLinkGenerate) InputToOptions() {
+	if cmd.Namespace == "" {
+		cmd.Namespace = "default"
+	}
+	if cmd.tokenPath == "" {
+		cmd.tokenPath = path.Join(api.GetHostNamespaceHome(cmd.Namespace), string(api.RuntimeTokenPath))
+	}
+
+	cmd.cost, _ = strconv.Atoi(cmd.Flags.Cost)
+	cmd.output = cmd.Flags.Output
+	cmd.generateCredential = cmd.Flags.GenerateCredential
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
 }
 
-func (cmd *CmdLinkGenerate) ValidateInput(args []string) []error { return nil }
-func (cmd *CmdLinkGenerate) InputToOptions()                     {}
 func (cmd *CmdLinkGenerate) Run() error {
-	return fmt.Errorf("command not supported by the selected platform")
+	if cmd.output == "" {
+		return fmt.Errorf("output format has not been specified")
+	}
+
+	token, err := cmd.loadToken()
+	if err != nil {
+		return err
+	}
+
+	link := token.Links[0]
+	link.Namespace = ""
+	link.Spec.Cost = cmd.cost
+	if cmd.tlsCredentials != "" {
+		link.Spec.TlsCredentials = cmd.tlsCredentials
+	}
+	cmd.generatedLink = link
+
+	if cmd.generateCredential {
+		secret := token.Secret
+		secret.Name = link.Spec.TlsCredentials
+		secret.Namespace = ""
+		cmd.generatedSecret = secret
+	}
+
+	return nil
+}
+
+func (cmd *CmdLinkGenerate) WaitUntil() error {
+	var resourcesToPrint []string
+
+	if cmd.generatedSecret != nil {
+		encodedSecret, err := utils.Encode(cmd.output, cmd.generatedSecret)
+		if err != nil {
+			return err
+		}
+		resourcesToPrint = append(resourcesToPrint, encodedSecret)
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, cmd.generatedLink)
+	if err != nil {
+		return err
+	}
+	resourcesToPrint = append(resourcesToPrint, encodedOutput)
+
+	for _, resource := range resourcesToPrint {
+		fmt.Println(resource)
+		if cmd.output == "yaml" {
+			fmt.Println("---")
+		}
+	}
+
+	return nil
+}
+
+// loadToken reads the static links rendered for the site, one per host the
+// router can be reached through, preferring the ones not bound to a local address
+func (cmd *CmdLinkGenerate) loadToken() (*api.Token, error) {
+	entries, err := os.ReadDir(cmd.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("the site in namespace %s has not been initialized yet: %w", cmd.Namespace, err)
+	}
+	var fileNames []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".yaml") {
+			fileNames = append(fileNames, entry.Name())
+		}
+	}
+	if len(fileNames) == 0 {
+		return nil, fmt.Errorf("the site in namespace %s does not accept links", cmd.Namespace)
+	}
+	sort.Strings(fileNames)
+
+	var selected *api.Token
+	for _, fileName := range fileNames {
+		data, err := os.ReadFile(path.Join(cmd.tokenPath, fileName))
+		if err != nil {
+			return nil, err
+		}
+		token, err := api.UnmarshalToken(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid static link %s: %w", fileName, err)
+		}
+		if selected == nil {
+			selected = token
+		}
+		if len(token.Links[0].Spec.Endpoints) > 0 && !isLocalAddress(token.Links[0].Spec.Endpoints[0].Host) {
+			return token, nil
+		}
+	}
+	return selected, nil
+}
+
+func isLocalAddress(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsUnspecified())
 }
-func (cmd *CmdLinkGenerate) WaitUntil() error { return nil }
@@ -1 +1,181 @@
 package nonkube
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdLinkGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandLinkGenerateFlags
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arguments were specified",
+			args:           []string{"something"},
+			flags:          &common.CommandLinkGenerateFlags{Cost: "1", GenerateCredential: true},
+			expectedErrors: []string{"arguments are not allowed in this command"},
+		},
+		{
+			name:           "tls secret was not specified",
+			flags:          &common.CommandLinkGenerateFlags{Cost: "1"},
+			expectedErrors: []string{"the TLS secret name was not specified"},
+		},
+		{
+			name:           "tls secret is not valid",
+			flags:          &common.CommandLinkGenerateFlags{Cost: "1", TlsCredentials: "my secret"},
+			expectedErrors: []string{"the name of the tls secret is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:  "cost is not valid",
+			flags: &common.CommandLinkGenerateFlags{Cost: "-1", GenerateCredential: true},
+			expectedErrors: []string{
+				"link cost is not valid: value is not positive",
+			},
+		},
+		{
+			name:  "output format is not valid",
+			flags: &common.CommandLinkGenerateFlags{Cost: "1", GenerateCredential: true, Output: "not-valid"},
+			expectedErrors: []string{
+				"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]",
+			},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			flags:          &common.CommandLinkGenerateFlags{Cost: "1", GenerateCredential: true, Output: "yaml"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:           "flags all valid",
+			flags:          &common.CommandLinkGenerateFlags{Cost: "1", TlsCredentials: "my-secret", Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdLinkGenerate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdLinkGenerate_Run(t *testing.T) {
+	type test struct {
+		name               string
+		flags              *common.CommandLinkGenerateFlags
+		withoutLinks       bool
+		expectedHost       string
+		expectedSecret     string
+		expectedCredential string
+		errorMessage       string
+	}
+
+	testTable := []test{
+		{
+			name:               "runs ok with generated credential",
+			flags:              &common.CommandLinkGenerateFlags{Cost: "3", GenerateCredential: true, Output: "yaml"},
+			expectedHost:       "10.0.0.1",
+			expectedSecret:     "link-link-access",
+			expectedCredential: "link-link-access",
+		},
+		{
+			name:               "runs ok with provided credential",
+			flags:              &common.CommandLinkGenerateFlags{Cost: "3", TlsCredentials: "my-secret", Output: "yaml"},
+			expectedHost:       "10.0.0.1",
+			expectedCredential: "my-secret",
+		},
+		{
+			name:               "provided credential is generated",
+			flags:              &common.CommandLinkGenerateFlags{Cost: "3", TlsCredentials: "my-secret", GenerateCredential: true, Output: "json"},
+			expectedHost:       "10.0.0.1",
+			expectedSecret:     "my-secret",
+			expectedCredential: "my-secret",
+		},
+		{
+			name:         "site not initialized",
+			flags:        &common.CommandLinkGenerateFlags{Cost: "1", GenerateCredential: true, Output: "yaml"},
+			withoutLinks: true,
+			errorMessage: "the site in namespace default has not been initialized yet",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			if !test.withoutLinks {
+				writeStaticLinks(t, "default", "127.0.0.1", "10.0.0.1")
+			}
+
+			command := &CmdLinkGenerate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.InputToOptions()
+
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, command.generatedLink.Spec.Cost, 3)
+			assert.Equal(t, command.generatedLink.Spec.Endpoints[0].Host, test.expectedHost)
+			assert.Equal(t, command.generatedLink.Spec.TlsCredentials, test.expectedCredential)
+			if test.expectedSecret == "" {
+				assert.Assert(t, command.generatedSecret == nil)
+			} else {
+				assert.Equal(t, command.generatedSecret.Name, test.expectedSecret)
+			}
+			assert.Assert(t, command.WaitUntil())
+		})
+	}
+}
+
+// --- helper methods
+
+func writeStaticLinks(t *testing.T, namespace string, hosts ...string) {
+	t.Helper()
+	routerAccess := v2alpha1.RouterAccess{}
+	routerAccess.Name = "link-access"
+	routerAccess.Spec.Roles = []v2alpha1.RouterAccessRole{
+		{Name: "inter-router", Port: 55671},
+		{Name: "edge", Port: 45671},
+	}
+	ca := certs.GenerateCASecret("skupper-site-ca", "skupper-site-ca")
+	server := certs.GenerateSecret("link-access", "link-access", strings.Join(hosts, ","), &ca)
+	client := certs.GenerateSecret("link-link-access", "link-link-access", "", &ca)
+	tokenPath := path.Join(api.GetHostNamespaceHome(namespace), string(api.RuntimeTokenPath))
+	assert.Assert(t, os.MkdirAll(tokenPath, 0755))
+	for _, token := range api.CreateTokens(routerAccess, server, client) {
+		data, err := token.Marshal()
+		assert.Assert(t, err)
+		fileName := "link-" + routerAccess.Name + "-" + token.Links[0].Spec.Endpoints[0].Host + ".yaml"
+		assert.Assert(t, os.WriteFile(path.Join(tokenPath, fileName), data, 0644))
+	}
+}
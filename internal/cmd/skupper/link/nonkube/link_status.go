@@ -2,15 +2,27 @@ package nonkube
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
 )
 
 type CmdLinkStatus struct {
-	CobraCmd  *cobra.Command
-	Flags     *common.CommandLinkStatusFlags
-	Namespace string
-	siteName  string
+	CobraCmd         *cobra.Command
+	Flags            *common.CommandLinkStatusFlags
+	Namespace        string
+	linkName         string
+	output           string
+	LoadRuntimeState func(namespace string) (*api.SiteState, error)
+	RouterStatus     func(namespace string) *router.Status
 }
 
 func NewCmdLinkStatus() *CmdLinkStatus {
@@ -18,12 +30,106 @@ func NewCmdLinkStatus() *CmdLinkStatus {
 }
 
 func (cmd *CmdLinkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
-	//TODO
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.Namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+	cmd.LoadRuntimeState = router.LoadRuntimeState
+	cmd.RouterStatus = router.GetStatus
+}
+
+func (cmd *CmdLinkStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command only accepts one argument"))
+	} else if len(args) == 1 && args[0] != "" {
+		cmd.linkName = args[0]
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdLinkStatus) InputToOptions() {
+	if cmd.Flags != nil {
+		cmd.output = cmd.Flags.Output
+	}
 }
 
-func (cmd *CmdLinkStatus) ValidateInput(args []string) []error { return nil }
-func (cmd *CmdLinkStatus) InputToOptions()                     {}
 func (cmd *CmdLinkStatus) Run() error {
-	return fmt.Errorf("command not supported by the selected platform")
+	siteState, err := cmd.LoadRuntimeState(cmd.Namespace)
+	if err != nil {
+		return fmt.Errorf("site not initialized yet: %s", err)
+	}
+	var links []*v2alpha1.Link
+	if cmd.linkName != "" {
+		link, ok := siteState.Links[cmd.linkName]
+		if !ok {
+			return fmt.Errorf("link %s does not exist", cmd.linkName)
+		}
+		links = append(links, link)
+	} else {
+		for _, link := range siteState.Links {
+			links = append(links, link)
+		}
+		sort.Slice(links, func(i, j int) bool {
+			return links[i].Name < links[j].Name
+		})
+	}
+	if len(links) == 0 {
+		fmt.Println("There are no link resources in the namespace")
+		return nil
+	}
+
+	if cmd.RouterStatus != nil {
+		status := cmd.RouterStatus(cmd.Namespace)
+		for _, link := range links {
+			status.UpdateLink(link, siteState.Site.Spec.Edge)
+		}
+	}
+
+	if cmd.output != "" {
+		for _, link := range links {
+			encodedOutput, err := utils.Encode(cmd.output, link)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		}
+	} else if cmd.linkName != "" {
+		displaySingleLink(links[0])
+	} else {
+		displayLinkList(links)
+	}
+	return nil
 }
+
 func (cmd *CmdLinkStatus) WaitUntil() error { return nil }
+
+func displaySingleLink(link *v2alpha1.Link) {
+	fmt.Printf("%s\t: %s\n", "Name", link.Name)
+	fmt.Printf("%s\t: %s\n", "Status", link.Status.StatusType)
+	fmt.Printf("%s\t: %s\n", "Remote site", link.Status.RemoteSiteName)
+	fmt.Printf("%s\t: %s\n", "Endpoint", link.Status.ActiveEndpoint)
+	fmt.Printf("%s\t: %d\n", "Cost", link.Spec.Cost)
+	fmt.Printf("%s\t: %s\n", "Message", link.Status.Message)
+}
+
+func displayLinkList(links []*v2alpha1.Link) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tSTATUS\tREMOTE SITE\tCOST\tMESSAGE")
+
+	for _, link := range links {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s", link.Name, link.Status.StatusType, link.Status.RemoteSiteName, link.Spec.Cost, link.Status.Message)
+		fmt.Fprintln(writer)
+	}
+
+	writer.Flush()
+}
@@ -1 +1,121 @@
 package nonkube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdLinkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          *common.CommandLinkStatusFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-link", "other"},
+			flags:          &common.CommandLinkStatusFlags{},
+			expectedErrors: []string{"this command only accepts one argument"},
+		},
+		{
+			name:  "output format is not valid",
+			args:  []string{"my-link"},
+			flags: &common.CommandLinkStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{
+				"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]",
+			},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-link"},
+			flags:          &common.CommandLinkStatusFlags{Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdLinkStatus{Flags: test.flags}
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdLinkStatus_Run(t *testing.T) {
+	type test struct {
+		name          string
+		linkName      string
+		loadError     error
+		routerStatus  *router.Status
+		errorMessage  string
+		expectedReady bool
+	}
+
+	testTable := []test{
+		{
+			name:         "site is not initialized",
+			loadError:    fmt.Errorf("no such file or directory"),
+			errorMessage: "site not initialized yet: no such file or directory",
+		},
+		{
+			name:         "link does not exist",
+			linkName:     "other",
+			routerStatus: router.Unavailable(fmt.Errorf("router is not reachable")),
+			errorMessage: "link other does not exist",
+		},
+		{
+			name:         "router is not reachable",
+			linkName:     "my-link",
+			routerStatus: router.Unavailable(fmt.Errorf("router is not reachable")),
+		},
+		{
+			name:         "all links",
+			routerStatus: router.Unavailable(fmt.Errorf("router is not reachable")),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			siteState := api.NewSiteState(false)
+			siteState.Site = &v2alpha1.Site{ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "test"}}
+			link := &v2alpha1.Link{ObjectMeta: metav1.ObjectMeta{Name: "my-link", Namespace: "test"}}
+			link.SetConfigured(nil)
+			siteState.Links["my-link"] = link
+
+			command := &CmdLinkStatus{
+				Namespace: "test",
+				linkName:  test.linkName,
+				LoadRuntimeState: func(namespace string) (*api.SiteState, error) {
+					assert.Equal(t, namespace, "test")
+					if test.loadError != nil {
+						return nil, test.loadError
+					}
+					return siteState, nil
+				},
+				RouterStatus: func(namespace string) *router.Status {
+					return test.routerStatus
+				},
+			}
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, link.Status.StatusType, v2alpha1.StatusPending)
+		})
+	}
+}
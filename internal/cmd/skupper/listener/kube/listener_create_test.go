@@ -117,7 +117,7 @@ func TestCmdListenerCreate_ValidateInput(t *testing.T) {
 				ListenerType: "not-valid",
 			},
 			expectedErrors: []string{
-				"listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+				"listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name: "routing key is not valid",
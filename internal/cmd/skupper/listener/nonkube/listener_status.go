@@ -8,8 +8,12 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type CmdListenerStatus struct {
@@ -19,6 +23,7 @@ type CmdListenerStatus struct {
 	namespace       string
 	listenerName    string
 	output          string
+	routerStatus    func(namespace string) *router.Status
 }
 
 func NewCmdListenerStatus() *CmdListenerStatus {
@@ -31,6 +36,7 @@ func (cmd *CmdListenerStatus) NewClient(cobraCommand *cobra.Command, args []stri
 	}
 
 	cmd.listenerHandler = fs.NewListenerHandler(cmd.namespace)
+	cmd.routerStatus = router.GetStatus
 }
 
 func (cmd *CmdListenerStatus) ValidateInput(args []string) []error {
@@ -82,6 +88,12 @@ func (cmd *CmdListenerStatus) Run() error {
 			fmt.Println("no listeners found:")
 			return err
 		}
+		if cmd.routerStatus != nil {
+			status := cmd.routerStatus(cmd.namespace)
+			for _, listener := range listeners {
+				status.UpdateListener(listener)
+			}
+		}
 		if cmd.output != "" {
 			for _, listener := range listeners {
 				encodedOutput, err := utils.Encode(cmd.output, listener)
@@ -92,15 +104,15 @@ func (cmd *CmdListenerStatus) Run() error {
 			}
 		} else {
 			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
-			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
-				"NAME", "STATUS", "ROUTING-KEY", "HOST", "PORT"))
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "MATCHED", "ROUTING-KEY", "HOST", "PORT"))
 			for _, listener := range listeners {
 				status := "Not Ready"
 				if listener.IsConfigured() {
 					status = "Ok"
 				}
-				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%d",
-					listener.Name, status, listener.Spec.RoutingKey, listener.Spec.Host, listener.Spec.Port))
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%d",
+					listener.Name, status, matched(listener.Status.Conditions), listener.Spec.RoutingKey, listener.Spec.Host, listener.Spec.Port))
 			}
 			_ = tw.Flush()
 		}
@@ -110,6 +122,9 @@ func (cmd *CmdListenerStatus) Run() error {
 			fmt.Println("No listeners found:", err)
 			return err
 		}
+		if cmd.routerStatus != nil {
+			cmd.routerStatus(cmd.namespace).UpdateListener(listener)
+		}
 		if cmd.output != "" {
 			encodedOutput, err := utils.Encode(cmd.output, listener)
 			if err != nil {
@@ -122,8 +137,8 @@ func (cmd *CmdListenerStatus) Run() error {
 				status = "Ok"
 			}
 			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
-			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nRouting key:\t%s\nHost:\t%s\nPort:\t%d\nTlsCredentials:\t%s\n",
-				listener.Name, status, listener.Spec.RoutingKey, listener.Spec.Host, listener.Spec.Port, listener.Spec.TlsCredentials))
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nMatched:\t%s\nRouting key:\t%s\nHost:\t%s\nPort:\t%d\nTlsCredentials:\t%s\n",
+				listener.Name, status, matched(listener.Status.Conditions), listener.Spec.RoutingKey, listener.Spec.Host, listener.Spec.Port, listener.Spec.TlsCredentials))
 			_ = tw.Flush()
 		}
 	}
@@ -132,3 +147,16 @@ func (cmd *CmdListenerStatus) Run() error {
 
 func (cmd *CmdListenerStatus) InputToOptions()  {}
 func (cmd *CmdListenerStatus) WaitUntil() error { return nil }
+
+// matched describes the Matched condition, which is only known once
+// the router of the site has been queried.
+func matched(conditions []metav1.Condition) string {
+	condition := meta.FindStatusCondition(conditions, v2alpha1.CONDITION_TYPE_MATCHED)
+	if condition == nil {
+		return "Unknown"
+	}
+	if condition.Status == metav1.ConditionTrue {
+		return "Yes"
+	}
+	return "No"
+}
@@ -66,7 +66,7 @@ func TestCmdListenerUpdate_ValidateInput(t *testing.T) {
 			name:           "listener type is not valid",
 			args:           []string{"my-listener"},
 			flags:          &common.CommandListenerUpdateFlags{ListenerType: "not-valid"},
-			expectedErrors: []string{"listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]"},
+			expectedErrors: []string{"listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp]"},
 		},
 		{
 			name:           "routing key is not valid",
@@ -0,0 +1,87 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdNetworkStatus struct {
+	client     skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient kubernetes.Interface
+	CobraCmd   *cobra.Command
+	Flags      *common.CommandNetworkStatusFlags
+	namespace  string
+	output     string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.KubeClient = cli.GetKubeClient()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkStatusOutputTypes)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions() {
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	sites, err := cmd.client.Sites(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if sites == nil || len(sites.Items) == 0 {
+		return fmt.Errorf("there is no skupper site in this namespace")
+	}
+
+	configMap, err := cmd.KubeClient.CoreV1().ConfigMaps(cmd.namespace).Get(context.TODO(), types.NetworkStatusConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("network status is not available yet: %s", err)
+	}
+	networkStatus, err := network.UnmarshalSkupperStatus(configMap.Data)
+	if err != nil {
+		return fmt.Errorf("network status could not be read: %s", err)
+	}
+	if networkStatus == nil {
+		return fmt.Errorf("network status is not available yet")
+	}
+
+	return utils.PrintNetworkStatus(cmd.output, sites.Items[0].GetSiteId(), networkStatus)
+}
+
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
@@ -0,0 +1,160 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const networkStatusJson = `{"addresses":[{"name":"backend","protocol":"tcp","listenerCount":1,"connectorCount":0}],` +
+	`"siteStatus":[{"site":{"identity":"site-1","name":"west","namespace":"west","platform":"kubernetes"},` +
+	`"routerStatus":[{"router":{"name":"0/west-skupper-router-1"},"links":[{"name":"east-skupper-router-1","status":"down"}]}]},` +
+	`{"site":{"identity":"site-2","name":"east","namespace":"east","platform":"kubernetes"},` +
+	`"routerStatus":[{"router":{"name":"0/east-skupper-router-1"},"links":[{"name":"west-skupper-router-1","status":"up"}]}]}]}`
+
+func TestCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandNetworkStatusFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arguments are not accepted",
+			args:           []string{"west"},
+			flags:          common.CommandNetworkStatusFlags{Output: "table"},
+			expectedErrors: []string{"this command does not need any arguments"},
+		},
+		{
+			name:           "output is not valid",
+			args:           []string{},
+			flags:          common.CommandNetworkStatusFlags{Output: "xml"},
+			expectedErrors: []string{"output type is not valid: value xml not allowed. It should be one of this options: [table tree json yaml]"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{},
+			flags:          common.CommandNetworkStatusFlags{Output: "tree"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdNetworkStatusWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdNetworkStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		k8sObjects     []runtime.Object
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	site := &v2alpha1.Site{
+		ObjectMeta: v1.ObjectMeta{Name: "west", Namespace: "test", UID: "site-1"},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: types.NetworkStatusConfigMapName, Namespace: "test"},
+		Data:       map[string]string{"NetworkStatus": networkStatusJson},
+	}
+
+	testTable := []test{
+		{
+			name:           "no site in the namespace",
+			k8sObjects:     []runtime.Object{configMap},
+			skupperObjects: []runtime.Object{},
+			errorMessage:   "there is no skupper site in this namespace",
+		},
+		{
+			name:           "network status is not published yet",
+			skupperObjects: []runtime.Object{site},
+			errorMessage:   "network status is not available yet: configmaps \"skupper-network-status\" not found",
+		},
+		{
+			name: "network status is not valid",
+			k8sObjects: []runtime.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: v1.ObjectMeta{Name: types.NetworkStatusConfigMapName, Namespace: "test"},
+					Data:       map[string]string{"NetworkStatus": "{"},
+				},
+			},
+			skupperObjects: []runtime.Object{site},
+			errorMessage:   "network status could not be read: unexpected end of JSON input",
+		},
+		{
+			name:           "table",
+			output:         "table",
+			k8sObjects:     []runtime.Object{configMap},
+			skupperObjects: []runtime.Object{site},
+		},
+		{
+			name:           "tree",
+			output:         "tree",
+			k8sObjects:     []runtime.Object{configMap},
+			skupperObjects: []runtime.Object{site},
+		},
+		{
+			name:           "yaml",
+			output:         "yaml",
+			k8sObjects:     []runtime.Object{configMap},
+			skupperObjects: []runtime.Object{site},
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdNetworkStatusWithMocks("test", test.k8sObjects, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil)
+				assert.Equal(t, err.Error(), test.errorMessage)
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdNetworkStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdNetworkStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdNetworkStatus := &CmdNetworkStatus{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdNetworkStatus, nil
+}
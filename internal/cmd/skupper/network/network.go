@@ -0,0 +1,50 @@
+package network
+
+import (
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdNetwork() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Inspect the application network the local site belongs to.",
+		Long:  `The network is the set of sites linked together, the listeners and connectors they define and the addresses that join them.`,
+		Example: `skupper network status
+skupper network status --output tree`,
+	}
+
+	cmd.AddCommand(CmdNetworkStatusFactory(config.GetPlatform()))
+
+	return cmd
+}
+
+func CmdNetworkStatusFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdNetworkStatus()
+	nonKubeCommand := nonkube.NewCmdNetworkStatus()
+
+	cmdNetworkStatusDesc := common.SkupperCmdDescription{
+		Use:   "status",
+		Short: "get status of the network",
+		Long: `Display the sites, links and addresses of the whole network, as seen from the local site.
+Links that are not operational and addresses that have listeners but no connectors are highlighted.`,
+		Example: "skupper network status --output yaml",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdNetworkStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandNetworkStatusFlags{}
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameNetworkStatusOutput, "o", "table", common.FlagDescNetworkStatusOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdNetworkStatus struct {
+	siteHandler          *fs.SiteHandler
+	networkStatusHandler *fs.NetworkStatusHandler
+	CobraCmd             *cobra.Command
+	Flags                *common.CommandNetworkStatusFlags
+	namespace            string
+	output               string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+	cmd.networkStatusHandler = fs.NewNetworkStatusHandler(cmd.namespace)
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkStatusOutputTypes)
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not need any arguments"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions() {
+	cmd.output = cmd.Flags.Output
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	sites, err := cmd.siteHandler.List()
+	if err != nil || len(sites) == 0 {
+		return fmt.Errorf("there is no skupper site in namespace %s", cmd.namespace)
+	}
+
+	networkStatus, err := cmd.networkStatusHandler.Get()
+	if err != nil {
+		return fmt.Errorf("network status is not available yet: %s", err)
+	}
+
+	return utils.PrintNetworkStatus(cmd.output, sites[0].GetSiteId(), networkStatus)
+}
+
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
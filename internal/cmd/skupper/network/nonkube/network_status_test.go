@@ -0,0 +1,145 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNonKubeCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandNetworkStatusFlags
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arguments are not accepted",
+			args:           []string{"west"},
+			flags:          &common.CommandNetworkStatusFlags{},
+			expectedErrors: []string{"this command does not need any arguments"},
+		},
+		{
+			name:           "output is not valid",
+			flags:          &common.CommandNetworkStatusFlags{Output: "xml"},
+			expectedErrors: []string{"output type is not valid: value xml not allowed. It should be one of this options: [table tree json yaml]"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			flags:          &common.CommandNetworkStatusFlags{Output: "table"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdNetworkStatus{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdNetworkStatus_Run(t *testing.T) {
+	type test struct {
+		name          string
+		output        string
+		withSite      bool
+		networkStatus *network.NetworkStatusInfo
+		errorMessage  string
+	}
+
+	networkStatus := &network.NetworkStatusInfo{
+		Addresses: []network.AddressInfo{{Name: "backend", Protocol: "tcp", ListenerCount: 1}},
+		SiteStatus: []network.SiteStatusInfo{
+			{
+				Site: network.SiteInfo{Identity: "site-1", Name: "west", Platform: "podman"},
+				RouterStatus: []network.RouterStatusInfo{
+					{Router: network.RouterInfo{Name: "0/west"}, Links: []network.LinkInfo{{Name: "east", Status: "down"}}},
+				},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:         "no site in the namespace",
+			errorMessage: "there is no skupper site in namespace default",
+		},
+		{
+			name:         "network status is not collected yet",
+			withSite:     true,
+			errorMessage: "network status is not available yet",
+		},
+		{
+			name:          "table",
+			output:        "table",
+			withSite:      true,
+			networkStatus: networkStatus,
+		},
+		{
+			name:          "tree",
+			output:        "tree",
+			withSite:      true,
+			networkStatus: networkStatus,
+		},
+		{
+			name:          "json",
+			output:        "json",
+			withSite:      true,
+			networkStatus: networkStatus,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdNetworkStatus{Flags: &common.CommandNetworkStatusFlags{Output: test.output}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.NewClient(command.CobraCmd, nil)
+			command.InputToOptions()
+
+			if test.withSite {
+				assert.Assert(t, command.siteHandler.Add(v2alpha1.Site{
+					ObjectMeta: metav1.ObjectMeta{Name: "west", Namespace: "default"},
+				}))
+			}
+			if test.networkStatus != nil {
+				assert.Assert(t, command.networkStatusHandler.Set(test.networkStatus))
+				stored, err := fs.NewNetworkStatusHandler("").Get()
+				assert.Assert(t, err)
+				assert.DeepEqual(t, stored, test.networkStatus)
+			}
+
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
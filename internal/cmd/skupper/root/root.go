@@ -2,11 +2,15 @@ package root
 
 import (
 	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/certificate"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/connector"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/debug"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/link"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/listener"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/securedaccess"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/site"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/token"
@@ -33,6 +37,10 @@ func NewSkupperRootCommand() *cobra.Command {
 	rootCmd.AddCommand(listener.NewCmdListener())
 	rootCmd.AddCommand(link.NewCmdLink())
 	rootCmd.AddCommand(connector.NewCmdConnector())
+	rootCmd.AddCommand(routeraccess.NewCmdRouterAccess())
+	rootCmd.AddCommand(securedaccess.NewCmdSecuredAccess())
+	rootCmd.AddCommand(certificate.NewCmdCertificate())
+	rootCmd.AddCommand(network.NewCmdNetwork())
 	rootCmd.AddCommand(version.NewCmdVersion())
 	rootCmd.AddCommand(debug.NewCmdDebug())
 	rootCmd.AddCommand(system.NewCmdSystem())
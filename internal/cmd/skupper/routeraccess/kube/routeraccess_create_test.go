@@ -0,0 +1,371 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessCreateFlags
+		k8sObjects     []runtime.Object
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:  "router access is not created because there is already the same router access in the namespace",
+			args:  []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{"there is already a router access my-access created for namespace test"},
+		},
+		{
+			name:           "router access name is not specified",
+			args:           []string{},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}, Timeout: time.Minute},
+			expectedErrors: []string{"router access name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}, Timeout: time.Minute},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "router access name is not valid",
+			args:           []string{"my_access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}, Timeout: time.Minute},
+			expectedErrors: []string{"router access name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "roles are not specified",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"at least one role must be configured"},
+		},
+		{
+			name:           "role is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"client"}, Timeout: time.Minute},
+			expectedErrors: []string{"roles are not valid: role client not allowed. It should be one of this options: [inter-router edge]"},
+		},
+		{
+			name:           "role port is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge:70000"}, Timeout: time.Minute},
+			expectedErrors: []string{"roles are not valid: port of role edge is not valid: value 70000 is out of range"},
+		},
+		{
+			name:           "tls credentials do not exist",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, TlsCredentials: "missing", Timeout: time.Minute},
+			expectedErrors: []string{"tls-credentials is not valid: does not exist"},
+		},
+		{
+			name:           "tls credentials to be generated",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, TlsCredentials: "missing", GenerateTlsCredentials: true, Timeout: time.Minute},
+			expectedErrors: []string{},
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, AccessType: "not-valid", Timeout: time.Minute},
+			expectedErrors: []string{"access type is not valid: value not-valid not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]"},
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, Timeout: 0},
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, Timeout: time.Minute, Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "wait status is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, Timeout: time.Minute, Wait: "created"},
+			expectedErrors: []string{"status is not valid: value created not allowed. It should be one of this options: [ready configured none]"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:          []string{"inter-router:55671", "edge:45671"},
+				TlsCredentials: "my-secret",
+				Issuer:         "skupper-site-ca",
+				AccessType:     "loadbalancer",
+				Timeout:        time.Minute,
+				Output:         "yaml",
+				Wait:           "ready",
+			},
+			k8sObjects: []runtime.Object{
+				&v12.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-secret",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessCreateWithMocks("test", test.k8sObjects, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name                           string
+		flags                          common.CommandRouterAccessCreateFlags
+		expectedTlsCredentials         string
+		expectedGenerateTlsCredentials bool
+		expectedAccessType             string
+		expectedTimeout                time.Duration
+		expectedOutput                 string
+		expectedStatus                 string
+	}
+
+	testTable := []test{
+		{
+			name:                           "credentials are generated by default",
+			flags:                          common.CommandRouterAccessCreateFlags{Timeout: 20 * time.Second, Output: "json", Wait: "configured"},
+			expectedTlsCredentials:         "my-access",
+			expectedGenerateTlsCredentials: true,
+			expectedTimeout:                20 * time.Second,
+			expectedOutput:                 "json",
+			expectedStatus:                 "configured",
+		},
+		{
+			name:                   "credentials are supplied",
+			flags:                  common.CommandRouterAccessCreateFlags{TlsCredentials: "my-secret", AccessType: "route", Timeout: 30 * time.Second, Wait: "ready"},
+			expectedTlsCredentials: "my-secret",
+			expectedAccessType:     "route",
+			expectedTimeout:        30 * time.Second,
+			expectedStatus:         "ready",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.Flags = &test.flags
+			cmd.name = "my-access"
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.tlsCredentials == test.expectedTlsCredentials)
+			assert.Check(t, cmd.generateTlsCredentials == test.expectedGenerateTlsCredentials)
+			assert.Check(t, cmd.accessType == test.expectedAccessType)
+			assert.Check(t, cmd.timeout == test.expectedTimeout)
+			assert.Check(t, cmd.output == test.expectedOutput)
+			assert.Check(t, cmd.status == test.expectedStatus)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:                "creation fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.roles = []v2alpha1.RouterAccessRole{{Name: "inter-router"}}
+		cmd.tlsCredentials = "my-access"
+		cmd.generateTlsCredentials = true
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name: "router access is not configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "router access is not returned",
+			expectError: true,
+		},
+		{
+			name:   "router access is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:   "router access is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Configured",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "output yaml is not waited for",
+			output:      "yaml",
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.output = test.output
+		cmd.status = test.status
+		cmd.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessCreate := &CmdRouterAccessCreate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessCreate, nil
+}
@@ -0,0 +1,157 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessStatusFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "router access does not exist",
+			args:           []string{"my-access"},
+			expectedErrors: []string{"router access my-access does not exist in namespace test"},
+		},
+		{
+			name: "output format is not valid",
+			args: []string{"my-access"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "all router accesses",
+			args:           []string{},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		routerAccess   string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	routerAccesses := []runtime.Object{
+		&v2alpha1.RouterAccess{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-access",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.RouterAccessSpec{
+				Roles:          []v2alpha1.RouterAccessRole{{Name: "inter-router"}, {Name: "edge"}},
+				TlsCredentials: "my-access",
+			},
+			Status: v2alpha1.RouterAccessStatus{
+				Status: v2alpha1.Status{StatusType: "Ready"},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "all router accesses",
+			skupperObjects: routerAccesses,
+		},
+		{
+			name:           "all router accesses yaml",
+			output:         "yaml",
+			skupperObjects: routerAccesses,
+		},
+		{
+			name:           "one router access",
+			routerAccess:   "my-access",
+			skupperObjects: routerAccesses,
+		},
+		{
+			name:           "one router access json",
+			routerAccess:   "my-access",
+			output:         "json",
+			skupperObjects: routerAccesses,
+		},
+		{
+			name:         "router access does not exist",
+			routerAccess: "my-access",
+			errorMessage: "routeraccesses.skupper.io \"my-access\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = test.routerAccess
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessStatus := &CmdRouterAccessStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdRouterAccessStatus, nil
+}
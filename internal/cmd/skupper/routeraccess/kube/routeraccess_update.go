@@ -0,0 +1,260 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type RouterAccessUpdates struct {
+	roles                  []v2alpha1.RouterAccessRole
+	tlsCredentials         string
+	generateTlsCredentials bool
+	issuer                 string
+	accessType             string
+	options                map[string]string
+	settings               map[string]string
+	timeout                time.Duration
+	output                 string
+}
+
+type CmdRouterAccessUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	KubeClient      kubernetes.Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandRouterAccessUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     RouterAccessUpdates
+	status          string
+}
+
+func NewCmdRouterAccessUpdate() *CmdRouterAccessUpdate {
+
+	return &CmdRouterAccessUpdate{}
+}
+
+func (cmd *CmdRouterAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdRouterAccessUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	accessTypeValidator := validator.NewOptionValidator(common.AccessTypes)
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a router access with this name in the namespace
+	if cmd.name != "" {
+		routerAccess, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if routerAccess == nil || errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = routerAccess.ResourceVersion
+			cmd.newSettings.roles = routerAccess.Spec.Roles
+			cmd.newSettings.tlsCredentials = routerAccess.Spec.TlsCredentials
+			cmd.newSettings.generateTlsCredentials = routerAccess.Spec.GenerateTlsCredentials
+			cmd.newSettings.issuer = routerAccess.Spec.Issuer
+			cmd.newSettings.accessType = routerAccess.Spec.AccessType
+			cmd.newSettings.options = routerAccess.Spec.Options
+			cmd.newSettings.settings = routerAccess.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && len(cmd.Flags.Roles) > 0 {
+		roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterAccessRoles)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: %s", err))
+		} else {
+			cmd.newSettings.roles = roles
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else if !cmd.Flags.GenerateTlsCredentials {
+			_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: does not exist"))
+			} else {
+				cmd.newSettings.tlsCredentials = cmd.Flags.TlsCredentials
+				cmd.newSettings.generateTlsCredentials = false
+			}
+		} else {
+			cmd.newSettings.tlsCredentials = cmd.Flags.TlsCredentials
+			cmd.newSettings.generateTlsCredentials = true
+		}
+	} else if cmd.Flags != nil && cmd.Flags.GenerateTlsCredentials {
+		cmd.newSettings.generateTlsCredentials = true
+	}
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		} else {
+			cmd.newSettings.issuer = cmd.Flags.Issuer
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := accessTypeValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		} else {
+			cmd.newSettings.accessType = cmd.Flags.AccessType
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdRouterAccessUpdate) InputToOptions() {
+	cmd.newSettings.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdRouterAccessUpdate) Run() error {
+
+	resource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			AccessType:             cmd.newSettings.accessType,
+			Roles:                  cmd.newSettings.roles,
+			TlsCredentials:         cmd.newSettings.tlsCredentials,
+			GenerateTlsCredentials: cmd.newSettings.generateTlsCredentials,
+			Issuer:                 cmd.newSettings.issuer,
+			Options:                cmd.newSettings.options,
+			Settings:               cmd.newSettings.settings,
+		},
+	}
+
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, resource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		_, err := cmd.client.RouterAccesses(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (cmd *CmdRouterAccessUpdate) WaitUntil() error {
+
+	// the router access resource was not updated
+	if cmd.newSettings.output != "" {
+		return nil
+	}
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.newSettings.timeout.Seconds())
+	var routerAccessCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			routerAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			routerAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if routerAccessCondition != nil {
+			isConditionFound = true
+			isConditionTrue = routerAccessCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && routerAccessCondition == nil {
+		return fmt.Errorf("RouterAccess %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && routerAccessCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("RouterAccess %q is not yet %s: %s\n", cmd.name, cmd.status, routerAccessCondition.Message)
+	}
+
+	fmt.Printf("RouterAccess %q is updated\n", cmd.name)
+	return nil
+}
@@ -0,0 +1,256 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name               string
+		args               []string
+		flags              common.CommandRouterAccessUpdateFlags
+		skupperObjects     []runtime.Object
+		expectedErrors     []string
+		expectedRoles      []v2alpha1.RouterAccessRole
+		expectedAccessType string
+	}
+
+	existing := []runtime.Object{
+		&v2alpha1.RouterAccess{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-access",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.RouterAccessSpec{
+				Roles:          []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+				TlsCredentials: "my-access",
+				AccessType:     "loadbalancer",
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "router access does not exist",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"router access my-access must exist in namespace test to be updated"},
+		},
+		{
+			name:           "router access name is not specified",
+			args:           []string{},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"router access name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "role is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Roles: []string{"client"}, Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"roles are not valid: role client not allowed. It should be one of this options: [inter-router edge]"},
+		},
+		{
+			name:           "tls credentials do not exist",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{TlsCredentials: "missing", Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"tls-credentials is not valid: does not exist"},
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{AccessType: "not-valid", Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"access type is not valid: value not-valid not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: time.Minute, Output: "not-valid"},
+			skupperObjects: existing,
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:               "existing values are kept",
+			args:               []string{"my-access"},
+			flags:              common.CommandRouterAccessUpdateFlags{Timeout: time.Minute},
+			skupperObjects:     existing,
+			expectedErrors:     []string{},
+			expectedRoles:      []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+			expectedAccessType: "loadbalancer",
+		},
+		{
+			name:               "flags override existing values",
+			args:               []string{"my-access"},
+			flags:              common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:45672"}, AccessType: "route", Timeout: time.Minute},
+			skupperObjects:     existing,
+			expectedErrors:     []string{},
+			expectedRoles:      []v2alpha1.RouterAccessRole{{Name: "edge", Port: 45672}},
+			expectedAccessType: "route",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+			if test.expectedRoles != nil {
+				assert.DeepEqual(t, command.newSettings.roles, test.expectedRoles)
+				assert.Equal(t, command.newSettings.accessType, test.expectedAccessType)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:         "router access does not exist",
+			errorMessage: "routeraccesses.skupper.io \"my-access\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessUpdateWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.newSettings.roles = []v2alpha1.RouterAccessRole{{Name: "edge"}}
+		cmd.newSettings.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "router access is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:   "router access is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Configured",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.status = test.status
+		cmd.newSettings.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessUpdate := &CmdRouterAccessUpdate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessUpdate, nil
+}
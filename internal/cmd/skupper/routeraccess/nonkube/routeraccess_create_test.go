@@ -0,0 +1,222 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNonKubeCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandRouterAccessCreateFlags
+		existing          bool
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "router access name is not specified",
+			args:           []string{},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}},
+			expectedErrors: []string{"router access name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "router access already exists",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"inter-router"}},
+			existing:       true,
+			expectedErrors: []string{"there is already a router access my-access created for namespace default"},
+		},
+		{
+			name:           "roles are not specified",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{},
+			expectedErrors: []string{"at least one role must be configured"},
+		},
+		{
+			name:           "role is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:abc"}},
+			expectedErrors: []string{"roles are not valid: port of role edge is not valid: strconv.Atoi: parsing \"abc\": invalid syntax"},
+		},
+		{
+			name:           "bind host is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, BindHost: "not valid"},
+			expectedErrors: []string{"bindhost is not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "subject alternative names are not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, SubjectAlternativeNames: []string{"test.com", "not valid"}},
+			expectedErrors: []string{"SubjectAlternativeNames is not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: &common.CommandRouterAccessCreateFlags{
+				Roles:                   []string{"inter-router:55671", "edge:45671"},
+				TlsCredentials:          "my-secret",
+				BindHost:                "1.2.3.4",
+				SubjectAlternativeNames: []string{"test.com"},
+				Output:                  "yaml",
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessCreate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.routerAccessHandler = fs.NewRouterAccessHandler("")
+
+			if test.existing {
+				assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name              string
+		namespace         string
+		flags             common.CommandRouterAccessCreateFlags
+		expectedNamespace string
+		expectedBindHost  string
+	}
+
+	testTable := []test{
+		{
+			name:              "options with default values",
+			flags:             common.CommandRouterAccessCreateFlags{},
+			expectedNamespace: "default",
+			expectedBindHost:  "0.0.0.0",
+		},
+		{
+			name:              "options with bind host",
+			namespace:         "test",
+			flags:             common.CommandRouterAccessCreateFlags{BindHost: "1.2.3.4"},
+			expectedNamespace: "test",
+			expectedBindHost:  "1.2.3.4",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := CmdRouterAccessCreate{}
+			cmd.Flags = &test.flags
+			cmd.namespace = test.namespace
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.namespace == test.expectedNamespace)
+			assert.Check(t, cmd.bindHost == test.expectedBindHost)
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessCreate_Run(t *testing.T) {
+	type test struct {
+		name   string
+		output string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessCreate{}
+			command.routerAccessName = "my-access"
+			command.namespace = "test"
+			command.roles = []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}}
+			command.bindHost = "0.0.0.0"
+			command.output = test.output
+			command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+			assert.Assert(t, command.Run())
+			assert.Assert(t, command.WaitUntil())
+
+			routerAccess, err := command.routerAccessHandler.Update("my-access")
+			if test.output != "" {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+				assert.Equal(t, routerAccess.Spec.BindHost, "0.0.0.0")
+				assert.DeepEqual(t, routerAccess.Spec.Roles, command.roles)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newRouterAccess(name string) v2alpha1.RouterAccess {
+	return v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles:          []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+			TlsCredentials: name,
+			BindHost:       "0.0.0.0",
+		},
+	}
+}
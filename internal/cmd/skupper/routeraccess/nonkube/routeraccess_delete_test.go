@@ -0,0 +1,86 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdRouterAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "router access name is not specified",
+			args:           []string{},
+			expectedErrors: []string{"router access name must be specified"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "router access name is empty",
+			args:           []string{""},
+			expectedErrors: []string{"router access name must not be empty"},
+		},
+		{
+			name:           "router access does not exist",
+			args:           []string{"other"},
+			expectedErrors: []string{"router access other does not exist"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-access"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessDelete{Flags: &common.CommandRouterAccessDeleteFlags{}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.routerAccessHandler = fs.NewRouterAccessHandler("")
+			assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessDelete_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdRouterAccessDelete{}
+	command.routerAccessName = "my-access"
+	command.routerAccessHandler = fs.NewRouterAccessHandler("")
+	assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	_, err := command.routerAccessHandler.Update("my-access")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,141 @@
+package nonkube
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdRouterAccessStatus struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessStatusFlags
+	namespace           string
+	routerAccessName    string
+	output              string
+}
+
+func NewCmdRouterAccessStatus() *CmdRouterAccessStatus {
+	return &CmdRouterAccessStatus{}
+}
+
+func (cmd *CmdRouterAccessStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+			} else {
+				cmd.routerAccessName = args[0]
+			}
+		}
+	}
+	// Validate that there is a router access with this name in the namespace
+	if cmd.routerAccessName != "" {
+		routerAccess, err := cmd.routerAccessHandler.Update(cmd.routerAccessName)
+		if routerAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s does not exist in namespace %s", cmd.routerAccessName, cmd.getNamespace()))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdRouterAccessStatus) Run() error {
+	if cmd.routerAccessName == "" {
+		routerAccesses, err := cmd.routerAccessHandler.List()
+		if routerAccesses == nil || err != nil {
+			fmt.Println("No router accesses found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, routerAccess := range routerAccesses {
+				encodedOutput, err := utils.Encode(cmd.output, routerAccess)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "ROLES", "BIND-HOST", "TLS-CREDENTIALS"))
+			for _, routerAccess := range routerAccesses {
+				status := "Not Ready"
+				if routerAccess.IsConfigured() {
+					status = "Ok"
+				}
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+					routerAccess.Name, status, utils.FormatRouterAccessRoles(routerAccess.Spec.Roles),
+					routerAccess.Spec.BindHost, routerAccess.Spec.TlsCredentials))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		routerAccess, err := cmd.routerAccessHandler.Get(cmd.routerAccessName)
+		if routerAccess == nil || err != nil {
+			fmt.Println("No router accesses found:", err)
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, routerAccess)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			status := "Not Ready"
+			if routerAccess.IsConfigured() {
+				status = "Ok"
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nRoles:\t%s\nBind host:\t%s\nTLS credentials:\t%s\nIssuer:\t%s\nSubject alternative names:\t%v\n",
+				routerAccess.Name, status, utils.FormatRouterAccessRoles(routerAccess.Spec.Roles), routerAccess.Spec.BindHost,
+				routerAccess.Spec.TlsCredentials, routerAccess.Spec.Issuer, routerAccess.Spec.SubjectAlternativeNames))
+			_ = tw.Flush()
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdRouterAccessStatus) InputToOptions()  {}
+func (cmd *CmdRouterAccessStatus) WaitUntil() error { return nil }
+
+func (cmd *CmdRouterAccessStatus) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
@@ -0,0 +1,118 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          *common.CommandRouterAccessStatusFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          &common.CommandRouterAccessStatusFlags{},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "router access does not exist",
+			args:           []string{"other"},
+			flags:          &common.CommandRouterAccessStatusFlags{},
+			expectedErrors: []string{"router access other does not exist in namespace default"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessStatusFlags{Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessStatus{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.routerAccessHandler = fs.NewRouterAccessHandler("")
+			assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name             string
+		routerAccessName string
+		output           string
+		withoutExisting  bool
+		expectError      bool
+	}
+
+	testTable := []test{
+		{
+			name: "all router accesses",
+		},
+		{
+			name:   "all router accesses yaml",
+			output: "yaml",
+		},
+		{
+			name:             "one router access",
+			routerAccessName: "my-access",
+		},
+		{
+			name:             "one router access json",
+			routerAccessName: "my-access",
+			output:           "json",
+		},
+		{
+			name:             "router access does not exist",
+			routerAccessName: "my-access",
+			withoutExisting:  true,
+			expectError:      true,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessStatus{}
+			command.routerAccessName = test.routerAccessName
+			command.output = test.output
+			command.routerAccessHandler = fs.NewRouterAccessHandler("")
+			if !test.withoutExisting {
+				assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+			}
+
+			err := command.Run()
+			if test.expectError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RouterAccessUpdates struct {
+	roles                   []v2alpha1.RouterAccessRole
+	tlsCredentials          string
+	issuer                  string
+	bindHost                string
+	subjectAlternativeNames []string
+	options                 map[string]string
+	settings                map[string]string
+	output                  string
+}
+
+type CmdRouterAccessUpdate struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessUpdateFlags
+	namespace           string
+	routerAccessName    string
+	newSettings         RouterAccessUpdates
+}
+
+func NewCmdRouterAccessUpdate() *CmdRouterAccessUpdate {
+	return &CmdRouterAccessUpdate{}
+}
+
+func (cmd *CmdRouterAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	hostStringValidator := validator.NewHostStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("router access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("router access name is not valid: %s", err))
+		} else {
+			cmd.routerAccessName = args[0]
+		}
+	}
+
+	// Validate that there is already a router access with this name in the namespace
+	if cmd.routerAccessName != "" {
+		routerAccess, err := cmd.routerAccessHandler.Update(cmd.routerAccessName)
+		if routerAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("router access %s must exist in namespace %s to be updated", cmd.routerAccessName, cmd.getNamespace()))
+		} else {
+			// save existing values
+			cmd.newSettings.roles = routerAccess.Spec.Roles
+			cmd.newSettings.tlsCredentials = routerAccess.Spec.TlsCredentials
+			cmd.newSettings.issuer = routerAccess.Spec.Issuer
+			cmd.newSettings.bindHost = routerAccess.Spec.BindHost
+			cmd.newSettings.subjectAlternativeNames = routerAccess.Spec.SubjectAlternativeNames
+			cmd.newSettings.options = routerAccess.Spec.Options
+			cmd.newSettings.settings = routerAccess.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if len(cmd.Flags.Roles) > 0 {
+		roles, err := utils.ParseRouterAccessRoles(cmd.Flags.Roles, common.RouterAccessRoles)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: %s", err))
+		} else {
+			cmd.newSettings.roles = roles
+		}
+	}
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else {
+			cmd.newSettings.tlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		} else {
+			cmd.newSettings.issuer = cmd.Flags.Issuer
+		}
+	}
+	if cmd.Flags.BindHost != "" {
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.BindHost)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("bindhost is not valid: a valid IP address or hostname is expected"))
+		} else {
+			cmd.newSettings.bindHost = cmd.Flags.BindHost
+		}
+	}
+	if len(cmd.Flags.SubjectAlternativeNames) > 0 {
+		valid := true
+		for _, name := range cmd.Flags.SubjectAlternativeNames {
+			ok, _ := hostStringValidator.Evaluate(name)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("SubjectAlternativeNames is not valid: a valid IP address or hostname is expected"))
+				valid = false
+				break
+			}
+		}
+		if valid {
+			cmd.newSettings.subjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+		}
+	}
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdRouterAccessUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdRouterAccessUpdate) Run() error {
+	routerAccessResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.routerAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles:                   cmd.newSettings.roles,
+			TlsCredentials:          cmd.newSettings.tlsCredentials,
+			Issuer:                  cmd.newSettings.issuer,
+			BindHost:                cmd.newSettings.bindHost,
+			SubjectAlternativeNames: cmd.newSettings.subjectAlternativeNames,
+			Options:                 cmd.newSettings.options,
+			Settings:                cmd.newSettings.settings,
+		},
+	}
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, routerAccessResource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		err := cmd.routerAccessHandler.Add(routerAccessResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdRouterAccessUpdate) WaitUntil() error { return nil }
+
+func (cmd *CmdRouterAccessUpdate) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
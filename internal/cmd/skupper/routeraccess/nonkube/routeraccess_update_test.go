@@ -0,0 +1,109 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name             string
+		args             []string
+		flags            *common.CommandRouterAccessUpdateFlags
+		withoutExisting  bool
+		expectedErrors   []string
+		expectedBindHost string
+		expectedRoles    []v2alpha1.RouterAccessRole
+	}
+
+	testTable := []test{
+		{
+			name:           "router access name is not specified",
+			args:           []string{},
+			flags:          &common.CommandRouterAccessUpdateFlags{},
+			expectedErrors: []string{"router access name must be configured"},
+		},
+		{
+			name:            "router access does not exist",
+			args:            []string{"my-access"},
+			flags:           &common.CommandRouterAccessUpdateFlags{},
+			withoutExisting: true,
+			expectedErrors:  []string{"router access my-access must exist in namespace default to be updated"},
+		},
+		{
+			name:           "role is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessUpdateFlags{Roles: []string{"client"}},
+			expectedErrors: []string{"roles are not valid: role client not allowed. It should be one of this options: [inter-router edge]"},
+		},
+		{
+			name:           "bind host is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandRouterAccessUpdateFlags{BindHost: "not valid"},
+			expectedErrors: []string{"bindhost is not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:             "existing values are kept",
+			args:             []string{"my-access"},
+			flags:            &common.CommandRouterAccessUpdateFlags{},
+			expectedErrors:   []string{},
+			expectedBindHost: "0.0.0.0",
+			expectedRoles:    []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+		},
+		{
+			name:             "flags override existing values",
+			args:             []string{"my-access"},
+			flags:            &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:45672"}, BindHost: "1.2.3.4"},
+			expectedErrors:   []string{},
+			expectedBindHost: "1.2.3.4",
+			expectedRoles:    []v2alpha1.RouterAccessRole{{Name: "edge", Port: 45672}},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdRouterAccessUpdate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.routerAccessHandler = fs.NewRouterAccessHandler("")
+
+			if !test.withoutExisting {
+				assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+			if test.expectedRoles != nil {
+				assert.Equal(t, command.newSettings.bindHost, test.expectedBindHost)
+				assert.DeepEqual(t, command.newSettings.roles, test.expectedRoles)
+			}
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessUpdate_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdRouterAccessUpdate{Flags: &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge"}}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.routerAccessHandler = fs.NewRouterAccessHandler("")
+	assert.Assert(t, command.routerAccessHandler.Add(newRouterAccess("my-access")))
+
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"my-access"})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	routerAccess, err := command.routerAccessHandler.Update("my-access")
+	assert.Assert(t, err)
+	assert.DeepEqual(t, routerAccess.Spec.Roles, []v2alpha1.RouterAccessRole{{Name: "edge"}})
+	assert.Equal(t, routerAccess.Spec.TlsCredentials, "my-access")
+}
@@ -0,0 +1,176 @@
+package routeraccess
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRouterAccess() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "router-access",
+		Short: "Exposes the router of the local site so that other sites can link to it.",
+		Long:  `A router access configures how the router of the local site is reached by other sites, for the inter-router and edge roles`,
+		Example: `skupper router-access create my-access --roles inter-router,edge
+skupper router-access status my-access`,
+	}
+
+	cmd.AddCommand(CmdRouterAccessCreateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdRouterAccessStatusFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdRouterAccessUpdateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdRouterAccessDeleteFactory(config.GetPlatform()))
+
+	return cmd
+}
+
+func CmdRouterAccessCreateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessCreate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessCreate()
+
+	cmdRouterAccessCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name>",
+		Short: "create a router access",
+		Long: `Expose the router of the local site for the given roles.
+	When no TLS credentials are supplied, they are generated and named after the router access.`,
+		Example: "skupper router-access create my-access --roles inter-router:55671,edge:45671",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessCreateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, []string{"inter-router:55671", "edge:45671"}, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, false, common.FlagDescGenerateTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+	cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "0.0.0.0", common.FlagDescBindHost)
+	cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, []string{}, common.FlagDescSubjectAlternativeNames)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform == types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameBindHost)
+		cmd.Flags().MarkHidden(common.FlagNameSubjectAlternativeNames)
+	} else {
+		cmd.Flags().MarkHidden(common.FlagNameGenerateTlsCredentials)
+		cmd.Flags().MarkHidden(common.FlagNameAccessType)
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdRouterAccessUpdateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessUpdate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessUpdate()
+
+	cmdRouterAccessUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a router access",
+		Long: `Change the way the router of the local site is exposed.
+	The user can change roles, TLS credentials, issuer and access type`,
+		Example: "skupper router-access update my-access --roles inter-router:55672",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessUpdateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, []string{}, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, false, common.FlagDescGenerateTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+	cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+	cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, []string{}, common.FlagDescSubjectAlternativeNames)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform == types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameBindHost)
+		cmd.Flags().MarkHidden(common.FlagNameSubjectAlternativeNames)
+	} else {
+		cmd.Flags().MarkHidden(common.FlagNameGenerateTlsCredentials)
+		cmd.Flags().MarkHidden(common.FlagNameAccessType)
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdRouterAccessStatusFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessStatus()
+	nonKubeCommand := nonkube.NewCmdRouterAccessStatus()
+
+	cmdRouterAccessStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of router accesses",
+		Long:    "Display status of all router accesses or a specific router access",
+		Example: "skupper router-access status my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessDeleteFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessDelete()
+	nonKubeCommand := nonkube.NewCmdRouterAccessDelete()
+
+	cmdRouterAccessDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a router access",
+		Long:    "Delete a router access <name>",
+		Example: "skupper router-access delete my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessDeleteFlags{}
+
+	cmd.Flags().DurationVarP(&cmdFlags.Timeout, common.FlagNameTimeout, "t", 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
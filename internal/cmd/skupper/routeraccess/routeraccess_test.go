@@ -0,0 +1,93 @@
+package routeraccess
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessFactory(t *testing.T) {
+
+	type test struct {
+		name                          string
+		expectedFlagsWithDefaultValue map[string]interface{}
+		command                       *cobra.Command
+	}
+
+	testTable := []test{
+		{
+			name: "CmdRouterAccessCreateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[inter-router:55671,edge:45671]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameGenerateTlsCredentials:  "false",
+				common.FlagNameIssuer:                  "",
+				common.FlagNameAccessType:              "",
+				common.FlagNameBindHost:                "0.0.0.0",
+				common.FlagNameSubjectAlternativeNames: "[]",
+				common.FlagNameOutput:                  "",
+				common.FlagNameTimeout:                 "1m0s",
+				common.FlagNameWait:                    "configured",
+			},
+			command: CmdRouterAccessCreateFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessUpdateFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameRoles:                   "[]",
+				common.FlagNameTlsCredentials:          "",
+				common.FlagNameGenerateTlsCredentials:  "false",
+				common.FlagNameIssuer:                  "",
+				common.FlagNameAccessType:              "",
+				common.FlagNameBindHost:                "",
+				common.FlagNameSubjectAlternativeNames: "[]",
+				common.FlagNameOutput:                  "",
+				common.FlagNameTimeout:                 "1m0s",
+				common.FlagNameWait:                    "configured",
+			},
+			command: CmdRouterAccessUpdateFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameOutput: "",
+			},
+			command: CmdRouterAccessStatusFactory(types.PlatformKubernetes),
+		},
+		{
+			name: "CmdRouterAccessDeleteFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdRouterAccessDeleteFactory(types.PlatformKubernetes),
+		},
+	}
+
+	for _, test := range testTable {
+
+		var flagList []string
+		t.Run(test.name, func(t *testing.T) {
+
+			test.command.Flags().VisitAll(func(flag *pflag.Flag) {
+				flagList = append(flagList, flag.Name)
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] != nil, fmt.Sprintf("flag %q not expected", flag.Name))
+				assert.Check(t, test.expectedFlagsWithDefaultValue[flag.Name] == flag.DefValue, fmt.Sprintf("default value %q for flag %q not expected", flag.DefValue, flag.Name))
+			})
+
+			assert.Check(t, len(flagList) == len(test.expectedFlagsWithDefaultValue))
+
+			assert.Assert(t, test.command.PreRunE != nil)
+			assert.Assert(t, test.command.Run != nil)
+			assert.Assert(t, test.command.PostRun != nil)
+			assert.Assert(t, test.command.Use != "")
+			assert.Assert(t, test.command.Short != "")
+			assert.Assert(t, test.command.Long != "")
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdSecuredAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandSecuredAccessCreateFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:  "secured access is not created because there is already the same secured access in the namespace",
+			args:  []string{"my-access"},
+			flags: common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedErrors: []string{"there is already a secured access my-access created for namespace test"},
+		},
+		{
+			name:           "secured access name is not specified",
+			args:           []string{},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Timeout: time.Minute},
+			expectedErrors: []string{"secured access name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Timeout: time.Minute},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "selector and ports are not specified",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"selector must be configured", "at least one port must be configured"},
+		},
+		{
+			name:           "selector is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app", Ports: []string{"api:8443"}, Timeout: time.Minute},
+			expectedErrors: []string{"selector is not valid: invalid selector: [app]"},
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"8443"}, Timeout: time.Minute},
+			expectedErrors: []string{"ports are not valid: port 8443 is not valid: expected name:port[:targetPort]"},
+		},
+		{
+			name:           "target port is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443:0"}, Timeout: time.Minute},
+			expectedErrors: []string{"ports are not valid: target port api:8443:0 is not valid: value 0 is out of range"},
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, AccessType: "not-valid", Timeout: time.Minute},
+			expectedErrors: []string{"access type is not valid: value not-valid not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]"},
+		},
+		{
+			name:           "certificate is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Certificate: "my cert", Timeout: time.Minute},
+			expectedErrors: []string{"certificate is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Timeout: 0},
+			expectedErrors: []string{"timeout is not valid: duration must not be less than 10s; got 0s"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Timeout: time.Minute, Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: common.CommandSecuredAccessCreateFlags{
+				Selector:    "app=backend,tier=api",
+				Ports:       []string{"api:8443:8080", "metrics:9090"},
+				AccessType:  "route",
+				Certificate: "my-cert",
+				Issuer:      "skupper-site-ca",
+				Timeout:     time.Minute,
+				Output:      "yaml",
+				Wait:        "ready",
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdSecuredAccessCreateWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdSecuredAccessCreate_Run(t *testing.T) {
+	type test struct {
+		name                string
+		output              string
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:                "creation fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdSecuredAccessCreateWithMocks("test", nil, nil, test.skupperErrorMessage)
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.selector = map[string]string{"app": "backend"}
+		cmd.ports = []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}}
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdSecuredAccessCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:   "secured access is not configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "secured access is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:   "secured access is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.SecuredAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "output yaml is not waited for",
+			output:      "yaml",
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdSecuredAccessCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.output = test.output
+		cmd.status = test.status
+		cmd.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSecuredAccessCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdSecuredAccessCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdSecuredAccessCreate := &CmdSecuredAccessCreate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdSecuredAccessCreate, nil
+}
@@ -0,0 +1,157 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdSecuredAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandSecuredAccessStatusFlags
+		skupperObjects []runtime.Object
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "secured access does not exist",
+			args:           []string{"my-access"},
+			expectedErrors: []string{"secured access my-access does not exist in namespace test"},
+		},
+		{
+			name: "output format is not valid",
+			args: []string{"my-access"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			flags:          common.CommandSecuredAccessStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "all secured accesses",
+			args:           []string{},
+			flags:          common.CommandSecuredAccessStatusFlags{Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdSecuredAccessStatusWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdSecuredAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		securedAccess  string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	securedAccesses := []runtime.Object{
+		&v2alpha1.SecuredAccess{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-access",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.SecuredAccessSpec{
+				Selector: map[string]string{"app": "backend"},
+				Ports:    []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, TargetPort: 8080, Protocol: "TCP"}},
+			},
+			Status: v2alpha1.SecuredAccessStatus{
+				Status: v2alpha1.Status{StatusType: "Ready"},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "all secured accesses",
+			skupperObjects: securedAccesses,
+		},
+		{
+			name:           "all secured accesses yaml",
+			output:         "yaml",
+			skupperObjects: securedAccesses,
+		},
+		{
+			name:           "one secured access",
+			securedAccess:  "my-access",
+			skupperObjects: securedAccesses,
+		},
+		{
+			name:           "one secured access json",
+			securedAccess:  "my-access",
+			output:         "json",
+			skupperObjects: securedAccesses,
+		},
+		{
+			name:          "secured access does not exist",
+			securedAccess: "my-access",
+			errorMessage:  "securedaccesses.skupper.io \"my-access\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdSecuredAccessStatusWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = test.securedAccess
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSecuredAccessStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdSecuredAccessStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdSecuredAccessStatus := &CmdSecuredAccessStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdSecuredAccessStatus, nil
+}
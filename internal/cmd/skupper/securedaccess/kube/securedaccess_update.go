@@ -0,0 +1,254 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SecuredAccessUpdates struct {
+	selector    map[string]string
+	ports       []v2alpha1.SecuredAccessPort
+	accessType  string
+	certificate string
+	issuer      string
+	options     map[string]string
+	settings    map[string]string
+	timeout     time.Duration
+	output      string
+}
+
+type CmdSecuredAccessUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandSecuredAccessUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     SecuredAccessUpdates
+	status          string
+}
+
+func NewCmdSecuredAccessUpdate() *CmdSecuredAccessUpdate {
+
+	return &CmdSecuredAccessUpdate{}
+}
+
+func (cmd *CmdSecuredAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdSecuredAccessUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	accessTypeValidator := validator.NewOptionValidator(common.AccessTypes)
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a secured access with this name in the namespace
+	if cmd.name != "" {
+		securedAccess, err := cmd.client.SecuredAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if securedAccess == nil || errors.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			cmd.resourceVersion = securedAccess.ResourceVersion
+			cmd.newSettings.selector = securedAccess.Spec.Selector
+			cmd.newSettings.ports = securedAccess.Spec.Ports
+			cmd.newSettings.accessType = securedAccess.Spec.AccessType
+			cmd.newSettings.certificate = securedAccess.Spec.Certificate
+			cmd.newSettings.issuer = securedAccess.Spec.Issuer
+			cmd.newSettings.options = securedAccess.Spec.Options
+			cmd.newSettings.settings = securedAccess.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		selector, err := utils.ParseSelector(cmd.Flags.Selector)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		} else {
+			cmd.newSettings.selector = selector
+		}
+	}
+	if cmd.Flags != nil && len(cmd.Flags.Ports) > 0 {
+		ports, err := utils.ParseSecuredAccessPorts(cmd.Flags.Ports)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("ports are not valid: %s", err))
+		} else {
+			cmd.newSettings.ports = ports
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := accessTypeValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		} else {
+			cmd.newSettings.accessType = cmd.Flags.AccessType
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Certificate != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Certificate)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate is not valid: %s", err))
+		} else {
+			cmd.newSettings.certificate = cmd.Flags.Certificate
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		} else {
+			cmd.newSettings.issuer = cmd.Flags.Issuer
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSecuredAccessUpdate) InputToOptions() {
+	cmd.newSettings.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdSecuredAccessUpdate) Run() error {
+
+	resource := v2alpha1.SecuredAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "SecuredAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion,
+		},
+		Spec: v2alpha1.SecuredAccessSpec{
+			AccessType:  cmd.newSettings.accessType,
+			Selector:    cmd.newSettings.selector,
+			Ports:       cmd.newSettings.ports,
+			Certificate: cmd.newSettings.certificate,
+			Issuer:      cmd.newSettings.issuer,
+			Options:     cmd.newSettings.options,
+			Settings:    cmd.newSettings.settings,
+		},
+	}
+
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, resource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		_, err := cmd.client.SecuredAccesses(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (cmd *CmdSecuredAccessUpdate) WaitUntil() error {
+
+	// the secured access resource was not updated
+	if cmd.newSettings.output != "" {
+		return nil
+	}
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.newSettings.timeout.Seconds())
+	var securedAccessCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.SecuredAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			securedAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			securedAccessCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if securedAccessCondition != nil {
+			isConditionFound = true
+			isConditionTrue = securedAccessCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && securedAccessCondition == nil {
+		return fmt.Errorf("SecuredAccess %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && securedAccessCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("SecuredAccess %q is not yet %s: %s\n", cmd.name, cmd.status, securedAccessCondition.Message)
+	}
+
+	fmt.Printf("SecuredAccess %q is updated\n", cmd.name)
+	return nil
+}
@@ -0,0 +1,233 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdSecuredAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name             string
+		args             []string
+		flags            common.CommandSecuredAccessUpdateFlags
+		skupperObjects   []runtime.Object
+		expectedErrors   []string
+		expectedSelector map[string]string
+		expectedPorts    []v2alpha1.SecuredAccessPort
+	}
+
+	existing := []runtime.Object{
+		&v2alpha1.SecuredAccess{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-access",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.SecuredAccessSpec{
+				Selector: map[string]string{"app": "backend"},
+				Ports:    []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "secured access does not exist",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"secured access my-access must exist in namespace test to be updated"},
+		},
+		{
+			name:           "secured access name is not specified",
+			args:           []string{},
+			flags:          common.CommandSecuredAccessUpdateFlags{Timeout: time.Minute},
+			expectedErrors: []string{"secured access name must be configured"},
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessUpdateFlags{Ports: []string{"api:abc"}, Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"ports are not valid: port api:abc is not valid: strconv.Atoi: parsing \"abc\": invalid syntax"},
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandSecuredAccessUpdateFlags{AccessType: "not-valid", Timeout: time.Minute},
+			skupperObjects: existing,
+			expectedErrors: []string{"access type is not valid: value not-valid not allowed. It should be one of this options: [local loadbalancer route nodeport ingress-nginx contour-http-proxy gateway]"},
+		},
+		{
+			name:             "existing values are kept",
+			args:             []string{"my-access"},
+			flags:            common.CommandSecuredAccessUpdateFlags{Timeout: time.Minute},
+			skupperObjects:   existing,
+			expectedErrors:   []string{},
+			expectedSelector: map[string]string{"app": "backend"},
+			expectedPorts:    []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}},
+		},
+		{
+			name:             "flags override existing values",
+			args:             []string{"my-access"},
+			flags:            common.CommandSecuredAccessUpdateFlags{Selector: "app=frontend", Ports: []string{"web:443:8080"}, Timeout: time.Minute},
+			skupperObjects:   existing,
+			expectedErrors:   []string{},
+			expectedSelector: map[string]string{"app": "frontend"},
+			expectedPorts:    []v2alpha1.SecuredAccessPort{{Name: "web", Port: 443, TargetPort: 8080, Protocol: "TCP"}},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdSecuredAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+			if test.expectedPorts != nil {
+				assert.DeepEqual(t, command.newSettings.selector, test.expectedSelector)
+				assert.DeepEqual(t, command.newSettings.ports, test.expectedPorts)
+			}
+		})
+	}
+}
+
+func TestCmdSecuredAccessUpdate_Run(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name:   "output yaml",
+			output: "yaml",
+		},
+		{
+			name:         "secured access does not exist",
+			errorMessage: "securedaccesses.skupper.io \"my-access\" not found",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdSecuredAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.newSettings.ports = []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}}
+		cmd.newSettings.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && test.errorMessage == err.Error())
+			} else {
+				assert.Check(t, err == nil)
+			}
+		})
+	}
+}
+
+func TestCmdSecuredAccessUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "secured access is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:   "secured access is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.SecuredAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.SecuredAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Configured",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdSecuredAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.name = "my-access"
+		cmd.status = test.status
+		cmd.newSettings.timeout = time.Second
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.WaitUntil()
+			if err != nil {
+				assert.Check(t, test.expectError)
+			} else {
+				assert.Check(t, !test.expectError)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSecuredAccessUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdSecuredAccessUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdSecuredAccessUpdate := &CmdSecuredAccessUpdate{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdSecuredAccessUpdate, nil
+}
@@ -0,0 +1,174 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdSecuredAccessCreate struct {
+	securedAccessHandler *fs.SecuredAccessHandler
+	CobraCmd             *cobra.Command
+	Flags                *common.CommandSecuredAccessCreateFlags
+	namespace            string
+	securedAccessName    string
+	selector             map[string]string
+	ports                []v2alpha1.SecuredAccessPort
+	certificate          string
+	issuer               string
+	output               string
+}
+
+func NewCmdSecuredAccessCreate() *CmdSecuredAccessCreate {
+	return &CmdSecuredAccessCreate{}
+}
+
+func (cmd *CmdSecuredAccessCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.securedAccessHandler = fs.NewSecuredAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdSecuredAccessCreate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access name is not valid: %s", err))
+		} else {
+			cmd.securedAccessName = args[0]
+		}
+	}
+
+	// Validate if there is already a secured access with this name
+	if cmd.securedAccessName != "" {
+		securedAccess, err := cmd.securedAccessHandler.Get(cmd.securedAccessName, opts)
+		if securedAccess != nil && err == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already a secured access %s created for namespace %s", cmd.securedAccessName, cmd.getNamespace()))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags.Selector == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("selector must be configured"))
+	} else {
+		selector, err := utils.ParseSelector(cmd.Flags.Selector)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		} else {
+			cmd.selector = selector
+		}
+	}
+
+	if len(cmd.Flags.Ports) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("at least one port must be configured"))
+	} else {
+		ports, err := utils.ParseSecuredAccessPorts(cmd.Flags.Ports)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("ports are not valid: %s", err))
+		} else {
+			cmd.ports = ports
+		}
+	}
+
+	if cmd.Flags.Certificate != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Certificate)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSecuredAccessCreate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	cmd.certificate = cmd.Flags.Certificate
+	cmd.issuer = cmd.Flags.Issuer
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdSecuredAccessCreate) Run() error {
+	securedAccessResource := v2alpha1.SecuredAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "SecuredAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.securedAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.SecuredAccessSpec{
+			Selector:    cmd.selector,
+			Ports:       cmd.ports,
+			Certificate: cmd.certificate,
+			Issuer:      cmd.issuer,
+		},
+	}
+
+	if cmd.output != "" {
+		encodedOutput, err := utils.Encode(cmd.output, securedAccessResource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		err := cmd.securedAccessHandler.Add(securedAccessResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdSecuredAccessCreate) WaitUntil() error { return nil }
+
+func (cmd *CmdSecuredAccessCreate) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
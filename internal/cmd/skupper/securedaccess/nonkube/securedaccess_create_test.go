@@ -0,0 +1,134 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNonKubeCmdSecuredAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandSecuredAccessCreateFlags
+		existing          bool
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "secured access name is not specified",
+			args:           []string{},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}},
+			expectedErrors: []string{"secured access name must be configured"},
+		},
+		{
+			name:           "secured access already exists",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}},
+			existing:       true,
+			expectedErrors: []string{"there is already a secured access my-access created for namespace default"},
+		},
+		{
+			name:           "selector and ports are not specified",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{},
+			expectedErrors: []string{"selector must be configured", "at least one port must be configured"},
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:99999"}},
+			expectedErrors: []string{"ports are not valid: port api:99999 is not valid: value 99999 is out of range"},
+		},
+		{
+			name:           "issuer is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}, Issuer: "my_ca"},
+			expectedErrors: []string{"issuer is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443:8080"}, Certificate: "my-cert", Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdSecuredAccessCreate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+
+			if test.existing {
+				assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdSecuredAccessCreate_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdSecuredAccessCreate{Flags: &common.CommandSecuredAccessCreateFlags{Selector: "app=backend", Ports: []string{"api:8443"}}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"my-access"})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	securedAccess, err := command.securedAccessHandler.Get("my-access", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, securedAccess.Namespace, "default")
+	assert.DeepEqual(t, securedAccess.Spec.Selector, map[string]string{"app": "backend"})
+	assert.DeepEqual(t, securedAccess.Spec.Ports, []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}})
+}
+
+// --- helper methods
+
+func newSecuredAccess(name string) v2alpha1.SecuredAccess {
+	return v2alpha1.SecuredAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "SecuredAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v2alpha1.SecuredAccessSpec{
+			Selector: map[string]string{"app": "backend"},
+			Ports:    []v2alpha1.SecuredAccessPort{{Name: "api", Port: 8443, Protocol: "TCP"}},
+		},
+	}
+}
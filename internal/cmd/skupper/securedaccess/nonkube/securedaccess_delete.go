@@ -0,0 +1,87 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdSecuredAccessDelete struct {
+	securedAccessHandler *fs.SecuredAccessHandler
+	CobraCmd             *cobra.Command
+	Flags                *common.CommandSecuredAccessDeleteFlags
+	namespace            string
+	securedAccessName    string
+}
+
+func NewCmdSecuredAccessDelete() *CmdSecuredAccessDelete {
+	return &CmdSecuredAccessDelete{}
+}
+
+func (cmd *CmdSecuredAccessDelete) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.securedAccessHandler = fs.NewSecuredAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdSecuredAccessDelete) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access name is not valid: %s", err))
+		} else {
+			cmd.securedAccessName = args[0]
+		}
+	}
+
+	if cmd.securedAccessName != "" {
+		// Validate that there is already a secured access with this name
+		securedAccess, err := cmd.securedAccessHandler.Get(cmd.securedAccessName, opts)
+		if securedAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access %s does not exist", cmd.securedAccessName))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSecuredAccessDelete) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdSecuredAccessDelete) Run() error {
+	err := cmd.securedAccessHandler.Delete(cmd.securedAccessName)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdSecuredAccessDelete) WaitUntil() error { return nil }
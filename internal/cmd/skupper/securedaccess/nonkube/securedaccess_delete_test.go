@@ -0,0 +1,86 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdSecuredAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "secured access name is not specified",
+			args:           []string{},
+			expectedErrors: []string{"secured access name must be specified"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "secured access name is empty",
+			args:           []string{""},
+			expectedErrors: []string{"secured access name must not be empty"},
+		},
+		{
+			name:           "secured access does not exist",
+			args:           []string{"other"},
+			expectedErrors: []string{"secured access other does not exist"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"my-access"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdSecuredAccessDelete{Flags: &common.CommandSecuredAccessDeleteFlags{}}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+			assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdSecuredAccessDelete_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdSecuredAccessDelete{}
+	command.securedAccessName = "my-access"
+	command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+	assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	_, err := command.securedAccessHandler.Get("my-access", fs.GetOptions{})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,143 @@
+package nonkube
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdSecuredAccessStatus struct {
+	securedAccessHandler *fs.SecuredAccessHandler
+	CobraCmd             *cobra.Command
+	Flags                *common.CommandSecuredAccessStatusFlags
+	namespace            string
+	securedAccessName    string
+	output               string
+}
+
+func NewCmdSecuredAccessStatus() *CmdSecuredAccessStatus {
+	return &CmdSecuredAccessStatus{}
+}
+
+func (cmd *CmdSecuredAccessStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.securedAccessHandler = fs.NewSecuredAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdSecuredAccessStatus) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("secured access name is not valid: %s", err))
+			} else {
+				cmd.securedAccessName = args[0]
+			}
+		}
+	}
+	// Validate that there is a secured access with this name in the namespace
+	if cmd.securedAccessName != "" {
+		securedAccess, err := cmd.securedAccessHandler.Get(cmd.securedAccessName, opts)
+		if securedAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access %s does not exist in namespace %s", cmd.securedAccessName, cmd.getNamespace()))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSecuredAccessStatus) Run() error {
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: true}
+	if cmd.securedAccessName == "" {
+		securedAccesses, err := cmd.securedAccessHandler.List()
+		if securedAccesses == nil || err != nil {
+			fmt.Println("No secured accesses found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, securedAccess := range securedAccesses {
+				encodedOutput, err := utils.Encode(cmd.output, securedAccess)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "SELECTOR", "PORTS", "ENDPOINTS"))
+			for _, securedAccess := range securedAccesses {
+				status := "Not Ready"
+				if securedAccess.IsReady() {
+					status = "Ok"
+				}
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s",
+					securedAccess.Name, status, utils.FormatSelector(securedAccess.Spec.Selector),
+					utils.FormatSecuredAccessPorts(securedAccess.Spec.Ports), utils.FormatEndpoints(securedAccess.Status.Endpoints)))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		securedAccess, err := cmd.securedAccessHandler.Get(cmd.securedAccessName, opts)
+		if securedAccess == nil || err != nil {
+			fmt.Println("No secured accesses found:", err)
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, securedAccess)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			status := "Not Ready"
+			if securedAccess.IsReady() {
+				status = "Ok"
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nSelector:\t%s\nPorts:\t%s\nCertificate:\t%s\nIssuer:\t%s\nEndpoints:\t%s\n",
+				securedAccess.Name, status, utils.FormatSelector(securedAccess.Spec.Selector), utils.FormatSecuredAccessPorts(securedAccess.Spec.Ports),
+				securedAccess.Spec.Certificate, securedAccess.Spec.Issuer, utils.FormatEndpoints(securedAccess.Status.Endpoints)))
+			_ = tw.Flush()
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdSecuredAccessStatus) InputToOptions()  {}
+func (cmd *CmdSecuredAccessStatus) WaitUntil() error { return nil }
+
+func (cmd *CmdSecuredAccessStatus) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
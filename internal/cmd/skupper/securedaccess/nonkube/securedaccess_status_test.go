@@ -0,0 +1,118 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdSecuredAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          *common.CommandSecuredAccessStatusFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"my-access", "other"},
+			flags:          &common.CommandSecuredAccessStatusFlags{},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "secured access does not exist",
+			args:           []string{"other"},
+			flags:          &common.CommandSecuredAccessStatusFlags{},
+			expectedErrors: []string{"secured access other does not exist in namespace default"},
+		},
+		{
+			name:           "output format is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessStatusFlags{Output: "not-valid"},
+			expectedErrors: []string{"output type is not valid: value not-valid not allowed. It should be one of this options: [json yaml]"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessStatusFlags{Output: "json"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdSecuredAccessStatus{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+			assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdSecuredAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name              string
+		securedAccessName string
+		output            string
+		withoutExisting   bool
+		expectError       bool
+	}
+
+	testTable := []test{
+		{
+			name: "all secured accesses",
+		},
+		{
+			name:   "all secured accesses yaml",
+			output: "yaml",
+		},
+		{
+			name:              "one secured access",
+			securedAccessName: "my-access",
+		},
+		{
+			name:              "one secured access json",
+			securedAccessName: "my-access",
+			output:            "json",
+		},
+		{
+			name:              "secured access does not exist",
+			securedAccessName: "my-access",
+			withoutExisting:   true,
+			expectError:       true,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdSecuredAccessStatus{}
+			command.securedAccessName = test.securedAccessName
+			command.output = test.output
+			command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+			if !test.withoutExisting {
+				assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+			}
+
+			err := command.Run()
+			if test.expectError {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
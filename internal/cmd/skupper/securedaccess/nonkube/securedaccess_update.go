@@ -0,0 +1,182 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SecuredAccessUpdates struct {
+	selector    map[string]string
+	ports       []v2alpha1.SecuredAccessPort
+	certificate string
+	issuer      string
+	options     map[string]string
+	settings    map[string]string
+	output      string
+}
+
+type CmdSecuredAccessUpdate struct {
+	securedAccessHandler *fs.SecuredAccessHandler
+	CobraCmd             *cobra.Command
+	Flags                *common.CommandSecuredAccessUpdateFlags
+	namespace            string
+	securedAccessName    string
+	newSettings          SecuredAccessUpdates
+}
+
+func NewCmdSecuredAccessUpdate() *CmdSecuredAccessUpdate {
+	return &CmdSecuredAccessUpdate{}
+}
+
+func (cmd *CmdSecuredAccessUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.securedAccessHandler = fs.NewSecuredAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdSecuredAccessUpdate) ValidateInput(args []string) []error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("secured access name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access name is not valid: %s", err))
+		} else {
+			cmd.securedAccessName = args[0]
+		}
+	}
+
+	// Validate that there is already a secured access with this name in the namespace
+	if cmd.securedAccessName != "" {
+		securedAccess, err := cmd.securedAccessHandler.Get(cmd.securedAccessName, opts)
+		if securedAccess == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("secured access %s must exist in namespace %s to be updated", cmd.securedAccessName, cmd.getNamespace()))
+		} else {
+			// save existing values
+			cmd.newSettings.selector = securedAccess.Spec.Selector
+			cmd.newSettings.ports = securedAccess.Spec.Ports
+			cmd.newSettings.certificate = securedAccess.Spec.Certificate
+			cmd.newSettings.issuer = securedAccess.Spec.Issuer
+			cmd.newSettings.options = securedAccess.Spec.Options
+			cmd.newSettings.settings = securedAccess.Spec.Settings
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags.Selector != "" {
+		selector, err := utils.ParseSelector(cmd.Flags.Selector)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		} else {
+			cmd.newSettings.selector = selector
+		}
+	}
+	if len(cmd.Flags.Ports) > 0 {
+		ports, err := utils.ParseSecuredAccessPorts(cmd.Flags.Ports)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("ports are not valid: %s", err))
+		} else {
+			cmd.newSettings.ports = ports
+		}
+	}
+	if cmd.Flags.Certificate != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Certificate)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("certificate is not valid: %s", err))
+		} else {
+			cmd.newSettings.certificate = cmd.Flags.Certificate
+		}
+	}
+	if cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		} else {
+			cmd.newSettings.issuer = cmd.Flags.Issuer
+		}
+	}
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.newSettings.output = cmd.Flags.Output
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSecuredAccessUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdSecuredAccessUpdate) Run() error {
+	securedAccessResource := v2alpha1.SecuredAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "SecuredAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.securedAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.SecuredAccessSpec{
+			Selector:    cmd.newSettings.selector,
+			Ports:       cmd.newSettings.ports,
+			Certificate: cmd.newSettings.certificate,
+			Issuer:      cmd.newSettings.issuer,
+			Options:     cmd.newSettings.options,
+			Settings:    cmd.newSettings.settings,
+		},
+	}
+	if cmd.newSettings.output != "" {
+		encodedOutput, err := utils.Encode(cmd.newSettings.output, securedAccessResource)
+		fmt.Println(encodedOutput)
+		return err
+	} else {
+		err := cmd.securedAccessHandler.Add(securedAccessResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdSecuredAccessUpdate) WaitUntil() error { return nil }
+
+func (cmd *CmdSecuredAccessUpdate) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
+}
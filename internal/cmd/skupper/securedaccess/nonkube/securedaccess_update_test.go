@@ -0,0 +1,87 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdSecuredAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name            string
+		args            []string
+		flags           *common.CommandSecuredAccessUpdateFlags
+		withoutExisting bool
+		expectedErrors  []string
+	}
+
+	testTable := []test{
+		{
+			name:           "secured access name is not specified",
+			args:           []string{},
+			flags:          &common.CommandSecuredAccessUpdateFlags{},
+			expectedErrors: []string{"secured access name must be configured"},
+		},
+		{
+			name:            "secured access does not exist",
+			args:            []string{"my-access"},
+			flags:           &common.CommandSecuredAccessUpdateFlags{},
+			withoutExisting: true,
+			expectedErrors:  []string{"secured access my-access must exist in namespace default to be updated"},
+		},
+		{
+			name:           "selector is not valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessUpdateFlags{Selector: "app"},
+			expectedErrors: []string{"selector is not valid: invalid selector: [app]"},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"my-access"},
+			flags:          &common.CommandSecuredAccessUpdateFlags{Ports: []string{"web:443"}, Output: "yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := &CmdSecuredAccessUpdate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+
+			if !test.withoutExisting {
+				assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestNonKubeCmdSecuredAccessUpdate_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	command := &CmdSecuredAccessUpdate{Flags: &common.CommandSecuredAccessUpdateFlags{Ports: []string{"web:443:8080"}}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.securedAccessHandler = fs.NewSecuredAccessHandler("")
+	assert.Assert(t, command.securedAccessHandler.Add(newSecuredAccess("my-access")))
+
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{"my-access"})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+	assert.Assert(t, command.WaitUntil())
+
+	securedAccess, err := command.securedAccessHandler.Get("my-access", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, securedAccess.Spec.Selector, map[string]string{"app": "backend"})
+	assert.DeepEqual(t, securedAccess.Spec.Ports, []v2alpha1.SecuredAccessPort{{Name: "web", Port: 443, TargetPort: 8080, Protocol: "TCP"}})
+}
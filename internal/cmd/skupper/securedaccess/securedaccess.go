@@ -0,0 +1,163 @@
+package securedaccess
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/securedaccess/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/securedaccess/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdSecuredAccess() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "secured-access",
+		Short: "Exposes selected workloads of the local site through TLS secured ports.",
+		Long:  `A secured access exposes the pods matching a selector outside the site, using a certificate to secure the connections`,
+		Example: `skupper secured-access create my-access --selector app=backend --ports api:8443
+skupper secured-access status my-access`,
+	}
+
+	cmd.AddCommand(CmdSecuredAccessCreateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSecuredAccessStatusFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSecuredAccessUpdateFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSecuredAccessDeleteFactory(config.GetPlatform()))
+
+	return cmd
+}
+
+func CmdSecuredAccessCreateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSecuredAccessCreate()
+	nonKubeCommand := nonkube.NewCmdSecuredAccessCreate()
+
+	cmdSecuredAccessCreateDesc := common.SkupperCmdDescription{
+		Use:     "create <name>",
+		Short:   "create a secured access",
+		Long:    "Expose the workloads matching the selector on the given ports, secured by a certificate signed by the issuer.",
+		Example: "skupper secured-access create my-access --selector app=backend --ports api:8443:8080",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSecuredAccessCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSecuredAccessCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().StringSliceVar(&cmdFlags.Ports, common.FlagNamePorts, []string{}, common.FlagDescPorts)
+	cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+	cmd.Flags().StringVar(&cmdFlags.Certificate, common.FlagNameCertificate, "", common.FlagDescCertificate)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameAccessType)
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdSecuredAccessUpdateFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSecuredAccessUpdate()
+	nonKubeCommand := nonkube.NewCmdSecuredAccessUpdate()
+
+	cmdSecuredAccessUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a secured access",
+		Long: `Change the way selected workloads are exposed.
+	The user can change selector, ports, access type, certificate and issuer`,
+		Example: "skupper secured-access update my-access --ports api:9443",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSecuredAccessUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSecuredAccessUpdateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().StringSliceVar(&cmdFlags.Ports, common.FlagNamePorts, []string{}, common.FlagDescPorts)
+	cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+	cmd.Flags().StringVar(&cmdFlags.Certificate, common.FlagNameCertificate, "", common.FlagDescCertificate)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameAccessType)
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
+
+func CmdSecuredAccessStatusFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSecuredAccessStatus()
+	nonKubeCommand := nonkube.NewCmdSecuredAccessStatus()
+
+	cmdSecuredAccessStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of secured accesses",
+		Long:    "Display status of all secured accesses or a specific secured access",
+		Example: "skupper secured-access status my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSecuredAccessStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSecuredAccessStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdSecuredAccessDeleteFactory(configuredPlatform types.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdSecuredAccessDelete()
+	nonKubeCommand := nonkube.NewCmdSecuredAccessDelete()
+
+	cmdSecuredAccessDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a secured access",
+		Long:    "Delete a secured access <name>",
+		Example: "skupper secured-access delete my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSecuredAccessDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSecuredAccessDeleteFlags{}
+
+	cmd.Flags().DurationVarP(&cmdFlags.Timeout, common.FlagNameTimeout, "t", 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	if configuredPlatform != types.PlatformKubernetes {
+		cmd.Flags().MarkHidden(common.FlagNameTimeout)
+		cmd.Flags().MarkHidden(common.FlagNameWait)
+	}
+
+	return cmd
+}
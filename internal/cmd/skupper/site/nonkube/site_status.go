@@ -8,17 +8,19 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
 	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
 )
 
 type CmdSiteStatus struct {
-	siteHandler *fs.SiteHandler
-	CobraCmd    *cobra.Command
-	Flags       *common.CommandSiteStatusFlags
-	namespace   string
-	siteName    string
-	output      string
+	siteHandler  *fs.SiteHandler
+	CobraCmd     *cobra.Command
+	Flags        *common.CommandSiteStatusFlags
+	namespace    string
+	siteName     string
+	output       string
+	routerStatus func(namespace string) *router.Status
 }
 
 func NewCmdSiteStatus() *CmdSiteStatus {
@@ -31,6 +33,7 @@ func (cmd *CmdSiteStatus) NewClient(cobraCommand *cobra.Command, args []string)
 	}
 
 	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+	cmd.routerStatus = router.GetStatus
 }
 
 func (cmd *CmdSiteStatus) ValidateInput(args []string) []error {
@@ -80,6 +83,12 @@ func (cmd *CmdSiteStatus) Run() error {
 		fmt.Println("no site found:")
 		return err
 	}
+	if cmd.routerStatus != nil {
+		status := cmd.routerStatus(cmd.namespace)
+		for _, site := range sites {
+			status.UpdateSite(site)
+		}
+	}
 
 	if cmd.output != "" {
 		for _, site := range sites {
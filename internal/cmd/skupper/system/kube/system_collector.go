@@ -0,0 +1,31 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type CmdSystemCollector struct {
+	CobraCmd *cobra.Command
+}
+
+func NewCmdSystemCollector() *CmdSystemCollector {
+
+	skupperCmd := CmdSystemCollector{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemCollector) NewClient(cobraCommand *cobra.Command, args []string) {}
+
+func (cmd *CmdSystemCollector) ValidateInput(args []string) []error { return nil }
+
+func (cmd *CmdSystemCollector) InputToOptions() {}
+
+func (cmd *CmdSystemCollector) Run() error {
+	fmt.Println("This command does not support kubernetes platforms.")
+	return nil
+}
+
+func (cmd *CmdSystemCollector) WaitUntil() error { return nil }
@@ -0,0 +1,31 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type CmdSystemController struct {
+	CobraCmd *cobra.Command
+}
+
+func NewCmdSystemController() *CmdSystemController {
+
+	skupperCmd := CmdSystemController{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemController) NewClient(cobraCommand *cobra.Command, args []string) {}
+
+func (cmd *CmdSystemController) ValidateInput(args []string) []error { return nil }
+
+func (cmd *CmdSystemController) InputToOptions() {}
+
+func (cmd *CmdSystemController) Run() error {
+	fmt.Println("This command does not support kubernetes platforms.")
+	return nil
+}
+
+func (cmd *CmdSystemController) WaitUntil() error { return nil }
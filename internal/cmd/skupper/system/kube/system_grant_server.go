@@ -0,0 +1,33 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdSystemGrantServer struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandSystemGrantServerFlags
+}
+
+func NewCmdSystemGrantServer() *CmdSystemGrantServer {
+
+	skupperCmd := CmdSystemGrantServer{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemGrantServer) NewClient(cobraCommand *cobra.Command, args []string) {}
+
+func (cmd *CmdSystemGrantServer) ValidateInput(args []string) []error { return nil }
+
+func (cmd *CmdSystemGrantServer) InputToOptions() {}
+
+func (cmd *CmdSystemGrantServer) Run() error {
+	fmt.Println("This command does not support kubernetes platforms.")
+	return nil
+}
+
+func (cmd *CmdSystemGrantServer) WaitUntil() error { return nil }
@@ -0,0 +1,66 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/collector"
+	"github.com/spf13/cobra"
+)
+
+type NetworkStatusCollector interface {
+	Run(ctx context.Context) error
+}
+
+type CmdSystemCollector struct {
+	CobraCmd     *cobra.Command
+	Namespace    string
+	NewCollector func(namespace string) NetworkStatusCollector
+}
+
+func NewCmdSystemCollector() *CmdSystemCollector {
+
+	skupperCmd := CmdSystemCollector{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemCollector) NewClient(cobraCommand *cobra.Command, args []string) {
+	cmd.Namespace = cobraCommand.Flag(common.FlagNameNamespace).Value.String()
+	cmd.NewCollector = func(namespace string) NetworkStatusCollector {
+		return collector.NewCollector(namespace)
+	}
+}
+
+func (cmd *CmdSystemCollector) ValidateInput(args []string) []error {
+	var validationErrors []error
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not accept arguments"))
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSystemCollector) InputToOptions() {
+	if cmd.Namespace == "" {
+		cmd.Namespace = "default"
+	}
+}
+
+func (cmd *CmdSystemCollector) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	networkStatusCollector := cmd.NewCollector(cmd.Namespace)
+	if err := networkStatusCollector.Run(ctx); err != nil {
+		return fmt.Errorf("collector failed: %s", err)
+	}
+	return nil
+}
+
+func (cmd *CmdSystemCollector) WaitUntil() error { return nil }
@@ -0,0 +1,131 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdSystemCollector_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arg-not-accepted",
+			args:           []string{"namespace"},
+			expectedErrors: []string{"this command does not accept arguments"},
+		},
+		{
+			name:           "no-args",
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command := &CmdSystemCollector{}
+			command.CobraCmd = common.ConfigureCobraCommand(types.PlatformSystemd, common.SkupperCmdDescription{}, command, nil)
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+
+		})
+	}
+}
+
+func TestCmdSystemCollector_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name              string
+		namespace         string
+		expectedNamespace string
+	}
+
+	testTable := []test{
+		{
+			name:              "options-by-default",
+			expectedNamespace: "default",
+		},
+		{
+			name:              "options-provided",
+			namespace:         "east",
+			expectedNamespace: "east",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := newCmdSystemCollectorWithMocks(nil)
+			cmd.Namespace = test.namespace
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.Namespace == test.expectedNamespace)
+		})
+	}
+}
+
+func TestCmdSystemCollector_Run(t *testing.T) {
+	type test struct {
+		name           string
+		collectorError error
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:           "collector fails",
+			collectorError: fmt.Errorf("fail"),
+			errorMessage:   "collector failed: fail",
+		},
+	}
+
+	for _, test := range testTable {
+		command := newCmdSystemCollectorWithMocks(test.collectorError)
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := command.Run()
+			if err != nil {
+				assert.Check(t, test.errorMessage == err.Error(), err.Error())
+			} else {
+				assert.Check(t, test.errorMessage == "")
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSystemCollectorWithMocks(collectorError error) *CmdSystemCollector {
+
+	cmdMock := &CmdSystemCollector{
+		NewCollector: func(namespace string) NetworkStatusCollector {
+			return &mockCollector{err: collectorError}
+		},
+	}
+
+	return cmdMock
+}
+
+type mockCollector struct {
+	err error
+}
+
+func (m *mockCollector) Run(ctx context.Context) error {
+	return m.err
+}
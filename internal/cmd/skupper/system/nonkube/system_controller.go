@@ -0,0 +1,75 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/skupperproject/skupper/internal/nonkube/controller"
+	"github.com/spf13/cobra"
+)
+
+type SiteController interface {
+	Run(ctx context.Context) error
+}
+
+type CmdSystemController struct {
+	CobraCmd          *cobra.Command
+	Namespace         string
+	Platform          string
+	selectedPlatform  types.Platform
+	NewSiteController func(namespace string, platform types.Platform) SiteController
+}
+
+func NewCmdSystemController() *CmdSystemController {
+
+	skupperCmd := CmdSystemController{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemController) NewClient(cobraCommand *cobra.Command, args []string) {
+	cmd.Namespace = cobraCommand.Flag(common.FlagNameNamespace).Value.String()
+	cmd.Platform = cobraCommand.Flag(common.FlagNamePlatform).Value.String()
+	cmd.NewSiteController = func(namespace string, platform types.Platform) SiteController {
+		return controller.NewController(namespace, string(platform))
+	}
+}
+
+func (cmd *CmdSystemController) ValidateInput(args []string) []error {
+	var validationErrors []error
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not accept arguments"))
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSystemController) InputToOptions() {
+	if cmd.Namespace == "" {
+		cmd.Namespace = "default"
+	}
+	cmd.selectedPlatform = config.GetPlatform()
+	if cmd.Platform != "" {
+		cmd.selectedPlatform = types.Platform(cmd.Platform)
+	}
+}
+
+func (cmd *CmdSystemController) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	siteController := cmd.NewSiteController(cmd.Namespace, cmd.selectedPlatform)
+	if err := siteController.Run(ctx); err != nil {
+		return fmt.Errorf("controller failed: %s", err)
+	}
+	return nil
+}
+
+func (cmd *CmdSystemController) WaitUntil() error { return nil }
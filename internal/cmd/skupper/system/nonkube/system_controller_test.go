@@ -0,0 +1,141 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdSystemController_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arg-not-accepted",
+			args:           []string{"namespace"},
+			expectedErrors: []string{"this command does not accept arguments"},
+		},
+		{
+			name:           "no-args",
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command := &CmdSystemController{}
+			command.CobraCmd = common.ConfigureCobraCommand(types.PlatformSystemd, common.SkupperCmdDescription{}, command, nil)
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+
+		})
+	}
+}
+
+func TestCmdSystemController_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name              string
+		namespace         string
+		platform          string
+		expectedNamespace string
+		expectedPlatform  types.Platform
+	}
+
+	testTable := []test{
+		{
+			name:              "options-by-default",
+			expectedNamespace: "default",
+			expectedPlatform:  types.PlatformPodman,
+		},
+		{
+			name:              "options-provided",
+			namespace:         "east",
+			platform:          "systemd",
+			expectedNamespace: "east",
+			expectedPlatform:  types.PlatformSystemd,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_PLATFORM", "podman")
+
+			cmd := newCmdSystemControllerWithMocks(nil)
+			cmd.Namespace = test.namespace
+			cmd.Platform = test.platform
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.Namespace == test.expectedNamespace)
+			assert.Check(t, cmd.selectedPlatform == test.expectedPlatform)
+
+		})
+	}
+}
+
+func TestCmdSystemController_Run(t *testing.T) {
+	type test struct {
+		name            string
+		controllerError error
+		errorMessage    string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:            "controller fails",
+			controllerError: fmt.Errorf("fail"),
+			errorMessage:    "controller failed: fail",
+		},
+	}
+
+	for _, test := range testTable {
+		command := newCmdSystemControllerWithMocks(test.controllerError)
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := command.Run()
+			if err != nil {
+				assert.Check(t, test.errorMessage == err.Error(), err.Error())
+			} else {
+				assert.Check(t, test.errorMessage == "")
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSystemControllerWithMocks(controllerError error) *CmdSystemController {
+
+	cmdMock := &CmdSystemController{
+		NewSiteController: func(namespace string, platform types.Platform) SiteController {
+			return &mockSiteController{err: controllerError}
+		},
+	}
+
+	return cmdMock
+}
+
+type mockSiteController struct {
+	err error
+}
+
+func (m *mockSiteController) Run(ctx context.Context) error {
+	return m.err
+}
@@ -0,0 +1,89 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type GrantServer interface {
+	ListenAndServe(ctx context.Context) error
+}
+
+type CmdSystemGrantServer struct {
+	CobraCmd       *cobra.Command
+	Flags          *common.CommandSystemGrantServerFlags
+	Namespace      string
+	host           string
+	port           int
+	NewGrantServer func(namespace string, host string, port int) GrantServer
+}
+
+func NewCmdSystemGrantServer() *CmdSystemGrantServer {
+
+	skupperCmd := CmdSystemGrantServer{}
+
+	return &skupperCmd
+}
+
+func (cmd *CmdSystemGrantServer) NewClient(cobraCommand *cobra.Command, args []string) {
+	cmd.Namespace = cobraCommand.Flag(common.FlagNameNamespace).Value.String()
+	cmd.NewGrantServer = func(namespace string, host string, port int) GrantServer {
+		return grants.NewGrantServer(namespace, host, port)
+	}
+}
+
+func (cmd *CmdSystemGrantServer) ValidateInput(args []string) []error {
+	var validationErrors []error
+	numberValidator := validator.NewNumberValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("this command does not accept arguments"))
+	}
+
+	if cmd.Flags != nil {
+		ok, err := numberValidator.Evaluate(cmd.Flags.Port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("port is not valid: %s", err))
+		}
+		if cmd.Flags.Host != "" {
+			ok, _ := hostStringValidator.Evaluate(cmd.Flags.Host)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("host is not valid: a valid IP address or hostname is expected"))
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdSystemGrantServer) InputToOptions() {
+	if cmd.Namespace == "" {
+		cmd.Namespace = "default"
+	}
+	if cmd.Flags != nil {
+		cmd.host = cmd.Flags.Host
+		cmd.port = cmd.Flags.Port
+	}
+}
+
+func (cmd *CmdSystemGrantServer) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := cmd.NewGrantServer(cmd.Namespace, cmd.host, cmd.port)
+	if err := server.ListenAndServe(ctx); err != nil {
+		return fmt.Errorf("grant server failed: %s", err)
+	}
+	return nil
+}
+
+func (cmd *CmdSystemGrantServer) WaitUntil() error { return nil }
@@ -0,0 +1,158 @@
+package nonkube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdSystemGrantServer_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          *common.CommandSystemGrantServerFlags
+		expectedErrors []string
+	}
+
+	testTable := []test{
+		{
+			name:           "arg-not-accepted",
+			args:           []string{"namespace"},
+			flags:          &common.CommandSystemGrantServerFlags{Port: 9090},
+			expectedErrors: []string{"this command does not accept arguments"},
+		},
+		{
+			name:           "port-not-valid",
+			flags:          &common.CommandSystemGrantServerFlags{Port: -1},
+			expectedErrors: []string{"port is not valid: value is not positive"},
+		},
+		{
+			name:           "host-not-valid",
+			flags:          &common.CommandSystemGrantServerFlags{Host: "not-valid$", Port: 9090},
+			expectedErrors: []string{"host is not valid: a valid IP address or hostname is expected"},
+		},
+		{
+			name:           "flags-all-valid",
+			flags:          &common.CommandSystemGrantServerFlags{Host: "10.0.0.1", Port: 9090},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command := &CmdSystemGrantServer{Flags: test.flags}
+			command.CobraCmd = common.ConfigureCobraCommand(types.PlatformSystemd, common.SkupperCmdDescription{}, command, nil)
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+
+		})
+	}
+}
+
+func TestCmdSystemGrantServer_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name              string
+		namespace         string
+		flags             *common.CommandSystemGrantServerFlags
+		expectedNamespace string
+		expectedHost      string
+		expectedPort      int
+	}
+
+	testTable := []test{
+		{
+			name:              "options-by-default",
+			flags:             &common.CommandSystemGrantServerFlags{Port: 9090},
+			expectedNamespace: "default",
+			expectedPort:      9090,
+		},
+		{
+			name:              "options-provided",
+			namespace:         "east",
+			flags:             &common.CommandSystemGrantServerFlags{Host: "10.0.0.1", Port: 8443},
+			expectedNamespace: "east",
+			expectedHost:      "10.0.0.1",
+			expectedPort:      8443,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd := newCmdSystemGrantServerWithMocks(nil)
+			cmd.Namespace = test.namespace
+			cmd.Flags = test.flags
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.Namespace == test.expectedNamespace)
+			assert.Check(t, cmd.host == test.expectedHost)
+			assert.Check(t, cmd.port == test.expectedPort)
+
+		})
+	}
+}
+
+func TestCmdSystemGrantServer_Run(t *testing.T) {
+	type test struct {
+		name         string
+		serverError  error
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+		},
+		{
+			name:         "grant server fails",
+			serverError:  fmt.Errorf("fail"),
+			errorMessage: "grant server failed: fail",
+		},
+	}
+
+	for _, test := range testTable {
+		command := newCmdSystemGrantServerWithMocks(test.serverError)
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := command.Run()
+			if err != nil {
+				assert.Check(t, test.errorMessage == err.Error(), err.Error())
+			} else {
+				assert.Check(t, test.errorMessage == "")
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdSystemGrantServerWithMocks(serverError error) *CmdSystemGrantServer {
+
+	cmdMock := &CmdSystemGrantServer{
+		Flags: &common.CommandSystemGrantServerFlags{},
+		NewGrantServer: func(namespace string, host string, port int) GrantServer {
+			return &mockGrantServer{err: serverError}
+		},
+	}
+
+	return cmdMock
+}
+
+type mockGrantServer struct {
+	err error
+}
+
+func (m *mockGrantServer) ListenAndServe(ctx context.Context) error {
+	return m.err
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system/kube"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system/nonkube"
 	"github.com/skupperproject/skupper/internal/config"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
 
 	"github.com/spf13/cobra"
 )
@@ -58,6 +59,9 @@ approach, which is based on the new set of Custom Resource Definitions (CRDs).`,
 	cmd.AddCommand(CmdSystemStartFactory(config.GetPlatform()))
 	cmd.AddCommand(CmdSystemStopFactory(config.GetPlatform()))
 	cmd.AddCommand(CmdSystemTeardownFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSystemGrantServerFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSystemControllerFactory(config.GetPlatform()))
+	cmd.AddCommand(CmdSystemCollectorFactory(config.GetPlatform()))
 
 	return cmd
 }
@@ -164,3 +168,88 @@ func CmdSystemTeardownFactory(configuredPlatform types.Platform) *cobra.Command
 
 	return cmd
 }
+
+func CmdSystemGrantServerFactory(configuredPlatform types.Platform) *cobra.Command {
+
+	//This implementation will warn the user that the command is not available for Kubernetes environments.
+	kubeCommand := kube.NewCmdSystemGrantServer()
+	nonKubeCommand := nonkube.NewCmdSystemGrantServer()
+
+	cmdSystemGrantServerDesc := common.SkupperCmdDescription{
+		Use:   "grant-server",
+		Short: "Serve the tokens issued for the current site",
+		Long: `Runs the grant server for the current site, until it is interrupted.
+
+Tokens issued through "skupper token issue" are redeemed against this server,
+which must be reachable by the remote sites. It is run alongside the router
+of every site, as a systemd service or a container, so it does not normally
+need to be run by hand.`,
+		Example: "skupper system grant-server --host my-vm.example.com -n my-namespace",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSystemGrantServerDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandSystemGrantServerFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Host, common.FlagNameGrantServerHost, "", common.FlagDescGrantServerHost)
+	cmd.Flags().IntVar(&cmdFlags.Port, common.FlagNameGrantServerPort, grants.DefaultPort, common.FlagDescGrantServerPort)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdSystemControllerFactory(configuredPlatform types.Platform) *cobra.Command {
+
+	//This implementation will warn the user that the command is not available for Kubernetes environments.
+	kubeCommand := kube.NewCmdSystemController()
+	nonKubeCommand := nonkube.NewCmdSystemController()
+
+	cmdSystemControllerDesc := common.SkupperCmdDescription{
+		Use:   "controller",
+		Short: "Apply changes to listeners and connectors without restarting the router",
+		Long: `Runs a controller for the current site, until it is interrupted.
+
+The controller watches the input/resources directory of the namespace and
+applies any change to its listeners and connectors to the running router,
+without dropping the existing connections. Other changes to the site, such
+as links or router accesses, still require "skupper system reload".`,
+		Example: "skupper system controller -n my-namespace",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSystemControllerDesc, kubeCommand, nonKubeCommand)
+
+	kubeCommand.CobraCmd = cmd
+	nonKubeCommand.CobraCmd = cmd
+
+	return cmd
+}
+
+func CmdSystemCollectorFactory(configuredPlatform types.Platform) *cobra.Command {
+
+	//This implementation will warn the user that the command is not available for Kubernetes environments.
+	kubeCommand := kube.NewCmdSystemCollector()
+	nonKubeCommand := nonkube.NewCmdSystemCollector()
+
+	cmdSystemCollectorDesc := common.SkupperCmdDescription{
+		Use:   "collector",
+		Short: "Collect the status of the network into the namespace",
+		Long: `Runs a network status collector for the current site, until it is interrupted.
+
+The collector connects to the local router of the site and keeps the status
+of every site reachable through it in the runtime/network directory of the
+namespace, where the other commands read it from. It is started along with
+the router of the site.`,
+		Example: "skupper system collector -n my-namespace",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdSystemCollectorDesc, kubeCommand, nonKubeCommand)
+
+	kubeCommand.CobraCmd = cmd
+	nonKubeCommand.CobraCmd = cmd
+
+	return cmd
+}
@@ -37,6 +37,14 @@ func TestCmdSystemFactory(t *testing.T) {
 			expectedFlagsWithDefaultValue: map[string]interface{}{},
 			command:                       CmdSystemStopFactory(types.PlatformKubernetes),
 		},
+		{
+			name: "CmdSystemGrantServerFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameGrantServerHost: "",
+				common.FlagNameGrantServerPort: "9090",
+			},
+			command: CmdSystemGrantServerFactory(types.PlatformKubernetes),
+		},
 	}
 
 	for _, test := range testTable {
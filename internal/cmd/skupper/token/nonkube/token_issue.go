@@ -2,15 +2,31 @@ package nonkube
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type CmdTokenIssue struct {
-	CobraCmd  *cobra.Command
-	Flags     *common.CommandTokenIssueFlags
-	Namespace string
-	siteName  string
+	siteHandler        *fs.SiteHandler
+	accessGrantHandler *fs.AccessGrantHandler
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandTokenIssueFlags
+	namespace          string
+	grantName          string
+	fileName           string
+	cost               int
+	serverConfig       *grants.ServerConfig
+	loadServerConfig   func(namespace string) (*grants.ServerConfig, error)
 }
 
 func NewCmdTokenIssue() *CmdTokenIssue {
@@ -18,12 +34,156 @@ func NewCmdTokenIssue() *CmdTokenIssue {
 }
 
 func (cmd *CmdTokenIssue) NewClient(cobraCommand *cobra.Command, args []string) {
-	//TODO
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+	cmd.accessGrantHandler = fs.NewAccessGrantHandler(cmd.namespace)
+	cmd.loadServerConfig = grants.LoadServerConfig
+}
+
+func (cmd *CmdTokenIssue) ValidateInput(args []string) []error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	tokenStringValidator := validator.NewFilePathStringValidator()
+	expirationValidator := validator.NewExpirationInSecondsValidator()
+	numberValidator := validator.NewNumberValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate token file name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must not be empty"))
+	} else {
+		ok, err := tokenStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token file name is not valid: %s", err))
+		} else {
+			cmd.fileName = args[0]
+		}
+	}
+
+	// Validate there is already a site defined in the namespace before a token can be created
+	sites, err := cmd.siteHandler.List()
+	if err != nil || len(sites) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("A site must exist in namespace %s before a token can be created", cmd.getNamespace()))
+	} else if cmd.Flags.Name != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Name)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token name is not valid: %s", err))
+		} else {
+			cmd.grantName = cmd.Flags.Name
+		}
+	} else {
+		cmd.grantName = sites[0].Name + "-" + uuid.New().String()
+	}
+
+	// Validate if we already have a token with this name in the namespace
+	if cmd.grantName != "" {
+		grant, err := cmd.accessGrantHandler.Get(cmd.grantName)
+		if err == nil && grant != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("there is already a token %s created in namespace %s", cmd.grantName, cmd.getNamespace()))
+		}
+	}
+
+	// Tokens can only be redeemed through the grant server
+	serverConfig, err := cmd.loadServerConfig(cmd.namespace)
+	if err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("%s, make sure the site is running", err))
+	} else {
+		cmd.serverConfig = serverConfig
+	}
+
+	// Validate flags
+	if cmd.Flags.RedemptionsAllowed < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("number of redemptions is not valid"))
+	}
+
+	if cmd.Flags.ExpirationWindow.String() != "" {
+		ok, err := expirationValidator.Evaluate(cmd.Flags.ExpirationWindow)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("expiration time is not valid: %s", err))
+		}
+	}
+
+	selectedCost, err := strconv.Atoi(cmd.Flags.Cost)
+	if err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("link cost is not valid: %s", err))
+	}
+	ok, err := numberValidator.Evaluate(selectedCost)
+	if !ok {
+		validationErrors = append(validationErrors, fmt.Errorf("link cost is not valid: %s", err))
+	} else {
+		cmd.cost = selectedCost
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdTokenIssue) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
 }
 
-func (cmd *CmdTokenIssue) ValidateInput(args []string) []error { return nil }
-func (cmd *CmdTokenIssue) InputToOptions()                     {}
 func (cmd *CmdTokenIssue) Run() error {
-	return fmt.Errorf("command not supported by the selected platform")
+	grant := grants.NewAccessGrant(cmd.grantName, cmd.namespace, cmd.Flags.RedemptionsAllowed, cmd.Flags.ExpirationWindow, cmd.serverConfig)
+	if err := cmd.accessGrantHandler.Add(grant); err != nil {
+		return err
+	}
+
+	accessToken := v2alpha1.AccessToken{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessToken",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: grant.Name,
+		},
+		Spec: v2alpha1.AccessTokenSpec{
+			Url:      grant.Status.Url,
+			Code:     grant.Status.Code,
+			Ca:       grant.Status.Ca,
+			LinkCost: cmd.cost,
+		},
+	}
+
+	encodedResource, err := utils.Encode("yaml", accessToken)
+	if err != nil {
+		return fmt.Errorf("Could not write out generated token: %s", err)
+	}
+
+	err = os.WriteFile(cmd.fileName, []byte(encodedResource), 0600)
+	if err != nil {
+		return fmt.Errorf("Could not write to file %s: %s", cmd.fileName, err)
+	}
+	return nil
+}
+
+func (cmd *CmdTokenIssue) WaitUntil() error {
+	fmt.Printf("\nGrant %q is ready\n", cmd.grantName)
+	fmt.Printf("Token file %s created\n", cmd.fileName)
+	fmt.Printf("\nTransfer this file to a remote site. At the remote site,\n")
+	fmt.Printf("create a link to this site using the \"skupper token redeem\" command:\n")
+	fmt.Printf("\n\tskupper token redeem <file>\n")
+	fmt.Printf("\nThe token expires after %d use(s) or after %s.\n", cmd.Flags.RedemptionsAllowed, cmd.Flags.ExpirationWindow.String())
+	return nil
+}
+
+func (cmd *CmdTokenIssue) getNamespace() string {
+	if cmd.namespace == "" {
+		return "default"
+	}
+	return cmd.namespace
 }
-func (cmd *CmdTokenIssue) WaitUntil() error { return nil }
@@ -1 +1,176 @@
 package nonkube
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/grants"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCmdTokenIssue_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		flags             *common.CommandTokenIssueFlags
+		withoutSite       bool
+		existingGrant     string
+		serverNotStarted  bool
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "file name is not specified",
+			args:           []string{},
+			flags:          &common.CommandTokenIssueFlags{RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			expectedErrors: []string{"file name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"token.yaml", "other.yaml"},
+			flags:          &common.CommandTokenIssueFlags{RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "there is no site",
+			args:           []string{"token.yaml"},
+			flags:          &common.CommandTokenIssueFlags{RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			withoutSite:    true,
+			expectedErrors: []string{"A site must exist in namespace default before a token can be created"},
+		},
+		{
+			name:           "token name is not valid",
+			args:           []string{"token.yaml"},
+			flags:          &common.CommandTokenIssueFlags{Name: "my grant", RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			expectedErrors: []string{"token name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$"},
+		},
+		{
+			name:           "token already exists",
+			args:           []string{"token.yaml"},
+			flags:          &common.CommandTokenIssueFlags{Name: "my-grant", RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			existingGrant:  "my-grant",
+			expectedErrors: []string{"there is already a token my-grant created in namespace default"},
+		},
+		{
+			name:             "grant server not started",
+			args:             []string{"token.yaml"},
+			flags:            &common.CommandTokenIssueFlags{RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			serverNotStarted: true,
+			expectedErrors:   []string{"the grant server has not started yet for namespace default, make sure the site is running"},
+		},
+		{
+			name:  "flags are not valid",
+			args:  []string{"token.yaml"},
+			flags: &common.CommandTokenIssueFlags{RedemptionsAllowed: 0, ExpirationWindow: 0, Cost: "one"},
+			expectedErrors: []string{
+				"number of redemptions is not valid",
+				"expiration time is not valid: duration must not be less than 1m0s; got 0s",
+				"link cost is not valid: strconv.Atoi: parsing \"one\": invalid syntax",
+			},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"token.yaml"},
+			flags:          &common.CommandTokenIssueFlags{RedemptionsAllowed: 1, ExpirationWindow: 15 * time.Minute, Cost: "1"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"token.yaml"},
+			flags:          &common.CommandTokenIssueFlags{Name: "my-grant", RedemptionsAllowed: 2, ExpirationWindow: 15 * time.Minute, Cost: "2"},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := newCmdTokenIssueWithMocks(t, !test.withoutSite, !test.serverNotStarted)
+			command.Flags = test.flags
+
+			if test.existingGrant != "" {
+				grant := grants.NewAccessGrant(test.existingGrant, "default", 1, time.Minute, &grants.ServerConfig{})
+				assert.Assert(t, command.accessGrantHandler.Add(grant))
+			}
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdTokenIssue_Run(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	// the file name validator does not accept the test name in t.TempDir()
+	tokenDir, err := os.MkdirTemp("", "token")
+	assert.Assert(t, err)
+	defer os.RemoveAll(tokenDir)
+	fileName := path.Join(tokenDir, "token.yaml")
+
+	command := newCmdTokenIssueWithMocks(t, true, true)
+	command.Flags = &common.CommandTokenIssueFlags{RedemptionsAllowed: 3, ExpirationWindow: 15 * time.Minute, Cost: "4"}
+	assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{fileName})), []string{})
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+
+	grant, err := command.accessGrantHandler.Get(command.grantName)
+	assert.Assert(t, err)
+	assert.Equal(t, grant.Spec.RedemptionsAllowed, 3)
+	assert.Equal(t, grant.Status.Url, "https://10.0.0.1:9090/"+command.grantName)
+
+	data, err := os.ReadFile(fileName)
+	assert.Assert(t, err)
+	var accessToken v2alpha1.AccessToken
+	assert.Assert(t, yaml.Unmarshal(data, &accessToken))
+	assert.Equal(t, accessToken.Kind, "AccessToken")
+	assert.Equal(t, accessToken.Name, command.grantName)
+	assert.Equal(t, accessToken.Spec.Url, grant.Status.Url)
+	assert.Equal(t, accessToken.Spec.Code, grant.Status.Code)
+	assert.Equal(t, accessToken.Spec.Ca, "fake-ca")
+	assert.Equal(t, accessToken.Spec.LinkCost, 4)
+}
+
+// --- helper methods
+
+func newCmdTokenIssueWithMocks(t *testing.T, withSite bool, serverStarted bool) *CmdTokenIssue {
+	t.Helper()
+	command := &CmdTokenIssue{
+		CobraCmd:           &cobra.Command{Use: "test"},
+		siteHandler:        fs.NewSiteHandler(""),
+		accessGrantHandler: fs.NewAccessGrantHandler(""),
+		loadServerConfig: func(namespace string) (*grants.ServerConfig, error) {
+			if !serverStarted {
+				return nil, fmt.Errorf("the grant server has not started yet for namespace default")
+			}
+			return &grants.ServerConfig{Url: "https://10.0.0.1:9090", Ca: "fake-ca"}, nil
+		},
+	}
+	if withSite {
+		assert.Assert(t, command.siteHandler.Add(v2alpha1.Site{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "default"},
+		}))
+	}
+	return command
+}
@@ -2,15 +2,28 @@ package nonkube
 
 import (
 	"fmt"
+	"os"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	nonkubecommon "github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/utils/validator"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
 type CmdTokenRedeem struct {
-	CobraCmd  *cobra.Command
-	Flags     *common.CommandTokenRedeemFlags
-	Namespace string
-	siteName  string
+	siteHandler *fs.SiteHandler
+	linkHandler *fs.LinkHandler
+	CobraCmd    *cobra.Command
+	Flags       *common.CommandTokenRedeemFlags
+	namespace   string
+	name        string
+	fileName    string
+	site        *v2alpha1.Site
+	redeem      func(claim *v2alpha1.AccessToken, siteState *api.SiteState) error
 }
 
 func NewCmdTokenRedeem() *CmdTokenRedeem {
@@ -18,12 +31,111 @@ func NewCmdTokenRedeem() *CmdTokenRedeem {
 }
 
 func (cmd *CmdTokenRedeem) NewClient(cobraCommand *cobra.Command, args []string) {
-	//TODO
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+	cmd.linkHandler = fs.NewLinkHandler(cmd.namespace)
+	cmd.redeem = nonkubecommon.RedeemAccessToken
+}
+
+func (cmd *CmdTokenRedeem) ValidateInput(args []string) []error {
+	var validationErrors []error
+	tokenStringValidator := validator.NewFilePathStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	// Validate token file name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("token file name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must not be empty"))
+	} else {
+		ok, err := tokenStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token file name is not valid: %s", err))
+		} else {
+			cmd.fileName = args[0]
+		}
+	}
+
+	// Validate there is already a site defined in the namespace before a token can be redeemed
+	sites, err := cmd.siteHandler.List()
+	if err != nil || len(sites) == 0 {
+		namespace := cmd.namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		validationErrors = append(validationErrors, fmt.Errorf("A site must exist in namespace %s before a token can be redeemed", namespace))
+	} else {
+		cmd.site = sites[0]
+	}
+
+	// Validate if token file exists
+	if cmd.fileName != "" {
+		_, err := os.Stat(cmd.fileName)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("token file does not exist: %s", err))
+		}
+	}
+
+	return validationErrors
+}
+
+func (cmd *CmdTokenRedeem) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
 }
 
-func (cmd *CmdTokenRedeem) ValidateInput(args []string) []error { return nil }
-func (cmd *CmdTokenRedeem) InputToOptions()                     {}
 func (cmd *CmdTokenRedeem) Run() error {
-	return fmt.Errorf("command not supported by the selected platform")
+	var accessToken v2alpha1.AccessToken
+	tokenFile, err := os.ReadFile(cmd.fileName)
+	if err != nil {
+		return fmt.Errorf("unable to read token file - %v", err)
+	}
+	if err = yaml.Unmarshal(tokenFile, &accessToken); err != nil {
+		return fmt.Errorf("unable to parse token file - %v", err)
+	}
+	if accessToken.Kind != "AccessToken" || accessToken.Spec.Url == "" {
+		return fmt.Errorf("%s is not a valid token file", cmd.fileName)
+	}
+	cmd.name = accessToken.Name
+
+	// the links obtained are stored as input resources, so
+	// the token is only redeemed once
+	siteState := api.NewSiteState(false)
+	siteState.Site = cmd.site
+	if err := cmd.redeem(&accessToken, siteState); err != nil {
+		return fmt.Errorf("failed to redeem token %q: %w", cmd.name, err)
+	}
+	for _, secret := range siteState.Secrets {
+		secret.Namespace = cmd.namespace
+		if err := cmd.linkHandler.AddSecret(*secret); err != nil {
+			return err
+		}
+	}
+	for _, link := range siteState.Links {
+		link.Namespace = cmd.namespace
+		if err := cmd.linkHandler.Add(*link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *CmdTokenRedeem) WaitUntil() error {
+	fmt.Printf("Token %q has been redeemed\n", cmd.name)
+	fmt.Printf("Run \"skupper system reload\" to apply the new link(s) to the site\n")
+	fmt.Printf("You can now safely delete %s\n", cmd.fileName)
+	return nil
 }
-func (cmd *CmdTokenRedeem) WaitUntil() error { return nil }
@@ -1 +1,196 @@
 package nonkube
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const validAccessToken = `apiVersion: skupper.io/v2alpha1
+kind: AccessToken
+metadata:
+  name: my-token
+spec:
+  url: https://10.0.0.1:9090/my-grant
+  code: secret-code
+  ca: fake-ca
+`
+
+func TestCmdTokenRedeem_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		args              []string
+		withoutSite       bool
+		cobraGenericFlags map[string]string
+		expectedErrors    []string
+	}
+
+	testTable := []test{
+		{
+			name:           "file name is not specified",
+			args:           []string{},
+			expectedErrors: []string{"token file name must be configured"},
+		},
+		{
+			name:           "more than one argument was specified",
+			args:           []string{"token.yaml", "other.yaml"},
+			expectedErrors: []string{"only one argument is allowed for this command"},
+		},
+		{
+			name:           "file name is empty",
+			args:           []string{""},
+			expectedErrors: []string{"file name must not be empty"},
+		},
+		{
+			name:           "there is no site",
+			args:           []string{"token.yaml"},
+			withoutSite:    true,
+			expectedErrors: []string{"A site must exist in namespace default before a token can be redeemed"},
+		},
+		{
+			name:           "token file does not exist",
+			args:           []string{"missing.yaml"},
+			expectedErrors: []string{"token file does not exist: stat missing.yaml: no such file or directory"},
+		},
+		{
+			name:           "kubernetes flags are not valid on this platform",
+			args:           []string{"token.yaml"},
+			expectedErrors: []string{},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:           "flags all valid",
+			args:           []string{"token.yaml"},
+			expectedErrors: []string{},
+		},
+	}
+
+	workDir, err := os.Getwd()
+	assert.Assert(t, err)
+	tokenDir, err := os.MkdirTemp("", "token")
+	assert.Assert(t, err)
+	defer os.RemoveAll(tokenDir)
+	assert.Assert(t, os.WriteFile(path.Join(tokenDir, "token.yaml"), []byte(validAccessToken), 0644))
+	assert.Assert(t, os.Chdir(tokenDir))
+	defer os.Chdir(workDir)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			command := newCmdTokenRedeemWithMocks(t, !test.withoutSite, nil)
+
+			for name, value := range test.cobraGenericFlags {
+				command.CobraCmd.Flags().String(name, value, "")
+			}
+
+			actualErrors := command.ValidateInput(test.args)
+			actualErrorsMessages := utils.ErrorsToMessages(actualErrors)
+			assert.DeepEqual(t, actualErrorsMessages, test.expectedErrors)
+		})
+	}
+}
+
+func TestCmdTokenRedeem_Run(t *testing.T) {
+	type test struct {
+		name         string
+		token        string
+		redeemError  error
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:  "runs ok",
+			token: validAccessToken,
+		},
+		{
+			name:         "not an access token",
+			token:        "apiVersion: v1\nkind: Secret\nmetadata:\n  name: my-token\n",
+			errorMessage: "is not a valid token file",
+		},
+		{
+			name:         "redemption fails",
+			token:        validAccessToken,
+			redeemError:  fmt.Errorf("no such claim"),
+			errorMessage: "failed to redeem token \"my-token\": no such claim",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			tokenDir, err := os.MkdirTemp("", "token")
+			assert.Assert(t, err)
+			defer os.RemoveAll(tokenDir)
+			fileName := path.Join(tokenDir, "token.yaml")
+			assert.Assert(t, os.WriteFile(fileName, []byte(test.token), 0644))
+
+			command := newCmdTokenRedeemWithMocks(t, true, test.redeemError)
+			assert.DeepEqual(t, utils.ErrorsToMessages(command.ValidateInput([]string{fileName})), []string{})
+			command.InputToOptions()
+
+			err = command.Run()
+			if test.errorMessage != "" {
+				assert.ErrorContains(t, err, test.errorMessage)
+				return
+			}
+			assert.Assert(t, err)
+
+			link, err := command.linkHandler.Get("my-token")
+			assert.Assert(t, err)
+			assert.Equal(t, link.Namespace, "default")
+			assert.Equal(t, link.Spec.TlsCredentials, "my-token")
+			secretFile := path.Join(api.GetHostNamespaceHome("default"), string(api.InputSiteStatePath), common.Secrets, "my-token.yaml")
+			_, err = os.Stat(secretFile)
+			assert.Assert(t, err)
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdTokenRedeemWithMocks(t *testing.T, withSite bool, redeemError error) *CmdTokenRedeem {
+	t.Helper()
+	command := &CmdTokenRedeem{
+		CobraCmd:    &cobra.Command{Use: "test"},
+		Flags:       &common.CommandTokenRedeemFlags{},
+		siteHandler: fs.NewSiteHandler(""),
+		linkHandler: fs.NewLinkHandler(""),
+		redeem: func(claim *v2alpha1.AccessToken, siteState *api.SiteState) error {
+			if redeemError != nil {
+				return redeemError
+			}
+			siteState.Secrets[claim.Name] = &corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Name: claim.Name},
+			}
+			siteState.Links[claim.Name] = &v2alpha1.Link{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Link"},
+				ObjectMeta: metav1.ObjectMeta{Name: claim.Name},
+				Spec:       v2alpha1.LinkSpec{TlsCredentials: claim.Name},
+			}
+			return nil
+		},
+	}
+	if withSite {
+		assert.Assert(t, command.siteHandler.Add(v2alpha1.Site{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+			ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: "default"},
+		}))
+	}
+	return command
+}
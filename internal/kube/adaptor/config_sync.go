@@ -1,9 +1,12 @@
 package adaptor
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -92,11 +95,22 @@ func (c *ConfigSync) secretEvent(key string, secret *corev1.Secret) error {
 			log.Printf("CONFIG_SYNC: Secret %q already up to date", secret.Name)
 			return nil
 		}
+		// if the secret was already in use, the router needs to be
+		// told to pick up the new credentials, and to close any
+		// connections made under trust that has since been withdrawn
+		refresh := current.secret != nil
+		withdrawn := refresh && trustWithdrawn(current.secret.Data["ca.crt"], secret.Data["ca.crt"], time.Now())
 		if err := current.sync(secret); err != nil {
 			log.Printf("CONFIG_SYNC: Error syncing secret %q: %s", secret.Name, err)
 			return err
 		}
 		log.Printf("CONFIG_SYNC: Secret %q synced", secret.Name)
+		if refresh {
+			if err := c.refreshSslProfile(current.profile, withdrawn); err != nil {
+				log.Printf("CONFIG_SYNC: Error refreshing ssl profile %q: %s", current.profile, err)
+				return err
+			}
+		}
 	} else {
 		log.Printf("CONFIG_SYNC: Secret %q not being tracked", secret.Name)
 	}
@@ -245,6 +259,83 @@ func (c *ConfigSync) syncSslProfilesToRouter(desired map[string]qdr.SslProfile)
 	return nil
 }
 
+// refreshSslProfile causes the router to reload the credentials for
+// an existing ssl profile, so that renewed certificates are used for
+// new connections without restarting the router. If closeExisting is
+// set, connections made with the previous credentials are closed.
+//
+// The router can only close the connections of an ssl profile as a
+// whole, and does not report which CA verified a peer, so withdrawing
+// trust in one CA (e.g. revoking a single AccessGrant redemption)
+// closes every link made to the site's server profile. Links with
+// credentials that are still trusted reconnect straight away, while
+// those issued by the withdrawn CA are refused. This is only done when
+// a CA that has not expired is withdrawn, which is rare, rather than
+// on every renewal.
+func (c *ConfigSync) refreshSslProfile(name string, closeExisting bool) error {
+	agent, err := c.agentPool.Get()
+	if err != nil {
+		return fmt.Errorf("Could not get management agent : %s", err)
+	}
+	err = refreshSslProfile(agent, name, closeExisting)
+	c.agentPool.Put(agent)
+	return err
+}
+
+func refreshSslProfile(agent *qdr.Agent, name string, closeExisting bool) error {
+	current, err := agent.GetSslProfileByName(name)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		// not yet created, so will pick up the current files
+		return nil
+	}
+	current.Ordinal += 1
+	if closeExisting {
+		current.OldestValidOrdinal = current.Ordinal
+	}
+	if err := agent.UpdateSslProfile(*current); err != nil {
+		return err
+	}
+	log.Printf("CONFIG_SYNC: Ssl profile %q refreshed (ordinal %d, oldest valid ordinal %d)", name, current.Ordinal, current.OldestValidOrdinal)
+	return nil
+}
+
+// trustWithdrawn returns true if any CA certificate in the previous
+// bundle that has not expired is missing from the current one. Expired
+// CAs are pruned from bundles routinely, and as they can no longer
+// verify new connections, removing them does not warrant closing
+// existing ones.
+func trustWithdrawn(previous []byte, current []byte, now time.Time) bool {
+	trusted := map[string]bool{}
+	for _, cert := range decodeCertificates(current) {
+		trusted[string(cert)] = true
+	}
+	for _, cert := range decodeCertificates(previous) {
+		if trusted[string(cert)] {
+			continue
+		}
+		if parsed, err := x509.ParseCertificate(cert); err == nil && now.After(parsed.NotAfter) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func decodeCertificates(data []byte) [][]byte {
+	var certs [][]byte
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		certs = append(certs, block.Bytes)
+		data = rest
+	}
+}
+
 func (c *ConfigSync) syncSslProfileCredentialsToDisk(profiles map[string]qdr.SslProfile) error {
 	for _, profile := range profiles {
 		if tracker, sync := c.trackSslProfile(profile.Name); sync {
@@ -30,8 +30,9 @@ func (s SslProfileSyncer) get(profile string) (*SslProfile, bool) {
 		return current, false
 	}
 	target := &SslProfile{
-		name: secret,
-		path: paths.Join(s.path, profile),
+		name:    secret,
+		profile: profile,
+		path:    paths.Join(s.path, profile),
 	}
 	s.profiles[secret] = target
 	return target, true
@@ -43,9 +44,10 @@ func (s SslProfileSyncer) bySecretName(secret string) (*SslProfile, bool) {
 }
 
 type SslProfile struct {
-	name   string
-	path   string
-	secret *corev1.Secret
+	name    string
+	profile string
+	path    string
+	secret  *corev1.Secret
 }
 
 func (s *SslProfile) sync(secret *corev1.Secret) error {
@@ -0,0 +1,289 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const (
+	IssuerKind        = "Issuer"
+	ClusterIssuerKind = "ClusterIssuer"
+
+	// SelfSigned is the value of the cert-manager-issuer site setting
+	// that opts a site out of a cert-manager issuer configured on the
+	// controller, so that its certificates are generated by skupper.
+	SelfSigned = "none"
+
+	certManagerGroup = "cert-manager.io"
+)
+
+var certManagerCertificateResource = schema.GroupVersionResource{
+	Group:    certManagerGroup,
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+var certManagerIssuerResource = schema.GroupVersionResource{
+	Group:    certManagerGroup,
+	Version:  "v1",
+	Resource: "issuers",
+}
+
+// CertManagerIssuer identifies the cert-manager Issuer or
+// ClusterIssuer that certificates are issued through.
+type CertManagerIssuer struct {
+	Kind string
+	Name string
+}
+
+// ParseCertManagerIssuer parses an issuer reference of the form
+// [<kind>/]<name>, where kind is Issuer (the default) or
+// ClusterIssuer. An empty value returns nil.
+func ParseCertManagerIssuer(value string) (*CertManagerIssuer, error) {
+	if value == "" {
+		return nil, nil
+	}
+	issuer := &CertManagerIssuer{
+		Kind: IssuerKind,
+		Name: value,
+	}
+	if parts := strings.SplitN(value, "/", 2); len(parts) == 2 {
+		issuer.Kind = parts[0]
+		issuer.Name = parts[1]
+	}
+	if issuer.Kind != IssuerKind && issuer.Kind != ClusterIssuerKind {
+		return nil, fmt.Errorf("Invalid cert-manager issuer kind %q, must be %s or %s", issuer.Kind, IssuerKind, ClusterIssuerKind)
+	}
+	if issuer.Name == "" {
+		return nil, fmt.Errorf("Invalid cert-manager issuer %q, no name specified", value)
+	}
+	return issuer, nil
+}
+
+func (i *CertManagerIssuer) String() string {
+	return i.Kind + "/" + i.Name
+}
+
+func (i *CertManagerIssuer) ref() map[string]interface{} {
+	return map[string]interface{}{
+		"group": certManagerGroup,
+		"kind":  i.Kind,
+		"name":  i.Name,
+	}
+}
+
+// SetIssuer selects how certificates in the namespace are issued,
+// overriding the cert-manager issuer configured for the controller. The
+// setting is either a cert-manager issuer reference as accepted by
+// ParseCertManagerIssuer, SelfSigned to have skupper generate the
+// certificates itself, or empty to revert to the controller's
+// configuration.
+func (m *CertificateManagerImpl) SetIssuer(namespace string, setting string) error {
+	current, overridden := m.issuers[namespace]
+	if setting == "" {
+		if !overridden {
+			return nil
+		}
+		delete(m.issuers, namespace)
+	} else {
+		var issuer *CertManagerIssuer
+		if setting != SelfSigned {
+			parsed, err := ParseCertManagerIssuer(setting)
+			if err != nil {
+				return err
+			}
+			issuer = parsed
+		}
+		if overridden && reflect.DeepEqual(current, issuer) {
+			return nil
+		}
+		m.issuers[namespace] = issuer
+	}
+	log.Printf("Certificates in %s will be issued through %s", namespace, describeIssuer(m.issuerFor(namespace)))
+	for key, certificate := range m.definitions {
+		if certificate.Namespace != namespace {
+			continue
+		}
+		if err := m.checkCertificate(key, certificate); err != nil {
+			log.Printf("Error reconciling Certificate %s after change of issuer: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// issuerFor returns the cert-manager issuer through which certificates
+// in the namespace are issued, or nil if skupper generates them.
+func (m *CertificateManagerImpl) issuerFor(namespace string) *CertManagerIssuer {
+	if issuer, ok := m.issuers[namespace]; ok {
+		return issuer
+	}
+	return m.defaultIssuer
+}
+
+func describeIssuer(issuer *CertManagerIssuer) string {
+	if issuer == nil {
+		return "skupper"
+	}
+	return "cert-manager " + issuer.String()
+}
+
+// reconcileWithCertManager ensures there is a cert-manager Certificate
+// for the supplied skupper Certificate (and, for a CA, a cert-manager
+// Issuer through which certificates referring to it are issued). The
+// secret itself is written by cert-manager, which also takes care of
+// renewal, so all that is required here is to report its state.
+func (m *CertificateManagerImpl) reconcileWithCertManager(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret, issuer *CertManagerIssuer) error {
+	if err := m.applyCertManagerResource(certManagerCertificateResource, desiredCertManagerCertificate(certificate, issuer)); err != nil {
+		return m.updateStatus(certificate, err)
+	}
+	if certificate.Spec.Signing {
+		if err := m.applyCertManagerResource(certManagerIssuerResource, desiredCertManagerIssuer(certificate)); err != nil {
+			return m.updateStatus(certificate, err)
+		}
+	}
+	if secret == nil || len(secret.Data["tls.crt"]) == 0 {
+		return m.updatePendingStatus(certificate, "Waiting for cert-manager to issue certificate")
+	}
+	m.definitionUpdated(key, certificate)
+	return m.updateStatus(certificate, nil)
+}
+
+func (m *CertificateManagerImpl) applyCertManagerResource(gvr schema.GroupVersionResource, desired *unstructured.Unstructured) error {
+	client := m.controller.GetDynamicClient().Resource(gvr).Namespace(desired.GetNamespace())
+	existing, err := client.Get(context.TODO(), desired.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := client.Create(context.TODO(), desired, metav1.CreateOptions{}); err != nil {
+			log.Printf("Error creating %s %s/%s: %s", desired.GetKind(), desired.GetNamespace(), desired.GetName(), err)
+			return err
+		}
+		log.Printf("Created %s %s/%s", desired.GetKind(), desired.GetNamespace(), desired.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if _, ok := existing.GetLabels()["internal.skupper.io/certificate"]; !ok {
+		return fmt.Errorf("%s %s exists but is not controlled by skupper", desired.GetKind(), desired.GetName())
+	}
+	if specMatches(existing, desired) {
+		return nil
+	}
+	updated := existing.DeepCopy()
+	updated.Object["spec"] = desired.Object["spec"]
+	if _, err := client.Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		log.Printf("Error updating %s %s/%s: %s", desired.GetKind(), desired.GetNamespace(), desired.GetName(), err)
+		return err
+	}
+	log.Printf("Updated %s %s/%s", desired.GetKind(), desired.GetNamespace(), desired.GetName())
+	return nil
+}
+
+// specMatches returns true if every field in the desired spec has the
+// same value in the existing spec. Fields not set by skupper (e.g.
+// defaults applied by cert-manager) are ignored.
+func specMatches(existing *unstructured.Unstructured, desired *unstructured.Unstructured) bool {
+	actual, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	wanted, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	for key, value := range wanted {
+		if !reflect.DeepEqual(actual[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+func newCertManagerResource(kind string, certificate *skupperv2alpha1.Certificate, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	obj.SetAPIVersion(certManagerGroup + "/v1")
+	obj.SetKind(kind)
+	obj.SetName(certificate.Name)
+	obj.SetNamespace(certificate.Namespace)
+	obj.SetOwnerReferences(ownerReferences(certificate))
+	obj.SetLabels(map[string]string{
+		"internal.skupper.io/certificate": "true",
+	})
+	return obj
+}
+
+func desiredCertManagerCertificate(certificate *skupperv2alpha1.Certificate, issuer *CertManagerIssuer) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"secretName": certificate.Name,
+		"commonName": certificate.Spec.Subject,
+		"isCA":       certificate.Spec.Signing,
+		"issuerRef":  certificateIssuer(certificate, issuer).ref(),
+		"secretTemplate": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"internal.skupper.io/certificate": "true",
+			},
+		},
+	}
+	if certificate.Spec.Signing {
+		spec["usages"] = []interface{}{"cert sign", "crl sign", "digital signature"}
+	} else {
+		// as for certificates generated by skupper, both roles are allowed
+		spec["usages"] = []interface{}{"digital signature", "key encipherment", "server auth", "client auth"}
+	}
+	var dnsNames []interface{}
+	var ipAddresses []interface{}
+	hosts := append([]string{}, certificate.Spec.Hosts...)
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		if net.ParseIP(host) != nil {
+			ipAddresses = append(ipAddresses, host)
+		} else {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	if len(dnsNames) > 0 {
+		spec["dnsNames"] = dnsNames
+	}
+	if len(ipAddresses) > 0 {
+		spec["ipAddresses"] = ipAddresses
+	}
+	return newCertManagerResource("Certificate", certificate, spec)
+}
+
+// desiredCertManagerIssuer returns a CA Issuer backed by the secret
+// for a skupper CA, so that certificates naming that CA are signed by
+// it.
+func desiredCertManagerIssuer(certificate *skupperv2alpha1.Certificate) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"ca": map[string]interface{}{
+			"secretName": certificate.Name,
+		},
+	}
+	return newCertManagerResource(IssuerKind, certificate, spec)
+}
+
+// certificateIssuer returns the issuer for a certificate: CAs (and
+// certificates that do not name a CA) are issued by the configured
+// issuer, anything else by the Issuer named as its CA. That is either
+// the Issuer created for a skupper CA or one defined independently.
+func certificateIssuer(certificate *skupperv2alpha1.Certificate, issuer *CertManagerIssuer) *CertManagerIssuer {
+	if certificate.Spec.Signing || certificate.Spec.Ca == "" || certificate.Spec.Ca == issuer.Name {
+		return issuer
+	}
+	return &CertManagerIssuer{
+		Kind: IssuerKind,
+		Name: certificate.Spec.Ca,
+	}
+}
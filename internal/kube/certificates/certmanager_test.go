@@ -0,0 +1,177 @@
+package certificates
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+)
+
+func TestParseCertManagerIssuer(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected *CertManagerIssuer
+		err      string
+	}{
+		{
+			value: "",
+		},
+		{
+			value:    "my-issuer",
+			expected: &CertManagerIssuer{Kind: IssuerKind, Name: "my-issuer"},
+		},
+		{
+			value:    "Issuer/my-issuer",
+			expected: &CertManagerIssuer{Kind: IssuerKind, Name: "my-issuer"},
+		},
+		{
+			value:    "ClusterIssuer/corporate",
+			expected: &CertManagerIssuer{Kind: ClusterIssuerKind, Name: "corporate"},
+		},
+		{
+			value: "Vault/corporate",
+			err:   "Invalid cert-manager issuer kind \"Vault\", must be Issuer or ClusterIssuer",
+		},
+		{
+			value: "ClusterIssuer/",
+			err:   "Invalid cert-manager issuer \"ClusterIssuer/\", no name specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			issuer, err := ParseCertManagerIssuer(tt.value)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, issuer, tt.expected)
+		})
+	}
+}
+
+func certificate(name string, spec skupperv2alpha1.CertificateSpec) *skupperv2alpha1.Certificate {
+	return &skupperv2alpha1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: spec,
+	}
+}
+
+func getCertManagerResource(t *testing.T, controller *internalclient.Controller, resource string, name string) *unstructured.Unstructured {
+	gvr := certManagerCertificateResource
+	gvr.Resource = resource
+	obj, err := controller.GetDynamicClient().Resource(gvr).Namespace("test").Get(context.Background(), name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	return obj
+}
+
+func getCertificate(t *testing.T, controller *internalclient.Controller, name string) *skupperv2alpha1.Certificate {
+	cert, err := controller.GetSkupperClient().SkupperV2alpha1().Certificates("test").Get(context.Background(), name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	return cert
+}
+
+func TestCertManagerIssuance(t *testing.T) {
+	ca := certificate("skupper-site-ca", skupperv2alpha1.CertificateSpec{
+		Subject: "skupper-site-ca",
+		Signing: true,
+	})
+	server := certificate("skupper-site-server", skupperv2alpha1.CertificateSpec{
+		Ca:      "skupper-site-ca",
+		Subject: "skupper-router",
+		Hosts:   []string{"b.example.com", "10.0.0.1", "a.example.com"},
+		Server:  true,
+	})
+	client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{ca, server}, "")
+	assert.NilError(t, err)
+	controller := internalclient.NewController("Controller", client)
+	config := DefaultConfig()
+	config.CertManagerIssuer = "ClusterIssuer/corporate"
+	mgr := NewCertificateManager(controller)
+	mgr.SetConfig(config)
+
+	// a CA is issued by the configured issuer and has an Issuer of its own
+	assert.NilError(t, mgr.checkCertificate(ca.Key(), ca))
+	cmCa := getCertManagerResource(t, controller, "certificates", "skupper-site-ca")
+	spec, _, _ := unstructured.NestedMap(cmCa.Object, "spec")
+	assert.Equal(t, spec["secretName"], "skupper-site-ca")
+	assert.Equal(t, spec["commonName"], "skupper-site-ca")
+	assert.Equal(t, spec["isCA"], true)
+	assert.DeepEqual(t, spec["issuerRef"], map[string]interface{}{"group": "cert-manager.io", "kind": "ClusterIssuer", "name": "corporate"})
+	assert.Equal(t, len(cmCa.GetOwnerReferences()), 1)
+	assert.Equal(t, cmCa.GetOwnerReferences()[0].Name, "skupper-site-ca")
+	cmIssuer := getCertManagerResource(t, controller, "issuers", "skupper-site-ca")
+	secretName, _, _ := unstructured.NestedString(cmIssuer.Object, "spec", "ca", "secretName")
+	assert.Equal(t, secretName, "skupper-site-ca")
+	assert.Assert(t, !getCertificate(t, controller, "skupper-site-ca").IsReady())
+
+	// other certificates are issued through the Issuer for their CA
+	assert.NilError(t, mgr.checkCertificate(server.Key(), server))
+	cmServer := getCertManagerResource(t, controller, "certificates", "skupper-site-server")
+	spec, _, _ = unstructured.NestedMap(cmServer.Object, "spec")
+	assert.Equal(t, spec["isCA"], false)
+	assert.DeepEqual(t, spec["dnsNames"], []interface{}{"a.example.com", "b.example.com"})
+	assert.DeepEqual(t, spec["ipAddresses"], []interface{}{"10.0.0.1"})
+	assert.DeepEqual(t, spec["issuerRef"], map[string]interface{}{"group": "cert-manager.io", "kind": "Issuer", "name": "skupper-site-ca"})
+
+	// once cert-manager has written the secret, the certificate is ready
+	secret := certs.GenerateSecret("skupper-site-server", "skupper-router", "a.example.com,b.example.com", nil)
+	secret.Namespace = "test"
+	assert.NilError(t, mgr.checkSecret(server.Key(), &secret))
+	latest := getCertificate(t, controller, "skupper-site-server")
+	assert.Assert(t, latest.IsReady())
+	assert.Assert(t, latest.Status.Expiration != "")
+
+	// a site can choose a different issuer
+	assert.NilError(t, mgr.SetIssuer("test", "Issuer/team"))
+	cmCa = getCertManagerResource(t, controller, "certificates", "skupper-site-ca")
+	issuerName, _, _ := unstructured.NestedString(cmCa.Object, "spec", "issuerRef", "name")
+	issuerKind, _, _ := unstructured.NestedString(cmCa.Object, "spec", "issuerRef", "kind")
+	assert.Equal(t, issuerKind, "Issuer")
+	assert.Equal(t, issuerName, "team")
+	assert.Error(t, mgr.SetIssuer("test", "Vault/team"), "Invalid cert-manager issuer kind \"Vault\", must be Issuer or ClusterIssuer")
+	assert.Assert(t, mgr.issuerFor("test") != nil)
+	assert.NilError(t, mgr.SetIssuer("test", SelfSigned))
+	assert.Assert(t, mgr.issuerFor("test") == nil)
+	assert.NilError(t, mgr.SetIssuer("test", ""))
+	assert.DeepEqual(t, mgr.issuerFor("test"), &CertManagerIssuer{Kind: ClusterIssuerKind, Name: "corporate"})
+}
+
+func TestCertManagerResourceNotControlled(t *testing.T) {
+	cert := certificate("my-cert", skupperv2alpha1.CertificateSpec{
+		Ca:      "corporate",
+		Subject: "my-cert",
+	})
+	client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{cert}, "")
+	assert.NilError(t, err)
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("cert-manager.io/v1")
+	existing.SetKind("Certificate")
+	existing.SetName("my-cert")
+	existing.SetNamespace("test")
+	_, err = client.GetDynamicClient().Resource(certManagerCertificateResource).Namespace("test").Create(context.Background(), existing, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	controller := internalclient.NewController("Controller", client)
+	mgr := NewCertificateManager(controller)
+	assert.NilError(t, mgr.SetIssuer("test", "corporate"))
+
+	assert.NilError(t, mgr.checkCertificate(cert.Key(), cert))
+	latest := getCertificate(t, controller, "my-cert")
+	assert.Assert(t, !latest.IsReady())
+	assert.Equal(t, latest.Status.Conditions[0].Message, "Certificate my-cert exists but is not controlled by skupper")
+}
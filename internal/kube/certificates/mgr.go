@@ -1,34 +1,55 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/certs"
 )
 
+// TrustedByLabel marks a Secret holding a CA that server certificates
+// issued by another CA in the same namespace, named by the value of the
+// label, also trust. Deleting the Secret withdraws that trust.
+const TrustedByLabel = "internal.skupper.io/trusted-by"
+
 type CertificateManager interface {
 	EnsureCA(namespace string, name string, subject string, refs []metav1.OwnerReference) error
 	Ensure(namespace string, name string, ca string, subject string, hosts []string, client bool, server bool, refs []metav1.OwnerReference) error
 }
 
+// ExpiryRecorder is notified of the expiry time of the certificates
+// managed by a CertificateManagerImpl.
+type ExpiryRecorder interface {
+	CertificateExpiry(namespace string, name string, expiry time.Time)
+	CertificateRemoved(namespace string, name string)
+}
+
 type CertificateManagerImpl struct {
 	definitions        map[string]*skupperv2alpha1.Certificate
 	secrets            map[string]*corev1.Secret
 	certificateWatcher *internalclient.CertificateWatcher
 	secretWatcher      *internalclient.SecretWatcher
 	controller         *internalclient.Controller
+	expiryRecorder     ExpiryRecorder
+	config             *Config
+	scheduled          map[string]time.Time
+	defaultIssuer      *CertManagerIssuer
+	issuers            map[string]*CertManagerIssuer
 }
 
 func NewCertificateManager(controller *internalclient.Controller) *CertificateManagerImpl {
@@ -36,7 +57,23 @@ func NewCertificateManager(controller *internalclient.Controller) *CertificateMa
 		definitions: map[string]*skupperv2alpha1.Certificate{},
 		secrets:     map[string]*corev1.Secret{},
 		controller:  controller,
+		config:      DefaultConfig(),
+		scheduled:   map[string]time.Time{},
+		issuers:     map[string]*CertManagerIssuer{},
+	}
+}
+
+func (m *CertificateManagerImpl) SetConfig(config *Config) {
+	m.config = config
+	issuer, err := ParseCertManagerIssuer(config.CertManagerIssuer)
+	if err != nil {
+		log.Printf("Ignoring cert-manager issuer: %s", err)
 	}
+	m.defaultIssuer = issuer
+}
+
+func (m *CertificateManagerImpl) SetExpiryRecorder(recorder ExpiryRecorder) {
+	m.expiryRecorder = recorder
 }
 
 func (m *CertificateManagerImpl) Watch(watchNamespace string) {
@@ -75,6 +112,22 @@ func (m *CertificateManagerImpl) Ensure(namespace string, name string, ca string
 }
 
 func (m *CertificateManagerImpl) definitionUpdated(key string, def *skupperv2alpha1.Certificate) {
+	if m.expiryRecorder == nil {
+		return
+	}
+	secret, ok := m.secrets[key]
+	if !ok {
+		return
+	}
+	data, ok := secret.Data["tls.crt"]
+	if !ok {
+		return
+	}
+	cert, err := certs.DecodeCertificate(data)
+	if err != nil {
+		return
+	}
+	m.expiryRecorder.CertificateExpiry(def.Namespace, def.Name, cert.NotAfter)
 }
 
 func (m *CertificateManagerImpl) ensure(namespace string, name string, spec skupperv2alpha1.CertificateSpec, refs []metav1.OwnerReference) error {
@@ -135,6 +188,9 @@ func (m *CertificateManagerImpl) checkCertificate(key string, certificate *skupp
 }
 
 func (m *CertificateManagerImpl) reconcile(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
+	if issuer := m.issuerFor(certificate.Namespace); issuer != nil {
+		return m.reconcileWithCertManager(key, certificate, secret, issuer)
+	}
 	if secret != nil {
 		if err := m.updateSecret(key, certificate, secret); err != nil {
 			return m.updateStatus(certificate, err)
@@ -145,11 +201,56 @@ func (m *CertificateManagerImpl) reconcile(key string, certificate *skupperv2alp
 		}
 	}
 	m.definitionUpdated(key, certificate)
+	m.scheduleRenewal(key, certificate)
 	return m.updateStatus(certificate, nil)
 }
 
+// scheduleRenewal arranges for the certificate to be checked again
+// when it is next due for renewal (or, for a CA, rollover).
+func (m *CertificateManagerImpl) scheduleRenewal(key string, certificate *skupperv2alpha1.Certificate) {
+	secret, ok := m.secrets[key]
+	if !ok || !isSecretControlled(secret) {
+		return
+	}
+	var due time.Time
+	if certificate.Spec.Signing {
+		next, err := m.config.nextCaCheck(secret)
+		if err != nil {
+			return
+		}
+		due = next
+	} else {
+		cert, err := decodeCertificate(secret.Data["tls.crt"])
+		if err != nil {
+			return
+		}
+		due = m.config.renewalTime(cert)
+	}
+	if current, ok := m.scheduled[key]; ok && !current.After(due) {
+		return
+	}
+	m.scheduled[key] = due
+	m.controller.CallbackAfter(time.Until(due), m.renew, key)
+}
+
+func (m *CertificateManagerImpl) renew(key string) error {
+	delete(m.scheduled, key)
+	certificate, ok := m.definitions[key]
+	if !ok {
+		return nil
+	}
+	log.Printf("Checking renewal of Certificate %s", key)
+	return m.checkCertificate(key, certificate)
+}
+
 func (m *CertificateManagerImpl) certificateDeleted(key string) error {
+	if m.expiryRecorder != nil {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(key); err == nil {
+			m.expiryRecorder.CertificateRemoved(namespace, name)
+		}
+	}
 	delete(m.definitions, key)
+	delete(m.scheduled, key)
 	if secret, ok := m.secrets[key]; ok {
 		err := m.controller.GetKubeClient().CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
 		if err != nil {
@@ -161,13 +262,30 @@ func (m *CertificateManagerImpl) certificateDeleted(key string) error {
 }
 
 func (m *CertificateManagerImpl) secretDeleted(key string) error {
+	secret, ok := m.secrets[key]
 	delete(m.secrets, key)
-	//TODO
+	if ok {
+		m.trustedCaChanged(secret)
+	}
 	return nil
 }
 
 func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certificate, err error) error {
 	certificate.SetReady(err)
+	return m.writeStatus(certificate)
+}
+
+func (m *CertificateManagerImpl) updatePendingStatus(certificate *skupperv2alpha1.Certificate, message string) error {
+	certificate.Status.SetCondition(skupperv2alpha1.CONDITION_TYPE_READY, skupperv2alpha1.PendingCondition(message), certificate.ObjectMeta.Generation)
+	return m.writeStatus(certificate)
+}
+
+func (m *CertificateManagerImpl) writeStatus(certificate *skupperv2alpha1.Certificate) error {
+	if secret, ok := m.secrets[certificate.Key()]; ok {
+		if cert, err := decodeCertificate(secret.Data["tls.crt"]); err == nil {
+			certificate.Status.Expiration = cert.NotAfter.Format(time.RFC3339)
+		}
+	}
 	latest, err := m.controller.GetSkupperClient().SkupperV2alpha1().Certificates(certificate.Namespace).UpdateStatus(context.TODO(), certificate, metav1.UpdateOptions{})
 	if err != nil {
 		return err
@@ -177,34 +295,188 @@ func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certi
 }
 
 func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
-	if !isSecretCorrect(certificate, secret) {
+	if !isSecretCorrect(certificate, secret) || m.isRenewalDue(certificate, secret) {
 		if !isSecretControlled(secret) {
 			return errors.New("Secret exists but is not controlled by skupper")
 		}
 
-		secret, err := m.generateSecret(certificate)
+		regenerated, err := m.generateSecret(certificate)
 		if err != nil {
-			log.Printf("Error generating Secret %s/%s for Certificate %s", certificate.Namespace, secret.Name, key)
+			log.Printf("Error generating Secret %s/%s for Certificate %s: %s", certificate.Namespace, secret.Name, key, err)
 			return err
 		}
-		updated, err := m.controller.GetKubeClient().CoreV1().Secrets(certificate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+		regenerated.ObjectMeta.Annotations = secret.ObjectMeta.Annotations
+		regenerated.ObjectMeta.Labels = secret.ObjectMeta.Labels
+		regenerated.ObjectMeta.ResourceVersion = secret.ObjectMeta.ResourceVersion
+		if regenerated.ObjectMeta.Annotations != nil {
+			regenerated.ObjectMeta.Annotations["internal.skupper.io/hosts"] = strings.Join(certificate.Spec.Hosts, ",")
+		}
+		return m.writeSecret(key, certificate, regenerated)
+	}
+	if !isSecretControlled(secret) {
+		return nil
+	}
+	if certificate.Spec.Signing {
+		updated, err := m.config.caRollover(secret, certificate.Spec.Subject, time.Now(), func(subject string) corev1.Secret {
+			return certs.GenerateCASecret(certificate.Name, subject)
+		})
 		if err != nil {
-			log.Printf("Error updating Secret %s/%s for Certificate %s: %s", secret.Namespace, secret.Name, key, err)
 			return err
 		}
-		m.secrets[key] = updated
-		log.Printf("Updated Secret %s/%s for Certificate %s (hosts %v)", secret.Namespace, secret.Name, key, certificate.Spec.Hosts)
+		if updated != nil {
+			log.Printf("Rolling over CA for Certificate %s", key)
+			if err := m.writeSecret(key, certificate, updated); err != nil {
+				return err
+			}
+			m.reconcileIssuedBy(certificate)
+		}
+		return nil
+	}
+	if ca, ok := m.secrets[m.caKey(certificate)]; ok {
+		bundle := m.issuedTrustBundle(certificate, ca)
+		chain := issuedChain(secret.Data["tls.crt"], ca, time.Now())
+		if !bytes.Equal(bundle, secret.Data["ca.crt"]) || !bytes.Equal(chain, secret.Data["tls.crt"]) {
+			updated := secret.DeepCopy()
+			updated.Data["ca.crt"] = bundle
+			updated.Data["tls.crt"] = chain
+			log.Printf("Updating trusted CAs and certificate chain for Certificate %s", key)
+			return m.writeSecret(key, certificate, updated)
+		}
 	}
 	return nil
 }
 
+func (m *CertificateManagerImpl) writeSecret(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
+	updated, err := m.controller.GetKubeClient().CoreV1().Secrets(certificate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+	if err != nil {
+		log.Printf("Error updating Secret %s/%s for Certificate %s: %s", certificate.Namespace, secret.Name, key, err)
+		return err
+	}
+	m.secrets[key] = updated
+	log.Printf("Updated Secret %s/%s for Certificate %s (hosts %v)", certificate.Namespace, secret.Name, key, certificate.Spec.Hosts)
+	return nil
+}
+
+// isRenewalDue returns true if a certificate that is not a CA has
+// reached the point in its lifetime at which it should be re-issued,
+// or if it was not issued by the current CA.
+func (m *CertificateManagerImpl) isRenewalDue(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) bool {
+	if certificate.Spec.Signing || !isSecretControlled(secret) {
+		return false
+	}
+	cert, err := decodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return false
+	}
+	if !time.Now().Before(m.config.renewalTime(cert)) {
+		log.Printf("Certificate %s is due for renewal", certificate.Key())
+		return true
+	}
+	if ca, ok := m.secrets[m.caKey(certificate)]; ok && !isSignedBy(secret, ca) {
+		log.Printf("Certificate %s was not issued by current CA %s", certificate.Key(), certificate.Spec.Ca)
+		return true
+	}
+	return false
+}
+
+// reconcileIssuedBy re-checks all certificates issued by the supplied
+// CA, so that they pick up any change to its trust bundle or signing
+// certificate.
+func (m *CertificateManagerImpl) reconcileIssuedBy(ca *skupperv2alpha1.Certificate) {
+	for key, certificate := range m.definitions {
+		if certificate.Namespace != ca.Namespace || certificate.Spec.Ca != ca.Name || certificate.Spec.Signing {
+			continue
+		}
+		if secret, ok := m.secrets[key]; ok {
+			if err := m.reconcile(key, certificate, secret); err != nil {
+				log.Printf("Error reconciling Certificate %s after change to CA %s: %s", key, ca.Key(), err)
+			}
+		}
+	}
+}
+
+// issuedTrustBundle returns the CA certificates that a certificate
+// issued by the supplied CA should trust. Server certificates also
+// trust any unexpired CA labelled as trusted by their issuer.
+func (m *CertificateManagerImpl) issuedTrustBundle(certificate *skupperv2alpha1.Certificate, ca *corev1.Secret) []byte {
+	bundles := [][]byte{trustBundle(ca)}
+	if certificate.Spec.Server {
+		var keys []string
+		for key, secret := range m.secrets {
+			if secret.Namespace == certificate.Namespace && secret.Labels[TrustedByLabel] == certificate.Spec.Ca && !trustExpired(secret, time.Now()) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			bundles = append(bundles, m.secrets[key].Data["tls.crt"])
+		}
+	}
+	return bytes.Join(bundles, nil)
+}
+
+// trustedCaChanged re-checks the certificates issued by the CA that a
+// CA labelled as trusted by it was added to or removed from.
+func (m *CertificateManagerImpl) trustedCaChanged(secret *corev1.Secret) {
+	issuer, ok := secret.Labels[TrustedByLabel]
+	if !ok {
+		return
+	}
+	if ca, ok := m.definitions[fmt.Sprintf("%s/%s", secret.Namespace, issuer)]; ok {
+		m.reconcileIssuedBy(ca)
+	}
+}
+
+// scheduleTrustExpiry arranges for a CA labelled as trusted by another
+// to be pruned once its certificate expires, as nothing it issued can
+// then be valid.
+func (m *CertificateManagerImpl) scheduleTrustExpiry(key string, secret *corev1.Secret) {
+	if _, ok := secret.Labels[TrustedByLabel]; !ok {
+		return
+	}
+	cert, err := decodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return
+	}
+	m.controller.CallbackAfter(time.Until(cert.NotAfter), m.pruneTrusted, key)
+}
+
+// pruneTrusted deletes a CA labelled as trusted by another if it has
+// expired, removing it from the trust bundles it was added to.
+func (m *CertificateManagerImpl) pruneTrusted(key string) error {
+	secret, ok := m.secrets[key]
+	if !ok || !trustExpired(secret, time.Now()) {
+		return nil
+	}
+	err := m.controller.GetKubeClient().CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	log.Printf("Deleted expired trusted CA %s", key)
+	return m.secretDeleted(key)
+}
+
+// trustExpired returns true if the secret holds a CA labelled as
+// trusted by another whose certificate has expired.
+func trustExpired(secret *corev1.Secret, now time.Time) bool {
+	if _, ok := secret.Labels[TrustedByLabel]; !ok {
+		return false
+	}
+	cert, err := decodeCertificate(secret.Data["tls.crt"])
+	return err == nil && now.After(cert.NotAfter)
+}
+
+func (m *CertificateManagerImpl) caKey(certificate *skupperv2alpha1.Certificate) string {
+	return fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)
+}
+
 func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Certificate) (*corev1.Secret, error) {
 	var secret corev1.Secret
 	if certificate.Spec.Signing {
 		secret = certs.GenerateCASecret(certificate.Name, certificate.Spec.Subject)
 	} else {
 		expiration := time.Hour * 24 * 365 * 5 // TODO: make this configurable (through controller setting or field on certificate?)
-		caKey := fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)
+		caKey := m.caKey(certificate)
 		ca, ok := m.secrets[caKey]
 		if !ok {
 			// TODO: no CA exists yet, set error on certificate status
@@ -212,6 +484,8 @@ func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Cer
 		}
 		// TODO: handle server and client roles properly
 		secret = certs.GenerateSecretWithExpiration(certificate.Name, certificate.Spec.Subject, strings.Join(certificate.Spec.Hosts, ","), expiration, ca)
+		secret.Data["tls.crt"] = issuedChain(secret.Data["tls.crt"], ca, time.Now())
+		secret.Data["ca.crt"] = m.issuedTrustBundle(certificate, ca)
 	}
 	//TODO: add labels and annotations from certificate to secret
 	secret.ObjectMeta.OwnerReferences = ownerReferences(certificate)
@@ -245,7 +519,12 @@ func (m *CertificateManagerImpl) checkSecret(key string, secret *corev1.Secret)
 	if secret == nil {
 		return m.secretDeleted(key)
 	}
+	previous, existed := m.secrets[key]
 	m.secrets[key] = secret
+	if !existed || !bytes.Equal(previous.Data["tls.crt"], secret.Data["tls.crt"]) {
+		m.trustedCaChanged(secret)
+		m.scheduleTrustExpiry(key, secret)
+	}
 	if definition, ok := m.definitions[key]; ok {
 		return m.reconcile(key, definition, secret)
 	}
@@ -0,0 +1,301 @@
+package certificates
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	iflag "github.com/skupperproject/skupper/internal/flag"
+)
+
+const (
+	// keys under which a CA that has been generated but is not yet
+	// used for signing is held in the secret of the current CA
+	nextCaCert = "next.crt"
+	nextCaKey  = "next.key"
+	// key under which the current CA, cross-signed by the CA it
+	// replaced, is held until that previous CA expires
+	crossCaCert = "cross.crt"
+)
+
+type Config struct {
+	// RenewalFraction is the fraction of a certificate's lifetime
+	// after which it is re-issued.
+	RenewalFraction float64
+	// CaRolloverPeriod is how long a new CA is trusted alongside
+	// the old one before it is used to sign certificates.
+	CaRolloverPeriod time.Duration
+	// CertManagerIssuer, if set, is the cert-manager Issuer or
+	// ClusterIssuer (as [<kind>/]<name>) through which certificates
+	// are issued instead of being generated by skupper.
+	CertManagerIssuer string
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		RenewalFraction:  0.66,
+		CaRolloverPeriod: 24 * time.Hour,
+	}
+}
+
+func BoundConfig(flags *flag.FlagSet) (*Config, error) {
+	c := DefaultConfig()
+	var errors []string
+	if err := iflag.Float64Var(flags, &c.RenewalFraction, "certificate-renewal-fraction", "SKUPPER_CERTIFICATE_RENEWAL_FRACTION", c.RenewalFraction, "The fraction of a certificate's lifetime after which it is re-issued."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.CaRolloverPeriod, "ca-rollover-period", "SKUPPER_CA_ROLLOVER_PERIOD", c.CaRolloverPeriod, "How long a renewed CA is trusted alongside the CA it replaces before being used to sign certificates."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	iflag.StringVar(flags, &c.CertManagerIssuer, "cert-manager-issuer", "SKUPPER_CERT_MANAGER_ISSUER", c.CertManagerIssuer, "The cert-manager issuer, as [Issuer|ClusterIssuer/]<name>, through which to issue certificates. If not set, skupper generates its own certificates.")
+	if len(errors) > 0 {
+		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
+	}
+	return c, nil
+}
+
+func (c *Config) Verify() error {
+	if c.RenewalFraction <= 0 || c.RenewalFraction >= 1 {
+		return fmt.Errorf("Certificate renewal fraction must be greater than 0 and less than 1, got %v.", c.RenewalFraction)
+	}
+	if c.CaRolloverPeriod < 0 {
+		return fmt.Errorf("CA rollover period must not be negative, got %s.", c.CaRolloverPeriod)
+	}
+	if _, err := ParseCertManagerIssuer(c.CertManagerIssuer); err != nil {
+		return err
+	}
+	return nil
+}
+
+// renewalTime returns the point in the certificate's lifetime at
+// which it should be replaced.
+func (c *Config) renewalTime(cert *x509.Certificate) time.Time {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(time.Duration(float64(lifetime) * c.RenewalFraction))
+}
+
+// promotionTime returns the point at which a new CA should replace
+// the current one for signing.
+func (c *Config) promotionTime(current *x509.Certificate, next *x509.Certificate) time.Time {
+	promotion := next.NotBefore.Add(c.CaRolloverPeriod)
+	if current.NotAfter.Before(promotion) {
+		return current.NotAfter
+	}
+	return promotion
+}
+
+// caRollover works out the next state of the secret for a CA, given
+// the current time. It returns nil if no change is needed. A CA goes
+// through the following stages:
+//
+//  1. once the renewal time is reached, a new CA is generated and
+//     added to the ca.crt bundle, but not yet used for signing
+//  2. once the rollover period has passed, the new CA replaces the
+//     current one in tls.crt and tls.key
+//  3. the old CA remains in the ca.crt bundle until it expires, and
+//     signs the new CA so that certificates issued by the new one can
+//     be verified by peers that only trust the old one
+func (c *Config) caRollover(secret *corev1.Secret, subject string, now time.Time, generate func(subject string) corev1.Secret) (*corev1.Secret, error) {
+	current, err := decodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return nil, err
+	}
+	updated := secret.DeepCopy()
+	changed := false
+	if data, ok := secret.Data[nextCaCert]; ok {
+		next, err := decodeCertificate(data)
+		if err != nil {
+			return nil, err
+		}
+		if !now.Before(c.promotionTime(current, next)) {
+			cross, err := crossSign(data, secret)
+			if err != nil {
+				return nil, err
+			}
+			updated.Data[crossCaCert] = cross
+			updated.Data["tls.crt"] = data
+			updated.Data["tls.key"] = secret.Data[nextCaKey]
+			delete(updated.Data, nextCaCert)
+			delete(updated.Data, nextCaKey)
+			changed = true
+		}
+	} else if !now.Before(c.renewalTime(current)) {
+		next := generate(subject)
+		updated.Data[nextCaCert] = next.Data["tls.crt"]
+		updated.Data[nextCaKey] = next.Data["tls.key"]
+		changed = true
+	}
+	if data, ok := updated.Data[crossCaCert]; ok {
+		if cross, err := decodeCertificate(data); err != nil || now.After(cross.NotAfter) {
+			delete(updated.Data, crossCaCert)
+			changed = true
+		}
+	}
+	bundle := caBundle(updated.Data["tls.crt"], updated.Data[nextCaCert], updated.Data["ca.crt"], now)
+	if !bytes.Equal(bundle, updated.Data["ca.crt"]) {
+		updated.Data["ca.crt"] = bundle
+		changed = true
+	}
+	if !changed {
+		return nil, nil
+	}
+	return updated, nil
+}
+
+// nextCaCheck returns the time at which the state of a CA secret
+// next needs to be checked.
+func (c *Config) nextCaCheck(secret *corev1.Secret) (time.Time, error) {
+	current, err := decodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return time.Time{}, err
+	}
+	due := c.renewalTime(current)
+	if data, ok := secret.Data[nextCaCert]; ok {
+		next, err := decodeCertificate(data)
+		if err != nil {
+			return time.Time{}, err
+		}
+		due = c.promotionTime(current, next)
+	}
+	// previous CAs are dropped from the bundle once they expire
+	for _, block := range decodeBlocks(secret.Data["ca.crt"]) {
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil && cert.NotAfter.Before(due) && cert.NotAfter.After(time.Now()) {
+			due = cert.NotAfter
+		}
+	}
+	return due, nil
+}
+
+// caBundle returns the set of CA certificates that should be trusted:
+// the current CA, any CA that is due to replace it and any previous
+// CAs that have not yet expired.
+func caBundle(current []byte, next []byte, previous []byte, now time.Time) []byte {
+	var blocks []*pem.Block
+	seen := map[string]bool{}
+	add := func(block *pem.Block) {
+		if !seen[string(block.Bytes)] {
+			seen[string(block.Bytes)] = true
+			blocks = append(blocks, block)
+		}
+	}
+	for _, block := range decodeBlocks(current) {
+		add(block)
+	}
+	for _, block := range decodeBlocks(next) {
+		add(block)
+	}
+	for _, block := range decodeBlocks(previous) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || now.After(cert.NotAfter) {
+			continue
+		}
+		add(block)
+	}
+	var bundle bytes.Buffer
+	for _, block := range blocks {
+		pem.Encode(&bundle, block)
+	}
+	return bundle.Bytes()
+}
+
+// crossSign returns the certificate of a new CA signed by the current
+// CA, valid for no longer than the current CA itself.
+func crossSign(next []byte, current *corev1.Secret) ([]byte, error) {
+	nextCert, err := decodeCertificate(next)
+	if err != nil {
+		return nil, err
+	}
+	currentCert, err := decodeCertificate(current.Data["tls.crt"])
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(current.Data["tls.key"])
+	if block == nil {
+		return nil, fmt.Errorf("No private key found")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	template := *nextCert
+	template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	if currentCert.NotAfter.Before(template.NotAfter) {
+		template.NotAfter = currentCert.NotAfter
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, currentCert, nextCert.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// issuedChain returns the certificate chain to serve for a certificate
+// issued by the supplied CA: the certificate itself followed, while the
+// CA it replaced is still valid, by the cross-signed CA.
+func issuedChain(cert []byte, ca *corev1.Secret, now time.Time) []byte {
+	blocks := decodeBlocks(cert)
+	if len(blocks) == 0 {
+		return cert
+	}
+	chain := pem.EncodeToMemory(blocks[0])
+	if data, ok := ca.Data[crossCaCert]; ok {
+		if cross, err := decodeCertificate(data); err == nil && !now.After(cross.NotAfter) {
+			chain = append(chain, data...)
+		}
+	}
+	return chain
+}
+
+// trustBundle returns the CA certificates that certificates issued by
+// the supplied CA should trust.
+func trustBundle(ca *corev1.Secret) []byte {
+	if bundle, ok := ca.Data["ca.crt"]; ok && bytes.Contains(bundle, ca.Data["tls.crt"]) {
+		return bundle
+	}
+	return ca.Data["tls.crt"]
+}
+
+func isSignedBy(secret *corev1.Secret, ca *corev1.Secret) bool {
+	cert, err := decodeCertificate(secret.Data["tls.crt"])
+	if err != nil {
+		return false
+	}
+	caCert, err := decodeCertificate(ca.Data["tls.crt"])
+	if err != nil {
+		return false
+	}
+	return cert.CheckSignatureFrom(caCert) == nil
+}
+
+func decodeBlocks(data []byte) []*pem.Block {
+	var blocks []*pem.Block
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return blocks
+		}
+		if block.Type == "CERTIFICATE" {
+			blocks = append(blocks, block)
+		}
+		data = rest
+	}
+}
+
+func decodeCertificate(data []byte) (*x509.Certificate, error) {
+	blocks := decodeBlocks(data)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("No certificate found")
+	}
+	return x509.ParseCertificate(blocks[0].Bytes)
+}
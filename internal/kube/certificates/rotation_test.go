@@ -0,0 +1,313 @@
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"gotest.tools/v3/assert"
+)
+
+func TestBoundConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		expected *Config
+		err      string
+	}{
+		{
+			name:     "defaults",
+			expected: DefaultConfig(),
+		},
+		{
+			name: "flags",
+			args: []string{"-certificate-renewal-fraction=0.5", "-ca-rollover-period=2h"},
+			expected: &Config{
+				RenewalFraction:  0.5,
+				CaRolloverPeriod: 2 * time.Hour,
+			},
+		},
+		{
+			name: "environment",
+			env: map[string]string{
+				"SKUPPER_CERTIFICATE_RENEWAL_FRACTION": "0.75",
+				"SKUPPER_CA_ROLLOVER_PERIOD":           "10m",
+			},
+			expected: &Config{
+				RenewalFraction:  0.75,
+				CaRolloverPeriod: 10 * time.Minute,
+			},
+		},
+		{
+			name: "cert-manager issuer",
+			env: map[string]string{
+				"SKUPPER_CERT_MANAGER_ISSUER": "ClusterIssuer/corporate",
+			},
+			expected: &Config{
+				RenewalFraction:   0.66,
+				CaRolloverPeriod:  24 * time.Hour,
+				CertManagerIssuer: "ClusterIssuer/corporate",
+			},
+		},
+		{
+			name: "bad environment",
+			env: map[string]string{
+				"SKUPPER_CERTIFICATE_RENEWAL_FRACTION": "most",
+				"SKUPPER_CA_ROLLOVER_PERIOD":           "a while",
+			},
+			err: "Invalid environment variable(s): Bad value for \"SKUPPER_CERTIFICATE_RENEWAL_FRACTION\": strconv.ParseFloat: parsing \"most\": invalid syntax, Bad value for \"SKUPPER_CA_ROLLOVER_PERIOD\": time: invalid duration \"a while\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			flags := flag.NewFlagSet("", flag.ContinueOnError)
+			config, err := BoundConfig(flags)
+			if tt.err != "" {
+				assert.Error(t, err, tt.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.NilError(t, flags.Parse(tt.args))
+			assert.DeepEqual(t, config, tt.expected)
+		})
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	assert.NilError(t, DefaultConfig().Verify())
+	assert.Error(t, (&Config{RenewalFraction: 1}).Verify(), "Certificate renewal fraction must be greater than 0 and less than 1, got 1.")
+	assert.Error(t, (&Config{RenewalFraction: 0.5, CaRolloverPeriod: -time.Hour}).Verify(), "CA rollover period must not be negative, got -1h0m0s.")
+	assert.Error(t, (&Config{RenewalFraction: 0.5, CertManagerIssuer: "Vault/corporate"}).Verify(), "Invalid cert-manager issuer kind \"Vault\", must be Issuer or ClusterIssuer")
+}
+
+func TestCaRollover(t *testing.T) {
+	config := &Config{
+		RenewalFraction:  0.5,
+		CaRolloverPeriod: time.Hour,
+	}
+	original := certs.GenerateCASecret("skupper-site-ca", "my site CA")
+	originalCert, err := decodeCertificate(original.Data["tls.crt"])
+	assert.NilError(t, err)
+	generate := func(subject string) corev1.Secret {
+		return certs.GenerateCASecret("skupper-site-ca", subject)
+	}
+
+	// before renewal time, nothing changes
+	updated, err := config.caRollover(&original, "my site CA", originalCert.NotBefore.Add(time.Hour), generate)
+	assert.NilError(t, err)
+	assert.Assert(t, updated == nil)
+
+	// at renewal time, a new CA is staged and trusted, but not used for signing
+	renewal := config.renewalTime(originalCert)
+	staged, err := config.caRollover(&original, "my site CA", renewal, generate)
+	assert.NilError(t, err)
+	assert.Assert(t, staged != nil)
+	assert.DeepEqual(t, staged.Data["tls.crt"], original.Data["tls.crt"])
+	assert.DeepEqual(t, staged.Data["tls.key"], original.Data["tls.key"])
+	assert.Assert(t, len(staged.Data[nextCaCert]) > 0)
+	assert.Assert(t, len(staged.Data[nextCaKey]) > 0)
+	assert.Equal(t, len(decodeBlocks(staged.Data["ca.crt"])), 2)
+	assert.Assert(t, bytes.Contains(staged.Data["ca.crt"], original.Data["tls.crt"]))
+	assert.Assert(t, bytes.Contains(staged.Data["ca.crt"], staged.Data[nextCaCert]))
+
+	// leaves issued during the rollover period trust both CAs
+	leaf := certs.GenerateSecret("skupper-site-server", "server", "host", staged)
+	leaf.Data["ca.crt"] = trustBundle(staged)
+	assert.DeepEqual(t, leaf.Data["ca.crt"], staged.Data["ca.crt"])
+	assert.Assert(t, isSignedBy(&leaf, staged))
+
+	// still within the rollover period, nothing changes
+	next, err := decodeCertificate(staged.Data[nextCaCert])
+	assert.NilError(t, err)
+	updated, err = config.caRollover(staged, "my site CA", next.NotBefore.Add(30*time.Minute), generate)
+	assert.NilError(t, err)
+	assert.Assert(t, updated == nil)
+	due, err := config.nextCaCheck(staged)
+	assert.NilError(t, err)
+	assert.Equal(t, due, next.NotBefore.Add(time.Hour))
+
+	// after the rollover period, the new CA is promoted and the old one still trusted
+	promoted, err := config.caRollover(staged, "my site CA", due, generate)
+	assert.NilError(t, err)
+	assert.Assert(t, promoted != nil)
+	assert.DeepEqual(t, promoted.Data["tls.crt"], staged.Data[nextCaCert])
+	assert.DeepEqual(t, promoted.Data["tls.key"], staged.Data[nextCaKey])
+	_, ok := promoted.Data[nextCaCert]
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(decodeBlocks(promoted.Data["ca.crt"])), 2)
+	assert.Assert(t, bytes.Contains(promoted.Data["ca.crt"], original.Data["tls.crt"]))
+	assert.Assert(t, bytes.Contains(promoted.Data["ca.crt"], promoted.Data["tls.crt"]))
+	assert.Assert(t, !isSignedBy(&leaf, promoted))
+
+	// leaves issued by the promoted CA serve it cross-signed by the old
+	// one, so that peers trusting either CA can verify them
+	reissued := certs.GenerateSecret("skupper-site-server", "server", "host", promoted)
+	reissued.Data["tls.crt"] = issuedChain(reissued.Data["tls.crt"], promoted, due)
+	assert.Equal(t, len(decodeBlocks(reissued.Data["tls.crt"])), 2)
+	assert.Assert(t, isSignedBy(&reissued, promoted))
+	for _, trusted := range [][]byte{original.Data["tls.crt"], promoted.Data["tls.crt"]} {
+		assert.NilError(t, verifyChain(reissued.Data["tls.crt"], trusted, due))
+	}
+	unchained := pem.EncodeToMemory(decodeBlocks(reissued.Data["tls.crt"])[0])
+	assert.Assert(t, verifyChain(unchained, original.Data["tls.crt"], due) != nil)
+
+	// once the old CA expires it is dropped from the bundle
+	shortLived := certs.GenerateSecretWithExpiration("old-ca", "old CA", "", time.Hour, nil)
+	promoted.Data["ca.crt"] = append(promoted.Data["ca.crt"], shortLived.Data["tls.crt"]...)
+	due, err = config.nextCaCheck(promoted)
+	assert.NilError(t, err)
+	shortLivedCert, err := decodeCertificate(shortLived.Data["tls.crt"])
+	assert.NilError(t, err)
+	assert.Equal(t, due, shortLivedCert.NotAfter)
+	pruned, err := config.caRollover(promoted, "my site CA", due.Add(time.Second), generate)
+	assert.NilError(t, err)
+	assert.Assert(t, pruned != nil)
+	assert.Equal(t, len(decodeBlocks(pruned.Data["ca.crt"])), 2)
+	assert.Assert(t, !bytes.Contains(pruned.Data["ca.crt"], shortLived.Data["tls.crt"]))
+
+	// once the old CA expires so does the cross-signed CA, which is
+	// no longer served
+	cross, err := decodeCertificate(promoted.Data[crossCaCert])
+	assert.NilError(t, err)
+	assert.Equal(t, cross.NotAfter, originalCert.NotAfter)
+	expired, err := config.caRollover(promoted, "my site CA", cross.NotAfter.Add(time.Second), generate)
+	assert.NilError(t, err)
+	_, ok = expired.Data[crossCaCert]
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(decodeBlocks(issuedChain(reissued.Data["tls.crt"], expired, cross.NotAfter.Add(time.Second)))), 1)
+}
+
+// verifyChain verifies a served certificate chain against a trusted CA,
+// as a peer would.
+func verifyChain(chain []byte, trusted []byte, now time.Time) error {
+	blocks := decodeBlocks(chain)
+	if len(blocks) == 0 {
+		return fmt.Errorf("No certificate found")
+	}
+	leaf, err := x509.ParseCertificate(blocks[0].Bytes)
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, block := range blocks[1:] {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		intermediates.AddCert(cert)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(trusted)
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+func TestTrustBundle(t *testing.T) {
+	ca := certs.GenerateCASecret("ca", "ca")
+	other := certs.GenerateCASecret("other", "other")
+	assert.DeepEqual(t, trustBundle(&ca), ca.Data["tls.crt"])
+
+	bundled := ca.DeepCopy()
+	bundled.Data["ca.crt"] = caBundle(ca.Data["tls.crt"], other.Data["tls.crt"], nil, time.Now())
+	assert.DeepEqual(t, trustBundle(bundled), bundled.Data["ca.crt"])
+
+	// a ca.crt that does not include the CA itself is not used
+	unrelated := ca.DeepCopy()
+	unrelated.Data["ca.crt"] = other.Data["tls.crt"]
+	assert.DeepEqual(t, trustBundle(unrelated), ca.Data["tls.crt"])
+}
+
+func TestRenewalTime(t *testing.T) {
+	secret := certs.GenerateSecretWithExpiration("leaf", "leaf", "", 100*time.Hour, nil)
+	cert, err := decodeCertificate(secret.Data["tls.crt"])
+	assert.NilError(t, err)
+	config := &Config{RenewalFraction: 0.75}
+	assert.Equal(t, config.renewalTime(cert), cert.NotBefore.Add(75*time.Hour))
+}
+
+func TestTrustedByLabel(t *testing.T) {
+	ca := certificate("skupper-site-ca", skupperv2alpha1.CertificateSpec{
+		Subject: "skupper-site-ca",
+		Signing: true,
+	})
+	server := certificate("skupper-site-server", skupperv2alpha1.CertificateSpec{
+		Ca:      "skupper-site-ca",
+		Subject: "skupper-router",
+		Hosts:   []string{"a.example.com"},
+		Server:  true,
+	})
+	client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{ca, server}, "")
+	assert.NilError(t, err)
+	controller := internalclient.NewController("Controller", client)
+	mgr := NewCertificateManager(controller)
+	assert.NilError(t, mgr.checkCertificate(ca.Key(), ca))
+	assert.NilError(t, mgr.checkCertificate(server.Key(), server))
+	getSecret := func(name string) *corev1.Secret {
+		t.Helper()
+		secret, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), name, metav1.GetOptions{})
+		assert.NilError(t, err)
+		return secret
+	}
+	siteCa := getSecret("skupper-site-ca")
+	assert.DeepEqual(t, getSecret("skupper-site-server").Data["ca.crt"], siteCa.Data["tls.crt"])
+
+	// a CA labelled as trusted by the site CA is trusted by its server certificates
+	trusted := certs.GenerateCASecret("trusted-ca", "trusted CA")
+	trusted.Namespace = "test"
+	trusted.Labels = map[string]string{TrustedByLabel: "skupper-site-ca"}
+	assert.NilError(t, mgr.checkSecret("test/trusted-ca", &trusted))
+	clientCert := certs.GenerateSecret("my-client", "my-client", "", &trusted)
+	bundle := getSecret("skupper-site-server").Data["ca.crt"]
+	assert.Equal(t, len(decodeBlocks(bundle)), 2)
+	assert.NilError(t, verifyChain(clientCert.Data["tls.crt"], bundle, time.Now()))
+	assert.NilError(t, verifyChain(getSecret("skupper-site-server").Data["tls.crt"], bundle, time.Now()))
+
+	// once it is deleted it is trusted no longer
+	assert.NilError(t, mgr.checkSecret("test/trusted-ca", nil))
+	bundle = getSecret("skupper-site-server").Data["ca.crt"]
+	assert.DeepEqual(t, bundle, siteCa.Data["tls.crt"])
+	assert.Assert(t, verifyChain(clientCert.Data["tls.crt"], bundle, time.Now()) != nil)
+
+	// an expired CA is not trusted, and is pruned
+	expired := certs.GenerateSecretWithExpiration("expired-ca", "expired CA", "", -time.Hour, nil)
+	expired.Namespace = "test"
+	expired.Labels = map[string]string{TrustedByLabel: "skupper-site-ca"}
+	_, err = client.GetKubeClient().CoreV1().Secrets("test").Create(context.Background(), &expired, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, mgr.checkSecret("test/expired-ca", &expired))
+	assert.DeepEqual(t, getSecret("skupper-site-server").Data["ca.crt"], siteCa.Data["tls.crt"])
+	assert.NilError(t, mgr.pruneTrusted("test/expired-ca"))
+	_, err = client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "expired-ca", metav1.GetOptions{})
+	assert.Assert(t, errors.IsNotFound(err))
+	_, ok := mgr.secrets["test/expired-ca"]
+	assert.Assert(t, !ok)
+
+	// one that has not expired is left alone
+	assert.NilError(t, mgr.checkSecret("test/trusted-ca", &trusted))
+	assert.NilError(t, mgr.pruneTrusted("test/trusted-ca"))
+	_, ok = mgr.secrets["test/trusted-ca"]
+	assert.Assert(t, ok)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -91,6 +92,9 @@ type Controller struct {
 	queue           workqueue.RateLimitingInterface
 	resync          time.Duration
 	watchers        []Watcher
+	metrics         QueueMetrics
+	pending         map[interface{}]time.Time
+	pendingLock     sync.Mutex
 }
 
 func NewController(name string, clients Clients) *Controller {
@@ -160,7 +164,7 @@ func (c *Controller) GetDeploymentForPod(podName string, namespace string) (*app
 }
 
 func (c *Controller) AddEvent(o interface{}) {
-	c.queue.Add(o)
+	c.enqueue(o)
 }
 
 func (c *Controller) Start(stopCh <-chan struct{}) {
@@ -192,7 +196,10 @@ func (c *Controller) process() bool {
 	retry := false
 	defer c.queue.Done(obj)
 	if evt, ok := obj.(ResourceChange); ok {
+		c.dequeued(evt)
+		start := time.Now()
 		err := evt.Handler.Handle(evt)
+		c.handled(evt, time.Since(start), err)
 		if err != nil {
 			retry = true
 			log.Printf("[%s] Error while handling %s: %s", c.errorKey, evt.Handler.Describe(evt), err)
@@ -203,6 +210,7 @@ func (c *Controller) process() bool {
 	c.queue.Forget(obj)
 
 	if retry && c.queue.NumRequeues(obj) < 5 {
+		c.track(obj)
 		c.queue.AddRateLimited(obj)
 	}
 
@@ -228,7 +236,7 @@ func (c *Controller) newEventHandler(handler ResourceChangeHandler) *cache.Resou
 				utilruntime.HandleError(err)
 			} else {
 				evt.Key = key
-				c.queue.Add(evt)
+				c.enqueue(evt)
 			}
 		},
 		UpdateFunc: func(old, new interface{}) {
@@ -237,7 +245,7 @@ func (c *Controller) newEventHandler(handler ResourceChangeHandler) *cache.Resou
 				utilruntime.HandleError(err)
 			} else {
 				evt.Key = key
-				c.queue.Add(evt)
+				c.enqueue(evt)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -246,7 +254,7 @@ func (c *Controller) newEventHandler(handler ResourceChangeHandler) *cache.Resou
 				utilruntime.HandleError(err)
 			} else {
 				evt.Key = key
-				c.queue.Add(evt)
+				c.enqueue(evt)
 			}
 		},
 	}
@@ -723,6 +731,7 @@ func (c *Controller) CallbackAfter(delay time.Duration, callback Callback, conte
 			context:  context,
 		},
 	}
+	c.track(evt)
 	c.queue.AddAfter(evt, delay)
 }
 
@@ -1762,3 +1771,70 @@ func (w *AttachedConnectorWatcher) List() []*skupperv2alpha1.AttachedConnector {
 	}
 	return results
 }
+
+func (c *Controller) WatchSitePolicies(namespace string, handler SitePolicyHandler) *SitePolicyWatcher {
+	watcher := &SitePolicyWatcher{
+		handler: handler,
+		informer: skupperv2alpha1informer.NewSitePolicyInformer(
+			c.skupperClient,
+			namespace,
+			time.Second*30,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		namespace: namespace,
+	}
+	watcher.informer.AddEventHandler(c.newEventHandler(watcher))
+	c.addWatcher(watcher)
+	return watcher
+}
+
+type SitePolicyHandler func(string, *skupperv2alpha1.SitePolicy) error
+
+type SitePolicyWatcher struct {
+	handler   SitePolicyHandler
+	informer  cache.SharedIndexInformer
+	namespace string
+}
+
+func (w *SitePolicyWatcher) Handle(event ResourceChange) error {
+	obj, err := w.Get(event.Key)
+	if err != nil {
+		return err
+	}
+	return w.handler(event.Key, obj)
+}
+
+func (w *SitePolicyWatcher) HasSynced() func() bool {
+	return w.informer.HasSynced
+}
+
+func (w *SitePolicyWatcher) Describe(event ResourceChange) string {
+	return fmt.Sprintf("SitePolicy %s", event.Key)
+}
+
+func (w *SitePolicyWatcher) Start(stopCh <-chan struct{}) {
+	go w.informer.Run(stopCh)
+}
+
+func (w *SitePolicyWatcher) Sync(stopCh <-chan struct{}) bool {
+	return cache.WaitForCacheSync(stopCh, w.informer.HasSynced)
+}
+
+func (w *SitePolicyWatcher) Get(key string) (*skupperv2alpha1.SitePolicy, error) {
+	entity, exists, err := w.informer.GetStore().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return entity.(*skupperv2alpha1.SitePolicy), nil
+}
+
+func (w *SitePolicyWatcher) List() []*skupperv2alpha1.SitePolicy {
+	list := w.informer.GetStore().List()
+	results := []*skupperv2alpha1.SitePolicy{}
+	for _, o := range list {
+		results = append(results, o.(*skupperv2alpha1.SitePolicy))
+	}
+	return results
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+// QueueMetrics is notified as events for a given kind of resource
+// pass through the event queue of a Controller.
+type QueueMetrics interface {
+	// Queued is called when an event is added to the queue.
+	Queued(kind string)
+	// Dequeued is called when an event is taken off the queue,
+	// along with the time it spent waiting.
+	Dequeued(kind string, latency time.Duration)
+	// Handled is called once an event has been handled, along with
+	// the time that took and any error returned.
+	Handled(kind string, duration time.Duration, err error)
+}
+
+// SetQueueMetrics registers the QueueMetrics the controller will
+// report to. It should be called before the controller is started.
+func (c *Controller) SetQueueMetrics(metrics QueueMetrics) {
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+	c.metrics = metrics
+	c.pending = map[interface{}]time.Time{}
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	c.track(obj)
+	c.queue.Add(obj)
+}
+
+// track records the time at which an event was queued. Events that
+// are already waiting are only counted once, as the queue itself
+// will merge them.
+func (c *Controller) track(obj interface{}) {
+	evt, ok := obj.(ResourceChange)
+	if !ok {
+		return
+	}
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+	if c.metrics == nil {
+		return
+	}
+	if _, ok := c.pending[evt]; ok {
+		return
+	}
+	c.pending[evt] = time.Now()
+	c.metrics.Queued(EventKind(evt))
+}
+
+func (c *Controller) dequeued(evt ResourceChange) {
+	c.pendingLock.Lock()
+	defer c.pendingLock.Unlock()
+	if c.metrics == nil {
+		return
+	}
+	queued, ok := c.pending[evt]
+	if !ok {
+		return
+	}
+	delete(c.pending, evt)
+	c.metrics.Dequeued(EventKind(evt), time.Since(queued))
+}
+
+func (c *Controller) handled(evt ResourceChange, duration time.Duration, err error) {
+	c.pendingLock.Lock()
+	metrics := c.metrics
+	c.pendingLock.Unlock()
+	if metrics != nil {
+		metrics.Handled(EventKind(evt), duration, err)
+	}
+}
+
+// EventKind returns the kind of resource an event relates to, for use
+// in reporting.
+func EventKind(evt ResourceChange) string {
+	switch handler := evt.Handler.(type) {
+	case *DynamicWatcher:
+		return handler.resource.Resource
+	case *CallbackHandler:
+		return "Callback"
+	default:
+		kind, _, _ := strings.Cut(handler.Describe(evt), " ")
+		return kind
+	}
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers/internalinterfaces"
@@ -16,6 +18,8 @@ import (
 	"github.com/skupperproject/skupper/internal/kube/certificates"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/internal/kube/metrics"
+	"github.com/skupperproject/skupper/internal/kube/policy"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 	"github.com/skupperproject/skupper/internal/kube/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -23,19 +27,23 @@ import (
 )
 
 type Controller struct {
-	controller           *internalclient.Controller
-	stopCh               <-chan struct{}
-	siteWatcher          *internalclient.SiteWatcher
-	listenerWatcher      *internalclient.ListenerWatcher
-	connectorWatcher     *internalclient.ConnectorWatcher
-	linkAccessWatcher    *internalclient.RouterAccessWatcher
-	grantWatcher         *internalclient.AccessGrantWatcher
-	sites                map[string]*site.Site
-	startGrantServer     func()
-	accessMgr            *securedaccess.SecuredAccessManager
-	accessRecovery       *securedaccess.SecuredAccessResourceWatcher
-	certMgr              *certificates.CertificateManagerImpl
-	attachableConnectors map[string]*skupperv2alpha1.AttachedConnector
+	controller                      *internalclient.Controller
+	stopCh                          <-chan struct{}
+	siteWatcher                     *internalclient.SiteWatcher
+	listenerWatcher                 *internalclient.ListenerWatcher
+	connectorWatcher                *internalclient.ConnectorWatcher
+	linkWatcher                     *internalclient.LinkWatcher
+	linkAccessWatcher               *internalclient.RouterAccessWatcher
+	grantWatcher                    *internalclient.AccessGrantWatcher
+	policyWatcher                   *internalclient.SitePolicyWatcher
+	attachedConnectorBindingWatcher *internalclient.AttachedConnectorBindingWatcher
+	sites                           map[string]*site.Site
+	grants                          *grants.GrantsEnabled
+	accessMgr                       *securedaccess.SecuredAccessManager
+	accessRecovery                  *securedaccess.SecuredAccessResourceWatcher
+	certMgr                         *certificates.CertificateManagerImpl
+	attachableConnectors            map[string]*skupperv2alpha1.AttachedConnector
+	policies                        *policy.Policies
 }
 
 func skupperRouterService() internalinterfaces.TweakListOptionsFunc {
@@ -50,12 +58,15 @@ func skupperNetworkStatus() internalinterfaces.TweakListOptionsFunc {
 	}
 }
 
-func NewController(cli internalclient.Clients, grantConfig *grants.GrantConfig, securedAccessConfig *securedaccess.Config, watchNamespace string, currentNamespace string) (*Controller, error) {
+func NewController(cli internalclient.Clients, grantConfig *grants.GrantConfig, securedAccessConfig *securedaccess.Config, certificateConfig *certificates.Config, watchNamespace string, currentNamespace string, reg prometheus.Registerer) (*Controller, error) {
 	controller := &Controller{
 		controller:           internalclient.NewController("Controller", cli),
 		sites:                map[string]*site.Site{},
 		attachableConnectors: map[string]*skupperv2alpha1.AttachedConnector{},
+		policies:             policy.NewPolicies(currentNamespace),
 	}
+	controllerMetrics := metrics.New(reg)
+	controller.controller.SetQueueMetrics(controllerMetrics)
 
 	podname := os.Getenv("HOSTNAME")
 	owner, err := controller.controller.GetDeploymentForPod(podname, currentNamespace)
@@ -74,22 +85,33 @@ func NewController(cli internalclient.Clients, grantConfig *grants.GrantConfig,
 	controller.connectorWatcher = controller.controller.WatchConnectors(watchNamespace, controller.checkConnector)
 	controller.linkAccessWatcher = controller.controller.WatchRouterAccesses(watchNamespace, controller.checkRouterAccess)
 	controller.controller.WatchAttachedConnectors(watchNamespace, controller.checkAttachedConnector)
-	controller.controller.WatchAttachedConnectorBindings(watchNamespace, controller.checkAttachedConnectorBinding)
-	controller.controller.WatchLinks(watchNamespace, controller.checkLink)
+	controller.attachedConnectorBindingWatcher = controller.controller.WatchAttachedConnectorBindings(watchNamespace, controller.checkAttachedConnectorBinding)
+	controller.linkWatcher = controller.controller.WatchLinks(watchNamespace, controller.checkLink)
 	controller.controller.WatchConfigMaps(skupperNetworkStatus(), watchNamespace, controller.networkStatusUpdate)
 	controller.controller.WatchAccessTokens(watchNamespace, controller.checkAccessToken)
 	controller.controller.WatchPods("skupper.io/component=router,skupper.io/type=site", watchNamespace, controller.routerPodEvent)
+	controller.policyWatcher = controller.controller.WatchSitePolicies(watchNamespace, controller.checkSitePolicy)
 
 	controller.certMgr = certificates.NewCertificateManager(controller.controller)
+	controller.certMgr.SetConfig(certificateConfig)
+	controller.certMgr.SetExpiryRecorder(controllerMetrics)
 	controller.certMgr.Watch(watchNamespace)
 
 	controller.accessMgr = securedaccess.NewSecuredAccessManager(controller.controller, controller.certMgr, securedAccessConfig, controllerContext)
+	controller.accessMgr.SetPolicy(controller.policies)
 	controller.accessRecovery = securedaccess.NewSecuredAccessResourceWatcher(controller.accessMgr)
 	controller.accessRecovery.WatchResources(controller.controller, watchNamespace)
 	controller.accessRecovery.WatchSecuredAccesses(controller.controller, watchNamespace, controller.checkSecuredAccess)
 	controller.accessRecovery.WatchGateway(controller.controller, currentNamespace)
 
-	controller.startGrantServer = grants.Initialise(controller.controller, currentNamespace, watchNamespace, grantConfig, controller.generateLinkConfig)
+	controller.grants = grants.Initialise(controller.controller, currentNamespace, watchNamespace, grantConfig, controller.generateLinkConfig, controllerMetrics, controller.policies)
+
+	reg.MustRegister(metrics.NewResourceCollector(metrics.ResourceListers{
+		Sites:      controller.siteWatcher.List,
+		Listeners:  controller.listenerWatcher.List,
+		Connectors: controller.connectorWatcher.List,
+		Links:      controller.linkWatcher.List,
+	}))
 
 	controller.controller.WatchConfigMaps(skupperLogConfig(), currentNamespace, controller.logConfigUpdate)
 
@@ -105,10 +127,16 @@ func (c *Controller) Run(stopCh <-chan struct{}) error {
 	if ok := c.controller.WaitForCacheSync(stopCh); !ok {
 		return fmt.Errorf("Failed to wait for caches to sync")
 	}
+	for _, policy := range c.policyWatcher.List() {
+		if _, err := c.policies.Update(policy.Namespace+"/"+policy.Name, policy); err != nil {
+			log.Printf("Error in SitePolicy %s/%s: %s", policy.Namespace, policy.Name, err)
+		}
+	}
 	//TODO: need to recover active sites first
 	//recover existing sites & bindings
 	for _, site := range c.siteWatcher.List() {
 		log.Printf("Recovering site %s/%s", site.ObjectMeta.Namespace, site.ObjectMeta.Name)
+		c.setCertificateIssuer(site.ObjectMeta.Namespace, site)
 		err := c.getSite(site.ObjectMeta.Namespace).StartRecovery(site)
 		if err != nil {
 			log.Printf("Error recovering site for %s/%s: %s", site.ObjectMeta.Namespace, site.ObjectMeta.Name, err)
@@ -137,8 +165,8 @@ func (c *Controller) Run(stopCh <-chan struct{}) error {
 	}
 	c.certMgr.Recover()
 	c.accessRecovery.Recover()
-	if c.startGrantServer != nil {
-		c.startGrantServer()
+	if c.grants != nil {
+		c.grants.Start()
 	}
 
 	log.Println("Starting event loop")
@@ -152,7 +180,7 @@ func (c *Controller) getSite(namespace string) *site.Site {
 	if existing, ok := c.sites[namespace]; ok {
 		return existing
 	}
-	site := site.NewSite(namespace, c.controller, c.certMgr, c.accessMgr)
+	site := site.NewSite(namespace, c.controller, c.certMgr, c.accessMgr, c.policies)
 	c.sites[namespace] = site
 	return site
 }
@@ -160,6 +188,7 @@ func (c *Controller) getSite(namespace string) *site.Site {
 func (c *Controller) checkSite(key string, site *skupperv2alpha1.Site) error {
 	log.Printf("Checking site %s", key)
 	if site != nil {
+		c.setCertificateIssuer(site.ObjectMeta.Namespace, site)
 		err := c.getSite(site.ObjectMeta.Namespace).Reconcile(site)
 		if err != nil {
 			log.Printf("Error initialising site for %s: %s", key, err)
@@ -171,10 +200,23 @@ func (c *Controller) checkSite(key string, site *skupperv2alpha1.Site) error {
 		}
 		c.getSite(namespace).Deleted()
 		delete(c.sites, namespace)
+		c.setCertificateIssuer(namespace, nil)
 	}
 	return nil
 }
 
+// setCertificateIssuer applies any choice of certificate issuer made
+// in the settings of the site for the namespace.
+func (c *Controller) setCertificateIssuer(namespace string, site *skupperv2alpha1.Site) {
+	setting := ""
+	if site != nil {
+		setting = site.Spec.GetCertManagerIssuer()
+	}
+	if err := c.certMgr.SetIssuer(namespace, setting); err != nil {
+		log.Printf("Invalid certificate issuer for site in %s: %s", namespace, err)
+	}
+}
+
 func (c *Controller) checkConnector(key string, connector *skupperv2alpha1.Connector) error {
 	log.Printf("checkConnector(%s)", key)
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -220,7 +262,7 @@ func (c *Controller) routerPodEvent(key string, pod *corev1.Pod) error {
 	return c.getSite(namespace).RouterPodEvent(key, pod)
 }
 
-func (c *Controller) generateLinkConfig(namespace string, name string, subject string, writer io.Writer) error {
+func (c *Controller) generateLinkConfig(namespace string, name string, subject string, issuer *corev1.Secret, writer io.Writer) error {
 	site := c.getSite(namespace).GetSite()
 	if site == nil {
 		return fmt.Errorf("Site not yet defined for %s", namespace)
@@ -229,10 +271,48 @@ func (c *Controller) generateLinkConfig(namespace string, name string, subject s
 	if err != nil {
 		return err
 	}
-	token := generator.NewCertToken(name, subject)
+	token, err := generator.NewRevocableCertToken(issuer, name, subject)
+	if err != nil {
+		return err
+	}
 	return token.Write(writer)
 }
 
+// checkSitePolicy applies a change to a SitePolicy, rechecking the
+// Listeners, Connectors, SecuredAccesses and AccessGrant redemptions it
+// may affect.
+func (c *Controller) checkSitePolicy(key string, sp *skupperv2alpha1.SitePolicy) error {
+	changed, invalid := c.policies.Update(key, sp)
+	if sp != nil && sp.SetConfigured(invalid) {
+		if _, err := c.controller.GetSkupperClient().SkupperV2alpha1().SitePolicies(sp.Namespace).UpdateStatus(context.TODO(), sp, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	if !changed {
+		return nil
+	}
+	log.Printf("SitePolicy %s changed, rechecking listeners, connectors, attached connector bindings, secured access and grant redemptions", key)
+	for _, connector := range c.connectorWatcher.List() {
+		if err := c.getSite(connector.Namespace).CheckConnector(connector.Name, connector); err != nil {
+			log.Printf("Error rechecking connector %s/%s against policy: %s", connector.Namespace, connector.Name, err)
+		}
+	}
+	for _, listener := range c.listenerWatcher.List() {
+		if err := c.getSite(listener.Namespace).CheckListener(listener.Name, listener); err != nil {
+			log.Printf("Error rechecking listener %s/%s against policy: %s", listener.Namespace, listener.Name, err)
+		}
+	}
+	for _, binding := range c.attachedConnectorBindingWatcher.List() {
+		if err := c.getSite(binding.Namespace).CheckAttachedConnectorBinding(binding.Namespace, binding.Name, binding); err != nil {
+			log.Printf("Error rechecking attached connector binding %s/%s against policy: %s", binding.Namespace, binding.Name, err)
+		}
+	}
+	if c.grants != nil {
+		c.grants.PolicyChanged()
+	}
+	return c.accessMgr.PolicyChanged()
+}
+
 func (c *Controller) checkSecuredAccess(key string, se *skupperv2alpha1.SecuredAccess) error {
 	c.getSite(se.ObjectMeta.Namespace).CheckSecuredAccess(se)
 	return nil
@@ -3,7 +3,9 @@ package grants
 import (
 	"flag"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	iflag "github.com/skupperproject/skupper/internal/flag"
 )
@@ -15,6 +17,13 @@ type GrantConfig struct {
 	Port                 int
 	TlsCredentialsSecret string
 	Hostname             string
+	SourceRateLimit      float64
+	SourceBurst          int
+	GrantRateLimit       float64
+	GrantBurst           int
+	LockoutThreshold     int
+	LockoutDuration      time.Duration
+	TrustedProxies       []string
 }
 
 func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
@@ -32,12 +41,76 @@ func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
 	}
 	iflag.StringVar(flags, &c.TlsCredentialsSecret, "grant-server-tls-credentials", "SKUPPER_GRANT_SERVER_TLS_CREDENTIALS", "skupper-grant-server", "The name of a secret in which TLS credentials for the AccessGrant server are found.")
 	iflag.StringVar(flags, &c.Hostname, "grant-server-podname", "HOSTNAME", "", "The name of the pod in which the AccessGrant server is running (defaults to $HOSTNAME).")
+	if err := iflag.Float64Var(flags, &c.SourceRateLimit, "grant-server-source-rate-limit", "SKUPPER_GRANT_SERVER_SOURCE_RATE_LIMIT", 0, "The number of redemption attempts per second allowed from a single source address (0, the default, for no limit). Behind an ingress controller, route or load balancer, the proxies must be listed in --grant-server-trusted-proxies, or every attempt shares the proxy's address."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &c.SourceBurst, "grant-server-source-burst", "SKUPPER_GRANT_SERVER_SOURCE_BURST", 5, "The number of redemption attempts a single source address may make in a burst."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.Float64Var(flags, &c.GrantRateLimit, "grant-server-grant-rate-limit", "SKUPPER_GRANT_SERVER_GRANT_RATE_LIMIT", 5, "The number of redemption attempts per second allowed for a single AccessGrant (0 for no limit)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &c.GrantBurst, "grant-server-grant-burst", "SKUPPER_GRANT_SERVER_GRANT_BURST", 10, "The number of redemption attempts that may be made for a single AccessGrant in a burst."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &c.LockoutThreshold, "grant-lockout-threshold", "SKUPPER_GRANT_LOCKOUT_THRESHOLD", 10, "The number of consecutive invalid codes after which an AccessGrant is locked (0 to disable lockout)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.LockoutDuration, "grant-lockout-duration", "SKUPPER_GRANT_LOCKOUT_DURATION", 15*time.Minute, "How long an AccessGrant remains locked after too many invalid codes (0 to lock until the grant is recreated)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	iflag.MultiStringVar(flags, &c.TrustedProxies, "grant-server-trusted-proxies", "SKUPPER_GRANT_SERVER_TRUSTED_PROXIES", nil, "Comma separated addresses or CIDR ranges of proxies (such as an ingress controller or load balancer) trusted to report the source of redemption attempts in X-Forwarded-For. Attempts from any other address are attributed to that address.")
 	if len(errors) > 0 {
 		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
 	}
 	return c, nil
 }
 
+func (c *GrantConfig) Verify() error {
+	if c.SourceRateLimit < 0 || c.GrantRateLimit < 0 {
+		return fmt.Errorf("Grant server rate limits must not be negative, got %v per source and %v per grant.", c.SourceRateLimit, c.GrantRateLimit)
+	}
+	if (c.SourceRateLimit > 0 && c.SourceBurst < 1) || (c.GrantRateLimit > 0 && c.GrantBurst < 1) {
+		return fmt.Errorf("Grant server bursts must be at least 1 when rate limited, got %d per source and %d per grant.", c.SourceBurst, c.GrantBurst)
+	}
+	if c.LockoutThreshold < 0 {
+		return fmt.Errorf("Grant lockout threshold must not be negative, got %d.", c.LockoutThreshold)
+	}
+	if c.LockoutDuration < 0 {
+		return fmt.Errorf("Grant lockout duration must not be negative, got %s.", c.LockoutDuration)
+	}
+	if _, err := c.trustedProxies(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// trustedProxies parses the addresses and CIDR ranges of the trusted
+// proxies.
+func (c *GrantConfig) trustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, proxy := range c.TrustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid grant server trusted proxy %q, must be an address or CIDR range.", proxy)
+		}
+		proxies = append(proxies, cidr)
+	}
+	return proxies, nil
+}
+
 func (c *GrantConfig) addr() string {
 	return fmt.Sprintf(":%d", c.Port)
 }
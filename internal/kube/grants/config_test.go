@@ -4,6 +4,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -22,6 +23,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "skupper-grant-server",
 				Hostname:             os.Getenv("HOSTNAME"),
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -41,6 +48,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -68,6 +81,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9876,
 				TlsCredentialsSecret: "a-different-secret",
 				Hostname:             "a-different-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -87,6 +106,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -111,6 +136,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -135,6 +166,12 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
 			},
 		},
 		{
@@ -159,6 +196,38 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				SourceRateLimit:      0,
+				SourceBurst:          5,
+				GrantRateLimit:       5,
+				GrantBurst:           10,
+				LockoutThreshold:     10,
+				LockoutDuration:      15 * time.Minute,
+			},
+		},
+		{
+			name: "limits",
+			env: map[string]string{
+				"SKUPPER_GRANT_SERVER_SOURCE_RATE_LIMIT": "0.5",
+				"SKUPPER_GRANT_LOCKOUT_THRESHOLD":        "3",
+			},
+			args: []string{
+				"--grant-server-source-burst=2",
+				"--grant-server-grant-rate-limit=0",
+				"--grant-server-grant-burst=1",
+				"--grant-lockout-duration=1h",
+				"--grant-server-trusted-proxies=10.0.0.1,192.168.0.0/16",
+			},
+			expectedValue: &GrantConfig{
+				Port:                 9090,
+				TlsCredentialsSecret: "skupper-grant-server",
+				Hostname:             os.Getenv("HOSTNAME"),
+				SourceRateLimit:      0.5,
+				SourceBurst:          2,
+				GrantRateLimit:       0,
+				GrantBurst:           1,
+				LockoutThreshold:     3,
+				LockoutDuration:      time.Hour,
+				TrustedProxies:       []string{"10.0.0.1", "192.168.0.0/16"},
 			},
 		},
 	}
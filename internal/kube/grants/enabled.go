@@ -14,6 +14,7 @@ func enabled(controller *internalclient.Controller, currentNamespace string, wat
 	gc := &GrantsEnabled{
 		grants: newGrants(controller, generator, config.scheme(), config.BaseUrl),
 	}
+	gc.grants.limits = newRedemptionLimits(config)
 	gc.server = newServer(config.addr(), config.tlsEnabled(), gc.grants)
 
 	gc.grantWatcher = controller.WatchAccessGrants(watchNamespace, gc.grants.checkGrant)
@@ -46,6 +47,12 @@ func (c *GrantsEnabled) Start() {
 	}
 }
 
+// PolicyChanged revokes any redemptions of grants by sites that the
+// policies no longer allow to link.
+func (c *GrantsEnabled) PolicyChanged() {
+	c.grants.recheckPolicy()
+}
+
 func (c *GrantsEnabled) recoverGrants() {
 	for _, grant := range c.grantWatcher.List() {
 		c.grants.checkGrant(fmt.Sprintf("%s/%s", grant.Namespace, grant.Name), grant)
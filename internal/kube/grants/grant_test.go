@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,14 +22,14 @@ import (
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
-func dummyGenerator(namespace string, name string, subject string, writer io.Writer) error {
+func dummyGenerator(namespace string, name string, subject string, issuer *corev1.Secret, writer io.Writer) error {
 	io.WriteString(writer, namespace+",")
 	io.WriteString(writer, name+",")
 	io.WriteString(writer, subject)
 	return nil
 }
 
-func dummyGeneratorWithError(namespace string, name string, subject string, writer io.Writer) error {
+func dummyGeneratorWithError(namespace string, name string, subject string, issuer *corev1.Secret, writer io.Writer) error {
 	return errors.New("Failed")
 }
 
@@ -212,12 +215,13 @@ func Test_ServeHttp(t *testing.T) {
 	}
 
 	var tests = []struct {
-		name         string
-		method       string
-		path         string
-		body         io.Reader
-		expectedCode int
-		generator    GrantResponse
+		name            string
+		method          string
+		path            string
+		body            io.Reader
+		expectedCode    int
+		generator       GrantResponse
+		expectedOutcome string
 	}{
 		{
 			name:         "bad method",
@@ -226,47 +230,53 @@ func Test_ServeHttp(t *testing.T) {
 			expectedCode: http.StatusMethodNotAllowed,
 		},
 		{
-			name:         "successful redeem",
-			method:       http.MethodPost,
-			path:         "/" + string(good.ObjectMeta.UID),
-			body:         bytes.NewBufferString(good.Status.Code),
-			expectedCode: http.StatusOK,
+			name:            "successful redeem",
+			method:          http.MethodPost,
+			path:            "/" + string(good.ObjectMeta.UID),
+			body:            bytes.NewBufferString(good.Status.Code),
+			expectedCode:    http.StatusOK,
+			expectedOutcome: RedemptionSucceeded,
 		},
 		{
-			name:         "expired grant",
-			method:       http.MethodPost,
-			path:         "/" + string(expired.ObjectMeta.UID),
-			body:         bytes.NewBufferString(expired.Status.Code),
-			expectedCode: http.StatusNotFound,
+			name:            "expired grant",
+			method:          http.MethodPost,
+			path:            "/" + string(expired.ObjectMeta.UID),
+			body:            bytes.NewBufferString(expired.Status.Code),
+			expectedCode:    http.StatusNotFound,
+			expectedOutcome: RedemptionExpired,
 		},
 		{
-			name:         "used grant",
-			method:       http.MethodPost,
-			path:         "/" + string(used.ObjectMeta.UID),
-			body:         bytes.NewBufferString(used.Status.Code),
-			expectedCode: http.StatusNotFound,
+			name:            "used grant",
+			method:          http.MethodPost,
+			path:            "/" + string(used.ObjectMeta.UID),
+			body:            bytes.NewBufferString(used.Status.Code),
+			expectedCode:    http.StatusNotFound,
+			expectedOutcome: RedemptionExhausted,
 		},
 		{
-			name:         "wrong code",
-			method:       http.MethodPost,
-			path:         "/" + string(good.ObjectMeta.UID),
-			body:         bytes.NewBufferString("opensesame"),
-			expectedCode: http.StatusForbidden,
+			name:            "wrong code",
+			method:          http.MethodPost,
+			path:            "/" + string(good.ObjectMeta.UID),
+			body:            bytes.NewBufferString("opensesame"),
+			expectedCode:    http.StatusForbidden,
+			expectedOutcome: RedemptionRefused,
 		},
 		{
-			name:         "invalid path",
-			method:       http.MethodPost,
-			path:         "/Idonotexist",
-			body:         bytes.NewBufferString("opensesame"),
-			expectedCode: http.StatusNotFound,
+			name:            "invalid path",
+			method:          http.MethodPost,
+			path:            "/Idonotexist",
+			body:            bytes.NewBufferString("opensesame"),
+			expectedCode:    http.StatusNotFound,
+			expectedOutcome: RedemptionNotFound,
 		},
 		{
-			name:         "generator error",
-			method:       http.MethodPost,
-			path:         "/" + string(good.ObjectMeta.UID),
-			body:         bytes.NewBufferString(good.Status.Code),
-			expectedCode: http.StatusInternalServerError,
-			generator:    dummyGeneratorWithError,
+			name:            "generator error",
+			method:          http.MethodPost,
+			path:            "/" + string(good.ObjectMeta.UID),
+			body:            bytes.NewBufferString(good.Status.Code),
+			expectedCode:    http.StatusInternalServerError,
+			generator:       dummyGeneratorWithError,
+			expectedOutcome: RedemptionFailed,
 		},
 		{
 			name:         "bad body",
@@ -276,18 +286,20 @@ func Test_ServeHttp(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 		},
 		{
-			name:         "bad expiration",
-			method:       http.MethodPost,
-			path:         "/" + string(badExpiration.ObjectMeta.UID),
-			body:         bytes.NewBufferString(badExpiration.Status.Code),
-			expectedCode: http.StatusInternalServerError,
+			name:            "bad expiration",
+			method:          http.MethodPost,
+			path:            "/" + string(badExpiration.ObjectMeta.UID),
+			body:            bytes.NewBufferString(badExpiration.Status.Code),
+			expectedCode:    http.StatusInternalServerError,
+			expectedOutcome: RedemptionFailed,
 		},
 		{
-			name:         "error updating status",
-			method:       http.MethodPost,
-			path:         "/" + string(deleted.ObjectMeta.UID),
-			body:         bytes.NewBufferString(deleted.Status.Code),
-			expectedCode: http.StatusServiceUnavailable,
+			name:            "error updating status",
+			method:          http.MethodPost,
+			path:            "/" + string(deleted.ObjectMeta.UID),
+			body:            bytes.NewBufferString(deleted.Status.Code),
+			expectedCode:    http.StatusServiceUnavailable,
+			expectedOutcome: RedemptionFailed,
 		},
 	}
 	for _, tt := range tests {
@@ -301,6 +313,8 @@ func Test_ServeHttp(t *testing.T) {
 				generator = dummyGenerator
 			}
 			registry := newGrants(client, generator, "https", "")
+			recorder := &fakeRedemptionRecorder{}
+			registry.recorder = recorder
 			for _, grant := range []*v2alpha1.AccessGrant{good, expired, used, badExpiration, deleted} {
 				err = registry.checkGrant(grant.Namespace+"/"+grant.Name, grant)
 				if err != nil {
@@ -315,11 +329,104 @@ func Test_ServeHttp(t *testing.T) {
 			res := httptest.NewRecorder()
 			registry.ServeHTTP(res, req)
 			assert.Equal(t, res.Code, tt.expectedCode)
+			if tt.expectedOutcome == "" {
+				assert.Equal(t, len(recorder.outcomes), 0)
+			} else {
+				assert.DeepEqual(t, recorder.outcomes, []string{tt.expectedOutcome})
+			}
 		})
 	}
 
 }
 
+type fakeRedemptionRecorder struct {
+	outcomes []string
+}
+
+func (r *fakeRedemptionRecorder) Redemption(namespace string, outcome string) {
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+type fakeRedemptionPolicy struct {
+	allowed bool
+	checked []string
+}
+
+func (p *fakeRedemptionPolicy) CheckGrantRedemption(namespace string, siteName string, siteNamespace string) error {
+	p.checked = append(p.checked, fmt.Sprintf("%s <- %s/%s", namespace, siteNamespace, siteName))
+	if !p.allowed {
+		return errors.New("Redemption not allowed by policy")
+	}
+	return nil
+}
+
+func TestRedemptionDeniedByPolicy(t *testing.T) {
+	grant := tf.grant("my-grant", "test", "")
+	client, err := fake.NewFakeClient("test", []runtime.Object{tf.secret("skupper-site-ca", "test", "Test Site CA", nil)}, []runtime.Object{tf.site("my-site", "remote"), grant}, "")
+	assert.NilError(t, err)
+	site, err := client.GetSkupperClient().SkupperV2alpha1().Sites("remote").Get(context.TODO(), "my-site", metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	grants := newGrants(client, dummyGenerator, "http", "")
+	recorder := &fakeRedemptionRecorder{}
+	grants.recorder = recorder
+	policy := &fakeRedemptionPolicy{}
+	grants.policy = policy
+	server := newServer(":0", false, grants)
+	server.listen()
+	grants.setUrl(fmt.Sprintf("localhost:%d", server.port()))
+	go server.serve()
+	defer server.stop()
+	grants.setCA("dummy")
+	assert.NilError(t, grants.checkGrant("test/my-grant", grant))
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	// the redeeming site identifies itself, and is refused if the policy denies it
+	token := tf.token("my-token", "remote", grant.Status.Url, grant.Status.Code, grant.Status.Ca)
+	token, err = client.GetSkupperClient().SkupperV2alpha1().AccessTokens("remote").Create(context.TODO(), token, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, RedeemAccessToken(token, site, client))
+	token, err = client.GetSkupperClient().SkupperV2alpha1().AccessTokens("remote").Get(context.TODO(), "my-token", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, !token.IsRedeemed())
+	assert.Assert(t, strings.Contains(token.Status.Message, "403"), token.Status.Message)
+	assert.DeepEqual(t, policy.checked, []string{"test <- remote/my-site"})
+	assert.Equal(t, recorder.outcomes[len(recorder.outcomes)-1], RedemptionDenied)
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, grant.Status.Redemptions, 0)
+
+	// a denied redemption does not use up the grant
+	policy.allowed = true
+	req := httptest.NewRequest(http.MethodPost, "/"+string(grant.ObjectMeta.UID), bytes.NewBufferString(grant.Status.Code))
+	req.Header.Add("site-name", "my-site")
+	req.Header.Add("site-namespace", "remote")
+	res := httptest.NewRecorder()
+	grants.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, http.StatusOK)
+	assert.Equal(t, recorder.outcomes[len(recorder.outcomes)-1], RedemptionSucceeded)
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, grant.Status.Redeemed[0].SiteName, "my-site")
+	assert.Equal(t, grant.Status.Redeemed[0].SiteNamespace, "remote")
+
+	// the redemption stands while the policy allows the site
+	grants.recheckPolicy()
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, grant.Status.Redeemed[0].Revoked, "")
+
+	// and is revoked once the policy changes to deny it
+	policy.allowed = false
+	policy.checked = nil
+	grants.recheckPolicy()
+	assert.DeepEqual(t, policy.checked, []string{"test <- remote/my-site"})
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, grant.Status.Redeemed[0].Revoked != "")
+}
+
 type CheckGrantTestInvocation struct {
 	key           string
 	grant         *v2alpha1.AccessGrant
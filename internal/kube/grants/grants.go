@@ -1,24 +1,62 @@
 package grants
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubetypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/utils"
 )
 
-type GrantResponse func(namespace string, name string, subject string, writer io.Writer) error
+// GrantResponse writes the token returned for a redemption. The client
+// certificate in it is issued by a new CA, created from the issuer
+// Secret, so that the access it gives can be revoked.
+type GrantResponse func(namespace string, name string, subject string, issuer *corev1.Secret, writer io.Writer) error
+
+// RedemptionRecorder is notified of each attempt to redeem an
+// AccessGrant. The namespace is empty when no matching grant exists.
+type RedemptionRecorder interface {
+	Redemption(namespace string, outcome string)
+}
+
+// RedemptionPolicy determines the remote sites that may redeem the
+// AccessGrants in a namespace. Remote sites are identified by the name
+// and namespace they give when redeeming a grant, which are not
+// verified: the policy guards against grants being redeemed by the
+// wrong sites, not against holders of a grant's code misrepresenting
+// themselves. It is checked on redemption and again, for each
+// redemption recorded, whenever the policies change, revoking those no
+// longer allowed.
+type RedemptionPolicy interface {
+	CheckGrantRedemption(namespace string, siteName string, siteNamespace string) error
+}
+
+const (
+	RedemptionSucceeded   = "succeeded"
+	RedemptionNotFound    = "not_found"
+	RedemptionExpired     = "expired"
+	RedemptionExhausted   = "exhausted"
+	RedemptionRefused     = "refused"
+	RedemptionRevoked     = "revoked"
+	RedemptionLocked      = "locked"
+	RedemptionRateLimited = "rate_limited"
+	RedemptionDenied      = "denied"
+	RedemptionFailed      = "failed"
+)
 
 type Grants struct {
 	clients    internalclient.Clients
@@ -29,6 +67,10 @@ type Grants struct {
 	grants     map[kubetypes.UID]*skupperv2alpha1.AccessGrant
 	grantIndex map[string]kubetypes.UID
 	lock       sync.Mutex
+	recorder   RedemptionRecorder
+	policy     RedemptionPolicy
+	limits     *redemptionLimits
+	audit      *slog.Logger
 }
 
 func newGrants(clients internalclient.Clients, generator GrantResponse, scheme string, url string) *Grants {
@@ -39,6 +81,9 @@ func newGrants(clients internalclient.Clients, generator GrantResponse, scheme s
 		url:        url,
 		grants:     map[kubetypes.UID]*skupperv2alpha1.AccessGrant{},
 		grantIndex: map[string]kubetypes.UID{},
+		audit: slog.New(slog.Default().Handler()).With(
+			slog.String("component", "kube.grants.audit"),
+		),
 	}
 }
 
@@ -74,6 +119,7 @@ func (g *Grants) remove(key string) {
 	if uid, ok := g.grantIndex[key]; ok {
 		delete(g.grantIndex, key)
 		delete(g.grants, uid)
+		g.limits.forget(string(uid))
 	}
 }
 
@@ -221,6 +267,12 @@ func (g *Grants) checkGrant(key string, grant *skupperv2alpha1.AccessGrant) erro
 	if grant.SetResolved() {
 		changed = true
 	}
+	if g.checkRevocations(key, grant) {
+		changed = true
+	}
+	if g.checkPolicy(key, grant) {
+		changed = true
+	}
 
 	if !changed {
 		return nil
@@ -228,6 +280,12 @@ func (g *Grants) checkGrant(key string, grant *skupperv2alpha1.AccessGrant) erro
 	return g.updateGrantStatus(grant)
 }
 
+func (g *Grants) recordRedemption(namespace string, outcome string) {
+	if g.recorder != nil {
+		g.recorder.Redemption(namespace, outcome)
+	}
+}
+
 func (g *Grants) updateGrantStatus(grant *skupperv2alpha1.AccessGrant) error {
 	updated, err := g.clients.GetSkupperClient().SkupperV2alpha1().AccessGrants(grant.ObjectMeta.Namespace).UpdateStatus(context.TODO(), grant, metav1.UpdateOptions{})
 	if err != nil {
@@ -237,73 +295,147 @@ func (g *Grants) updateGrantStatus(grant *skupperv2alpha1.AccessGrant) error {
 	return nil
 }
 
-func (g *Grants) checkAndUpdateAccessToken(key string, data []byte) (*skupperv2alpha1.AccessGrant, *HttpError) {
-	log.Printf("Checking access token for %s", key)
-	grant := g.get(key)
+func (g *Grants) checkAccessToken(attempt redemptionAttempt, data []byte, name string, subject string) (*skupperv2alpha1.AccessGrant, *HttpError) {
+	log.Printf("Checking access token for %s", attempt.key)
+	grant := g.get(attempt.key)
 	if grant == nil {
+		g.rejected(attempt, nil, RedemptionNotFound, "No such AccessGrant")
 		return nil, httpError("No such claim", http.StatusNotFound)
 	}
+	if g.limits.isLocked(grant) {
+		g.rejected(attempt, grant, RedemptionLocked, "AccessGrant locked after too many invalid codes")
+		return nil, httpError("Redemption of access token refused", http.StatusForbidden)
+	}
 
 	expiration, err := time.Parse(time.RFC3339, grant.Status.ExpirationTime)
 	if err != nil {
-		log.Printf("Cannot determine expiration for %s/%s: %s", grant.Namespace, grant.Name, err)
+		g.rejected(attempt, grant, RedemptionFailed, fmt.Sprintf("Cannot determine expiration: %s", err))
 		return nil, httpError("Corrupted claim", http.StatusInternalServerError)
 	}
 	if expiration.Before(time.Now()) {
-		log.Printf("AccessGrant %s/%s expired", grant.Namespace, grant.Name)
+		g.rejected(attempt, grant, RedemptionExpired, "AccessGrant expired")
 		return nil, httpError("No such claim", http.StatusNotFound)
 	}
 	if grant.Spec.RedemptionsAllowed <= grant.Status.Redemptions {
-		log.Printf("AccessGrant %s/%s already redeemed", grant.Namespace, grant.Name)
+		g.rejected(attempt, grant, RedemptionExhausted, "AccessGrant already redeemed")
 		return nil, httpError("No such access granted", http.StatusNotFound)
 	}
 	if grant.Status.Code != string(data) {
+		reason := "Invalid code"
+		if g.invalidCode(grant) {
+			reason = "Invalid code, AccessGrant now locked"
+		}
+		g.rejected(attempt, grant, RedemptionRefused, reason)
 		return nil, httpError("Redemption of access token refused", http.StatusForbidden)
 	}
-	grant.Status.Redemptions += 1
-	err = g.updateGrantStatus(grant)
-	if err != nil {
-		log.Printf("Error updating access grant %s/%s: %s", grant.Namespace, grant.Name, err)
-		return nil, httpError("Internal error", http.StatusServiceUnavailable)
+	if _, subject := redeemer(grant, name, subject); grant.IsRevoked(skupperv2alpha1.GrantRedemption{Subject: subject}) {
+		g.rejected(attempt, grant, RedemptionRevoked, fmt.Sprintf("Access revoked for %s", subject))
+		return nil, httpError("Redemption of access token refused", http.StatusForbidden)
+	}
+	if g.policy != nil {
+		if err := g.policy.CheckGrantRedemption(grant.Namespace, attempt.siteName, attempt.siteNamespace); err != nil {
+			g.rejected(attempt, grant, RedemptionDenied, err.Error())
+			return nil, httpError("Redemption of access token refused", http.StatusForbidden)
+		}
 	}
 	return grant, nil
 }
 
+// invalidCode counts an attempt to redeem the grant with an invalid
+// code in its status, returning true if the grant is now locked.
+func (g *Grants) invalidCode(grant *skupperv2alpha1.AccessGrant) bool {
+	if !g.limits.lockoutEnabled() {
+		return false
+	}
+	locked := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := g.clients.GetSkupperClient().SkupperV2alpha1().AccessGrants(grant.Namespace).Get(context.TODO(), grant.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		locked = g.limits.invalidCode(latest)
+		return g.updateGrantStatus(latest)
+	})
+	if err != nil {
+		log.Printf("Error counting invalid code for AccessGrant %s/%s: %s", grant.Namespace, grant.Name, err)
+	}
+	return locked
+}
+
 func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		log.Printf("Bad method %s for path %s", r.Method, r.URL.Path)
 		http.Error(w, "Only POST is supported", http.StatusMethodNotAllowed)
 		return
 	}
-	key := strings.Join(strings.Split(r.URL.Path, "/"), "")
+	attempt := redemptionAttempt{
+		key:           strings.Join(strings.Split(r.URL.Path, "/"), ""),
+		source:        g.limits.sourceAddress(r),
+		siteName:      r.Header.Get("site-name"),
+		siteNamespace: r.Header.Get("site-namespace"),
+	}
+	if !g.limits.allowSource(attempt.source) {
+		g.rejected(attempt, nil, RedemptionRateLimited, "Too many attempts from source")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+		return
+	}
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		log.Printf("Error reading body for path %s: %s", r.URL.Path, err.Error())
 		http.Error(w, "Request body not valid", http.StatusBadRequest)
 		return
 	}
+	// only grants that exist are limited, so that unknown keys do not
+	// each hold a limiter
+	if grant := g.get(attempt.key); grant != nil && !g.limits.allowGrant(attempt.key) {
+		g.rejected(attempt, grant, RedemptionRateLimited, "Too many attempts for AccessGrant")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+		return
+	}
 
-	grant, e := g.checkAndUpdateAccessToken(key, body)
+	grant, e := g.checkAccessToken(attempt, body, r.Header.Get("name"), r.Header.Get("subject"))
 	if e != nil {
 		e.write(w)
 		return
 	}
 
-	name := r.Header.Get("name")
-	if name == "" {
+	if r.Header.Get("name") == "" {
 		log.Printf("No name specified when redeeming access token for %s/%s, using access grant name", grant.Namespace, grant.Name)
-		name = grant.Name
-	}
-	subject := r.Header.Get("subject")
-	if subject == "" {
-		subject = name
 	}
-	if err := g.generator(grant.Namespace, name, subject, w); err != nil {
-		log.Printf("Failed to create token for %s/%s: %s", grant.Namespace, grant.Name, err.Error())
+	name, subject := redeemer(grant, r.Header.Get("name"), r.Header.Get("subject"))
+	issuer := newRedemptionIssuer(grant)
+	var response bytes.Buffer
+	if err := g.generator(grant.Namespace, name, subject, issuer, &response); err != nil {
+		g.rejected(attempt, grant, RedemptionFailed, fmt.Sprintf("Failed to create token: %s", err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if err := g.redeemed(grant, newGrantRedemption(issuer.Name, subject, attempt, response.Bytes())); err != nil {
+		if err := g.removeIssuer(grant, issuer.Name); err != nil {
+			log.Printf("Error removing issuer of failed redemption of %s/%s: %s", grant.Namespace, grant.Name, err)
+		}
+		g.rejected(attempt, grant, RedemptionFailed, fmt.Sprintf("Error updating AccessGrant: %s", err))
+		http.Error(w, "Internal error", http.StatusServiceUnavailable)
+		return
+	}
+	if _, err := w.Write(response.Bytes()); err != nil {
+		log.Printf("Error writing token for %s/%s: %s", grant.Namespace, grant.Name, err)
+	}
 	log.Printf("Redemption of access token %s/%s succeeded", grant.Namespace, grant.Name)
+	g.recordRedemption(grant.Namespace, RedemptionSucceeded)
+}
+
+// redeemer returns the name under which credentials are issued for a
+// redemption and the subject they are issued to, defaulting the name
+// to that of the grant and the subject to the name.
+func redeemer(grant *skupperv2alpha1.AccessGrant, name string, subject string) (string, string) {
+	if name == "" {
+		name = grant.Name
+	}
+	if subject == "" {
+		subject = name
+	}
+	return name, subject
 }
 
 type HttpError struct {
@@ -4,11 +4,15 @@ import (
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 )
 
-func Initialise(controller *internalclient.Controller, currentNamespace string, watchNamespace string, config *GrantConfig, generator GrantResponse) func() {
+// Initialise sets up the handling of AccessGrants, returning nil if
+// they are not enabled.
+func Initialise(controller *internalclient.Controller, currentNamespace string, watchNamespace string, config *GrantConfig, generator GrantResponse, recorder RedemptionRecorder, policy RedemptionPolicy) *GrantsEnabled {
 	if !config.Enabled {
 		disabled(controller, watchNamespace)
 		return nil
 	}
 	ge := enabled(controller, currentNamespace, watchNamespace, config, generator)
-	return ge.Start
+	ge.grants.recorder = recorder
+	ge.grants.policy = policy
+	return ge
 }
@@ -77,7 +77,7 @@ func Test_Initialise(t *testing.T) {
 			}
 			controller := internalclient.NewController("Controller", client)
 
-			start := Initialise(controller, "test", metav1.NamespaceAll, &tt.config, nil)
+			grants := Initialise(controller, "test", metav1.NamespaceAll, &tt.config, nil, nil, nil)
 			if tt.endpoint != nil {
 				err = updateSecuredAccessEndpoint(controller, "skupper-grant-server", "test", tt.endpoint)
 				if err != nil {
@@ -89,8 +89,8 @@ func Test_Initialise(t *testing.T) {
 			controller.StartWatchers(stopCh)
 			assert.Assert(t, controller.WaitForCacheSync(stopCh))
 			if tt.config.Enabled {
-				assert.Assert(t, start != nil)
-				start()
+				assert.Assert(t, grants != nil)
+				grants.Start()
 			}
 			for range tt.k8sObjects {
 				assert.Assert(t, controller.TestProcess())
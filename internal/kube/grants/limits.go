@@ -0,0 +1,205 @@
+package grants
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// redemptionLimits throttles attempts to redeem AccessGrants, both per
+// source address and per grant, and locks a grant once too many
+// consecutive attempts have presented an invalid code. The count of
+// invalid codes and the lockout are held in the status of the grant,
+// so that they survive a restart of the controller.
+type redemptionLimits struct {
+	lock             sync.Mutex
+	sourceLimit      rate.Limit
+	sourceBurst      int
+	grantLimit       rate.Limit
+	grantBurst       int
+	lockoutThreshold int
+	lockoutDuration  time.Duration
+	trustedProxies   []*net.IPNet
+	sources          map[string]*rate.Limiter
+	grants           map[string]*rate.Limiter
+	now              func() time.Time
+}
+
+// limiters for which no attempt is pending are discarded once there are
+// more than this many, to bound the memory used by distinct sources
+const maxIdleLimiters = 1024
+
+func newRedemptionLimits(config *GrantConfig) *redemptionLimits {
+	// verified with the rest of the configuration
+	trustedProxies, _ := config.trustedProxies()
+	return &redemptionLimits{
+		sourceLimit:      rate.Limit(config.SourceRateLimit),
+		sourceBurst:      config.SourceBurst,
+		grantLimit:       rate.Limit(config.GrantRateLimit),
+		grantBurst:       config.GrantBurst,
+		lockoutThreshold: config.LockoutThreshold,
+		lockoutDuration:  config.LockoutDuration,
+		trustedProxies:   trustedProxies,
+		sources:          map[string]*rate.Limiter{},
+		grants:           map[string]*rate.Limiter{},
+		now:              time.Now,
+	}
+}
+
+func (l *redemptionLimits) allowSource(source string) bool {
+	if l == nil {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.allow(l.sources, source, l.sourceLimit, l.sourceBurst)
+}
+
+func (l *redemptionLimits) allowGrant(key string) bool {
+	if l == nil {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.allow(l.grants, key, l.grantLimit, l.grantBurst)
+}
+
+func (l *redemptionLimits) allow(limiters map[string]*rate.Limiter, key string, limit rate.Limit, burst int) bool {
+	if limit <= 0 {
+		return true
+	}
+	now := l.now()
+	limiter, ok := limiters[key]
+	if !ok {
+		if len(limiters) >= maxIdleLimiters {
+			for k, idle := range limiters {
+				if idle.TokensAt(now) >= float64(idle.Burst()) {
+					delete(limiters, k)
+				}
+			}
+		}
+		limiter = rate.NewLimiter(limit, burst)
+		limiters[key] = limiter
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// isLocked returns true if the grant has been locked and the lockout
+// has not yet expired.
+func (l *redemptionLimits) isLocked(grant *skupperv2alpha1.AccessGrant) bool {
+	if l == nil || grant.Status.Locked == "" {
+		return false
+	}
+	if l.lockoutDuration == 0 {
+		return true
+	}
+	locked, err := time.Parse(time.RFC3339, grant.Status.Locked)
+	if err != nil {
+		return true
+	}
+	return l.now().Before(locked.Add(l.lockoutDuration))
+}
+
+func (l *redemptionLimits) lockoutEnabled() bool {
+	return l != nil && l.lockoutThreshold > 0
+}
+
+// invalidCode counts a failed attempt in the status of the grant,
+// returning true if that caused the grant to be locked.
+func (l *redemptionLimits) invalidCode(grant *skupperv2alpha1.AccessGrant) bool {
+	if !l.lockoutEnabled() {
+		return false
+	}
+	grant.Status.FailedAttempts += 1
+	if grant.Status.FailedAttempts < l.lockoutThreshold {
+		return false
+	}
+	grant.Status.FailedAttempts = 0
+	grant.Status.Locked = l.now().Format(time.RFC3339)
+	return true
+}
+
+// forget discards any state held for a grant that no longer exists.
+func (l *redemptionLimits) forget(key string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.grants, key)
+}
+
+// sourceAddress returns the address from which a request came. That is
+// the address of the connection unless it is from a trusted proxy, in
+// which case it is the last address in X-Forwarded-For that is not
+// itself a trusted proxy.
+func (l *redemptionLimits) sourceAddress(r *http.Request) string {
+	source, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		source = r.RemoteAddr
+	}
+	if l == nil || !l.isTrustedProxy(source) {
+		return source
+	}
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, address := range strings.Split(header, ",") {
+			forwarded = append(forwarded, strings.TrimSpace(address))
+		}
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		source = forwarded[i]
+		if !l.isTrustedProxy(source) {
+			break
+		}
+	}
+	return source
+}
+
+func (l *redemptionLimits) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range l.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// redemptionAttempt identifies a request to redeem an AccessGrant: the
+// key (the grant's UID) from the request path, the address it came
+// from and the name and namespace of the redeeming site, if known.
+type redemptionAttempt struct {
+	key           string
+	source        string
+	siteName      string
+	siteNamespace string
+}
+
+// rejected writes an audit record for a redemption attempt that did
+// not succeed and counts it against the outcome.
+func (g *Grants) rejected(attempt redemptionAttempt, grant *skupperv2alpha1.AccessGrant, outcome string, reason string) {
+	attrs := []any{
+		slog.String("outcome", outcome),
+		slog.String("reason", reason),
+		slog.String("source", attempt.source),
+		slog.String("grant", attempt.key),
+	}
+	namespace := ""
+	if grant != nil {
+		namespace = grant.Namespace
+		attrs = append(attrs, slog.String("namespace", grant.Namespace), slog.String("name", grant.Name))
+	}
+	g.audit.Warn("AccessGrant redemption rejected", attrs...)
+	g.recordRedemption(namespace, outcome)
+}
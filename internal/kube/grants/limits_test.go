@@ -0,0 +1,221 @@
+package grants
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func TestRedemptionLimits(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limits := newRedemptionLimits(&GrantConfig{
+		SourceRateLimit:  1,
+		SourceBurst:      2,
+		GrantRateLimit:   0,
+		LockoutThreshold: 3,
+		LockoutDuration:  time.Minute,
+	})
+	limits.now = func() time.Time { return now }
+
+	// a source may make a burst of attempts, then has to wait
+	assert.Assert(t, limits.allowSource("10.0.0.1"))
+	assert.Assert(t, limits.allowSource("10.0.0.1"))
+	assert.Assert(t, !limits.allowSource("10.0.0.1"))
+	assert.Assert(t, limits.allowSource("10.0.0.2"))
+	now = now.Add(time.Second)
+	assert.Assert(t, limits.allowSource("10.0.0.1"))
+
+	// with no rate set, grants are not limited
+	for i := 0; i < 100; i++ {
+		assert.Assert(t, limits.allowGrant("my-grant"))
+	}
+
+	// consecutive invalid codes lock the grant
+	grant := &v2alpha1.AccessGrant{}
+	assert.Assert(t, !limits.invalidCode(grant))
+	assert.Assert(t, !limits.invalidCode(grant))
+	assert.Equal(t, grant.Status.FailedAttempts, 2)
+	assert.Assert(t, !limits.isLocked(grant))
+	assert.Assert(t, limits.invalidCode(grant))
+	assert.Assert(t, limits.isLocked(grant))
+	assert.Equal(t, grant.Status.FailedAttempts, 0)
+	assert.Equal(t, grant.Status.Locked, now.Format(time.RFC3339))
+	assert.Assert(t, !limits.isLocked(&v2alpha1.AccessGrant{}))
+
+	// until the lockout expires
+	now = now.Add(time.Minute)
+	assert.Assert(t, !limits.isLocked(grant))
+
+	// or indefinitely when no duration is set
+	limits.lockoutDuration = 0
+	now = now.Add(24 * time.Hour)
+	assert.Assert(t, limits.isLocked(grant))
+
+	// no limits at all when not configured
+	var none *redemptionLimits
+	assert.Assert(t, none.allowSource("10.0.0.1"))
+	assert.Assert(t, none.allowGrant("my-grant"))
+	assert.Assert(t, !none.invalidCode(grant))
+	assert.Assert(t, !none.isLocked(grant))
+}
+
+func TestSourceAddress(t *testing.T) {
+	config := &GrantConfig{TrustedProxies: []string{"10.0.0.1", "192.168.0.0/16"}}
+	assert.NilError(t, config.Verify())
+	limits := newRedemptionLimits(config)
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expected   string
+	}{
+		{
+			name:       "direct",
+			remoteAddr: "172.16.0.1:4321",
+			expected:   "172.16.0.1",
+		},
+		{
+			name:       "forwarded by untrusted source",
+			remoteAddr: "172.16.0.1:4321",
+			forwarded:  []string{"1.2.3.4"},
+			expected:   "172.16.0.1",
+		},
+		{
+			name:       "forwarded by trusted proxy",
+			remoteAddr: "10.0.0.1:4321",
+			forwarded:  []string{"1.2.3.4"},
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "forwarded through trusted proxies",
+			remoteAddr: "10.0.0.1:4321",
+			forwarded:  []string{"6.6.6.6, 1.2.3.4", "192.168.1.1"},
+			expected:   "1.2.3.4",
+		},
+		{
+			name:       "trusted proxy without forwarded address",
+			remoteAddr: "10.0.0.1:4321",
+			expected:   "10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, forwarded := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", forwarded)
+			}
+			assert.Equal(t, limits.sourceAddress(req), tt.expected)
+		})
+	}
+	var none *redemptionLimits
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:4321"
+	req.Header.Add("X-Forwarded-For", "1.2.3.4")
+	assert.Equal(t, none.sourceAddress(req), "10.0.0.1")
+}
+
+func TestGrantLockout(t *testing.T) {
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+			UID:       "a40fbe84-f276-4755-bf22-5ba980ab1661",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, []runtime.Object{grant}, "")
+	assert.NilError(t, err)
+	registry := newGrants(client, dummyGenerator, "https", "")
+	registry.limits = newRedemptionLimits(&GrantConfig{
+		GrantRateLimit:   1,
+		GrantBurst:       3,
+		LockoutThreshold: 2,
+	})
+	recorder := &fakeRedemptionRecorder{}
+	registry.recorder = recorder
+	assert.NilError(t, registry.checkGrant("test/my-grant", grant))
+	redeem := func(code string) int {
+		req := httptest.NewRequest(http.MethodPost, "/"+string(grant.ObjectMeta.UID), bytes.NewBufferString(code))
+		res := httptest.NewRecorder()
+		registry.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	assert.Equal(t, redeem("guess"), http.StatusForbidden)
+	assert.Equal(t, redeem("another-guess"), http.StatusForbidden)
+	// once locked, even the correct code is refused
+	assert.Equal(t, redeem("supersecret"), http.StatusForbidden)
+	// and further attempts exceed the rate allowed for the grant
+	assert.Equal(t, redeem("supersecret"), http.StatusTooManyRequests)
+	assert.DeepEqual(t, recorder.outcomes, []string{RedemptionRefused, RedemptionRefused, RedemptionLocked, RedemptionRateLimited})
+
+	// keys of grants that do not exist are not rate limited, so hold no
+	// limiter
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/unknown-"+strconv.Itoa(i), bytes.NewBufferString("guess"))
+		res := httptest.NewRecorder()
+		registry.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusNotFound)
+	}
+	assert.Equal(t, len(registry.limits.grants), 1)
+
+	// the lockout is held in the grant's status, so survives a restart
+	latest, err := client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, latest.Status.Locked != "")
+	registry = newGrants(client, dummyGenerator, "https", "")
+	registry.limits = newRedemptionLimits(&GrantConfig{
+		LockoutThreshold: 2,
+	})
+	registry.recorder = recorder
+	assert.NilError(t, registry.checkGrant("test/my-grant", latest))
+	assert.Equal(t, redeem("supersecret"), http.StatusForbidden)
+	assert.Equal(t, recorder.outcomes[len(recorder.outcomes)-1], RedemptionLocked)
+
+	// once the lockout expires, a redemption clears it
+	registry.limits.lockoutDuration = time.Minute
+	registry.limits.now = func() time.Time { return time.Now().Add(time.Hour) }
+	assert.Equal(t, redeem("supersecret"), http.StatusOK)
+	latest, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, latest.Status.Locked, "")
+	assert.Equal(t, latest.Status.FailedAttempts, 0)
+	assert.Equal(t, latest.Status.Redemptions, 1)
+}
+
+func TestGrantConfigVerify(t *testing.T) {
+	valid := &GrantConfig{
+		SourceRateLimit:  1,
+		SourceBurst:      5,
+		GrantRateLimit:   5,
+		GrantBurst:       10,
+		LockoutThreshold: 10,
+		LockoutDuration:  time.Minute,
+	}
+	assert.NilError(t, valid.Verify())
+	assert.NilError(t, (&GrantConfig{}).Verify())
+	assert.Error(t, (&GrantConfig{SourceRateLimit: -1}).Verify(), "Grant server rate limits must not be negative, got -1 per source and 0 per grant.")
+	assert.Error(t, (&GrantConfig{GrantRateLimit: 1}).Verify(), "Grant server bursts must be at least 1 when rate limited, got 0 per source and 0 per grant.")
+	assert.Error(t, (&GrantConfig{LockoutThreshold: -1}).Verify(), "Grant lockout threshold must not be negative, got -1.")
+	assert.Error(t, (&GrantConfig{LockoutDuration: -time.Minute}).Verify(), "Grant lockout duration must not be negative, got -1m0s.")
+	assert.NilError(t, (&GrantConfig{TrustedProxies: []string{"10.0.0.1", "10.1.0.0/16", "fd00::/8", ""}}).Verify())
+	assert.Error(t, (&GrantConfig{TrustedProxies: []string{"10.0.0.1", "ingress"}}).Verify(), "Invalid grant server trusted proxy \"ingress\", must be an address or CIDR range.")
+}
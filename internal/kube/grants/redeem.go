@@ -53,6 +53,8 @@ func postTokenRequest(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1.
 	}
 	request.Header.Add("name", token.Name)
 	request.Header.Add("subject", string(site.ObjectMeta.UID))
+	request.Header.Add("site-name", site.ObjectMeta.Name)
+	request.Header.Add("site-namespace", site.ObjectMeta.Namespace)
 	response, err := client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("Controller got error: %s", err)
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -473,12 +474,15 @@ type TestTokenGenerator struct {
 	clients internalclient.Clients
 }
 
-func (g *TestTokenGenerator) generate(namespace string, name string, subject string, writer io.Writer) error {
+func (g *TestTokenGenerator) generate(namespace string, name string, subject string, issuer *corev1.Secret, writer io.Writer) error {
 	generator, err := NewTokenGenerator(g.site, g.clients)
 	if err != nil {
 		return err
 	}
-	token := generator.NewCertToken(name, subject)
+	token, err := generator.NewRevocableCertToken(issuer, name, subject)
+	if err != nil {
+		return err
+	}
 	return token.Write(writer)
 }
 
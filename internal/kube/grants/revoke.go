@@ -0,0 +1,174 @@
+package grants
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/skupperproject/skupper/internal/kube/certificates"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+)
+
+// grantLabel marks a redemption's CA with the UID of the AccessGrant
+// it was issued for, so that only revocations of that grant delete it.
+const grantLabel = "internal.skupper.io/access-grant"
+
+// newRedemptionIssuer returns the Secret for a new CA from which the
+// credentials for the next redemption of a grant are issued. It is
+// named after the grant, its UID and the number of the redemption, so
+// that concurrent redemptions of the grant cannot both be issued, nor
+// collide with those of an earlier grant of the same name. It is not
+// owned by the grant: access issued by a grant outlives the grant, and
+// is withdrawn only by revoking the redemption before deleting it.
+func newRedemptionIssuer(grant *skupperv2alpha1.AccessGrant) *corev1.Secret {
+	name := fmt.Sprintf("%s-redemption-%d", grant.Name, grant.Status.Redemptions+1)
+	if uid := string(grant.ObjectMeta.UID); len(uid) >= 8 {
+		name = fmt.Sprintf("%s-%s", name, uid[:8])
+	}
+	issuer := certs.GenerateCASecret(name, name)
+	issuer.ObjectMeta.Labels = map[string]string{
+		grantLabel: string(grant.ObjectMeta.UID),
+	}
+	return &issuer
+}
+
+// newGrantRedemption describes a successful redemption: the CA that
+// issued its credentials, the subject they were issued to, the site
+// that redeemed it and the serial number of the client certificate in
+// the token returned.
+func newGrantRedemption(issuer string, subject string, attempt redemptionAttempt, token []byte) skupperv2alpha1.GrantRedemption {
+	redemption := skupperv2alpha1.GrantRedemption{
+		Issuer:        issuer,
+		Subject:       subject,
+		SiteName:      attempt.siteName,
+		SiteNamespace: attempt.siteNamespace,
+		RemoteAddress: attempt.source,
+		Time:          time.Now().Format(time.RFC3339),
+	}
+	decoder := newLinkDecoder(bytes.NewReader(token))
+	if err := decoder.decodeAll(); err != nil {
+		return redemption
+	}
+	if block, _ := pem.Decode(decoder.secret.Data["tls.crt"]); block != nil {
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			redemption.SerialNumber = cert.SerialNumber.Text(16)
+		}
+	}
+	return redemption
+}
+
+// redeemed counts a redemption against the grant and records what was
+// issued for it in a single update, so that concurrent redemptions
+// cannot exceed those allowed or lose each other's records. Any count
+// of invalid codes, or expired lockout, is cleared.
+func (g *Grants) redeemed(grant *skupperv2alpha1.AccessGrant, redemption skupperv2alpha1.GrantRedemption) error {
+	updated := grant.DeepCopy()
+	updated.Status.Redemptions += 1
+	updated.Status.FailedAttempts = 0
+	updated.Status.Locked = ""
+	updated.Status.Redeemed = append(updated.Status.Redeemed, redemption)
+	return g.updateGrantStatus(updated)
+}
+
+// checkRevocations marks any redemptions listed for revocation in the
+// grant's spec as revoked, deleting the CA that issued their
+// credentials. The site's server certificates then no longer trust
+// those credentials, and the router closes the links made with them.
+func (g *Grants) checkRevocations(key string, grant *skupperv2alpha1.AccessGrant) bool {
+	changed := false
+	for i := range grant.Status.Redeemed {
+		redemption := &grant.Status.Redeemed[i]
+		if redemption.Revoked != "" || !grant.IsRevoked(*redemption) {
+			continue
+		}
+		log.Printf("Revoking access issued to %s by AccessGrant %s", redemption.Subject, key)
+		if err := g.removeIssuer(grant, redemption.Issuer); err != nil {
+			log.Printf("Error revoking access issued to %s by AccessGrant %s: %s", redemption.Subject, key, err)
+			continue
+		}
+		redemption.Revoked = time.Now().Format(time.RFC3339)
+		changed = true
+	}
+	return changed
+}
+
+// checkPolicy revokes the redemptions of the grant by sites that the
+// policy no longer allows to link to the grant's namespace, as they
+// identified themselves when redeeming it, deleting the CA that issued
+// their credentials.
+func (g *Grants) checkPolicy(key string, grant *skupperv2alpha1.AccessGrant) bool {
+	if g.policy == nil {
+		return false
+	}
+	changed := false
+	for i := range grant.Status.Redeemed {
+		redemption := &grant.Status.Redeemed[i]
+		if redemption.Revoked != "" {
+			continue
+		}
+		err := g.policy.CheckGrantRedemption(grant.Namespace, redemption.SiteName, redemption.SiteNamespace)
+		if err == nil {
+			continue
+		}
+		log.Printf("Revoking access issued to %s by AccessGrant %s: %s", redemption.Subject, key, err)
+		if err := g.removeIssuer(grant, redemption.Issuer); err != nil {
+			log.Printf("Error revoking access issued to %s by AccessGrant %s: %s", redemption.Subject, key, err)
+			continue
+		}
+		redemption.Revoked = time.Now().Format(time.RFC3339)
+		changed = true
+	}
+	return changed
+}
+
+// recheckPolicy applies a change to the policies to the redemptions of
+// every grant.
+func (g *Grants) recheckPolicy() {
+	for _, grant := range g.getAll() {
+		key := fmt.Sprintf("%s/%s", grant.Namespace, grant.Name)
+		updated := grant.DeepCopy()
+		if g.checkPolicy(key, updated) {
+			if err := g.updateGrantStatus(updated); err != nil {
+				log.Printf("Error updating grant %s after revoking access not allowed by policy: %s", key, err)
+			}
+		}
+	}
+}
+
+// removeIssuer deletes the CA created for a redemption of the grant.
+// Secrets that are not such a CA, or that belong to another grant, are
+// left alone.
+func (g *Grants) removeIssuer(grant *skupperv2alpha1.AccessGrant, name string) error {
+	if name == "" {
+		return nil
+	}
+	secrets := g.clients.GetKubeClient().CoreV1().Secrets(grant.Namespace)
+	secret, err := secrets.Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if _, ok := secret.ObjectMeta.Labels[certificates.TrustedByLabel]; !ok || !isIssuedFor(secret, grant) {
+		return fmt.Errorf("Secret %s/%s was not issued for AccessGrant %s", grant.Namespace, name, grant.Name)
+	}
+	if err := secrets.Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	log.Printf("Deleted issuer %s/%s of revoked redemption", grant.Namespace, name)
+	return nil
+}
+
+func isIssuedFor(secret *corev1.Secret, grant *skupperv2alpha1.AccessGrant) bool {
+	uid, ok := secret.ObjectMeta.Labels[grantLabel]
+	return ok && uid == string(grant.ObjectMeta.UID)
+}
@@ -0,0 +1,113 @@
+package grants
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/skupperproject/skupper/internal/kube/certificates"
+	"github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+)
+
+func TestRedemptionRecordAndRevoke(t *testing.T) {
+	grant := tf.grant("my-grant", "test", "")
+	grant.Spec.RedemptionsAllowed = 2
+	client, err := fake.NewFakeClient("test", []runtime.Object{tf.secret("skupper-site-ca", "test", "Test Site CA", nil)}, []runtime.Object{tf.site("my-site", "test"), grant}, "")
+	assert.NilError(t, err)
+	site, err := client.GetSkupperClient().SkupperV2alpha1().Sites("test").Get(context.TODO(), "my-site", metav1.GetOptions{})
+	assert.NilError(t, err)
+	site.Status.DefaultIssuer = "skupper-site-ca"
+	site.Status.Endpoints = []v2alpha1.Endpoint{
+		{
+			Name: "inter-router",
+			Host: "my-link-host",
+			Port: "1111",
+		},
+	}
+	site, err = client.GetSkupperClient().SkupperV2alpha1().Sites("test").UpdateStatus(context.TODO(), site, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+
+	grants := newGrants(client, generator(site, client), "http", "")
+	recorder := &fakeRedemptionRecorder{}
+	grants.recorder = recorder
+	server := newServer(":0", false, grants)
+	server.listen()
+	grants.setUrl(fmt.Sprintf("localhost:%d", server.port()))
+	go server.serve()
+	defer server.stop()
+	assert.NilError(t, grants.checkGrant("test/my-grant", grant))
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+
+	// redeeming the grant records what was issued, and to whom
+	token := tf.token("my-token", "test", grant.Status.Url, grant.Status.Code, grant.Status.Ca)
+	token, err = client.GetSkupperClient().SkupperV2alpha1().AccessTokens("test").Create(context.TODO(), token, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, RedeemAccessToken(token, site, client))
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, grant.Status.Redemptions, 1)
+	assert.Equal(t, len(grant.Status.Redeemed), 1)
+	redemption := grant.Status.Redeemed[0]
+	assert.Equal(t, redemption.Issuer, "my-grant-redemption-1-"+string(grant.ObjectMeta.UID)[:8])
+	assert.Equal(t, redemption.Subject, string(site.ObjectMeta.UID))
+	assert.Assert(t, redemption.RemoteAddress != "")
+	assert.Assert(t, redemption.Time != "")
+	assert.Assert(t, redemption.SerialNumber != "")
+	assert.Equal(t, redemption.Revoked, "")
+
+	// the credentials are issued by a CA of their own, trusted by the site CA's server certificates
+	issuer, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.TODO(), redemption.Issuer, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, issuer.ObjectMeta.Labels[certificates.TrustedByLabel], "skupper-site-ca")
+	assert.Assert(t, isIssuedFor(issuer, grant))
+	assert.Equal(t, len(issuer.ObjectMeta.OwnerReferences), 0)
+	credentials, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.TODO(), "my-token", metav1.GetOptions{})
+	assert.NilError(t, err)
+	issuerCert, err := certs.DecodeCertificate(issuer.Data["tls.crt"])
+	assert.NilError(t, err)
+	clientCert, err := certs.DecodeCertificate(credentials.Data["tls.crt"])
+	assert.NilError(t, err)
+	assert.NilError(t, clientCert.CheckSignatureFrom(issuerCert))
+	siteCa, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.TODO(), "skupper-site-ca", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, credentials.Data["ca.crt"], siteCa.Data["tls.crt"])
+
+	// a redemption record naming a secret that was not issued for the grant is not acted on
+	grant.Status.Redeemed = append(grant.Status.Redeemed, v2alpha1.GrantRedemption{Issuer: "skupper-site-ca", Subject: "forged"})
+	grant.Spec.Revoked = []string{"forged"}
+	assert.NilError(t, grants.checkGrant("test/my-grant", grant))
+	_, err = client.GetKubeClient().CoreV1().Secrets("test").Get(context.TODO(), "skupper-site-ca", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, grant.Status.Redeemed[1].Revoked, "")
+
+	// revoking the redemption deletes its issuer
+	grant.Spec.Revoked = []string{redemption.Issuer}
+	assert.NilError(t, grants.checkGrant("test/my-grant", grant))
+	grant, err = client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, grant.Status.Redeemed[0].Revoked != "")
+	_, err = client.GetKubeClient().CoreV1().Secrets("test").Get(context.TODO(), redemption.Issuer, metav1.GetOptions{})
+	assert.Assert(t, errors.IsNotFound(err))
+
+	// and the revoked subject cannot redeem the grant again
+	grant.Spec.Revoked = []string{string(site.ObjectMeta.UID)}
+	assert.NilError(t, grants.checkGrant("test/my-grant", grant))
+	req := httptest.NewRequest(http.MethodPost, "/"+string(grant.ObjectMeta.UID), bytes.NewBufferString(grant.Status.Code))
+	req.Header.Add("name", "another-token")
+	req.Header.Add("subject", string(site.ObjectMeta.UID))
+	res := httptest.NewRecorder()
+	grants.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, http.StatusForbidden)
+	assert.Equal(t, recorder.outcomes[len(recorder.outcomes)-1], RedemptionRevoked)
+}
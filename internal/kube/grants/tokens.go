@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/skupperproject/skupper/internal/kube/certificates"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/certs"
@@ -90,8 +91,31 @@ func (g *TokenGenerator) setValidHostsFromSite(site *skupperv2alpha1.Site) bool
 
 func (g *TokenGenerator) NewCertToken(name string, subject string) Token {
 	cert := certs.GenerateSecret(name, subject, strings.Join(g.hosts, ","), g.ca)
+	return g.newCertToken(name, &cert)
+}
+
+// NewRevocableCertToken returns a token whose certificate is issued by
+// a new CA, created from the supplied Secret, rather than by the site
+// CA. The site's server certificates trust that CA for as long as the
+// Secret exists, so deleting it revokes the token.
+func (g *TokenGenerator) NewRevocableCertToken(issuer *corev1.Secret, name string, subject string) (Token, error) {
+	if issuer.ObjectMeta.Labels == nil {
+		issuer.ObjectMeta.Labels = map[string]string{}
+	}
+	issuer.ObjectMeta.Labels[certificates.TrustedByLabel] = g.ca.Name
+	if _, err := g.clients.GetKubeClient().CoreV1().Secrets(g.namespace).Create(context.TODO(), issuer, metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+	cert := certs.GenerateSecret(name, subject, strings.Join(g.hosts, ","), issuer)
+	// the redeeming site verifies the site's server certificate,
+	// issued by the site CA
+	cert.Data["ca.crt"] = g.ca.Data["tls.crt"]
+	return g.newCertToken(name, &cert), nil
+}
+
+func (g *TokenGenerator) newCertToken(name string, cert *corev1.Secret) Token {
 	token := &CertToken{
-		tlsCredentials: &cert,
+		tlsCredentials: cert,
 	}
 	for i, endpoints := range g.endpoints {
 		linkName := name
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	kindMetricLabels        = []string{"kind"}
+	certificateMetricLabels = []string{"namespace", "name"}
+	redemptionMetricLabels  = []string{"namespace", "outcome"}
+)
+
+// Metrics records the state of the controller's reconcile loops,
+// certificates and grant server. It satisfies the recorder interfaces
+// of the client, certificates and grants packages.
+type Metrics struct {
+	queueDepth        *prometheus.GaugeVec
+	queueLatency      *prometheus.HistogramVec
+	reconcileDuration *prometheus.HistogramVec
+	reconcileErrors   *prometheus.CounterVec
+	certificateExpiry *prometheus.GaugeVec
+	grantRedemptions  *prometheus.CounterVec
+}
+
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "skupper",
+			Subsystem: "controller",
+			Name:      "queue_depth",
+			Help:      "Number of events waiting to be handled by the controller, including those scheduled for retry",
+		}, kindMetricLabels),
+		queueLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "skupper",
+			Subsystem: "controller",
+			Name:      "queue_latency_seconds",
+			Help:      "Time events spend waiting in the controller queue before being handled",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, kindMetricLabels),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "skupper",
+			Subsystem: "controller",
+			Name:      "reconcile_duration_seconds",
+			Help:      "Time taken by the controller to handle an event",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, kindMetricLabels),
+		reconcileErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "controller",
+			Name:      "reconcile_errors_total",
+			Help:      "Number of events the controller failed to handle",
+		}, kindMetricLabels),
+		certificateExpiry: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "skupper",
+			Name:      "certificate_expiry_timestamp_seconds",
+			Help:      "Time at which a certificate managed by the controller expires, in seconds since the epoch",
+		}, certificateMetricLabels),
+		grantRedemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Name:      "grant_redemptions_total",
+			Help:      "Number of attempts to redeem an AccessGrant, by outcome",
+		}, redemptionMetricLabels),
+	}
+	reg.MustRegister(
+		m.queueDepth,
+		m.queueLatency,
+		m.reconcileDuration,
+		m.reconcileErrors,
+		m.certificateExpiry,
+		m.grantRedemptions,
+	)
+	return m
+}
+
+func (m *Metrics) Queued(kind string) {
+	m.queueDepth.WithLabelValues(kind).Inc()
+}
+
+func (m *Metrics) Dequeued(kind string, latency time.Duration) {
+	m.queueDepth.WithLabelValues(kind).Dec()
+	m.queueLatency.WithLabelValues(kind).Observe(latency.Seconds())
+}
+
+func (m *Metrics) Handled(kind string, duration time.Duration, err error) {
+	m.reconcileDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	if err != nil {
+		m.reconcileErrors.WithLabelValues(kind).Inc()
+	}
+}
+
+func (m *Metrics) CertificateExpiry(namespace string, name string, expiry time.Time) {
+	m.certificateExpiry.WithLabelValues(namespace, name).Set(float64(expiry.Unix()))
+}
+
+func (m *Metrics) CertificateRemoved(namespace string, name string) {
+	m.certificateExpiry.DeleteLabelValues(namespace, name)
+}
+
+func (m *Metrics) Redemption(namespace string, outcome string) {
+	m.grantRedemptions.WithLabelValues(namespace, outcome).Inc()
+}
+
+// Handler serves the metrics gathered by the supplied registry.
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
+}
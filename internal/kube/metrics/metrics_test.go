@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prom_testutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestQueueMetrics(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	metrics := New(reg)
+
+	metrics.Queued("Site")
+	metrics.Queued("Site")
+	metrics.Queued("Listener")
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.queueDepth.WithLabelValues("Site")), 2.0)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.queueDepth.WithLabelValues("Listener")), 1.0)
+
+	metrics.Dequeued("Site", time.Millisecond)
+	metrics.Handled("Site", time.Millisecond, nil)
+	metrics.Dequeued("Listener", time.Millisecond)
+	metrics.Handled("Listener", time.Millisecond, errors.New("failed"))
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.queueDepth.WithLabelValues("Site")), 1.0)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.queueDepth.WithLabelValues("Listener")), 0.0)
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_controller_queue_latency_seconds"), 2)
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_controller_reconcile_duration_seconds"), 2)
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_controller_reconcile_errors_total"), 1)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.reconcileErrors.WithLabelValues("Listener")), 1.0)
+}
+
+func TestCertificateMetrics(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	metrics := New(reg)
+	expiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	metrics.CertificateExpiry("test", "skupper-site-server", expiry)
+	metrics.CertificateExpiry("test", "skupper-site-ca", expiry.Add(time.Hour))
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_certificate_expiry_timestamp_seconds"), 2)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.certificateExpiry.WithLabelValues("test", "skupper-site-server")), float64(expiry.Unix()))
+	metrics.CertificateRemoved("test", "skupper-site-ca")
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_certificate_expiry_timestamp_seconds"), 1)
+}
+
+func TestGrantMetrics(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	metrics := New(reg)
+
+	metrics.Redemption("test", "succeeded")
+	metrics.Redemption("test", "succeeded")
+	metrics.Redemption("test", "expired")
+	metrics.Redemption("", "not_found")
+	assert.Equal(t, prom_testutil.CollectAndCount(reg, "skupper_grant_redemptions_total"), 3)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.grantRedemptions.WithLabelValues("test", "succeeded")), 2.0)
+	assert.Equal(t, prom_testutil.ToFloat64(metrics.grantRedemptions.WithLabelValues("", "not_found")), 1.0)
+}
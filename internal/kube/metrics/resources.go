@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// ResourceListers provide the current set of each kind of resource
+// counted by a ResourceCollector. Any of them may be nil.
+type ResourceListers struct {
+	Sites      func() []*skupperv2alpha1.Site
+	Listeners  func() []*skupperv2alpha1.Listener
+	Connectors func() []*skupperv2alpha1.Connector
+	Links      func() []*skupperv2alpha1.Link
+}
+
+// ResourceCollector reports the number of sites, listeners,
+// connectors and links in each namespace, by the status of their
+// Ready condition. The counts are computed when scraped, so they
+// always reflect the controller's informer caches.
+type ResourceCollector struct {
+	listers ResourceListers
+	desc    *prometheus.Desc
+}
+
+func NewResourceCollector(listers ResourceListers) *ResourceCollector {
+	return &ResourceCollector{
+		listers: listers,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName("skupper", "controller", "resources"),
+			"Number of resources watched by the controller, by namespace and Ready condition",
+			[]string{"kind", "namespace", "ready"},
+			nil,
+		),
+	}
+}
+
+func (c *ResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *ResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := resourceCounts{}
+	if c.listers.Sites != nil {
+		for _, site := range c.listers.Sites() {
+			counts.add("Site", site.Namespace, site.Status.Conditions)
+		}
+	}
+	if c.listers.Listeners != nil {
+		for _, listener := range c.listers.Listeners() {
+			counts.add("Listener", listener.Namespace, listener.Status.Conditions)
+		}
+	}
+	if c.listers.Connectors != nil {
+		for _, connector := range c.listers.Connectors() {
+			counts.add("Connector", connector.Namespace, connector.Status.Conditions)
+		}
+	}
+	if c.listers.Links != nil {
+		for _, link := range c.listers.Links() {
+			counts.add("Link", link.Namespace, link.Status.Conditions)
+		}
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), key.kind, key.namespace, key.ready)
+	}
+}
+
+type resourceKey struct {
+	kind      string
+	namespace string
+	ready     string
+}
+
+type resourceCounts map[resourceKey]int
+
+func (counts resourceCounts) add(kind string, namespace string, conditions []metav1.Condition) {
+	key := resourceKey{
+		kind:      kind,
+		namespace: namespace,
+		ready:     string(metav1.ConditionUnknown),
+	}
+	if condition := meta.FindStatusCondition(conditions, skupperv2alpha1.CONDITION_TYPE_READY); condition != nil {
+		key.ready = string(condition.Status)
+	}
+	counts[key] += 1
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	prom_testutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func ready(status metav1.ConditionStatus) skupperv2alpha1.Status {
+	return skupperv2alpha1.Status{
+		Conditions: []metav1.Condition{
+			{
+				Type:   skupperv2alpha1.CONDITION_TYPE_READY,
+				Status: status,
+			},
+		},
+	}
+}
+
+func TestResourceCollector(t *testing.T) {
+	sites := []*skupperv2alpha1.Site{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "site1", Namespace: "east"},
+			Status:     skupperv2alpha1.SiteStatus{Status: ready(metav1.ConditionTrue)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "site2", Namespace: "west"},
+		},
+	}
+	listeners := []*skupperv2alpha1.Listener{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "east"},
+			Status:     skupperv2alpha1.ListenerStatus{Status: ready(metav1.ConditionTrue)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "east"},
+			Status:     skupperv2alpha1.ListenerStatus{Status: ready(metav1.ConditionTrue)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "east"},
+			Status:     skupperv2alpha1.ListenerStatus{Status: ready(metav1.ConditionFalse)},
+		},
+	}
+	links := []*skupperv2alpha1.Link{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "link1", Namespace: "west"},
+			Status:     skupperv2alpha1.LinkStatus{Status: ready(metav1.ConditionFalse)},
+		},
+	}
+	collector := NewResourceCollector(ResourceListers{
+		Sites:     func() []*skupperv2alpha1.Site { return sites },
+		Listeners: func() []*skupperv2alpha1.Listener { return listeners },
+		Links:     func() []*skupperv2alpha1.Link { return links },
+	})
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(collector)
+
+	expected := `
+# HELP skupper_controller_resources Number of resources watched by the controller, by namespace and Ready condition
+# TYPE skupper_controller_resources gauge
+skupper_controller_resources{kind="Link",namespace="west",ready="False"} 1
+skupper_controller_resources{kind="Listener",namespace="east",ready="False"} 1
+skupper_controller_resources{kind="Listener",namespace="east",ready="True"} 2
+skupper_controller_resources{kind="Site",namespace="east",ready="True"} 1
+skupper_controller_resources{kind="Site",namespace="west",ready="Unknown"} 1
+`
+	assert.NilError(t, prom_testutil.GatherAndCompare(reg, strings.NewReader(expected), "skupper_controller_resources"))
+}
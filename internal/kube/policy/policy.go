@@ -0,0 +1,182 @@
+// Package policy evaluates SitePolicies, which restrict the grant
+// redemptions, routing keys and access types permitted for the sites
+// in the namespaces they apply to.
+package policy
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// Policies holds the SitePolicies known to the controller. A request
+// is permitted only if every policy that applies to the namespace
+// permits it. Policies may be checked from other goroutines than the
+// one updating them.
+type Policies struct {
+	controllerNamespace string
+	lock                sync.RWMutex
+	policies            map[string]*skupperv2alpha1.SitePolicy
+}
+
+func NewPolicies(controllerNamespace string) *Policies {
+	return &Policies{
+		controllerNamespace: controllerNamespace,
+		policies:            map[string]*skupperv2alpha1.SitePolicy{},
+	}
+}
+
+// Update records the latest definition of the policy with the given
+// key, removing it if the definition is nil. It returns true if what
+// the policy restricts has changed, and any error in the definition,
+// in which case it is still applied.
+func (p *Policies) Update(key string, policy *skupperv2alpha1.SitePolicy) (bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	existing, ok := p.policies[key]
+	if policy == nil {
+		delete(p.policies, key)
+		return ok, nil
+	}
+	p.policies[key] = policy
+	changed := !ok || !reflect.DeepEqual(existing.Spec, policy.Spec)
+	return changed, Validate(&policy.Spec)
+}
+
+// Validate returns an error if any of the patterns in the spec are
+// malformed. A malformed pattern matches nothing.
+func Validate(spec *skupperv2alpha1.SitePolicySpec) error {
+	patterns := spec.AllowedRoutingKeys
+	if spec.GrantRedemptions != nil {
+		patterns = slices.Concat(patterns, spec.GrantRedemptions.AllowedSites, spec.GrantRedemptions.AllowedNamespaces, spec.GrantRedemptions.DeniedSites, spec.GrantRedemptions.DeniedNamespaces)
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Invalid pattern %q: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// namespaces returns the namespaces, other than its own, that the
+// policy applies to, "*" meaning all namespaces.
+func (p *Policies) namespaces(policy *skupperv2alpha1.SitePolicy) []string {
+	if policy == nil || policy.Namespace != p.controllerNamespace {
+		return nil
+	}
+	return policy.Spec.Namespaces
+}
+
+// applicable returns the policies that apply to the namespace, ordered
+// by key. Must be called with the lock held.
+func (p *Policies) applicable(namespace string) []*skupperv2alpha1.SitePolicy {
+	var keys []string
+	for key, policy := range p.policies {
+		if p.appliesTo(policy, namespace) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var results []*skupperv2alpha1.SitePolicy
+	for _, key := range keys {
+		results = append(results, p.policies[key])
+	}
+	return results
+}
+
+func (p *Policies) appliesTo(policy *skupperv2alpha1.SitePolicy, namespace string) bool {
+	if policy.Namespace == namespace {
+		return true
+	}
+	for _, ns := range p.namespaces(policy) {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRoutingKey returns an error if a Listener or Connector in the
+// namespace may not use the routing key.
+func (p *Policies) CheckRoutingKey(namespace string, routingKey string) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, policy := range p.applicable(namespace) {
+		if len(policy.Spec.AllowedRoutingKeys) > 0 && !matchesAny(policy.Spec.AllowedRoutingKeys, routingKey) {
+			return fmt.Errorf("Routing key %q not allowed by SitePolicy %s/%s", routingKey, policy.Namespace, policy.Name)
+		}
+	}
+	return nil
+}
+
+// CheckAccessType returns an error if a SecuredAccess in the namespace
+// may not use the access type.
+func (p *Policies) CheckAccessType(namespace string, accessType string) error {
+	if p == nil {
+		return nil
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, policy := range p.applicable(namespace) {
+		if len(policy.Spec.AllowedAccessTypes) > 0 && !slices.Contains(policy.Spec.AllowedAccessTypes, accessType) {
+			return fmt.Errorf("Access type %q not allowed by SitePolicy %s/%s", accessType, policy.Namespace, policy.Name)
+		}
+	}
+	return nil
+}
+
+// CheckGrantRedemption returns an error if an AccessGrant in the
+// namespace may not be redeemed by the remote site with the given name
+// and namespace. These are as given by the redeeming site and not
+// verified, so the check is advisory: it keeps grants from being
+// redeemed by sites they were not meant for, but cannot stop a holder
+// of a grant's code claiming to be another site. Redeemers that do not
+// identify themselves at all, such as nonkube sites, are not filtered.
+// Links configured by other means, e.g. from a generated link Secret,
+// are not checked.
+func (p *Policies) CheckGrantRedemption(namespace string, siteName string, siteNamespace string) error {
+	if p == nil || (siteName == "" && siteNamespace == "") {
+		return nil
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, policy := range p.applicable(namespace) {
+		if !allowsRedemption(policy.Spec.GrantRedemptions, siteName, siteNamespace) {
+			return fmt.Errorf("Redemption by site %q in namespace %q not allowed by SitePolicy %s/%s", siteName, siteNamespace, policy.Namespace, policy.Name)
+		}
+	}
+	return nil
+}
+
+func allowsRedemption(redemptions *skupperv2alpha1.GrantRedemptionPolicy, siteName string, siteNamespace string) bool {
+	if redemptions == nil {
+		return true
+	}
+	if matchesAny(redemptions.DeniedSites, siteName) || matchesAny(redemptions.DeniedNamespaces, siteNamespace) {
+		return false
+	}
+	if len(redemptions.AllowedSites) == 0 && len(redemptions.AllowedNamespaces) == 0 {
+		return true
+	}
+	return matchesAny(redemptions.AllowedSites, siteName) || matchesAny(redemptions.AllowedNamespaces, siteNamespace)
+}
+
+func matchesAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package policy
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func sitePolicy(namespace string, name string, spec skupperv2alpha1.SitePolicySpec) *skupperv2alpha1.SitePolicy {
+	return &skupperv2alpha1.SitePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []*skupperv2alpha1.SitePolicy
+		check    func(p *Policies) error
+		expected string
+	}{
+		{
+			name: "no policy",
+			check: func(p *Policies) error {
+				return p.CheckRoutingKey("west", "anything")
+			},
+		},
+		{
+			name: "routing key allowed",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend", "team-a.*"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckRoutingKey("west", "team-a.db")
+			},
+		},
+		{
+			name: "routing key denied",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend", "team-a.*"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckRoutingKey("west", "team-b.db")
+			},
+			expected: `Routing key "team-b.db" not allowed by SitePolicy west/keys`,
+		},
+		{
+			name: "policy in other namespace does not apply",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("east", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend"}, Namespaces: []string{"west"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckRoutingKey("west", "frontend")
+			},
+		},
+		{
+			name: "policy in controller namespace applies to listed namespaces",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("skupper", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend"}, Namespaces: []string{"west"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckRoutingKey("west", "frontend")
+			},
+			expected: `Routing key "frontend" not allowed by SitePolicy skupper/keys`,
+		},
+		{
+			name: "policy in controller namespace applies to all namespaces",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("skupper", "types", skupperv2alpha1.SitePolicySpec{AllowedAccessTypes: []string{"route"}, Namespaces: []string{"*"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckAccessType("west", "loadbalancer")
+			},
+			expected: `Access type "loadbalancer" not allowed by SitePolicy skupper/types`,
+		},
+		{
+			name: "every policy must allow",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "a", skupperv2alpha1.SitePolicySpec{AllowedAccessTypes: []string{"route", "loadbalancer"}}),
+				sitePolicy("west", "b", skupperv2alpha1.SitePolicySpec{AllowedAccessTypes: []string{"route"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckAccessType("west", "loadbalancer")
+			},
+			expected: `Access type "loadbalancer" not allowed by SitePolicy west/b`,
+		},
+		{
+			name: "unrestricted redemptions",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend"}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "", "")
+			},
+		},
+		{
+			name: "redemption allowed by namespace",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{AllowedSites: []string{"hq"}, AllowedNamespaces: []string{"team-*"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "edge", "team-a")
+			},
+		},
+		{
+			name: "redemption not allowed",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{AllowedSites: []string{"hq"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "edge", "east")
+			},
+			expected: `Redemption by site "edge" in namespace "east" not allowed by SitePolicy west/links`,
+		},
+		{
+			name: "unidentified redeemer not filtered",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{AllowedSites: []string{"hq"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "", "")
+			},
+		},
+		{
+			name: "partially identified site not allowed",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{AllowedSites: []string{"*"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "", "east")
+			},
+			expected: `Redemption by site "" in namespace "east" not allowed by SitePolicy west/links`,
+		},
+		{
+			name: "deny takes precedence",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{AllowedNamespaces: []string{"*"}, DeniedSites: []string{"rogue"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "rogue", "east")
+			},
+			expected: `Redemption by site "rogue" in namespace "east" not allowed by SitePolicy west/links`,
+		},
+		{
+			name: "denied namespace",
+			policies: []*skupperv2alpha1.SitePolicy{
+				sitePolicy("west", "links", skupperv2alpha1.SitePolicySpec{GrantRedemptions: &skupperv2alpha1.GrantRedemptionPolicy{DeniedNamespaces: []string{"untrusted-*"}}}),
+			},
+			check: func(p *Policies) error {
+				return p.CheckGrantRedemption("west", "edge", "untrusted-1")
+			},
+			expected: `Redemption by site "edge" in namespace "untrusted-1" not allowed by SitePolicy west/links`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPolicies("skupper")
+			for _, policy := range tt.policies {
+				changed, err := p.Update(policy.Namespace+"/"+policy.Name, policy)
+				assert.NilError(t, err)
+				assert.Assert(t, changed)
+			}
+			err := tt.check(p)
+			if tt.expected == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPoliciesUpdate(t *testing.T) {
+	p := NewPolicies("skupper")
+	policy := sitePolicy("west", "keys", skupperv2alpha1.SitePolicySpec{AllowedRoutingKeys: []string{"backend"}})
+	changed, err := p.Update("west/keys", policy)
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.Error(t, p.CheckRoutingKey("west", "frontend"), `Routing key "frontend" not allowed by SitePolicy west/keys`)
+
+	// a change to the status alone is not a change to the policy
+	updated := policy.DeepCopy()
+	updated.SetConfigured(nil)
+	changed, err = p.Update("west/keys", updated)
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	updated = updated.DeepCopy()
+	updated.Spec.AllowedRoutingKeys = append(updated.Spec.AllowedRoutingKeys, "[")
+	changed, err = p.Update("west/keys", updated)
+	assert.Error(t, err, `Invalid pattern "[": syntax error in pattern`)
+	assert.Assert(t, changed)
+
+	changed, err = p.Update("west/keys", nil)
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.NilError(t, p.CheckRoutingKey("west", "frontend"))
+
+	var nilPolicies *Policies
+	assert.NilError(t, nilPolicies.CheckAccessType("west", "route"))
+}
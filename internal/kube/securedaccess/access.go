@@ -25,6 +25,12 @@ type AccessType interface {
 	RealiseAndResolve(access *skupperv2alpha1.SecuredAccess, service *corev1.Service) ([]skupperv2alpha1.Endpoint, error)
 }
 
+// AccessTypePolicy determines the access types that SecuredAccesses in
+// a namespace may use.
+type AccessTypePolicy interface {
+	CheckAccessType(namespace string, accessType string) error
+}
+
 type ControllerContext struct {
 	Namespace string
 	Name      string
@@ -43,6 +49,7 @@ type SecuredAccessManager struct {
 	enabledAccessTypes map[string]AccessType
 	defaultAccessType  string
 	gatewayInit        func() error
+	policy             AccessTypePolicy
 }
 
 func NewSecuredAccessManager(clients internalclient.Clients, certMgr certificates.CertificateManager, config *Config, context ControllerContext) *SecuredAccessManager {
@@ -85,6 +92,32 @@ func NewSecuredAccessManager(clients internalclient.Clients, certMgr certificate
 	return mgr
 }
 
+// SetPolicy restricts the access types that may be used. Any
+// SecuredAccess using a type the policy does not allow is marked as not
+// configured and no resources are created for it.
+func (m *SecuredAccessManager) SetPolicy(policy AccessTypePolicy) {
+	m.policy = policy
+}
+
+// PolicyChanged reconciles all SecuredAccesses against the current
+// policy.
+func (m *SecuredAccessManager) PolicyChanged() error {
+	var errs []error
+	for _, sa := range m.definitions {
+		if err := m.reconcile(sa); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *SecuredAccessManager) checkPolicy(sa *skupperv2alpha1.SecuredAccess) error {
+	if m.policy == nil {
+		return nil
+	}
+	return m.policy.CheckAccessType(sa.Namespace, m.actualAccessType(sa))
+}
+
 func (m *SecuredAccessManager) IsValidAccessType(accessType string) bool {
 	_, ok := m.enabledAccessTypes[accessType]
 	return ok
@@ -162,6 +195,12 @@ func (m *SecuredAccessManager) accessType(sa *skupperv2alpha1.SecuredAccess) Acc
 }
 
 func (m *SecuredAccessManager) reconcile(sa *skupperv2alpha1.SecuredAccess) error {
+	if err := m.checkPolicy(sa); err != nil {
+		if sa.SetConfigured(err) {
+			return m.updateStatus(sa)
+		}
+		return nil
+	}
 	svc, err := m.checkService(sa)
 	if err != nil {
 		if sa.SetConfigured(err) {
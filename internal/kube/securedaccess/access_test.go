@@ -3529,3 +3529,43 @@ func TestRecreateOnDelete(t *testing.T) {
 		})
 	}
 }
+
+type allowedAccessTypes []string
+
+func (types allowedAccessTypes) CheckAccessType(namespace string, accessType string) error {
+	for _, allowed := range types {
+		if allowed == accessType {
+			return nil
+		}
+	}
+	return fmt.Errorf("Access type %q not allowed", accessType)
+}
+
+func TestSecuredAccessPolicy(t *testing.T) {
+	sa := securedAccess("mysvc", "test", selector(), securedAccessPorts())
+	client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{sa}, "")
+	assert.Assert(t, err)
+	m := NewSecuredAccessManager(client, newMockCertificateManager(), &Config{
+		EnabledAccessTypes: []string{ACCESS_TYPE_LOADBALANCER, ACCESS_TYPE_ROUTE},
+		DefaultAccessType:  ACCESS_TYPE_LOADBALANCER,
+	}, ControllerContext{Namespace: "test"})
+	m.SetPolicy(allowedAccessTypes{ACCESS_TYPE_ROUTE})
+
+	// the default access type is subject to policy too
+	assert.Assert(t, m.SecuredAccessChanged("test/mysvc", sa))
+	sa, err = client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, sa.Status.Message, `Access type "loadbalancer" not allowed`)
+	assert.Assert(t, !sa.IsReady())
+	_, err = client.GetKubeClient().CoreV1().Services("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+	assert.ErrorContains(t, err, "not found")
+
+	m.SetPolicy(allowedAccessTypes{ACCESS_TYPE_ROUTE, ACCESS_TYPE_LOADBALANCER})
+	assert.Assert(t, m.PolicyChanged())
+	svc, err := client.GetKubeClient().CoreV1().Services("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, svc.Spec.Type, corev1.ServiceTypeLoadBalancer)
+	sa, err = client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Assert(t, sa.Status.Message != `Access type "loadbalancer" not allowed`, sa.Status.Message)
+}
@@ -291,6 +291,8 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 							SiteId: "00000000-0000-0000-0000-000000000001",
 						},
 					},
+					UdpListeners:  map[string]qdr.UdpEndpoint{},
+					UdpConnectors: map[string]qdr.UdpEndpoint{},
 				},
 			},
 		},
@@ -333,6 +335,8 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 							ProcessID: "30af5279-be83-41e4-86fe-cc45396786f4",
 						},
 					},
+					UdpListeners:  map[string]qdr.UdpEndpoint{},
+					UdpConnectors: map[string]qdr.UdpEndpoint{},
 				},
 			},
 		},
@@ -360,6 +364,8 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 				config: qdr.BridgeConfig{
 					TcpListeners:  map[string]qdr.TcpEndpoint{},
 					TcpConnectors: map[string]qdr.TcpEndpoint{},
+					UdpListeners:  map[string]qdr.UdpEndpoint{},
+					UdpConnectors: map[string]qdr.UdpEndpoint{},
 				},
 			},
 		},
@@ -385,6 +391,8 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 				config: qdr.BridgeConfig{
 					TcpListeners:  map[string]qdr.TcpEndpoint{},
 					TcpConnectors: map[string]qdr.TcpEndpoint{},
+					UdpListeners:  map[string]qdr.UdpEndpoint{},
+					UdpConnectors: map[string]qdr.UdpEndpoint{},
 				},
 			},
 		},
@@ -600,6 +608,8 @@ func TestBindingAdaptor_updateBridgeConfigForListener(t *testing.T) {
 						},
 					},
 					TcpConnectors: map[string]qdr.TcpEndpoint{},
+					UdpListeners:  map[string]qdr.UdpEndpoint{},
+					UdpConnectors: map[string]qdr.UdpEndpoint{},
 				},
 			},
 		},
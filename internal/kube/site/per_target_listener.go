@@ -103,7 +103,8 @@ func (p *PerTargetListener) unexpose(target string, mapping *qdr.PortMapping, ex
 
 func (p *PerTargetListener) updateBridgeConfig(siteId string, config *qdr.BridgeConfig) {
 	for target, port := range p.targets {
-		if p.definition.Spec.Type == "tcp" || p.definition.Spec.Type == "" {
+		switch p.definition.Spec.Type {
+		case "tcp", "":
 			config.AddTcpListener(qdr.TcpEndpoint{
 				Name:       p.definition.Name + "@" + target,
 				SiteId:     siteId,
@@ -112,6 +113,14 @@ func (p *PerTargetListener) updateBridgeConfig(siteId string, config *qdr.Bridge
 				Address:    p.address(target),
 				SslProfile: p.definition.Spec.TlsCredentials,
 			})
+		case "udp":
+			config.AddUdpListener(qdr.UdpEndpoint{
+				Name:    p.definition.Name + "@" + target,
+				SiteId:  siteId,
+				Host:    "0.0.0.0",
+				Port:    strconv.Itoa(port),
+				Address: p.address(target),
+			})
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -57,6 +58,18 @@ type CoreParams struct {
 	ConfigDigest   string
 	RouterImage    skuppertypes.ImageDetails
 	AdaptorImage   skuppertypes.ImageDetails
+	// The following are from the site's RouterPodSpec. The maps are
+	// rendered entry by entry, the rest as inline JSON (or empty if
+	// not set).
+	Labels                    map[string]string
+	Annotations               map[string]string
+	NodeSelector              string
+	Tolerations               string
+	Affinity                  string
+	TopologySpreadConstraints string
+	PriorityClassName         string
+	RouterResources           string
+	AdaptorResources          string
 }
 
 func configDigest(config *skupperv2alpha1.SiteSpec) string {
@@ -80,7 +93,7 @@ func configDigest(config *skupperv2alpha1.SiteSpec) string {
 }
 
 func getCoreParams(site *skupperv2alpha1.Site, group string) CoreParams {
-	return CoreParams{
+	params := CoreParams{
 		SiteId:         site.GetSiteId(),
 		SiteName:       site.Name,
 		Group:          group,
@@ -90,6 +103,43 @@ func getCoreParams(site *skupperv2alpha1.Site, group string) CoreParams {
 		RouterImage:    images.GetRouterImageDetails(),
 		AdaptorImage:   images.GetKubeAdaptorImageDetails(),
 	}
+	if pod := site.Spec.RouterPod; pod != nil {
+		params.Labels = pod.Labels
+		params.Annotations = pod.Annotations
+		if len(pod.NodeSelector) > 0 {
+			params.NodeSelector = inlineJson(pod.NodeSelector)
+		}
+		if len(pod.Tolerations) > 0 {
+			params.Tolerations = inlineJson(pod.Tolerations)
+		}
+		if pod.Affinity != nil {
+			params.Affinity = inlineJson(pod.Affinity)
+		}
+		if len(pod.TopologySpreadConstraints) > 0 {
+			params.TopologySpreadConstraints = inlineJson(pod.TopologySpreadConstraints)
+		}
+		if pod.PriorityClassName != "" {
+			params.PriorityClassName = inlineJson(pod.PriorityClassName)
+		}
+		if pod.RouterResources != nil {
+			params.RouterResources = inlineJson(pod.RouterResources)
+		}
+		if pod.AdaptorResources != nil {
+			params.AdaptorResources = inlineJson(pod.AdaptorResources)
+		}
+	}
+	return params
+}
+
+// inlineJson renders a value for inclusion in a template. JSON is
+// valid YAML, so this avoids having to lay out nested structures in
+// the template itself.
+func inlineJson(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
 }
 
 func Apply(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, group string) error {
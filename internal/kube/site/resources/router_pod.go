@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// labels and annotations that skupper sets on the router deployment
+// and its pods, which therefore cannot be specified in a RouterPodSpec
+var reservedLabels = []string{
+	"app.kubernetes.io/name",
+	"app.kubernetes.io/part-of",
+	"application",
+	"skupper.io/component",
+	"skupper.io/group",
+	"skupper.io/type",
+}
+
+var reservedAnnotations = []string{
+	"prometheus.io/port",
+	"prometheus.io/scrape",
+	"skupper.io/config-digest",
+}
+
+// ValidateRouterPod checks that the customisations requested for the
+// router pods of a site are valid, so that a bad value is reported on
+// the site rather than as a failure to apply the router deployment.
+func ValidateRouterPod(pod *skupperv2alpha1.RouterPodSpec) error {
+	if pod == nil {
+		return nil
+	}
+	var errs []string
+	errs = append(errs, validateStringMap("label", pod.Labels, reservedLabels, true)...)
+	errs = append(errs, validateStringMap("annotation", pod.Annotations, reservedAnnotations, false)...)
+	errs = append(errs, validateStringMap("node selector", pod.NodeSelector, nil, true)...)
+	for _, toleration := range pod.Tolerations {
+		errs = append(errs, validateToleration(toleration)...)
+	}
+	for _, constraint := range pod.TopologySpreadConstraints {
+		errs = append(errs, validateTopologySpreadConstraint(constraint)...)
+	}
+	if pod.PriorityClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(pod.PriorityClassName) {
+			errs = append(errs, fmt.Sprintf("priority class name %q: %s", pod.PriorityClassName, msg))
+		}
+	}
+	errs = append(errs, validateResources("router", pod.RouterResources)...)
+	errs = append(errs, validateResources("kube-adaptor", pod.AdaptorResources)...)
+	if len(errs) > 0 {
+		return fmt.Errorf("Invalid routerPod: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func validateStringMap(description string, values map[string]string, reserved []string, labelValues bool) []string {
+	var errs []string
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if slices.Contains(reserved, key) {
+			errs = append(errs, fmt.Sprintf("%s %q is reserved", description, key))
+			continue
+		}
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Sprintf("%s %q: %s", description, key, msg))
+		}
+		if labelValues {
+			for _, msg := range validation.IsValidLabelValue(values[key]) {
+				errs = append(errs, fmt.Sprintf("%s %q value %q: %s", description, key, values[key], msg))
+			}
+		}
+	}
+	return errs
+}
+
+func validateToleration(toleration corev1.Toleration) []string {
+	var errs []string
+	if toleration.Key != "" {
+		for _, msg := range validation.IsQualifiedName(toleration.Key) {
+			errs = append(errs, fmt.Sprintf("toleration key %q: %s", toleration.Key, msg))
+		}
+	}
+	switch toleration.Operator {
+	case "", corev1.TolerationOpEqual:
+		if toleration.Key == "" {
+			errs = append(errs, "toleration with no key must use operator Exists")
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			errs = append(errs, fmt.Sprintf("toleration for %q with operator Exists must not specify a value", toleration.Key))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("toleration for %q has unsupported operator %q", toleration.Key, toleration.Operator))
+	}
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		errs = append(errs, fmt.Sprintf("toleration for %q has unsupported effect %q", toleration.Key, toleration.Effect))
+	}
+	return errs
+}
+
+func validateTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) []string {
+	var errs []string
+	if constraint.TopologyKey == "" {
+		errs = append(errs, "topology spread constraint must specify topologyKey")
+	}
+	if constraint.MaxSkew < 1 {
+		errs = append(errs, fmt.Sprintf("topology spread constraint for %q must have maxSkew of at least 1", constraint.TopologyKey))
+	}
+	switch constraint.WhenUnsatisfiable {
+	case corev1.DoNotSchedule, corev1.ScheduleAnyway:
+	default:
+		errs = append(errs, fmt.Sprintf("topology spread constraint for %q has unsupported whenUnsatisfiable %q", constraint.TopologyKey, constraint.WhenUnsatisfiable))
+	}
+	return errs
+}
+
+func validateResources(container string, resources *corev1.ResourceRequirements) []string {
+	if resources == nil {
+		return nil
+	}
+	var errs []string
+	var names []string
+	for name := range resources.Requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		request := resources.Requests[corev1.ResourceName(name)]
+		if limit, ok := resources.Limits[corev1.ResourceName(name)]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, fmt.Sprintf("%s %s request %s exceeds limit %s", container, name, request.String(), limit.String()))
+		}
+	}
+	return errs
+}
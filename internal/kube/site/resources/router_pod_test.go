@@ -0,0 +1,216 @@
+package resources
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func renderDeployment(t *testing.T, site *skupperv2alpha1.Site) *appsv1.Deployment {
+	var buffer bytes.Buffer
+	tmpl := template.Must(template.New("deployment").Parse(routerDeploymentTemplate))
+	assert.NilError(t, tmpl.Execute(&buffer, getCoreParams(site, "skupper-router")))
+	deployment := &appsv1.Deployment{}
+	assert.NilError(t, yaml.UnmarshalStrict(buffer.Bytes(), deployment), buffer.String())
+	return deployment
+}
+
+func testSite(pod *skupperv2alpha1.RouterPodSpec) *skupperv2alpha1.Site {
+	return &skupperv2alpha1.Site{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-site",
+			Namespace: "test",
+			UID:       "00000000-0000-0000-0000-000000000001",
+		},
+		Spec: skupperv2alpha1.SiteSpec{
+			RouterPod: pod,
+		},
+	}
+}
+
+func TestRouterDeploymentDefaults(t *testing.T) {
+	deployment := renderDeployment(t, testSite(nil))
+	pod := deployment.Spec.Template.Spec
+	assert.Equal(t, len(pod.Containers), 2)
+	assert.Assert(t, pod.NodeSelector == nil)
+	assert.Assert(t, pod.Tolerations == nil)
+	assert.Assert(t, pod.Affinity == nil)
+	assert.Equal(t, pod.PriorityClassName, "")
+	assert.Equal(t, len(pod.Containers[0].Resources.Requests), 0)
+	assert.Equal(t, len(deployment.ObjectMeta.Annotations), 0)
+	assert.Equal(t, len(deployment.Spec.Template.ObjectMeta.Labels), 6)
+}
+
+func TestRouterDeploymentCustomised(t *testing.T) {
+	routerResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	adaptorResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("50m"),
+		},
+	}
+	spec := &skupperv2alpha1.RouterPodSpec{
+		Labels: map[string]string{
+			"team":            "networking",
+			"example.com/app": "router: \"primary\"",
+		},
+		Annotations: map[string]string{
+			"example.com/owner": "ops@example.com",
+		},
+		NodeSelector: map[string]string{
+			"kubernetes.io/os": "linux",
+		},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "skupper",
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"skupper.io/component": "router"},
+							},
+						},
+					},
+				},
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			},
+		},
+		PriorityClassName: "high-priority",
+		RouterResources:   routerResources,
+		AdaptorResources:  adaptorResources,
+	}
+	deployment := renderDeployment(t, testSite(spec))
+	assert.Equal(t, deployment.ObjectMeta.Labels["team"], "networking")
+	assert.Equal(t, deployment.ObjectMeta.Labels["skupper.io/component"], "router")
+	assert.Equal(t, deployment.ObjectMeta.Annotations["example.com/owner"], "ops@example.com")
+	template := deployment.Spec.Template
+	assert.Equal(t, template.ObjectMeta.Labels["team"], "networking")
+	assert.Equal(t, template.ObjectMeta.Labels["example.com/app"], "router: \"primary\"")
+	assert.Equal(t, template.ObjectMeta.Labels["skupper.io/group"], "skupper-router")
+	assert.Equal(t, template.ObjectMeta.Annotations["example.com/owner"], "ops@example.com")
+	assert.Equal(t, template.ObjectMeta.Annotations["prometheus.io/scrape"], "true")
+	pod := template.Spec
+	assert.DeepEqual(t, pod.NodeSelector, spec.NodeSelector)
+	assert.DeepEqual(t, pod.Tolerations, spec.Tolerations)
+	assert.DeepEqual(t, pod.Affinity, spec.Affinity)
+	assert.DeepEqual(t, pod.TopologySpreadConstraints, spec.TopologySpreadConstraints)
+	assert.Equal(t, pod.PriorityClassName, "high-priority")
+	assert.Equal(t, pod.Containers[0].Name, "router")
+	assert.Assert(t, pod.Containers[0].Resources.Requests.Cpu().Equal(resource.MustParse("250m")))
+	assert.Assert(t, pod.Containers[0].Resources.Limits.Memory().Equal(resource.MustParse("512Mi")))
+	assert.Equal(t, pod.Containers[1].Name, "kube-adaptor")
+	assert.Assert(t, pod.Containers[1].Resources.Requests.Cpu().Equal(resource.MustParse("50m")))
+	assert.Equal(t, len(pod.InitContainers[0].Resources.Requests), 0)
+}
+
+func TestValidateRouterPod(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *skupperv2alpha1.RouterPodSpec
+		err  string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				Labels:            map[string]string{"team": "networking"},
+				Annotations:       map[string]string{"example.com/note": "any value at all"},
+				NodeSelector:      map[string]string{"kubernetes.io/os": "linux"},
+				PriorityClassName: "high-priority",
+				Tolerations: []corev1.Toleration{
+					{Operator: corev1.TolerationOpExists},
+					{Key: "dedicated", Value: "skupper", Effect: corev1.TaintEffectNoExecute},
+				},
+				RouterResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+		},
+		{
+			name: "reserved",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				Labels:      map[string]string{"skupper.io/component": "other"},
+				Annotations: map[string]string{"skupper.io/config-digest": "abc"},
+			},
+			err: "Invalid routerPod: label \"skupper.io/component\" is reserved, annotation \"skupper.io/config-digest\" is reserved",
+		},
+		{
+			name: "bad label",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				Labels: map[string]string{"team": "net working"},
+			},
+			err: "Invalid routerPod: label \"team\" value \"net working\": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')",
+		},
+		{
+			name: "bad toleration",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "skupper", Effect: "Sometimes"},
+				},
+			},
+			err: "Invalid routerPod: toleration for \"dedicated\" with operator Exists must not specify a value, toleration for \"dedicated\" has unsupported effect \"Sometimes\"",
+		},
+		{
+			name: "bad topology spread",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{}},
+			},
+			err: "Invalid routerPod: topology spread constraint must specify topologyKey, topology spread constraint for \"\" must have maxSkew of at least 1, topology spread constraint for \"\" has unsupported whenUnsatisfiable \"\"",
+		},
+		{
+			name: "request exceeds limit",
+			pod: &skupperv2alpha1.RouterPodSpec{
+				PriorityClassName: "High",
+				AdaptorResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			},
+			err: "Invalid routerPod: priority class name \"High\": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'), kube-adaptor memory request 1Gi exceeds limit 512Mi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRouterPod(tt.pod)
+			if tt.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -24,6 +26,12 @@ import (
 	"github.com/skupperproject/skupper/pkg/version"
 )
 
+// BindingPolicy determines the routing keys that Listeners and
+// Connectors in a namespace may use.
+type BindingPolicy interface {
+	CheckRoutingKey(namespace string, routingKey string) error
+}
+
 type SecuredAccessFactory interface {
 	Ensure(namespace string, name string, spec skupperv2alpha1.SecuredAccessSpec, annotations map[string]string, refs []metav1.OwnerReference) error
 	Delete(namespace string, name string) error
@@ -45,9 +53,10 @@ type Site struct {
 	routerPods    map[string]*corev1.Pod
 	logger        *slog.Logger
 	currentGroups []string
+	policy        BindingPolicy
 }
 
-func NewSite(namespace string, controller *internalclient.Controller, certs certificates.CertificateManager, access SecuredAccessFactory) *Site {
+func NewSite(namespace string, controller *internalclient.Controller, certs certificates.CertificateManager, access SecuredAccessFactory, policy BindingPolicy) *Site {
 	return &Site{
 		bindings:   NewExtendedBindings(controller, SSL_PROFILE_PATH),
 		namespace:  namespace,
@@ -57,6 +66,7 @@ func NewSite(namespace string, controller *internalclient.Controller, certs cert
 		certs:      certs,
 		access:     access,
 		routerPods: map[string]*corev1.Pod{},
+		policy:     policy,
 		logger: slog.New(slog.Default().Handler()).With(
 			slog.String("component", "kube.site.site"),
 		),
@@ -67,6 +77,14 @@ func (s *Site) verifySiteSpec(site *skupperv2alpha1.Site) error {
 	if site.Spec.LinkAccess != "" && site.Spec.LinkAccess != "none" && site.Spec.LinkAccess != "default" && !s.access.IsValidAccessType(site.Spec.LinkAccess) {
 		return fmt.Errorf("Unsupported value for LinkAccess: %s", site.Spec.LinkAccess)
 	}
+	if value := site.Spec.GetRouterGroups(); value != "" {
+		if count, err := strconv.Atoi(value); err != nil || count < 1 {
+			return fmt.Errorf("Invalid value for router-groups setting: %s", value)
+		}
+	}
+	if err := resources.ValidateRouterPod(site.Spec.RouterPod); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -132,12 +150,8 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 		s.bindings.SetSite(s)
 		s.setBindingsConfiguredStatus(nil)
 		s.checkSecuredAccess()
-	} else if len(s.currentGroups) != len(s.groups()) {
-		s.currentGroups = s.groups()
-		if _, err := s.recoverRouterConfig(true); err != nil {
-			return err
-		}
-		if err := s.checkSecuredAccess(); err != nil {
+	} else if !slices.Equal(s.currentGroups, s.groups()) {
+		if err := s.reconcileGroups(); err != nil {
 			return err
 		}
 	} else {
@@ -175,7 +189,6 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 
 	// 3. deployment
 	for _, group := range s.groups() {
-		//TODO: if change from HA=true to HA=false, will need to remove previous resources
 		if err := resources.Apply(s.controller, ctxt, s.site, group); err != nil {
 			return err
 		}
@@ -206,12 +219,69 @@ func (s *Site) initialRouterConfig() *qdr.RouterConfig {
 	return &rc
 }
 
+const routerGroupPrefix = "skupper-router"
+
+// groups returns the names of the router groups for the site. Each
+// group has its own deployment and config, and routers in each group
+// connect to those in the groups preceding it. HA sites have at least
+// two groups; the router-groups setting allows for more.
 func (s *Site) groups() []string {
+	count := 1
 	if s.site.Spec.HA {
-		return []string{"skupper-router", "skupper-router-2"}
-	} else {
-		return []string{"skupper-router"}
+		count = 2
+	}
+	if value, err := strconv.Atoi(s.site.Spec.GetRouterGroups()); err == nil && value > count {
+		count = value
+	}
+	groups := []string{routerGroupPrefix}
+	for i := 2; i <= count; i++ {
+		groups = append(groups, fmt.Sprintf("%s-%d", routerGroupPrefix, i))
 	}
+	return groups
+}
+
+// groupQualified returns the name used for a per-group instance of
+// some resource, e.g. the SecuredAccess for a RouterAccess. The first
+// group uses the name unchanged.
+func groupQualified(name string, group string) string {
+	return name + strings.TrimPrefix(group, routerGroupPrefix)
+}
+
+// reconcileGroups brings the router groups into line with the site
+// after a change to HA or to the number of router groups. Config is
+// created for any new group and all resources are removed for any
+// group no longer required. The inter-router connectors between groups
+// and the SecuredAccess for each group are then updated to match.
+func (s *Site) reconcileGroups() error {
+	previous := s.currentGroups
+	current := s.groups()
+	s.logger.Info("Router groups changed for site",
+		slog.String("namespace", s.namespace),
+		slog.String("name", s.name),
+		slog.Any("previous", previous),
+		slog.Any("current", current))
+	if _, err := s.recoverRouterConfig(true); err != nil {
+		// groups are reconciled again when the site is next checked
+		return err
+	}
+	s.currentGroups = current
+	var removed ConfigUpdateList
+	for _, group := range previous {
+		if !slices.Contains(s.currentGroups, group) {
+			removed = append(removed, site.NewRemoveConnector(group))
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.updateRouterConfig(removed); err != nil {
+			return err
+		}
+	}
+	for i, group := range s.currentGroups {
+		if err := s.updateRouterConfigForGroup(s.linkAccess.DesiredConfig(s.currentGroups[:i], SSL_PROFILE_PATH), group); err != nil {
+			return err
+		}
+	}
+	return s.checkSecuredAccess()
 }
 
 func (s *Site) checkDefaultRouterAccess(ctxt context.Context, site *skupperv2alpha1.Site) error {
@@ -608,6 +678,7 @@ func (s *Site) recoverRouterConfig(update bool) ([]*qdr.RouterConfig, error) {
 	}
 	//need to ensure that the list of configs is in the right order, i.e. matching s.groups()
 	var configs []*qdr.RouterConfig
+	var previousGroups []string
 	for _, group := range s.groups() {
 		if config, ok := byName[group]; ok {
 			if update {
@@ -621,8 +692,7 @@ func (s *Site) recoverRouterConfig(update bool) ([]*qdr.RouterConfig, error) {
 			configs = append(configs, config)
 			delete(byName, group)
 		} else {
-			routerConfig := s.initialRouterConfig()
-			s.bindings.Apply(routerConfig)
+			routerConfig := s.initialGroupConfig(previousGroups)
 			if err := s.createRouterConfigForGroup(group, routerConfig); err != nil {
 				s.logger.Error("Failed to create router config map",
 					slog.String("namespace", s.namespace),
@@ -634,38 +704,68 @@ func (s *Site) recoverRouterConfig(update bool) ([]*qdr.RouterConfig, error) {
 					slog.String("name", group))
 			}
 		}
+		previousGroups = append(previousGroups, group)
 	}
+	var errs []error
 	for name, _ := range byName {
 		// no longer needed, delete it (and other associated router resources?)
-		s.deleteRouterResources(name)
+		if err := s.deleteRouterResources(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to delete resources of removed router groups: %w", stderrors.Join(errs...))
 	}
 	return configs, nil
 }
 
+// initialGroupConfig returns the config for a router group that does
+// not yet exist, including any links and router access already
+// defined for the site.
+func (s *Site) initialGroupConfig(previousGroups []string) *qdr.RouterConfig {
+	routerConfig := s.initialRouterConfig()
+	s.bindings.Apply(routerConfig)
+	for _, link := range s.links {
+		link.Apply(routerConfig)
+	}
+	s.linkAccess.DesiredConfig(previousGroups, SSL_PROFILE_PATH).Apply(routerConfig)
+	return routerConfig
+}
+
+// deleteRouterResources deletes the deployment, secured access and config
+// map of a router group, ignoring those already deleted.
 func (s *Site) deleteRouterResources(group string) error {
 	var errs []error
-	if err := s.controller.GetKubeClient().CoreV1().ConfigMaps(s.namespace).Delete(context.TODO(), group, metav1.DeleteOptions{}); err != nil {
-		s.logger.Error("Failed to delete router config map",
-			slog.String("namespace", s.namespace),
-			slog.String("name", group),
-			slog.Any("error", err))
-		errs = append(errs, err)
-	}
-	if err := s.controller.GetKubeClient().AppsV1().Deployments(s.namespace).Delete(context.TODO(), group, metav1.DeleteOptions{}); err != nil {
+	if err := s.controller.GetKubeClient().AppsV1().Deployments(s.namespace).Delete(context.TODO(), group, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
 		s.logger.Error("Failed to delete router deployment",
 			slog.String("namespace", s.namespace),
 			slog.String("name", group),
 			slog.Any("error", err))
 		errs = append(errs, err)
 	}
-	if err := s.access.Delete(s.namespace, group); err != nil {
-		s.logger.Error("Failed to delete securedaccess for router",
+	for _, la := range s.linkAccess {
+		name := groupQualified(la.Name, group)
+		if err := s.access.Delete(s.namespace, name); err != nil && !errors.IsNotFound(err) {
+			s.logger.Error("Failed to delete securedaccess for router",
+				slog.String("namespace", s.namespace),
+				slog.String("name", name),
+				slog.Any("error", err))
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		// the config map identifies the group as removed, so it is
+		// kept until the other resources are deleted
+		return stderrors.Join(errs...)
+	}
+	if err := s.controller.GetKubeClient().CoreV1().ConfigMaps(s.namespace).Delete(context.TODO(), group, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		s.logger.Error("Failed to delete router config map",
 			slog.String("namespace", s.namespace),
 			slog.String("name", group),
 			slog.Any("error", err))
-		errs = append(errs, err)
+		return err
 	}
-	return stderrors.Join(errs...)
+	return nil
 }
 
 func (s *Site) createRouterConfig(config *qdr.RouterConfig) error {
@@ -763,7 +863,19 @@ func (s *Site) updateConnectorConfiguredStatusWithSelectedPods(connector *skuppe
 	return nil
 }
 
+func (s *Site) checkRoutingKey(routingKey string) error {
+	if s.policy == nil {
+		return nil
+	}
+	return s.policy.CheckRoutingKey(s.namespace, routingKey)
+}
+
 func (s *Site) CheckConnector(name string, connector *skupperv2alpha1.Connector) error {
+	if connector != nil {
+		if denied := s.checkRoutingKey(connector.Spec.RoutingKey); denied != nil {
+			return s.denyConnector(name, connector, denied)
+		}
+	}
 	update := s.bindings.UpdateConnector(name, connector)
 	if s.site == nil {
 		return s.updateConnectorConfiguredStatus(connector, stderrors.New("No active site in namespace"))
@@ -778,6 +890,22 @@ func (s *Site) CheckConnector(name string, connector *skupperv2alpha1.Connector)
 	return s.updateConnectorConfiguredStatus(connector, err)
 }
 
+// denyConnector removes a connector that policy does not allow from
+// the router config, recording the reason in its status without
+// adding it back to the bindings.
+func (s *Site) denyConnector(name string, connector *skupperv2alpha1.Connector, denied error) error {
+	var err error
+	if update := s.bindings.UpdateConnector(name, nil); update != nil && s.site != nil {
+		err = s.updateRouterConfig(update)
+	}
+	if connector.SetConfigured(denied) {
+		if _, statusErr := updateConnectorStatus(s.controller, connector); statusErr != nil {
+			err = stderrors.Join(err, statusErr)
+		}
+	}
+	return err
+}
+
 func (s *Site) updateListenerStatus(listener *skupperv2alpha1.Listener, err error) error {
 	if listener.SetConfigured(err) {
 		updated, err := s.controller.GetSkupperClient().SkupperV2alpha1().Listeners(listener.ObjectMeta.Namespace).UpdateStatus(context.TODO(), listener, metav1.UpdateOptions{})
@@ -790,6 +918,11 @@ func (s *Site) updateListenerStatus(listener *skupperv2alpha1.Listener, err erro
 }
 
 func (s *Site) CheckListener(name string, listener *skupperv2alpha1.Listener) error {
+	if listener != nil {
+		if denied := s.checkRoutingKey(listener.Spec.RoutingKey); denied != nil {
+			return s.denyListener(name, listener, denied)
+		}
+	}
 	update, err1 := s.bindings.UpdateListener(name, listener)
 	if s.site == nil {
 		if listener == nil {
@@ -807,6 +940,22 @@ func (s *Site) CheckListener(name string, listener *skupperv2alpha1.Listener) er
 	return s.updateListenerStatus(listener, stderrors.Join(err1, err2))
 }
 
+// denyListener removes a listener that policy does not allow from the
+// router config, recording the reason in its status without adding it
+// back to the bindings.
+func (s *Site) denyListener(name string, listener *skupperv2alpha1.Listener, denied error) error {
+	update, err := s.bindings.UpdateListener(name, nil)
+	if update != nil && s.site != nil {
+		err = stderrors.Join(err, s.updateRouterConfig(update))
+	}
+	if listener.SetConfigured(denied) {
+		if _, statusErr := updateListenerStatus(s.controller, listener); statusErr != nil {
+			err = stderrors.Join(err, statusErr)
+		}
+	}
+	return err
+}
+
 func (s *Site) setBindingsConfiguredStatus(err error) {
 	lf := func(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
 		if listener.SetConfigured(nil) {
@@ -970,13 +1119,65 @@ func (s *Site) updateSiteStatus() error {
 	return nil
 }
 
-func (s *Site) updateLinkOperationalCondition(link *skupperv2alpha1.Link, operational bool, remoteSiteId string, remoteSiteName string) error {
-	if link.SetOperational(operational, remoteSiteId, remoteSiteName) {
+func (s *Site) updateLinkOperationalCondition(link *skupperv2alpha1.Link, operational bool, remoteSiteId string, remoteSiteName string, activeEndpoint string) error {
+	changed := link.SetOperational(operational, remoteSiteId, remoteSiteName)
+	if link.SetActiveEndpoint(activeEndpoint) {
+		changed = true
+	}
+	if changed {
 		return s.updateLinkStatus(link)
 	}
 	return nil
 }
 
+// updateLinksFromRecords updates the status of each link from the records
+// for the router connectors configured for it. A link with standby
+// endpoints is operational if any of them is connected, and the
+// endpoint in use is the lowest cost of those that are.
+func (s *Site) updateLinksFromRecords(linkRecords []skupperv2alpha1.LinkRecord) {
+	records := map[string][]skupperv2alpha1.LinkRecord{}
+	var names []string
+	for _, linkRecord := range linkRecords {
+		name, _ := site.LinkForConnector(linkRecord.Name)
+		if _, ok := records[name]; !ok {
+			names = append(names, name)
+		}
+		records[name] = append(records[name], linkRecord)
+	}
+	for _, name := range names {
+		link, ok := s.links[name]
+		if !ok {
+			continue
+		}
+		operational := false
+		var remoteSiteId, remoteSiteName string
+		var connected []string
+		for _, linkRecord := range records[name] {
+			if linkRecord.Operational {
+				connected = append(connected, linkRecord.Name)
+			}
+			if remoteSiteId == "" || (linkRecord.Operational && !operational) {
+				remoteSiteId = linkRecord.RemoteSiteId
+				remoteSiteName = linkRecord.RemoteSiteName
+			}
+			operational = operational || linkRecord.Operational
+		}
+		activeEndpoint := ""
+		isConnected := func(connector string, _ skupperv2alpha1.Endpoint) bool {
+			return slices.Contains(connected, connector)
+		}
+		if endpoint, ok := link.ActiveEndpoint(s.isEdge(), isConnected); ok {
+			activeEndpoint = endpoint.Url()
+		}
+		if err := s.updateLinkOperationalCondition(link.Definition(), operational, remoteSiteId, remoteSiteName, activeEndpoint); err != nil {
+			s.logger.Error("Error updating operational status of link",
+				slog.String("namespace", s.site.Namespace),
+				slog.String("link", name),
+				slog.Any("error", err))
+		}
+	}
+}
+
 func getLinkRecordsForSite(siteId string, network []skupperv2alpha1.SiteRecord) []skupperv2alpha1.LinkRecord {
 	for _, siteRecord := range network {
 		if siteRecord.Id == siteId {
@@ -999,17 +1200,7 @@ func (s *Site) NetworkStatusUpdated(network []skupperv2alpha1.SiteRecord) error
 	s.site = updated
 
 	// find the site record for this site, then process the link records it contains
-	linkRecords := getLinkRecordsForSite(s.site.GetSiteId(), network)
-	for _, linkRecord := range linkRecords {
-		if link, ok := s.links[linkRecord.Name]; ok {
-			if err := s.updateLinkOperationalCondition(link.Definition(), linkRecord.Operational, linkRecord.RemoteSiteId, linkRecord.RemoteSiteName); err != nil {
-				s.logger.Error("Error updating operational status of link",
-					slog.String("namespace", s.site.Namespace),
-					slog.String("link", linkRecord.Name),
-					slog.Any("error", err))
-			}
-		}
-	}
+	s.updateLinksFromRecords(getLinkRecordsForSite(s.site.GetSiteId(), network))
 	if config := s.bindings.networkUpdated(network); config != nil {
 		if err := s.updateRouterConfig(config); err != nil {
 			return err
@@ -1097,13 +1288,9 @@ func asSecuredAccessSpec(la *skupperv2alpha1.RouterAccess, group string, default
 }
 
 func (s *Site) checkSecuredAccess() error {
-	groups := s.groups()
-	for i, group := range groups {
+	for _, group := range s.groups() {
 		for _, la := range s.linkAccess {
-			name := la.Name
-			if i > 0 {
-				name = fmt.Sprintf("%s-%d", la.Name, (i + 1))
-			}
+			name := groupQualified(la.Name, group)
 			annotations := map[string]string{
 				"internal.skupper.io/controlled":   "true",
 				"internal.skupper.io/routeraccess": la.Name,
@@ -1137,7 +1324,7 @@ func (s *Site) CheckRouterAccess(name string, la *skupperv2alpha1.RouterAccess)
 		var previousGroups []string
 		groups := s.groups()
 		var errors []string
-		for i, group := range groups {
+		for _, group := range groups {
 			if err := s.updateRouterConfigForGroup(s.linkAccess.DesiredConfig(previousGroups, SSL_PROFILE_PATH), group); err != nil {
 				s.logger.Error("Error updating router config",
 					slog.String("namespace", s.namespace),
@@ -1145,10 +1332,7 @@ func (s *Site) CheckRouterAccess(name string, la *skupperv2alpha1.RouterAccess)
 				errors = append(errors, err.Error())
 			}
 			if la != nil {
-				name := la.Name
-				if i > 0 {
-					name = fmt.Sprintf("%s-%d", la.Name, (i + 1))
-				}
+				name := groupQualified(la.Name, group)
 				annotations := map[string]string{
 					"internal.skupper.io/controlled":   "true",
 					"internal.skupper.io/routeraccess": la.Name,
@@ -1174,9 +1358,27 @@ func (s *Site) CheckRouterAccess(name string, la *skupperv2alpha1.RouterAccess)
 }
 
 func (s *Site) CheckAttachedConnectorBinding(namespace string, name string, binding *skupperv2alpha1.AttachedConnectorBinding) error {
+	if binding != nil {
+		if denied := s.checkRoutingKey(binding.Spec.RoutingKey); denied != nil {
+			return s.denyAttachedConnectorBinding(namespace, name, binding, denied)
+		}
+	}
 	return s.bindings.checkAttachedConnectorBinding(namespace, name, binding)
 }
 
+// denyAttachedConnectorBinding removes a binding that policy does not
+// allow from the router config, as if it had been deleted, recording the
+// reason in its status.
+func (s *Site) denyAttachedConnectorBinding(namespace string, name string, binding *skupperv2alpha1.AttachedConnectorBinding, denied error) error {
+	err := s.bindings.checkAttachedConnectorBinding(namespace, name, nil)
+	if binding.SetConfigured(denied) {
+		if _, statusErr := s.controller.GetSkupperClient().SkupperV2alpha1().AttachedConnectorBindings(namespace).UpdateStatus(context.TODO(), binding, metav1.UpdateOptions{}); statusErr != nil {
+			err = stderrors.Join(err, statusErr)
+		}
+	}
+	return err
+}
+
 func (s *Site) AttachedConnectorUpdated(connector *skupperv2alpha1.AttachedConnector) error {
 	return s.bindings.attachedConnectorUpdated(connector.Name, connector)
 }
@@ -2,6 +2,8 @@ package site
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/skupperproject/skupper/internal/kube/certificates"
@@ -15,9 +17,13 @@ import (
 	"github.com/skupperproject/skupper/pkg/version"
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"log/slog"
 )
 
@@ -410,6 +416,8 @@ func TestSite_CheckConnector(t *testing.T) {
 		wantErr             bool
 		want                string
 		wantConnectors      uint
+		wantDenied          string
+		policy              BindingPolicy
 		k8sObjects          []runtime.Object
 		skupperObjects      []runtime.Object
 		skupperErrorMessage string
@@ -450,6 +458,38 @@ func TestSite_CheckConnector(t *testing.T) {
 			wantErr:        false,
 			wantConnectors: 1,
 		},
+		{
+			name: "connector denied by policy",
+			args: args{
+				name: "connector1",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "connector1",
+						Namespace: "test",
+						UID:       "8a96ffdf-403b-4e4a-83a8-97d3d459adb6",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "backend",
+						Port:       8080,
+						Type:       "tcp",
+						Host:       "1.2.3.4",
+					},
+				},
+			},
+			skupperObjects: []runtime.Object{
+				&skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "connector1",
+						Namespace: "test",
+					},
+				},
+			},
+			policy:         allowedRoutingKeys{"frontend"},
+			want:           "initialized",
+			wantErr:        false,
+			wantConnectors: 0,
+			wantDenied:     `Routing key "backend" not allowed`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -462,9 +502,16 @@ func TestSite_CheckConnector(t *testing.T) {
 				assert.Assert(t, err)
 			}
 
+			s.policy = tt.policy
 			if err := s.CheckConnector(tt.args.name, tt.args.connector); (err != nil) != tt.wantErr {
 				t.Errorf("Site.Checkconnector() error = %v", err)
 			}
+			if tt.wantDenied != "" {
+				condition := meta.FindStatusCondition(tt.args.connector.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_CONFIGURED)
+				assert.Assert(t, condition != nil)
+				assert.Equal(t, condition.Status, v1.ConditionFalse)
+				assert.Equal(t, condition.Message, tt.wantDenied)
+			}
 
 			// check if connector is expected and has correct values
 			connector := s.bindings.bindings.GetConnector(tt.args.name)
@@ -492,6 +539,54 @@ func TestSite_CheckConnector(t *testing.T) {
 	}
 }
 
+func TestSite_CheckAttachedConnectorBinding(t *testing.T) {
+	tests := []struct {
+		name       string
+		routingKey string
+		wantBound  bool
+		wantDenied string
+	}{
+		{
+			name:       "binding allowed by policy",
+			routingKey: "frontend",
+			wantBound:  true,
+		},
+		{
+			name:       "binding denied by policy",
+			routingKey: "backend",
+			wantDenied: `Routing key "backend" not allowed`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binding := &skupperv2alpha1.AttachedConnectorBinding{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "db",
+					Namespace: "test",
+				},
+				Spec: skupperv2alpha1.AttachedConnectorBindingSpec{
+					ConnectorNamespace: "other",
+					RoutingKey:         tt.routingKey,
+				},
+			}
+			s, err := newSiteMocks("test", nil, []runtime.Object{binding.DeepCopy()}, "", false)
+			assert.Assert(t, err)
+			s.policy = allowedRoutingKeys{"frontend"}
+
+			assert.NilError(t, s.CheckAttachedConnectorBinding("test", "db", binding))
+			connector, ok := s.bindings.connectors["db"]
+			assert.Assert(t, ok)
+			assert.Equal(t, connector.binding != nil, tt.wantBound)
+			if tt.wantDenied != "" {
+				condition := meta.FindStatusCondition(binding.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_CONFIGURED)
+				assert.Assert(t, condition != nil)
+				assert.Equal(t, condition.Status, v1.ConditionFalse)
+				assert.Equal(t, condition.Message, tt.wantDenied)
+			}
+		})
+	}
+}
+
 func TestSite_CheckLink(t *testing.T) {
 	type args struct {
 		name       string
@@ -881,6 +976,49 @@ func Test_NetworkStatusUpdate(t *testing.T) {
 	}
 }
 
+func TestSite_updateLinksFromRecords(t *testing.T) {
+	link := &skupperv2alpha1.Link{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "link1",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.LinkSpec{
+			Endpoints: []skupperv2alpha1.Endpoint{
+				{Name: string(qdr.RoleInterRouter), Host: "lb.example.com", Port: "55671"},
+				{Name: string(qdr.RoleInterRouter), Host: "route.example.com", Port: "443", Standby: true},
+			},
+		},
+	}
+	s, err := newSiteMocks("test", nil, []runtime.Object{link}, "", false)
+	assert.Assert(t, err)
+	s.links["link1"] = s.newLink(link)
+	getLink := func() *skupperv2alpha1.Link {
+		latest, err := s.controller.GetSkupperClient().SkupperV2alpha1().Links("test").Get(context.Background(), "link1", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return latest
+	}
+
+	// the first endpoint is lost, so the link has failed over to the second
+	s.updateLinksFromRecords([]skupperv2alpha1.LinkRecord{
+		{Name: "link1", RemoteSiteId: "east-id", RemoteSiteName: "east"},
+		{Name: "link1/2", RemoteSiteId: "east-id", RemoteSiteName: "east", Operational: true},
+		{Name: "unknown", Operational: true},
+	})
+	latest := getLink()
+	assert.Assert(t, meta.IsStatusConditionTrue(latest.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_OPERATIONAL))
+	assert.Equal(t, latest.Status.ActiveEndpoint, "route.example.com:443")
+	assert.Equal(t, latest.Status.RemoteSiteName, "east")
+
+	// with neither endpoint connected the link is not operational
+	s.updateLinksFromRecords([]skupperv2alpha1.LinkRecord{
+		{Name: "link1", RemoteSiteId: "east-id", RemoteSiteName: "east"},
+		{Name: "link1/2", RemoteSiteId: "east-id", RemoteSiteName: "east"},
+	})
+	latest = getLink()
+	assert.Assert(t, !meta.IsStatusConditionTrue(latest.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_OPERATIONAL))
+	assert.Equal(t, latest.Status.ActiveEndpoint, "")
+}
+
 func Test_CheckSecuredAccess(t *testing.T) {
 	type args struct {
 		sa *skupperv2alpha1.SecuredAccess
@@ -1014,8 +1152,167 @@ func Test_CheckSecuredAccess(t *testing.T) {
 	}
 }
 
+func TestSite_groups(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     skupperv2alpha1.SiteSpec
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"skupper-router"},
+		},
+		{
+			name:     "ha",
+			spec:     skupperv2alpha1.SiteSpec{HA: true},
+			expected: []string{"skupper-router", "skupper-router-2"},
+		},
+		{
+			name: "router groups",
+			spec: skupperv2alpha1.SiteSpec{
+				Settings: map[string]string{"router-groups": "4"},
+			},
+			expected: []string{"skupper-router", "skupper-router-2", "skupper-router-3", "skupper-router-4"},
+		},
+		{
+			name: "ha requires at least two groups",
+			spec: skupperv2alpha1.SiteSpec{
+				HA:       true,
+				Settings: map[string]string{"router-groups": "1"},
+			},
+			expected: []string{"skupper-router", "skupper-router-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Site{
+				site: &skupperv2alpha1.Site{Spec: tt.spec},
+			}
+			assert.DeepEqual(t, s.groups(), tt.expected)
+		})
+	}
+}
+
+func TestSite_verifyRouterGroups(t *testing.T) {
+	s, err := newSiteMocks("test", nil, nil, "", false)
+	assert.Assert(t, err)
+	site := &skupperv2alpha1.Site{
+		Spec: skupperv2alpha1.SiteSpec{
+			Settings: map[string]string{"router-groups": "0"},
+		},
+	}
+	assert.Error(t, s.verifySiteSpec(site), "Invalid value for router-groups setting: 0")
+	site.Spec.Settings["router-groups"] = "three"
+	assert.Error(t, s.verifySiteSpec(site), "Invalid value for router-groups setting: three")
+	site.Spec.Settings["router-groups"] = "3"
+	assert.NilError(t, s.verifySiteSpec(site))
+}
+
+func TestSite_reconcileGroups(t *testing.T) {
+	s, err := newSiteMocks("test", nil, nil, "", true)
+	assert.Assert(t, err)
+	for _, name := range []string{"skupper-router", "extra"} {
+		s.linkAccess[name] = &skupperv2alpha1.RouterAccess{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+			},
+			Spec: skupperv2alpha1.RouterAccessSpec{
+				TlsCredentials: "skupper-site-server",
+				Roles: []skupperv2alpha1.RouterAccessRole{
+					{
+						Name: "inter-router",
+						Port: 55671,
+					},
+				},
+			},
+		}
+	}
+	s.currentGroups = s.groups()
+	assert.NilError(t, s.createRouterConfigForGroup("skupper-router", s.initialGroupConfig(nil)))
+	s.initialised = true
+	getConfig := func(group string) (*qdr.RouterConfig, error) {
+		cm, err := s.controller.GetKubeClient().CoreV1().ConfigMaps("test").Get(context.Background(), group, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return qdr.GetRouterConfigFromConfigMap(cm)
+	}
+	getSecuredAccess := func(name string) error {
+		_, err := s.controller.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), name, metav1.GetOptions{})
+		return err
+	}
+
+	// scale up to three groups, each connecting to those before it
+	s.site.Spec.Settings = map[string]string{"router-groups": "3"}
+	assert.NilError(t, s.reconcileGroups())
+	assert.DeepEqual(t, s.currentGroups, []string{"skupper-router", "skupper-router-2", "skupper-router-3"})
+	for i, group := range s.currentGroups {
+		config, err := getConfig(group)
+		assert.NilError(t, err)
+		assert.Equal(t, len(config.Connectors), i, group)
+		for _, previous := range s.currentGroups[:i] {
+			_, ok := config.Connectors[previous]
+			assert.Assert(t, ok, "%s should connect to %s", group, previous)
+		}
+		_, ok := config.Listeners["skupper-router-inter-router"]
+		assert.Assert(t, ok, group)
+		for _, name := range []string{"skupper-router", "extra"} {
+			assert.NilError(t, getSecuredAccess(groupQualified(name, group)))
+		}
+	}
+
+	// scale back down, removing everything for the groups no longer needed
+	s.site.Spec.Settings = nil
+	assert.NilError(t, s.reconcileGroups())
+	assert.DeepEqual(t, s.currentGroups, []string{"skupper-router"})
+	config, err := getConfig("skupper-router")
+	assert.NilError(t, err)
+	assert.Equal(t, len(config.Connectors), 0)
+	for _, group := range []string{"skupper-router-2", "skupper-router-3"} {
+		_, err := getConfig(group)
+		assert.Assert(t, errors.IsNotFound(err), group)
+		for _, name := range []string{"skupper-router", "extra"} {
+			assert.Assert(t, errors.IsNotFound(getSecuredAccess(groupQualified(name, group))), name)
+		}
+	}
+	assert.NilError(t, getSecuredAccess("skupper-router"))
+	assert.NilError(t, getSecuredAccess("extra"))
+
+	// resources that fail to be deleted are deleted when the groups are
+	// next reconciled
+	s.site.Spec.Settings = map[string]string{"router-groups": "2"}
+	assert.NilError(t, s.reconcileGroups())
+	failDelete := true
+	s.controller.GetKubeClient().(*k8sfake.Clientset).PrependReactor("delete", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if failDelete {
+			return true, nil, fmt.Errorf("deployment cannot be deleted")
+		}
+		return false, nil, nil
+	})
+	s.site.Spec.Settings = nil
+	assert.ErrorContains(t, s.reconcileGroups(), "deployment cannot be deleted")
+	assert.DeepEqual(t, s.currentGroups, []string{"skupper-router", "skupper-router-2"})
+	_, err = getConfig("skupper-router-2")
+	assert.NilError(t, err)
+	failDelete = false
+	assert.NilError(t, s.reconcileGroups())
+	assert.DeepEqual(t, s.currentGroups, []string{"skupper-router"})
+	_, err = getConfig("skupper-router-2")
+	assert.Assert(t, errors.IsNotFound(err))
+}
+
 // --- helper
 
+type allowedRoutingKeys []string
+
+func (keys allowedRoutingKeys) CheckRoutingKey(namespace string, routingKey string) error {
+	if !slices.Contains(keys, routingKey) {
+		return fmt.Errorf("Routing key %q not allowed", routingKey)
+	}
+	return nil
+}
+
 func newSiteMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string, accessMgr bool) (*Site, error) {
 
 	site := &skupperv2alpha1.Site{
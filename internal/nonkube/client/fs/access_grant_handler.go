@@ -0,0 +1,101 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+type AccessGrantHandler struct {
+	BaseCustomResourceHandler
+	pathProvider PathProvider
+}
+
+func NewAccessGrantHandler(namespace string) *AccessGrantHandler {
+	return &AccessGrantHandler{
+		pathProvider: PathProvider{
+			Namespace: namespace,
+		},
+	}
+}
+
+func (s *AccessGrantHandler) Add(resource v2alpha1.AccessGrant) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.AccessGrants)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update overwrites an existing AccessGrant without reporting it, as it is
+// used by the grant server to keep track of the redemptions
+func (s *AccessGrantHandler) Update(resource v2alpha1.AccessGrant) error {
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(s.pathProvider.GetNamespace(), common.AccessGrants, resource.Name+".yaml")
+	return os.WriteFile(fileName, []byte(content), 0644)
+}
+
+func (s *AccessGrantHandler) Get(name string) (*v2alpha1.AccessGrant, error) {
+	var context v2alpha1.AccessGrant
+	fileName := name + ".yaml"
+
+	err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.AccessGrants)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DecodeYaml(file, &context); err != nil {
+		return nil, err
+	}
+
+	return &context, nil
+}
+
+func (s *AccessGrantHandler) Delete(name string) error {
+	fileName := name + ".yaml"
+
+	if err := s.DeleteFile(s.pathProvider.GetNamespace(), fileName, common.AccessGrants); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AccessGrantHandler) List() ([]*v2alpha1.AccessGrant, error) {
+	var grants []*v2alpha1.AccessGrant
+
+	path := s.pathProvider.GetNamespace()
+	err, files := s.ReadDir(path, common.AccessGrants)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		err, grant := s.ReadFile(path, file.Name(), common.AccessGrants)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.AccessGrant
+		if err = s.DecodeYaml(grant, &context); err != nil {
+			return nil, err
+		}
+		grants = append(grants, &context)
+	}
+	return grants, nil
+}
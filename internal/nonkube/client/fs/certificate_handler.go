@@ -0,0 +1,116 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+type CertificateHandler struct {
+	BaseCustomResourceHandler
+	pathProvider PathProvider
+}
+
+func NewCertificateHandler(namespace string) *CertificateHandler {
+	return &CertificateHandler{
+		pathProvider: PathProvider{
+			Namespace: namespace,
+		},
+	}
+}
+
+func (s *CertificateHandler) Add(resource v2alpha1.Certificate) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.Certificates)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *CertificateHandler) Get(name string, opts GetOptions) (*v2alpha1.Certificate, error) {
+	var context v2alpha1.Certificate
+	fileName := name + ".yaml"
+
+	if opts.RuntimeFirst == true {
+		// First read from runtime directory, where output is found after bootstrap
+		// has run.  If no runtime certificates try and display configured ones
+		err, file := s.ReadFile(s.pathProvider.GetRuntimeNamespace(), fileName, common.Certificates)
+		if err != nil {
+			if opts.LogWarning {
+				os.Stderr.WriteString("Site not initialized yet\n")
+			}
+			err, file = s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.Certificates)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	} else {
+		// read from input directory to get lastest config
+		err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	}
+
+	return &context, nil
+}
+
+func (s *CertificateHandler) Delete(name string) error {
+	fileName := name + ".yaml"
+
+	if err := s.DeleteFile(s.pathProvider.GetNamespace(), fileName, common.Certificates); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CertificateHandler) List() ([]*v2alpha1.Certificate, error) {
+	var certificates []*v2alpha1.Certificate
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime certificates try and display configured ones
+	path := s.pathProvider.GetRuntimeNamespace()
+	err, files := s.ReadDir(path, common.Certificates)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = s.pathProvider.GetNamespace()
+		err, files = s.ReadDir(path, common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, certificate := s.ReadFile(path, file.Name(), common.Certificates)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.Certificate
+		if err = s.DecodeYaml(certificate, &context); err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, &context)
+	}
+	return certificates, nil
+}
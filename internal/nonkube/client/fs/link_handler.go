@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type LinkHandler struct {
+	BaseCustomResourceHandler
+	pathProvider PathProvider
+}
+
+func NewLinkHandler(namespace string) *LinkHandler {
+	return &LinkHandler{
+		pathProvider: PathProvider{
+			Namespace: namespace,
+		},
+	}
+}
+
+func (s *LinkHandler) Add(resource v2alpha1.Link) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.Links)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddSecret stores the TLS credentials referenced by a link
+func (s *LinkHandler) AddSecret(resource corev1.Secret) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.Secrets)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LinkHandler) Get(name string) (*v2alpha1.Link, error) {
+	var context v2alpha1.Link
+	fileName := name + ".yaml"
+
+	err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.Links)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DecodeYaml(file, &context); err != nil {
+		return nil, err
+	}
+
+	return &context, nil
+}
+
+func (s *LinkHandler) Delete(name string) error {
+	fileName := name + ".yaml"
+
+	if err := s.DeleteFile(s.pathProvider.GetNamespace(), fileName, common.Links); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
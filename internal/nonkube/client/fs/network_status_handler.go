@@ -0,0 +1,66 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+)
+
+const NetworkStatusFile = "network-status.json"
+
+// NetworkStatusHandler reads and writes the network status collected for a
+// non-kubernetes site, the counterpart of the network status ConfigMap.
+type NetworkStatusHandler struct {
+	Namespace string
+}
+
+func NewNetworkStatusHandler(namespace string) *NetworkStatusHandler {
+	return &NetworkStatusHandler{
+		Namespace: namespace,
+	}
+}
+
+func (n *NetworkStatusHandler) path() string {
+	return filepath.Join(api.GetHostNamespaceHome(n.Namespace), string(api.NetworkStatusPath), NetworkStatusFile)
+}
+
+func (n *NetworkStatusHandler) Get() (*network.NetworkStatusInfo, error) {
+	content, err := os.ReadFile(n.path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network status: %w", err)
+	}
+	var networkStatus network.NetworkStatusInfo
+	if err := json.Unmarshal(content, &networkStatus); err != nil {
+		return nil, fmt.Errorf("failed to decode network status: %w", err)
+	}
+	return &networkStatus, nil
+}
+
+// Set replaces the stored network status; the file is written to a temporary
+// name first so that readers never see a partial document.
+func (n *NetworkStatusHandler) Set(networkStatus *network.NetworkStatusInfo) error {
+	content, err := json.Marshal(networkStatus)
+	if err != nil {
+		return err
+	}
+	statusPath := n.path()
+	if err := os.MkdirAll(filepath.Dir(statusPath), 0775); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+	tmpPath := statusPath + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, statusPath)
+}
+
+// Publish stores the network status built by a status sync, so that the
+// handler can stand in for the network status ConfigMap.
+func (n *NetworkStatusHandler) Publish(ctx context.Context, info network.NetworkStatusInfo) error {
+	return n.Set(&info)
+}
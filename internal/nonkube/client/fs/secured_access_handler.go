@@ -0,0 +1,116 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+type SecuredAccessHandler struct {
+	BaseCustomResourceHandler
+	pathProvider PathProvider
+}
+
+func NewSecuredAccessHandler(namespace string) *SecuredAccessHandler {
+	return &SecuredAccessHandler{
+		pathProvider: PathProvider{
+			Namespace: namespace,
+		},
+	}
+}
+
+func (s *SecuredAccessHandler) Add(resource v2alpha1.SecuredAccess) error {
+
+	fileName := resource.Name + ".yaml"
+	content, err := s.EncodeToYaml(resource)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(s.pathProvider.GetNamespace(), fileName, content, common.SecuredAccesses)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *SecuredAccessHandler) Get(name string, opts GetOptions) (*v2alpha1.SecuredAccess, error) {
+	var context v2alpha1.SecuredAccess
+	fileName := name + ".yaml"
+
+	if opts.RuntimeFirst == true {
+		// First read from runtime directory, where output is found after bootstrap
+		// has run.  If no runtime securedAccesses try and display configured ones
+		err, file := s.ReadFile(s.pathProvider.GetRuntimeNamespace(), fileName, common.SecuredAccesses)
+		if err != nil {
+			if opts.LogWarning {
+				os.Stderr.WriteString("Site not initialized yet\n")
+			}
+			err, file = s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.SecuredAccesses)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	} else {
+		// read from input directory to get lastest config
+		err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.SecuredAccesses)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	}
+
+	return &context, nil
+}
+
+func (s *SecuredAccessHandler) Delete(name string) error {
+	fileName := name + ".yaml"
+
+	if err := s.DeleteFile(s.pathProvider.GetNamespace(), fileName, common.SecuredAccesses); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SecuredAccessHandler) List() ([]*v2alpha1.SecuredAccess, error) {
+	var securedAccesses []*v2alpha1.SecuredAccess
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime securedAccesses try and display configured ones
+	path := s.pathProvider.GetRuntimeNamespace()
+	err, files := s.ReadDir(path, common.SecuredAccesses)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = s.pathProvider.GetNamespace()
+		err, files = s.ReadDir(path, common.SecuredAccesses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, securedAccess := s.ReadFile(path, file.Name(), common.SecuredAccesses)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.SecuredAccess
+		if err = s.DecodeYaml(securedAccess, &context); err != nil {
+			return nil, err
+		}
+		securedAccesses = append(securedAccesses, &context)
+	}
+	return securedAccesses, nil
+}
@@ -0,0 +1,266 @@
+// Package router provides access to the router of a nonkube site
+// through its management interface, from which the runtime status
+// of the resources defined for the site is determined.
+package router
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/qdr"
+	"github.com/skupperproject/skupper/pkg/site"
+)
+
+const localAccess = "skupper-local"
+
+// Agent is the subset of the router management agent used to
+// determine the runtime status of a site.
+type Agent interface {
+	GetConnections() ([]qdr.Connection, error)
+	GetLocalBridgeConfig() (*qdr.BridgeConfig, error)
+	GetAllRouters() ([]qdr.Router, error)
+	GetBridges(routers []qdr.Router) ([]qdr.BridgeConfig, error)
+	Close() error
+}
+
+// LoadRuntimeState loads the site state rendered for the namespace.
+func LoadRuntimeState(namespace string) (*api.SiteState, error) {
+	loader := &common.FileSystemSiteStateLoader{
+		Path: path.Join(api.GetHostNamespaceHome(namespace), string(api.RuntimeSiteStatePath)),
+	}
+	return loader.Load()
+}
+
+// LocalAccess returns the url of the local router access of the site,
+// along with the client certificate issued for it.
+func LocalAccess(runtimeState *api.SiteState) (string, *certs.TlsConfigRetriever, error) {
+	access, ok := runtimeState.RouterAccesses[localAccess]
+	if !ok {
+		return "", nil, fmt.Errorf("RouterAccess %s not found", localAccess)
+	}
+	idx := slices.IndexFunc(access.Spec.Roles, func(role v2alpha1.RouterAccessRole) bool { return role.Name == "normal" })
+	if idx < 0 {
+		return "", nil, fmt.Errorf("RouterAccess %s has no normal role", localAccess)
+	}
+	host := access.Spec.BindHost
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	url := fmt.Sprintf("amqps://%s", net.JoinHostPort(host, strconv.Itoa(access.Spec.Roles[idx].Port)))
+	certPath := path.Join(api.GetHostNamespaceHome(runtimeState.GetNamespace()), string(api.CertificatesPath), localAccess+"-client")
+	tlsConfig := certs.GetTlsConfigRetriever(true, path.Join(certPath, "tls.crt"), path.Join(certPath, "tls.key"), path.Join(certPath, "ca.crt"))
+	return url, tlsConfig, nil
+}
+
+// Connect opens a management connection to the router of the site
+// through its local router access.
+func Connect(runtimeState *api.SiteState) (*qdr.Agent, error) {
+	url, tlsConfig, err := LocalAccess(runtimeState)
+	if err != nil {
+		return nil, err
+	}
+	return qdr.Connect(url, tlsConfig)
+}
+
+// Status holds what the router of a site reports about its
+// connections and about the bridges configured across the network.
+type Status struct {
+	connections    []qdr.Connection
+	local          *qdr.BridgeConfig
+	listenerKeys   map[string]bool
+	connectorKeys  map[string]bool
+	routerSites    map[string]string
+	siteNames      map[string]string
+	unavailableErr error
+}
+
+// GetStatus connects to the router of the site in the namespace and
+// collects its status. The names of remote sites are taken from the
+// network status of the namespace, when it has been collected. If the
+// router cannot be reached, the status returned marks the site as not
+// running.
+func GetStatus(namespace string) *Status {
+	runtimeState, err := LoadRuntimeState(namespace)
+	if err != nil {
+		return Unavailable(err)
+	}
+	agent, err := Connect(runtimeState)
+	if err != nil {
+		return Unavailable(fmt.Errorf("router is not reachable: %w", err))
+	}
+	defer agent.Close()
+	var networkStatus *network.NetworkStatusInfo
+	if info, err := fs.NewNetworkStatusHandler(namespace).Get(); err == nil {
+		networkStatus = info
+	}
+	status, err := NewStatus(agent, networkStatus)
+	if err != nil {
+		return Unavailable(err)
+	}
+	return status
+}
+
+// Unavailable returns the status of a site whose router could not be
+// queried.
+func Unavailable(err error) *Status {
+	return &Status{unavailableErr: err}
+}
+
+// NewStatus queries the router through the agent.
+func NewStatus(agent Agent, networkStatus *network.NetworkStatusInfo) (*Status, error) {
+	s := &Status{
+		listenerKeys:  map[string]bool{},
+		connectorKeys: map[string]bool{},
+		routerSites:   map[string]string{},
+		siteNames:     map[string]string{},
+	}
+	var err error
+	if s.connections, err = agent.GetConnections(); err != nil {
+		return nil, fmt.Errorf("error retrieving connections: %w", err)
+	}
+	if s.local, err = agent.GetLocalBridgeConfig(); err != nil {
+		return nil, fmt.Errorf("error retrieving bridges: %w", err)
+	}
+	routers, err := agent.GetAllRouters()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving routers: %w", err)
+	}
+	bridges, err := agent.GetBridges(routers)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bridges: %w", err)
+	}
+	for _, router := range routers {
+		s.routerSites[router.Id] = router.Site.Id
+	}
+	for _, config := range bridges {
+		for _, listener := range config.TcpListeners {
+			s.listenerKeys[listener.Address] = true
+		}
+		for _, listener := range config.UdpListeners {
+			s.listenerKeys[listener.Address] = true
+		}
+		for _, connector := range config.TcpConnectors {
+			s.connectorKeys[connector.Address] = true
+		}
+		for _, connector := range config.UdpConnectors {
+			s.connectorKeys[connector.Address] = true
+		}
+	}
+	if networkStatus != nil {
+		for _, site := range networkStatus.SiteStatus {
+			s.siteNames[site.Site.Identity] = site.Site.Name
+		}
+	}
+	return s, nil
+}
+
+// UpdateSite sets the Running condition of the site.
+func (s *Status) UpdateSite(site *v2alpha1.Site) bool {
+	if s.unavailableErr != nil {
+		return site.SetRunning(v2alpha1.ErrorCondition(s.unavailableErr))
+	}
+	return site.SetRunning(v2alpha1.ReadyCondition())
+}
+
+// UpdateListener sets the Configured condition of the listener to
+// whether it is configured on the router, and the Matched condition to
+// whether there is a connector for its routing key anywhere in the
+// network.
+func (s *Status) UpdateListener(listener *v2alpha1.Listener) bool {
+	if s.unavailableErr != nil {
+		return false
+	}
+	configured := s.local.TcpListeners[listener.Name].Name != "" || s.local.UdpListeners[listener.Name].Name != ""
+	changed := listener.SetConfigured(notConfigured(configured))
+	if listener.SetHasMatchingConnector(s.connectorKeys[listener.Spec.RoutingKey]) {
+		changed = true
+	}
+	return changed
+}
+
+// UpdateConnector sets the Configured condition of the connector to
+// whether it is configured on the router, and the Matched condition to
+// whether there is a listener for its routing key anywhere in the
+// network.
+func (s *Status) UpdateConnector(connector *v2alpha1.Connector) bool {
+	if s.unavailableErr != nil {
+		return false
+	}
+	configured := false
+	for name := range s.local.TcpConnectors {
+		if name == connector.Name || strings.HasPrefix(name, connector.Name+"@") {
+			configured = true
+		}
+	}
+	for name := range s.local.UdpConnectors {
+		if name == connector.Name || strings.HasPrefix(name, connector.Name+"@") {
+			configured = true
+		}
+	}
+	changed := connector.SetConfigured(notConfigured(configured))
+	if connector.SetHasMatchingListener(s.listenerKeys[connector.Spec.RoutingKey]) {
+		changed = true
+	}
+	return changed
+}
+
+// UpdateLink sets the Operational condition of the link to whether the
+// router has an outgoing connection to any of the endpoints it is
+// configured to connect to, recording the lowest cost of those that
+// are connected as the active endpoint along with the site connected
+// to.
+func (s *Status) UpdateLink(link *v2alpha1.Link, edge bool) bool {
+	if s.unavailableErr != nil {
+		return false
+	}
+	role := string(qdr.RoleInterRouter)
+	if edge {
+		role = string(qdr.RoleEdge)
+	}
+	outgoing := func(endpoint v2alpha1.Endpoint) (qdr.Connection, bool) {
+		address := net.JoinHostPort(endpoint.Host, endpoint.Port)
+		for _, connection := range s.connections {
+			if connection.Dir == "out" && connection.Role == role && connection.Host == address {
+				return connection, true
+			}
+		}
+		return qdr.Connection{}, false
+	}
+	siteLink := site.NewLink(link.Name, "")
+	siteLink.Update(link)
+	endpoint, ok := siteLink.ActiveEndpoint(edge, func(_ string, endpoint v2alpha1.Endpoint) bool {
+		_, connected := outgoing(endpoint)
+		return connected
+	})
+	if ok {
+		connection, _ := outgoing(endpoint)
+		siteId := s.routerSites[connection.Container]
+		changed := link.SetOperational(true, siteId, s.siteNames[siteId])
+		if link.SetActiveEndpoint(connection.Host) {
+			changed = true
+		}
+		return changed
+	}
+	changed := link.SetOperational(false, "", "")
+	if link.SetActiveEndpoint("") {
+		changed = true
+	}
+	return changed
+}
+
+func notConfigured(configured bool) error {
+	if configured {
+		return nil
+	}
+	return fmt.Errorf("Not configured on the router")
+}
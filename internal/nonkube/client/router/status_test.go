@@ -0,0 +1,165 @@
+package router
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/network"
+	"github.com/skupperproject/skupper/pkg/qdr"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStatus(t *testing.T) {
+	local := qdr.BridgeConfig{
+		TcpListeners: qdr.TcpEndpointMap{
+			"frontend": {Name: "frontend", Address: "frontend"},
+			"orphan":   {Name: "orphan", Address: "orphan"},
+		},
+		TcpConnectors: qdr.TcpEndpointMap{
+			"backend@10.0.0.1": {Name: "backend@10.0.0.1", Address: "backend"},
+		},
+	}
+	remote := qdr.BridgeConfig{
+		TcpListeners: qdr.TcpEndpointMap{
+			"backend": {Name: "backend", Address: "backend"},
+		},
+		TcpConnectors: qdr.TcpEndpointMap{
+			"frontend@10.0.0.2": {Name: "frontend@10.0.0.2", Address: "frontend"},
+		},
+	}
+	agent := &fakeAgent{
+		connections: []qdr.Connection{
+			{Container: "east-router", Host: "east.example.com:55671", Role: "inter-router", Dir: "out"},
+			{Container: "east-router", Host: "east-route.example.com:443", Role: "inter-router", Dir: "out"},
+			{Container: "other-router", Host: "10.0.0.9:33456", Role: "inter-router", Dir: "in"},
+		},
+		local: local,
+		routers: []qdr.Router{
+			{Id: "west-router", Site: qdr.SiteMetadata{Id: "west-id"}},
+			{Id: "east-router", Site: qdr.SiteMetadata{Id: "east-id"}},
+		},
+		bridges: []qdr.BridgeConfig{local, remote},
+	}
+	networkStatus := &network.NetworkStatusInfo{
+		SiteStatus: []network.SiteStatusInfo{
+			{Site: network.SiteInfo{Identity: "east-id", Name: "east"}},
+		},
+	}
+	status, err := NewStatus(agent, networkStatus)
+	assert.Assert(t, err)
+
+	site := &v2alpha1.Site{}
+	site.SetConfigured(nil)
+	assert.Assert(t, status.UpdateSite(site))
+	assert.Assert(t, site.IsReady())
+
+	t.Run("listener matched", func(t *testing.T) {
+		listener := &v2alpha1.Listener{ObjectMeta: metav1.ObjectMeta{Name: "frontend"}, Spec: v2alpha1.ListenerSpec{RoutingKey: "frontend"}}
+		assert.Assert(t, status.UpdateListener(listener))
+		assert.Assert(t, listener.IsConfigured())
+		assert.Assert(t, meta.IsStatusConditionTrue(listener.Status.Conditions, v2alpha1.CONDITION_TYPE_MATCHED))
+	})
+	t.Run("listener not matched", func(t *testing.T) {
+		listener := &v2alpha1.Listener{ObjectMeta: metav1.ObjectMeta{Name: "orphan"}, Spec: v2alpha1.ListenerSpec{RoutingKey: "orphan"}}
+		status.UpdateListener(listener)
+		assert.Assert(t, listener.IsConfigured())
+		assert.Equal(t, listener.Status.Message, "No matching connectors")
+	})
+	t.Run("listener not configured", func(t *testing.T) {
+		listener := &v2alpha1.Listener{ObjectMeta: metav1.ObjectMeta{Name: "missing"}, Spec: v2alpha1.ListenerSpec{RoutingKey: "backend"}}
+		status.UpdateListener(listener)
+		assert.Assert(t, !listener.IsConfigured())
+		assert.Equal(t, listener.Status.Message, "Not configured on the router")
+	})
+	t.Run("connector matched", func(t *testing.T) {
+		connector := &v2alpha1.Connector{ObjectMeta: metav1.ObjectMeta{Name: "backend"}, Spec: v2alpha1.ConnectorSpec{RoutingKey: "backend"}}
+		assert.Assert(t, status.UpdateConnector(connector))
+		assert.Assert(t, connector.IsConfigured())
+		assert.Assert(t, meta.IsStatusConditionTrue(connector.Status.Conditions, v2alpha1.CONDITION_TYPE_MATCHED))
+	})
+	t.Run("link operational", func(t *testing.T) {
+		link := &v2alpha1.Link{
+			ObjectMeta: metav1.ObjectMeta{Name: "to-east"},
+			Spec: v2alpha1.LinkSpec{
+				Endpoints: []v2alpha1.Endpoint{{Name: "inter-router", Host: "east.example.com", Port: "55671"}},
+			},
+		}
+		link.SetConfigured(nil)
+		assert.Assert(t, status.UpdateLink(link, false))
+		assert.Assert(t, meta.IsStatusConditionTrue(link.Status.Conditions, v2alpha1.CONDITION_TYPE_OPERATIONAL))
+		assert.Equal(t, link.Status.RemoteSiteId, "east-id")
+		assert.Equal(t, link.Status.RemoteSiteName, "east")
+		assert.Equal(t, link.Status.ActiveEndpoint, "east.example.com:55671")
+	})
+	t.Run("link active endpoint is lowest cost", func(t *testing.T) {
+		link := &v2alpha1.Link{
+			ObjectMeta: metav1.ObjectMeta{Name: "to-east-standby"},
+			Spec: v2alpha1.LinkSpec{
+				Endpoints: []v2alpha1.Endpoint{
+					{Name: "inter-router", Host: "east.example.com", Port: "55671", Cost: 10, Standby: true},
+					{Name: "inter-router", Host: "east-route.example.com", Port: "443", Cost: 5},
+				},
+			},
+		}
+		link.SetConfigured(nil)
+		assert.Assert(t, status.UpdateLink(link, false))
+		assert.Assert(t, meta.IsStatusConditionTrue(link.Status.Conditions, v2alpha1.CONDITION_TYPE_OPERATIONAL))
+		assert.Equal(t, link.Status.ActiveEndpoint, "east-route.example.com:443")
+	})
+	t.Run("link not operational", func(t *testing.T) {
+		link := &v2alpha1.Link{
+			ObjectMeta: metav1.ObjectMeta{Name: "to-south"},
+			Spec: v2alpha1.LinkSpec{
+				Endpoints: []v2alpha1.Endpoint{{Name: "inter-router", Host: "south.example.com", Port: "55671"}},
+			},
+		}
+		link.SetConfigured(nil)
+		status.UpdateLink(link, false)
+		assert.Assert(t, !meta.IsStatusConditionTrue(link.Status.Conditions, v2alpha1.CONDITION_TYPE_OPERATIONAL))
+		assert.Equal(t, link.Status.Message, "Not operational")
+	})
+}
+
+func TestStatusUnavailable(t *testing.T) {
+	status := Unavailable(fmt.Errorf("router is not reachable"))
+	site := &v2alpha1.Site{}
+	site.SetConfigured(nil)
+	assert.Assert(t, status.UpdateSite(site))
+	assert.Assert(t, !site.IsReady())
+	assert.Equal(t, site.Status.Message, "router is not reachable")
+
+	listener := &v2alpha1.Listener{}
+	listener.SetConfigured(nil)
+	assert.Assert(t, !status.UpdateListener(listener))
+	assert.Assert(t, listener.IsConfigured())
+}
+
+type fakeAgent struct {
+	connections []qdr.Connection
+	local       qdr.BridgeConfig
+	routers     []qdr.Router
+	bridges     []qdr.BridgeConfig
+}
+
+func (a *fakeAgent) GetConnections() ([]qdr.Connection, error) {
+	return a.connections, nil
+}
+
+func (a *fakeAgent) GetLocalBridgeConfig() (*qdr.BridgeConfig, error) {
+	return &a.local, nil
+}
+
+func (a *fakeAgent) GetAllRouters() ([]qdr.Router, error) {
+	return a.routers, nil
+}
+
+func (a *fakeAgent) GetBridges(routers []qdr.Router) ([]qdr.BridgeConfig, error) {
+	return a.bridges, nil
+}
+
+func (a *fakeAgent) Close() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+// Package collector gathers the status of the whole network, as seen by
+// the router of a nonkube site, into the runtime directory of its
+// namespace. It is the nonkube counterpart of the site collector run
+// by the kube adaptor.
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	kubeflow "github.com/skupperproject/skupper/pkg/kube/flow"
+	"github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+)
+
+const containerId = "nonkube-flow-collector"
+
+// Collector runs a network status sync against the local router of a
+// site, writing what it learns from the vanflow beacons of the network
+// through the NetworkStatusHandler of the namespace.
+type Collector struct {
+	namespace string
+	logger    *slog.Logger
+	Factory   func(url string, config session.ContainerConfig) session.ContainerFactory
+}
+
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &Collector{
+		namespace: namespace,
+		logger:    common.NewLogger().With(slog.String("component", "collector"), slog.String("namespace", namespace)),
+		Factory:   session.NewContainerFactory,
+	}
+}
+
+// Run collects the network status until the context is cancelled.
+func (c *Collector) Run(ctx context.Context) error {
+	factory, err := c.sessionFactory()
+	if err != nil {
+		return err
+	}
+	c.logger.Info("collecting network status")
+	statusSync := kubeflow.NewStatusSyncWithPublisher(factory, nil, fs.NewNetworkStatusHandler(c.namespace))
+	statusSync.Run(ctx)
+	return nil
+}
+
+func (c *Collector) sessionFactory() (session.ContainerFactory, error) {
+	runtimeState, err := router.LoadRuntimeState(c.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load runtime site state: %w", err)
+	}
+	url, tlsRetriever, err := router.LocalAccess(runtimeState)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := tlsRetriever.GetTlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	return c.Factory(url, session.ContainerConfig{
+		ContainerID: containerId,
+		TLSConfig:   tlsConfig,
+		SASLType:    session.SASLTypeExternal,
+	}), nil
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCollector(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	setupNamespace(t, "west")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory := session.NewMockContainerFactory()
+	conn := factory.Create()
+	go conn.Start(ctx)
+	records := store.NewSyncMapStore(store.SyncMapStoreConfig{})
+	source := eventsource.NewManager(conn, eventsource.ManagerConfig{
+		Source: eventsource.Info{
+			ID: "router-a", Type: "ROUTER",
+			Address: "mc/sfe.router-a", Direct: "sfe.router-a",
+		},
+		HeartbeatInterval: 5 * time.Millisecond, BeaconInterval: 50 * time.Millisecond,
+		Stores: []store.Interface{records},
+	})
+	go source.Run(ctx)
+	siteName := "west"
+	records.Replace([]store.Entry{
+		{Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-a"), Name: &siteName}},
+	})
+
+	var url string
+	var config session.ContainerConfig
+	c := NewCollector("west")
+	c.Factory = func(u string, cfg session.ContainerConfig) session.ContainerFactory {
+		url, config = u, cfg
+		return factory
+	}
+	go func() {
+		assert.Check(t, c.Run(ctx))
+	}()
+
+	handler := fs.NewNetworkStatusHandler("west")
+	poll.WaitOn(t, func(log poll.LogT) poll.Result {
+		info, err := handler.Get()
+		if err != nil {
+			return poll.Continue("network status not written yet: %s", err)
+		}
+		if len(info.SiteStatus) != 1 {
+			return poll.Continue("expected a single site, got %d", len(info.SiteStatus))
+		}
+		if info.SiteStatus[0].Site.Name != siteName {
+			return poll.Error(fmt.Errorf("unexpected site name %q", info.SiteStatus[0].Site.Name))
+		}
+		return poll.Success()
+	}, poll.WithDelay(time.Millisecond*10), poll.WithTimeout(time.Second*2))
+	assert.Equal(t, url, "amqps://127.0.0.1:5671")
+	assert.Equal(t, config.ContainerID, containerId)
+	assert.Equal(t, config.SASLType, session.SASLTypeExternal)
+	assert.Assert(t, config.TLSConfig != nil)
+}
+
+func TestCollectorNotInitialized(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	c := NewCollector("west")
+	err := c.Run(context.Background())
+	assert.ErrorContains(t, err, "failed to load runtime site state")
+}
+
+// setupNamespace renders the runtime site state of a site with its local
+// router access, along with the client certificate issued for it.
+func setupNamespace(t *testing.T, namespace string) {
+	t.Helper()
+	home := api.GetHostNamespaceHome(namespace)
+	runtimeState := api.NewSiteState(false)
+	runtimeState.Site = &v2alpha1.Site{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: namespace},
+	}
+	runtimeState.CreateRouterAccess("skupper-local", 5671)
+	assert.Assert(t, api.MarshalSiteState(*runtimeState, path.Join(home, string(api.RuntimeSiteStatePath))))
+
+	ca := certs.GenerateCASecret("skupper-local-ca", "skupper-local-ca")
+	client := certs.GenerateSecret("skupper-local-client", "skupper-local-client", "", &ca)
+	certPath := path.Join(home, string(api.CertificatesPath), "skupper-local-client")
+	assert.Assert(t, os.MkdirAll(certPath, 0755))
+	for _, name := range []string{"tls.crt", "tls.key", "ca.crt"} {
+		assert.Assert(t, os.WriteFile(path.Join(certPath, name), client.Data[name], 0644))
+	}
+}
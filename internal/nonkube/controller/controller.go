@@ -0,0 +1,327 @@
+// Package controller keeps the router of a nonkube site in line with
+// the Listeners and Connectors defined in the input resources of its
+// namespace, applying changes to the running router rather than
+// restarting it.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/router"
+	"github.com/skupperproject/skupper/pkg/fs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/qdr"
+)
+
+const (
+	debounce      = 2 * time.Second
+	retryInterval = 10 * time.Second
+)
+
+// BridgeAgent is the subset of the router management agent used to
+// read and update the bridge configuration.
+type BridgeAgent interface {
+	GetLocalBridgeConfig() (*qdr.BridgeConfig, error)
+	UpdateLocalBridgeConfig(changes *qdr.BridgeConfigDifference) error
+	Close() error
+}
+
+// Controller watches the input resources of a namespace and, when
+// they change, applies the resulting changes to the Listeners and
+// Connectors of the site to its running router. Any other change to
+// the site still requires "skupper system reload".
+type Controller struct {
+	namespace string
+	platform  string
+	trigger   chan struct{}
+	logger    *slog.Logger
+	Connect   func(runtimeState *api.SiteState) (BridgeAgent, error)
+}
+
+func NewController(namespace string, platform string) *Controller {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &Controller{
+		namespace: namespace,
+		platform:  platform,
+		trigger:   make(chan struct{}, 1),
+		logger:    common.NewLogger().With(slog.String("component", "controller"), slog.String("namespace", namespace)),
+		Connect:   connect,
+	}
+}
+
+// Run reconciles the router with the input resources until the
+// context is cancelled, once on start and then whenever the input
+// resources change.
+func (c *Controller) Run(ctx context.Context) error {
+	inputPath := c.path(api.InputSiteStatePath)
+	if _, err := os.Stat(inputPath); err != nil {
+		return fmt.Errorf("No sources found for namespace %q: %s", c.namespace, err)
+	}
+	watcher, err := fs.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch %s: %w", inputPath, err)
+	}
+	c.watch(watcher, inputPath)
+	watcher.Start(ctx.Done())
+	c.logger.Info("Watching input resources", slog.String("path", inputPath))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.trigger:
+			timer.Reset(debounce)
+		case <-timer.C:
+			if err := c.Reconcile(); err != nil {
+				c.logger.Error("Unable to apply changes", slog.String("error", err.Error()))
+				timer.Reset(retryInterval)
+			}
+		}
+	}
+}
+
+// watch adds the directory and all directories beneath it to the
+// watcher, as the input resources are read recursively.
+func (c *Controller) watch(watcher *fs.FileWatcher, dir string) {
+	_ = filepath.WalkDir(dir, func(name string, entry os.DirEntry, err error) error {
+		if err == nil && entry.IsDir() {
+			watcher.Add(name, &inputHandler{controller: c, watcher: watcher})
+		}
+		return nil
+	})
+}
+
+func (c *Controller) changed() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+type inputHandler struct {
+	controller *Controller
+	watcher    *fs.FileWatcher
+}
+
+func (h *inputHandler) OnCreate(name string) {
+	if stat, err := os.Stat(name); err == nil && stat.IsDir() {
+		h.controller.watch(h.watcher, name)
+	}
+	h.controller.changed()
+}
+
+func (h *inputHandler) OnUpdate(name string) {
+	h.controller.changed()
+}
+
+func (h *inputHandler) OnRemove(name string) {
+	h.controller.changed()
+}
+
+// Reconcile loads the input resources and, unless they differ from
+// those the site was last rendered with in ways that require the
+// router to be restarted, updates the bridge configuration of the running router and the
+// rendered configuration to match them.
+func (c *Controller) Reconcile() error {
+	inputState, err := c.load(api.InputSiteStatePath)
+	if err != nil {
+		return err
+	}
+	if ns := inputState.GetNamespace(); ns != c.namespace {
+		return fmt.Errorf("namespace must be %q, but sources are defined using %q", c.namespace, ns)
+	}
+	validator := &common.SiteStateValidator{}
+	if err := validator.Validate(inputState); err != nil {
+		return fmt.Errorf("invalid input resources: %w", err)
+	}
+	loadedState, err := c.load(api.LoadedSiteStatePath)
+	if err != nil {
+		return err
+	}
+	if changes := RequiresReload(loadedState, inputState); len(changes) > 0 {
+		c.logger.Warn("Changes cannot be applied to the running site, use \"skupper system reload\"", slog.Any("resources", changes))
+		return nil
+	}
+	runtimeState, err := c.load(api.RuntimeSiteStatePath)
+	if err != nil {
+		return err
+	}
+	routerConfig, err := c.loadRouterConfig()
+	if err != nil {
+		return err
+	}
+
+	bindings := common.CopySiteState(inputState)
+	runtimeState.Listeners = bindings.Listeners
+	runtimeState.Connectors = bindings.Connectors
+	desired := runtimeState.ToRouterConfig(common.DefaultSslProfileBasePath, c.platform)
+	for name := range desired.SslProfiles {
+		if _, ok := routerConfig.SslProfiles[name]; !ok {
+			c.logger.Warn("TLS credentials have not been rendered, use \"skupper system reload\"", slog.String("sslProfile", name))
+			return nil
+		}
+	}
+
+	agent, err := c.Connect(runtimeState)
+	if err != nil {
+		return fmt.Errorf("unable to connect to the router: %w", err)
+	}
+	defer agent.Close()
+	actual, err := agent.GetLocalBridgeConfig()
+	if err != nil {
+		return fmt.Errorf("error retrieving bridges: %w", err)
+	}
+	if differences := actual.Difference(&desired.Bridges); !differences.Empty() {
+		if err := agent.UpdateLocalBridgeConfig(differences); err != nil {
+			return fmt.Errorf("error syncing bridges: %w", err)
+		}
+		c.logger.Info("Bridge configuration updated",
+			slog.Int("added", len(differences.TcpListeners.Added)+len(differences.TcpConnectors.Added)+
+				len(differences.UdpListeners.Added)+len(differences.UdpConnectors.Added)),
+			slog.Int("deleted", len(differences.TcpListeners.Deleted)+len(differences.TcpConnectors.Deleted)+
+				len(differences.UdpListeners.Deleted)+len(differences.UdpConnectors.Deleted)))
+	}
+
+	// keep the rendered configuration consistent, so that the router
+	// starts with the same bridges if it is restarted
+	if reflect.DeepEqual(routerConfig.Bridges, desired.Bridges) {
+		return nil
+	}
+	routerConfig.Bridges = desired.Bridges
+	if err := c.save(routerConfig, inputState, runtimeState); err != nil {
+		return fmt.Errorf("unable to save configuration: %w", err)
+	}
+	return nil
+}
+
+func (c *Controller) path(internalPath api.InternalPath) string {
+	return path.Join(api.GetHostNamespaceHome(c.namespace), string(internalPath))
+}
+
+func (c *Controller) load(internalPath api.InternalPath) (*api.SiteState, error) {
+	loader := &common.FileSystemSiteStateLoader{
+		Path: c.path(internalPath),
+	}
+	siteState, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", internalPath, err)
+	}
+	return siteState, nil
+}
+
+func (c *Controller) loadRouterConfig() (*qdr.RouterConfig, error) {
+	data, err := os.ReadFile(path.Join(c.path(api.RouterConfigPath), "skrouterd.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load router configuration: %w", err)
+	}
+	routerConfig, err := qdr.UnmarshalRouterConfig(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse router configuration: %w", err)
+	}
+	return &routerConfig, nil
+}
+
+func (c *Controller) save(routerConfig *qdr.RouterConfig, loadedState *api.SiteState, runtimeState *api.SiteState) error {
+	routerConfigJson, err := qdr.MarshalRouterConfig(*routerConfig)
+	if err != nil {
+		return err
+	}
+	routerConfigFile := path.Join(c.path(api.RouterConfigPath), "skrouterd.json")
+	if err := os.WriteFile(routerConfigFile, []byte(routerConfigJson), 0644); err != nil {
+		return err
+	}
+	states := map[api.InternalPath]*api.SiteState{
+		api.LoadedSiteStatePath:  loadedState,
+		api.RuntimeSiteStatePath: runtimeState,
+	}
+	for internalPath, siteState := range states {
+		statePath := c.path(internalPath)
+		// bindings no longer defined must not be left behind
+		for _, dir := range []string{"listeners", "connectors"} {
+			if err := os.RemoveAll(path.Join(statePath, dir)); err != nil {
+				return err
+			}
+		}
+		if err := api.MarshalSiteState(*siteState, statePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequiresReload returns the kinds of resource whose definitions
+// differ between the two site states and that are only rendered into
+// the router's configuration by a reload, which restarts the router.
+// Listeners and Connectors are applied to the running router, and
+// AccessGrants and SecuredAccesses are not part of its configuration.
+func RequiresReload(current *api.SiteState, updated *api.SiteState) []string {
+	var changes []string
+	if current.Site.Name != updated.Site.Name || !reflect.DeepEqual(current.Site.Spec, updated.Site.Spec) {
+		changes = append(changes, "Site")
+	}
+	if !sameSpecs(current.RouterAccesses, updated.RouterAccesses) {
+		changes = append(changes, "RouterAccess")
+	}
+	if !sameSpecs(current.Links, updated.Links) {
+		changes = append(changes, "Link")
+	}
+	if !sameSpecs(current.Claims, updated.Claims) {
+		changes = append(changes, "AccessToken")
+	}
+	if !sameSpecs(current.Certificates, updated.Certificates) {
+		changes = append(changes, "Certificate")
+	}
+	if !sameSpecs(current.Secrets, updated.Secrets) {
+		changes = append(changes, "Secret")
+	}
+	return changes
+}
+
+// sameSpecs compares the resources by name, ignoring their metadata
+// and status.
+func sameSpecs[T any](a map[string]T, b map[string]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, resource := range a {
+		other, ok := b[name]
+		if !ok || !reflect.DeepEqual(spec(resource), spec(other)) {
+			return false
+		}
+	}
+	return true
+}
+
+func spec(resource any) any {
+	value := reflect.Indirect(reflect.ValueOf(resource))
+	if value.Kind() != reflect.Struct {
+		return resource
+	}
+	if field := value.FieldByName("Spec"); field.IsValid() {
+		return field.Interface()
+	}
+	// secrets have no spec
+	var fields []any
+	for _, name := range []string{"Type", "Data", "StringData"} {
+		if field := value.FieldByName(name); field.IsValid() {
+			fields = append(fields, field.Interface())
+		}
+	}
+	return fields
+}
+
+func connect(runtimeState *api.SiteState) (BridgeAgent, error) {
+	return router.Connect(runtimeState)
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/qdr"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          func(siteState *api.SiteState)
+		expectedAdded   []string
+		expectedDeleted []string
+		expectedConfig  []string
+	}{
+		{
+			name:           "unchanged",
+			expectedConfig: []string{"backend"},
+		},
+		{
+			name: "listener added and connector removed",
+			update: func(siteState *api.SiteState) {
+				siteState.Listeners["frontend"] = listener("west", "frontend", 8080)
+				delete(siteState.Connectors, "backend")
+			},
+			expectedAdded:   []string{"frontend"},
+			expectedDeleted: []string{"backend@10.0.0.1"},
+			expectedConfig:  []string{"frontend"},
+		},
+		{
+			name: "site changed",
+			update: func(siteState *api.SiteState) {
+				siteState.Listeners["frontend"] = listener("west", "frontend", 8080)
+				siteState.Site.Spec.Edge = true
+			},
+			expectedConfig: []string{"backend"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+			current := setupNamespace(t, "west")
+			agent := &fakeAgent{actual: current}
+			c := NewController("west", "podman")
+			c.Connect = func(runtimeState *api.SiteState) (BridgeAgent, error) {
+				return agent, nil
+			}
+
+			input := siteState("west")
+			if tt.update != nil {
+				tt.update(input)
+			}
+			inputPath := c.path(api.InputSiteStatePath)
+			assert.Assert(t, os.RemoveAll(inputPath))
+			assert.Assert(t, api.MarshalSiteState(*input, inputPath))
+
+			assert.Assert(t, c.Reconcile())
+			var added []string
+			var deleted []string
+			if agent.changes != nil {
+				for _, listener := range agent.changes.TcpListeners.Added {
+					added = append(added, listener.Name)
+				}
+				deleted = append(deleted, agent.changes.TcpConnectors.Deleted...)
+			}
+			assert.DeepEqual(t, added, tt.expectedAdded)
+			assert.DeepEqual(t, deleted, tt.expectedDeleted)
+			assert.Assert(t, agent.closed == (agent.changes != nil || tt.update == nil))
+
+			routerConfig, err := c.loadRouterConfig()
+			assert.Assert(t, err)
+			var bridges []string
+			for _, listener := range routerConfig.Bridges.TcpListeners {
+				bridges = append(bridges, listener.Name)
+			}
+			for _, connector := range routerConfig.Bridges.TcpConnectors {
+				bridges = append(bridges, connector.Address)
+			}
+			assert.DeepEqual(t, bridges, tt.expectedConfig)
+
+			runtimeState, err := c.load(api.RuntimeSiteStatePath)
+			assert.Assert(t, err)
+			assert.Equal(t, len(runtimeState.Listeners)+len(runtimeState.Connectors), len(tt.expectedConfig))
+		})
+	}
+}
+
+func TestRequiresReload(t *testing.T) {
+	current := siteState("west")
+	updated := common.CopySiteState(current)
+	updated.Listeners["frontend"] = listener("west", "frontend", 8080)
+	updated.Site.SetConfigured(nil)
+	assert.Assert(t, len(RequiresReload(current, updated)) == 0)
+
+	// grants and secured access are not part of the router's configuration
+	updated.Grants["my-grant"] = &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-grant", Namespace: "west"},
+		Spec:       v2alpha1.AccessGrantSpec{RedemptionsAllowed: 1},
+	}
+	updated.SecuredAccesses["my-access"] = &v2alpha1.SecuredAccess{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-access", Namespace: "west"},
+	}
+	assert.Assert(t, len(RequiresReload(current, updated)) == 0)
+
+	updated.Links["remote"] = &v2alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "west"},
+	}
+	updated.Site.Spec.Edge = true
+	assert.DeepEqual(t, RequiresReload(current, updated), []string{"Site", "Link"})
+}
+
+func siteState(namespace string) *api.SiteState {
+	siteState := api.NewSiteState(false)
+	siteState.Site = &v2alpha1.Site{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: namespace},
+	}
+	siteState.Connectors["backend"] = &v2alpha1.Connector{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Connector"},
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: namespace},
+		Spec: v2alpha1.ConnectorSpec{
+			RoutingKey: "backend",
+			Host:       "10.0.0.1",
+			Port:       8080,
+		},
+	}
+	return siteState
+}
+
+func listener(namespace string, name string, port int) *v2alpha1.Listener {
+	return &v2alpha1.Listener{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Listener"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v2alpha1.ListenerSpec{
+			RoutingKey: name,
+			Host:       name,
+			Port:       port,
+		},
+	}
+}
+
+// setupNamespace renders a site with a single connector, as bootstrap
+// would, returning the bridges of its router.
+func setupNamespace(t *testing.T, namespace string) qdr.BridgeConfig {
+	t.Helper()
+	home := api.GetHostNamespaceHome(namespace)
+	loaded := siteState(namespace)
+	assert.Assert(t, api.MarshalSiteState(*loaded, path.Join(home, string(api.LoadedSiteStatePath))))
+	runtimeState := common.CopySiteState(loaded)
+	runtimeState.CreateRouterAccess("skupper-local", 5671)
+	routerConfig := runtimeState.ToRouterConfig(common.DefaultSslProfileBasePath, "podman")
+	assert.Assert(t, api.MarshalSiteState(*runtimeState, path.Join(home, string(api.RuntimeSiteStatePath))))
+	routerConfigJson, err := qdr.MarshalRouterConfig(routerConfig)
+	assert.Assert(t, err)
+	assert.Assert(t, os.MkdirAll(path.Join(home, string(api.RouterConfigPath)), 0755))
+	assert.Assert(t, os.WriteFile(path.Join(home, string(api.RouterConfigPath), "skrouterd.json"), []byte(routerConfigJson), 0644))
+	return routerConfig.Bridges
+}
+
+type fakeAgent struct {
+	actual  qdr.BridgeConfig
+	changes *qdr.BridgeConfigDifference
+	closed  bool
+}
+
+func (a *fakeAgent) GetLocalBridgeConfig() (*qdr.BridgeConfig, error) {
+	return &a.actual, nil
+}
+
+func (a *fakeAgent) UpdateLocalBridgeConfig(changes *qdr.BridgeConfigDifference) error {
+	a.changes = changes
+	return nil
+}
+
+func (a *fakeAgent) Close() error {
+	a.closed = true
+	return nil
+}
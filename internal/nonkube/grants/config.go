@@ -0,0 +1,86 @@
+package grants
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	DefaultPort      = 9090
+	serverConfigFile = "server.yaml"
+)
+
+// ServerConfig holds what a token needs to reach the grant server of a
+// namespace. It is written when the grant server, which runs alongside
+// the router of the site, starts.
+type ServerConfig struct {
+	Url string `json:"url"`
+	Ca  string `json:"ca"`
+}
+
+func serverConfigPath(namespace string) string {
+	return path.Join(api.GetHostNamespaceHome(namespace), string(api.RuntimeGrantsPath), serverConfigFile)
+}
+
+func LoadServerConfig(namespace string) (*ServerConfig, error) {
+	data, err := os.ReadFile(serverConfigPath(namespace))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("the grant server has not started yet for namespace %s", utils.DefaultStr(namespace, "default"))
+		}
+		return nil, err
+	}
+	config := &ServerConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid grant server configuration: %w", err)
+	}
+	return config, nil
+}
+
+func (c *ServerConfig) save(namespace string) error {
+	fileName := serverConfigPath(namespace)
+	if err := os.MkdirAll(path.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// NewAccessGrant returns an AccessGrant that is ready to be redeemed
+// through the grant server described by the given configuration.
+func NewAccessGrant(name string, namespace string, redemptionsAllowed int, expirationWindow time.Duration, config *ServerConfig) v2alpha1.AccessGrant {
+	grant := v2alpha1.AccessGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: redemptionsAllowed,
+			ExpirationWindow:   expirationWindow.String(),
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Url:            fmt.Sprintf("%s/%s", config.Url, name),
+			Ca:             config.Ca,
+			Code:           utils.RandomId(24),
+			ExpirationTime: time.Now().Add(expirationWindow).Format(time.RFC3339),
+		},
+	}
+	grant.SetProcessed(nil)
+	grant.SetResolved()
+	return grant
+}
@@ -0,0 +1,317 @@
+package grants
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/skupperproject/skupper/pkg/nonkube/common"
+	"github.com/skupperproject/skupper/pkg/utils/tlscfg"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultIssuer = "skupper-site-ca"
+
+// GrantServer redeems the AccessGrants defined for a nonkube namespace,
+// handing out links to the router access of the local site.
+type GrantServer struct {
+	namespace string
+	host      string
+	port      int
+	grants    *fs.AccessGrantHandler
+	logger    *slog.Logger
+	lock      sync.Mutex
+}
+
+func NewGrantServer(namespace string, host string, port int) *GrantServer {
+	if namespace == "" {
+		namespace = "default"
+	}
+	if port == 0 {
+		port = DefaultPort
+	}
+	return &GrantServer{
+		namespace: namespace,
+		host:      host,
+		port:      port,
+		grants:    fs.NewAccessGrantHandler(namespace),
+		logger:    common.NewLogger().With(slog.String("component", "grant-server")),
+	}
+}
+
+// Configure creates the credentials the server uses, signed by the CA that
+// issues the link certificates, and records the URL tokens must use.
+func (s *GrantServer) Configure() (*tls.Certificate, error) {
+	linkAccess, err := s.linkAccess()
+	if err != nil {
+		return nil, err
+	}
+	ca, err := s.loadSecret(api.IssuersPath, issuer(linkAccess))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load site CA: %w", err)
+	}
+	if s.host == "" {
+		s.host = s.defaultHost(linkAccess)
+	}
+	secret := certs.GenerateSecret("skupper-grant-server", s.host, s.host, ca)
+	cert, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+	if err != nil {
+		return nil, err
+	}
+	config := &ServerConfig{
+		Url: "https://" + net.JoinHostPort(s.host, strconv.Itoa(s.port)),
+		Ca:  string(secret.Data["ca.crt"]),
+	}
+	if err := config.save(s.namespace); err != nil {
+		return nil, fmt.Errorf("unable to save grant server configuration: %w", err)
+	}
+	return &cert, nil
+}
+
+// ListenAndServe serves grant redemptions until the given context is done.
+func (s *GrantServer) ListenAndServe(ctx context.Context) error {
+	cert, err := s.Configure()
+	if err != nil {
+		return err
+	}
+	tlsConfig := tlscfg.Modern()
+	tlsConfig.Certificates = []tls.Certificate{*cert}
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.port),
+		Handler:      s,
+		ReadTimeout:  60 * time.Second,
+		WriteTimeout: 60 * time.Second,
+		TLSConfig:    tlsConfig,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	s.logger.Info("Grant server listening", slog.String("address", server.Addr), slog.String("host", s.host))
+	err = server.ListenAndServeTLS("", "")
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *GrantServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	key := strings.Trim(r.URL.Path, "/")
+	// the key names the AccessGrant file, so must not escape the grants directory
+	if err := common.ValidateName(key); err != nil {
+		http.Error(w, "No such claim", http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Request body not valid", http.StatusBadRequest)
+		return
+	}
+	grant, httpErr := s.checkAndUpdateAccessToken(key, body)
+	if httpErr != nil {
+		http.Error(w, httpErr.text, httpErr.code)
+		return
+	}
+	name := r.Header.Get("name")
+	if name == "" {
+		name = grant.Name
+	}
+	subject := r.Header.Get("subject")
+	if subject == "" {
+		subject = name
+	}
+	if err := s.generate(name, subject, w); err != nil {
+		s.logger.Error("Failed to create token", slog.String("grant", grant.Name), slog.Any("error", err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.logger.Info("Redemption of access token succeeded", slog.String("grant", grant.Name), slog.String("subject", subject))
+}
+
+type httpError struct {
+	text string
+	code int
+}
+
+func (s *GrantServer) checkAndUpdateAccessToken(key string, code []byte) (*v2alpha1.AccessGrant, *httpError) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	grant, err := s.grants.Get(key)
+	if err != nil || grant == nil {
+		return nil, &httpError{"No such claim", http.StatusNotFound}
+	}
+	expiration, err := time.Parse(time.RFC3339, grant.Status.ExpirationTime)
+	if err != nil {
+		s.logger.Error("Cannot determine expiration", slog.String("grant", grant.Name), slog.Any("error", err))
+		return nil, &httpError{"Corrupted claim", http.StatusInternalServerError}
+	}
+	if expiration.Before(time.Now()) {
+		s.logger.Info("AccessGrant expired", slog.String("grant", grant.Name))
+		return nil, &httpError{"No such claim", http.StatusNotFound}
+	}
+	if grant.Spec.RedemptionsAllowed <= grant.Status.Redemptions {
+		s.logger.Info("AccessGrant already redeemed", slog.String("grant", grant.Name))
+		return nil, &httpError{"No such access granted", http.StatusNotFound}
+	}
+	if grant.Status.Code != string(code) {
+		return nil, &httpError{"Redemption of access token refused", http.StatusForbidden}
+	}
+	grant.Status.Redemptions += 1
+	if err := s.grants.Update(*grant); err != nil {
+		s.logger.Error("Error updating access grant", slog.String("grant", grant.Name), slog.Any("error", err))
+		return nil, &httpError{"Internal error", http.StatusServiceUnavailable}
+	}
+	return grant, nil
+}
+
+// generate writes a Secret, holding a client certificate for the given
+// subject, followed by the Link that uses it
+func (s *GrantServer) generate(name string, subject string, writer io.Writer) error {
+	linkAccess, err := s.linkAccess()
+	if err != nil {
+		return err
+	}
+	ca, err := s.loadSecret(api.IssuersPath, issuer(linkAccess))
+	if err != nil {
+		return fmt.Errorf("unable to load site CA: %w", err)
+	}
+	serverSecret, err := s.loadSecret(api.CertificatesPath, linkAccess.Spec.TlsCredentials)
+	if err != nil {
+		return fmt.Errorf("unable to load server certificate: %w", err)
+	}
+	clientSecret := certs.GenerateSecret(name, subject, "", ca)
+	token := selectToken(api.CreateTokens(*linkAccess, *serverSecret, clientSecret), s.host)
+	if token == nil {
+		return fmt.Errorf("no link endpoints available")
+	}
+	token.Secret.Name = name
+	token.Links[0].Name = name
+	token.Links[0].Spec.TlsCredentials = name
+	data, err := token.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// linkAccess returns the RouterAccess through which remote sites are able
+// to link to the local one
+func (s *GrantServer) linkAccess() (*v2alpha1.RouterAccess, error) {
+	loader := &common.FileSystemSiteStateLoader{
+		Path: path.Join(api.GetHostNamespaceHome(s.namespace), string(api.RuntimeSiteStatePath)),
+	}
+	siteState, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load runtime state of namespace %s: %w", s.namespace, err)
+	}
+	var names []string
+	for name, routerAccess := range siteState.RouterAccesses {
+		if routerAccess.FindRole("inter-router") != nil || routerAccess.FindRole("edge") != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the site in namespace %s does not accept links", s.namespace)
+	}
+	sort.Strings(names)
+	linkAccess := siteState.RouterAccesses[names[0]]
+	if linkAccess.Spec.TlsCredentials == "" {
+		linkAccess.Spec.TlsCredentials = linkAccess.Name
+	}
+	return linkAccess, nil
+}
+
+// defaultHost is the first address, other than loopback or unspecified ones, in
+// the certificate of the router access
+func (s *GrantServer) defaultHost(linkAccess *v2alpha1.RouterAccess) string {
+	serverSecret, err := s.loadSecret(api.CertificatesPath, linkAccess.Spec.TlsCredentials)
+	if err == nil {
+		for _, token := range api.CreateTokens(*linkAccess, *serverSecret, corev1.Secret{}) {
+			if host := token.Links[0].Spec.Endpoints[0].Host; !isLocalAddress(host) {
+				return host
+			}
+		}
+	}
+	if linkAccess.Spec.BindHost != "" && linkAccess.Spec.BindHost != "0.0.0.0" {
+		return linkAccess.Spec.BindHost
+	}
+	return "127.0.0.1"
+}
+
+func (s *GrantServer) loadSecret(internalPath api.InternalPath, name string) (*corev1.Secret, error) {
+	secretPath := path.Join(api.GetHostNamespaceHome(s.namespace), string(internalPath), name)
+	files, err := os.ReadDir(secretPath)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: map[string][]byte{},
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(path.Join(secretPath, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[file.Name()] = data
+	}
+	return secret, nil
+}
+
+func issuer(linkAccess *v2alpha1.RouterAccess) string {
+	if linkAccess.Spec.Issuer != "" {
+		return linkAccess.Spec.Issuer
+	}
+	return defaultIssuer
+}
+
+func selectToken(tokens []*api.Token, host string) *api.Token {
+	for _, token := range tokens {
+		if token.Links[0].Spec.Endpoints[0].Host == host {
+			return token
+		}
+	}
+	if len(tokens) > 0 {
+		return tokens[0]
+	}
+	return nil
+}
+
+func isLocalAddress(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsUnspecified())
+}
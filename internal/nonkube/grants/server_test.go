@@ -0,0 +1,132 @@
+package grants
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/certs"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGrantServer(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	namespace := "grants"
+	setupNamespace(t, namespace)
+
+	server := NewGrantServer(namespace, "", 0)
+	_, err := server.Configure()
+	assert.Assert(t, err)
+	assert.Equal(t, server.host, "10.0.0.1")
+
+	config, err := LoadServerConfig(namespace)
+	assert.Assert(t, err)
+	assert.Equal(t, config.Url, "https://10.0.0.1:9090")
+	assert.Assert(t, config.Ca != "")
+
+	grantHandler := fs.NewAccessGrantHandler(namespace)
+	grant := NewAccessGrant("my-grant", namespace, 1, time.Minute, config)
+	assert.Equal(t, grant.Status.Url, "https://10.0.0.1:9090/my-grant")
+	assert.Assert(t, grantHandler.Add(grant))
+	expired := NewAccessGrant("expired-grant", namespace, 1, -time.Minute, config)
+	assert.Assert(t, grantHandler.Add(expired))
+
+	redeem := func(key string, code string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/"+key, bytes.NewReader([]byte(code)))
+		request.Header.Add("name", "my-token")
+		request.Header.Add("subject", "remote-site")
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("bad method", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/my-grant", nil))
+		assert.Equal(t, recorder.Code, http.StatusMethodNotAllowed)
+	})
+	t.Run("unknown grant", func(t *testing.T) {
+		assert.Equal(t, redeem("unknown", grant.Status.Code).Code, http.StatusNotFound)
+	})
+	t.Run("invalid grant name", func(t *testing.T) {
+		assert.Equal(t, redeem("../input/resources/my-grant", grant.Status.Code).Code, http.StatusNotFound)
+	})
+	t.Run("expired grant", func(t *testing.T) {
+		assert.Equal(t, redeem("expired-grant", expired.Status.Code).Code, http.StatusNotFound)
+	})
+	t.Run("invalid code", func(t *testing.T) {
+		assert.Equal(t, redeem("my-grant", "invalid").Code, http.StatusForbidden)
+	})
+	t.Run("redeemed", func(t *testing.T) {
+		recorder := redeem("my-grant", grant.Status.Code)
+		assert.Equal(t, recorder.Code, http.StatusOK, recorder.Body.String())
+		token, err := api.UnmarshalToken(recorder.Body.Bytes())
+		assert.Assert(t, err)
+		assert.Equal(t, token.Secret.Name, "my-token")
+		assert.Assert(t, len(token.Secret.Data["tls.crt"]) > 0)
+		assert.Equal(t, len(token.Links), 1)
+		assert.Equal(t, token.Links[0].Name, "my-token")
+		assert.Equal(t, token.Links[0].Spec.TlsCredentials, "my-token")
+		assert.Equal(t, token.Links[0].Spec.Endpoints[0].Host, "10.0.0.1")
+		assert.Equal(t, token.Links[0].Spec.Endpoints[0].Port, "55671")
+
+		updated, err := grantHandler.Get("my-grant")
+		assert.Assert(t, err)
+		assert.Equal(t, updated.Status.Redemptions, 1)
+	})
+	t.Run("already redeemed", func(t *testing.T) {
+		assert.Equal(t, redeem("my-grant", grant.Status.Code).Code, http.StatusNotFound)
+	})
+}
+
+func TestGrantServerNoLinkAccess(t *testing.T) {
+	t.Setenv("SKUPPER_OUTPUT_PATH", t.TempDir())
+	_, err := NewGrantServer("missing", "", 0).Configure()
+	assert.ErrorContains(t, err, "unable to load runtime state of namespace missing")
+
+	_, err = LoadServerConfig("missing")
+	assert.ErrorContains(t, err, "the grant server has not started yet for namespace missing")
+}
+
+func setupNamespace(t *testing.T, namespace string) {
+	t.Helper()
+	home := api.GetHostNamespaceHome(namespace)
+	siteState := api.NewSiteState(false)
+	siteState.Site = &v2alpha1.Site{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "Site"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-site", Namespace: namespace},
+	}
+	siteState.RouterAccesses["link-access"] = &v2alpha1.RouterAccess{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "skupper.io/v2alpha1", Kind: "RouterAccess"},
+		ObjectMeta: metav1.ObjectMeta{Name: "link-access", Namespace: namespace},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+			},
+			BindHost: "0.0.0.0",
+		},
+	}
+	assert.Assert(t, api.MarshalSiteState(*siteState, path.Join(home, string(api.RuntimeSiteStatePath))))
+
+	ca := certs.GenerateCASecret("skupper-site-ca", "skupper-site-ca")
+	writeSecret(t, path.Join(home, string(api.IssuersPath), "skupper-site-ca"), &ca)
+	server := certs.GenerateSecret("link-access", "link-access", "0.0.0.0,10.0.0.1", &ca)
+	writeSecret(t, path.Join(home, string(api.CertificatesPath), "link-access"), &server)
+}
+
+func writeSecret(t *testing.T, dir string, secret *corev1.Secret) {
+	t.Helper()
+	assert.Assert(t, os.MkdirAll(dir, 0755))
+	for name, data := range secret.Data {
+		assert.Assert(t, os.WriteFile(path.Join(dir, name), data, 0644))
+	}
+}
@@ -200,6 +200,25 @@ type SiteSpec struct {
 	Edge           bool              `json:"edge,omitempty"`
 	HA             bool              `json:"ha,omitempty"`
 	Settings       map[string]string `json:"settings,omitempty"`
+	RouterPod      *RouterPodSpec    `json:"routerPod,omitempty"`
+}
+
+// RouterPodSpec customises the pods in which the router runs for a
+// site.
+type RouterPodSpec struct {
+	// Labels and Annotations are added to the router Deployment and
+	// its pods.
+	Labels                    map[string]string                 `json:"labels,omitempty"`
+	Annotations               map[string]string                 `json:"annotations,omitempty"`
+	NodeSelector              map[string]string                 `json:"nodeSelector,omitempty"`
+	Tolerations               []corev1.Toleration               `json:"tolerations,omitempty"`
+	Affinity                  *corev1.Affinity                  `json:"affinity,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                            `json:"priorityClassName,omitempty"`
+	// RouterResources and AdaptorResources are the compute resources
+	// for the router and kube-adaptor containers respectively.
+	RouterResources  *corev1.ResourceRequirements `json:"routerResources,omitempty"`
+	AdaptorResources *corev1.ResourceRequirements `json:"adaptorResources,omitempty"`
 }
 
 func (s *SiteSpec) GetServiceAccount() string {
@@ -223,6 +242,20 @@ func (s *SiteSpec) GetRouterDataConnectionCount() string {
 	return ""
 }
 
+func (s *SiteSpec) GetRouterGroups() string {
+	if value, ok := s.Settings["router-groups"]; ok {
+		return value
+	}
+	return ""
+}
+
+func (s *SiteSpec) GetCertManagerIssuer() string {
+	if value, ok := s.Settings["cert-manager-issuer"]; ok {
+		return value
+	}
+	return ""
+}
+
 func (s *Site) SetConfigured(err error) bool {
 	if s.Status.SetCondition(CONDITION_TYPE_CONFIGURED, ErrorOrReadyCondition(err), s.ObjectMeta.Generation) {
 		s.Status.setReady(s.requiredConditions(), s.ObjectMeta.Generation)
@@ -295,6 +328,15 @@ type Endpoint struct {
 	Host  string `json:"host,omitempty"`
 	Port  string `json:"port,omitempty"`
 	Group string `json:"group,omitempty"`
+	// Cost of the connection to this endpoint. A link connects to the
+	// lowest cost of its endpoints for the router's role. Defaults to
+	// the cost of the link for the first endpoint and increases by one
+	// for each further one.
+	Cost int `json:"cost,omitempty"`
+	// Standby keeps a connection open to this endpoint alongside the
+	// lowest cost one, so that the router fails over to it when the
+	// connections that cost less are lost.
+	Standby bool `json:"standby,omitempty"`
 }
 
 func (a *Endpoint) MatchHostPort(b *Endpoint) bool {
@@ -550,6 +592,16 @@ func (l *Link) SetOperational(operational bool, remoteSiteId string, remoteSiteN
 	return changed
 }
 
+// SetActiveEndpoint records the host and port of the lowest cost
+// endpoint to which the link is currently connected, if any.
+func (l *Link) SetActiveEndpoint(endpoint string) bool {
+	if l.Status.ActiveEndpoint == endpoint {
+		return false
+	}
+	l.Status.ActiveEndpoint = endpoint
+	return true
+}
+
 func (l *Link) IsConfigured() bool {
 	return meta.IsStatusConditionTrue(l.Status.Conditions, CONDITION_TYPE_CONFIGURED)
 }
@@ -584,10 +636,25 @@ func (s *LinkSpec) GetEndpointForRole(name string) (Endpoint, bool) {
 	return Endpoint{}, false
 }
 
+// GetEndpointsForRole returns all the endpoints with the given role, in
+// the order in which they are listed.
+func (s *LinkSpec) GetEndpointsForRole(name string) []Endpoint {
+	var endpoints []Endpoint
+	for _, endpoint := range s.Endpoints {
+		if endpoint.Name == name {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
 type LinkStatus struct {
 	Status         `json:",inline"`
 	RemoteSiteId   string `json:"remoteSiteId,omitempty"`
 	RemoteSiteName string `json:"remoteSiteName,omitempty"`
+	// ActiveEndpoint is the lowest cost of the endpoints the link is
+	// connected to, which the router prefers to route over.
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
 }
 
 // +genclient
@@ -675,6 +742,21 @@ func (s *AccessGrant) IsReady() bool {
 	return meta.IsStatusConditionTrue(s.Status.Conditions, CONDITION_TYPE_READY)
 }
 
+// IsRevoked returns true if the spec requests revocation of the access
+// given by a redemption, identified by the CA that issued it or by the
+// subject it was issued to.
+func (g *AccessGrant) IsRevoked(redemption GrantRedemption) bool {
+	for _, revoked := range g.Spec.Revoked {
+		if revoked == "" {
+			continue
+		}
+		if revoked == redemption.Issuer || revoked == redemption.Subject {
+			return true
+		}
+	}
+	return false
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AccessGrantList contains a List of AccessGrant instances
@@ -690,15 +772,53 @@ type AccessGrantSpec struct {
 	Code               string            `json:"code,omitempty"`
 	Issuer             string            `json:"issuer,omitempty"`
 	Settings           map[string]string `json:"settings,omitempty"`
+	// Revoked lists the issuers (or subjects) of redemptions whose
+	// access should be withdrawn. The router cannot close the links
+	// of a single peer, so revoking a redemption briefly closes every
+	// incoming link of the site, and all but the revoked ones
+	// reconnect.
+	Revoked []string `json:"revoked,omitempty"`
 }
 
 type AccessGrantStatus struct {
 	Status         `json:",inline"`
-	Url            string `json:"url"`
-	Code           string `json:"code"`
-	Ca             string `json:"ca"`
-	Redemptions    int    `json:"redemptions,omitempty"`
-	ExpirationTime string `json:"expirationTime,omitempty"`
+	Url            string            `json:"url"`
+	Code           string            `json:"code"`
+	Ca             string            `json:"ca"`
+	Redemptions    int               `json:"redemptions,omitempty"`
+	ExpirationTime string            `json:"expirationTime,omitempty"`
+	Redeemed       []GrantRedemption `json:"redeemed,omitempty"`
+	// FailedAttempts counts the attempts to redeem the grant with an
+	// invalid code since it was last redeemed or locked.
+	FailedAttempts int `json:"failedAttempts,omitempty"`
+	// Locked is when the grant was last locked after too many
+	// invalid codes.
+	Locked string `json:"locked,omitempty"`
+}
+
+// GrantRedemption records the credentials issued when an AccessGrant
+// was redeemed.
+type GrantRedemption struct {
+	// Issuer is the Secret holding the CA that issued the client
+	// certificate for the redemption. It is deleted when the
+	// redemption is revoked, after which the certificate is no longer
+	// trusted. Deleting the AccessGrant does not delete it, so
+	// redemptions to be withdrawn must be revoked first.
+	Issuer string `json:"issuer"`
+	// Subject is the subject of the client certificate, as requested
+	// by the redeeming site (its UID when redeemed by a skupper
+	// controller).
+	Subject string `json:"subject"`
+	// SiteName and SiteNamespace identify the redeeming site as it
+	// described itself. They are not verified, and are checked
+	// against any SitePolicy restricting grant redemptions again when
+	// the policies change.
+	SiteName      string `json:"siteName,omitempty"`
+	SiteNamespace string `json:"siteNamespace,omitempty"`
+	RemoteAddress string `json:"remoteAddress,omitempty"`
+	Time          string `json:"time"`
+	SerialNumber  string `json:"serialNumber,omitempty"`
+	Revoked       string `json:"revoked,omitempty"`
 }
 
 // +genclient
@@ -839,6 +959,10 @@ func (c *Certificate) SetReady(err error) bool {
 	return c.Status.SetCondition(CONDITION_TYPE_READY, ErrorOrReadyCondition(err), c.ObjectMeta.Generation)
 }
 
+func (c *Certificate) IsReady() bool {
+	return meta.IsStatusConditionTrue(c.Status.Conditions, CONDITION_TYPE_READY)
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -1069,3 +1193,71 @@ type AttachedConnectorBindingSpec struct {
 	ExposePodsByName   bool              `json:"exposePodsByName,omitempty"`
 	Settings           map[string]string `json:"settings,omitempty"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SitePolicy restricts what the sites it applies to may do. A policy
+// applies to the namespace it is defined in and, when defined in the
+// namespace of the controller, to any namespaces listed in its spec.
+type SitePolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          SitePolicySpec   `json:"spec,omitempty"`
+	Status        SitePolicyStatus `json:"status,omitempty"`
+}
+
+func (p *SitePolicy) SetConfigured(err error) bool {
+	if p.Status.SetCondition(CONDITION_TYPE_CONFIGURED, ErrorOrReadyCondition(err), p.ObjectMeta.Generation) {
+		p.Status.setReady([]string{CONDITION_TYPE_CONFIGURED}, p.ObjectMeta.Generation)
+		return true
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SitePolicyList contains a List of SitePolicy instances
+type SitePolicyList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []SitePolicy `json:"items"`
+}
+
+// SitePolicySpec describes the restrictions imposed by a SitePolicy.
+// A field that is not set imposes no restriction.
+type SitePolicySpec struct {
+	// Namespaces lists further namespaces the policy applies to, "*"
+	// meaning all namespaces. It is honoured only for policies in the
+	// namespace of the controller.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// GrantRedemptions restricts the remote sites that may redeem
+	// AccessGrants in order to link to the site. It does not apply to
+	// links configured by other means.
+	GrantRedemptions *GrantRedemptionPolicy `json:"grantRedemptions,omitempty"`
+	// AllowedRoutingKeys lists the patterns that the routing keys of
+	// Listeners, Connectors and AttachedConnectorBindings must match,
+	// using path.Match syntax.
+	AllowedRoutingKeys []string `json:"allowedRoutingKeys,omitempty"`
+	// AllowedAccessTypes lists the access types SecuredAccesses may
+	// use.
+	AllowedAccessTypes []string `json:"allowedAccessTypes,omitempty"`
+}
+
+// GrantRedemptionPolicy allows or denies the redemption of AccessGrants
+// by remote sites by the name or namespace of the site, using
+// path.Match syntax. Sites that are denied are refused even if also
+// allowed. The name and namespace are those the site gives when
+// redeeming an AccessGrant and are not verified, so the policy is
+// advisory with respect to anyone holding a grant's code. Redeemers
+// that give neither, such as nonkube sites, are not filtered.
+type GrantRedemptionPolicy struct {
+	AllowedSites      []string `json:"allowedSites,omitempty"`
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	DeniedSites       []string `json:"deniedSites,omitempty"`
+	DeniedNamespaces  []string `json:"deniedNamespaces,omitempty"`
+}
+
+type SitePolicyStatus struct {
+	Status `json:",inline"`
+}
@@ -22,6 +22,7 @@ limitations under the License.
 package v2alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -97,6 +98,11 @@ func (in *AccessGrantSpec) DeepCopyInto(out *AccessGrantSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Revoked != nil {
+		in, out := &in.Revoked, &out.Revoked
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -114,6 +120,11 @@ func (in *AccessGrantSpec) DeepCopy() *AccessGrantSpec {
 func (in *AccessGrantStatus) DeepCopyInto(out *AccessGrantStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Redeemed != nil {
+		in, out := &in.Redeemed, &out.Redeemed
+		*out = make([]GrantRedemption, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -679,6 +690,58 @@ func (in *Endpoint) DeepCopy() *Endpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantRedemption) DeepCopyInto(out *GrantRedemption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantRedemption.
+func (in *GrantRedemption) DeepCopy() *GrantRedemption {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantRedemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantRedemptionPolicy) DeepCopyInto(out *GrantRedemptionPolicy) {
+	*out = *in
+	if in.AllowedSites != nil {
+		in, out := &in.AllowedSites, &out.AllowedSites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedSites != nil {
+		in, out := &in.DeniedSites, &out.DeniedSites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedNamespaces != nil {
+		in, out := &in.DeniedNamespaces, &out.DeniedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantRedemptionPolicy.
+func (in *GrantRedemptionPolicy) DeepCopy() *GrantRedemptionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantRedemptionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Link) DeepCopyInto(out *Link) {
 	*out = *in
@@ -1057,6 +1120,72 @@ func (in *RouterAccessStatus) DeepCopy() *RouterAccessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPodSpec) DeepCopyInto(out *RouterPodSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RouterResources != nil {
+		in, out := &in.RouterResources, &out.RouterResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdaptorResources != nil {
+		in, out := &in.AdaptorResources, &out.AdaptorResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPodSpec.
+func (in *RouterPodSpec) DeepCopy() *RouterPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuredAccess) DeepCopyInto(out *SecuredAccess) {
 	*out = *in
@@ -1301,6 +1430,120 @@ func (in *SiteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SitePolicy) DeepCopyInto(out *SitePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SitePolicy.
+func (in *SitePolicy) DeepCopy() *SitePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SitePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SitePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SitePolicyList) DeepCopyInto(out *SitePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SitePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SitePolicyList.
+func (in *SitePolicyList) DeepCopy() *SitePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SitePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SitePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SitePolicySpec) DeepCopyInto(out *SitePolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GrantRedemptions != nil {
+		in, out := &in.GrantRedemptions, &out.GrantRedemptions
+		*out = new(GrantRedemptionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedRoutingKeys != nil {
+		in, out := &in.AllowedRoutingKeys, &out.AllowedRoutingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedAccessTypes != nil {
+		in, out := &in.AllowedAccessTypes, &out.AllowedAccessTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SitePolicySpec.
+func (in *SitePolicySpec) DeepCopy() *SitePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SitePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SitePolicyStatus) DeepCopyInto(out *SitePolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SitePolicyStatus.
+func (in *SitePolicyStatus) DeepCopy() *SitePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SitePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SiteRecord) DeepCopyInto(out *SiteRecord) {
 	*out = *in
@@ -1339,6 +1582,11 @@ func (in *SiteSpec) DeepCopyInto(out *SiteSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RouterPod != nil {
+		in, out := &in.RouterPod, &out.RouterPod
+		*out = new(RouterPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -72,6 +72,10 @@ func (c *FakeSkupperV2alpha1) Sites(namespace string) v2alpha1.SiteInterface {
 	return &FakeSites{c, namespace}
 }
 
+func (c *FakeSkupperV2alpha1) SitePolicies(namespace string) v2alpha1.SitePolicyInterface {
+	return &FakeSitePolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSkupperV2alpha1) RESTClient() rest.Interface {
@@ -39,3 +39,5 @@ type RouterAccessExpansion interface{}
 type SecuredAccessExpansion interface{}
 
 type SiteExpansion interface{}
+
+type SitePolicyExpansion interface{}
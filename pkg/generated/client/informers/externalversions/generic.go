@@ -75,6 +75,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Skupper().V2alpha1().SecuredAccesses().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("sites"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Skupper().V2alpha1().Sites().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("sitepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Skupper().V2alpha1().SitePolicies().Informer()}, nil
 
 	}
 
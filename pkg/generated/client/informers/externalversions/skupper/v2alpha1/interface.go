@@ -46,6 +46,8 @@ type Interface interface {
 	SecuredAccesses() SecuredAccessInformer
 	// Sites returns a SiteInformer.
 	Sites() SiteInformer
+	// SitePolicies returns a SitePolicyInformer.
+	SitePolicies() SitePolicyInformer
 }
 
 type version struct {
@@ -113,3 +115,8 @@ func (v *version) SecuredAccesses() SecuredAccessInformer {
 func (v *version) Sites() SiteInformer {
 	return &siteInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// SitePolicies returns a SitePolicyInformer.
+func (v *version) SitePolicies() SitePolicyInformer {
+	return &sitePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
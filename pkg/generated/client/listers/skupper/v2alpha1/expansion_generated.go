@@ -105,3 +105,11 @@ type SiteListerExpansion interface{}
 // SiteNamespaceListerExpansion allows custom methods to be added to
 // SiteNamespaceLister.
 type SiteNamespaceListerExpansion interface{}
+
+// SitePolicyListerExpansion allows custom methods to be added to
+// SitePolicyLister.
+type SitePolicyListerExpansion interface{}
+
+// SitePolicyNamespaceListerExpansion allows custom methods to be added to
+// SitePolicyNamespaceLister.
+type SitePolicyNamespaceListerExpansion interface{}
@@ -33,14 +33,19 @@ var recordTypes []vanflow.Record = []vanflow.Record{
 	vanflow.ProcessRecord{},
 }
 
+// StatusPublisher stores the network status built by a StatusSync
+// wherever the site exposes it.
+type StatusPublisher interface {
+	Publish(ctx context.Context, info network.NetworkStatusInfo) error
+}
+
 type StatusSync struct {
 	records       store.Interface
 	recordMapping eventsource.RecordStoreMap
 
-	session       session.Container
-	discovery     *eventsource.Discovery
-	client        v1.ConfigMapInterface
-	configMapName string
+	session   session.Container
+	discovery *eventsource.Discovery
+	publisher StatusPublisher
 
 	logger *slog.Logger
 	ctx    context.Context
@@ -55,6 +60,15 @@ type StatusSync struct {
 }
 
 func NewStatusSync(factory session.ContainerFactory, localSources map[string][]store.Interface, client v1.ConfigMapInterface, configMap string) *StatusSync {
+	return NewStatusSyncWithPublisher(factory, localSources, &configMapPublisher{
+		client:        client,
+		configMapName: configMap,
+	})
+}
+
+// NewStatusSyncWithPublisher creates a StatusSync that hands the network
+// status to publisher rather than to a ConfigMap.
+func NewStatusSyncWithPublisher(factory session.ContainerFactory, localSources map[string][]store.Interface, publisher StatusPublisher) *StatusSync {
 
 	// TODO ignore local sources and use their stores
 	logger := slog.New(slog.Default().Handler()).With(
@@ -67,10 +81,9 @@ func NewStatusSync(factory session.ContainerFactory, localSources map[string][]s
 	discovery := eventsource.NewDiscovery(sessionCtr, eventsource.DiscoveryOptions{})
 
 	s := &StatusSync{
-		session:       sessionCtr,
-		discovery:     discovery,
-		configMapName: configMap,
-		client:        client,
+		session:   sessionCtr,
+		discovery: discovery,
+		publisher: publisher,
 
 		recordMapping: make(eventsource.RecordStoreMap, len(recordTypes)),
 		clients:       make(map[string]*eventsource.Client),
@@ -177,8 +190,10 @@ func (s *StatusSync) buildAndPublish(prev network.NetworkStatusInfo) (network.Ne
 		s.logger.Debug("no change since last publish")
 		return prev, nil
 	}
-	s.logger.Info("updating network status info", slog.String("configmap", s.configMapName))
-	return next, s.publish(next)
+	s.logger.Info("updating network status info")
+	ctx, cancel := context.WithTimeout(s.ctx, time.Second*10)
+	defer cancel()
+	return next, s.publisher.Publish(ctx, next)
 }
 
 func (s *StatusSync) build() network.NetworkStatusInfo {
@@ -306,9 +321,12 @@ func (s *StatusSync) build() network.NetworkStatusInfo {
 	return info
 }
 
-func (s *StatusSync) publish(info network.NetworkStatusInfo) error {
-	ctx, cancel := context.WithTimeout(s.ctx, time.Second*10)
-	defer cancel()
+type configMapPublisher struct {
+	client        v1.ConfigMapInterface
+	configMapName string
+}
+
+func (p *configMapPublisher) Publish(ctx context.Context, info network.NetworkStatusInfo) error {
 	bs, err := json.Marshal(info)
 	if err != nil {
 		return fmt.Errorf("failed to marshal network info: %s", err)
@@ -316,12 +334,12 @@ func (s *StatusSync) publish(info network.NetworkStatusInfo) error {
 	networkStatus := string(bs)
 	data := map[string]string{"NetworkStatus": networkStatus}
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		current, err := s.client.Get(ctx, s.configMapName, metav1.GetOptions{})
+		current, err := p.client.Get(ctx, p.configMapName, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
 		current.Data = data
-		_, err = s.client.Update(ctx, current, metav1.UpdateOptions{})
+		_, err = p.client.Update(ctx, current, metav1.UpdateOptions{})
 		return err
 	})
 	if err != nil {
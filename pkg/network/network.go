@@ -145,6 +145,38 @@ func (s *SkupperStatus) RemoveLinksFromSameSite(router RouterStatusInfo, site Si
 	return filteredLinks
 }
 
+// GetAddressesWithoutConnectors returns the addresses that have listeners
+// somewhere in the network but no connector to route the traffic to.
+func (s *SkupperStatus) GetAddressesWithoutConnectors() []AddressInfo {
+	var addresses []AddressInfo
+	for _, address := range s.NetworkStatus.Addresses {
+		if address.ListenerCount > 0 && address.ConnectorCount == 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// GetNonOperationalLinks returns, per site name, the links between sites
+// that the router does not report as up.
+func (s *SkupperStatus) GetNonOperationalLinks() map[string][]LinkInfo {
+	links := make(map[string][]LinkInfo)
+	for _, siteStatus := range s.NetworkStatus.SiteStatus {
+		for _, router := range siteStatus.RouterStatus {
+			for _, link := range s.RemoveLinksFromSameSite(router, siteStatus.Site) {
+				if !IsLinkOperational(link) {
+					links[siteStatus.Site.Name] = append(links[siteStatus.Site.Name], link)
+				}
+			}
+		}
+	}
+	return links
+}
+
+func IsLinkOperational(link LinkInfo) bool {
+	return strings.EqualFold(link.Status, "up")
+}
+
 func UnmarshalSkupperStatus(data map[string]string) (*NetworkStatusInfo, error) {
 
 	var networkStatusInfo *NetworkStatusInfo
@@ -161,3 +161,35 @@ func TestRemoveLinksFromSameSite(t *testing.T) {
 	assert.DeepEqual(t, result, []LinkInfo{link})
 
 }
+
+func TestGetAddressesWithoutConnectors(t *testing.T) {
+
+	skupperStatus := createTestSkupperStatus()
+
+	assert.Check(t, len(skupperStatus.GetAddressesWithoutConnectors()) == 0)
+
+	skupperStatus.NetworkStatus.Addresses = append(skupperStatus.NetworkStatus.Addresses,
+		AddressInfo{Name: "frontend:8080", ListenerCount: 1, ConnectorCount: 0},
+		AddressInfo{Name: "database:5432", ListenerCount: 0, ConnectorCount: 1})
+
+	results := skupperStatus.GetAddressesWithoutConnectors()
+	assert.Check(t, len(results) == 1)
+	assert.Equal(t, results[0].Name, "frontend:8080")
+}
+
+func TestGetNonOperationalLinks(t *testing.T) {
+
+	skupperStatus := createTestSkupperStatus()
+
+	// the test links carry no status, so none of them are reported as up
+	results := skupperStatus.GetNonOperationalLinks()
+	assert.Check(t, len(results) == 2)
+	assert.Check(t, len(results["public1"]) == 1)
+	assert.Check(t, len(results["public2"]) == 1)
+
+	skupperStatus.NetworkStatus.SiteStatus[0].RouterStatus[0].Links[0].Status = "up"
+	skupperStatus.NetworkStatus.SiteStatus[1].RouterStatus[0].Links[0].Status = "Up"
+
+	results = skupperStatus.GetNonOperationalLinks()
+	assert.Check(t, len(results) == 0)
+}
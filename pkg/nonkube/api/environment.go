@@ -21,6 +21,8 @@ const (
 	RuntimePath           InternalPath = "runtime"
 	RuntimeSiteStatePath  InternalPath = "runtime/resources"
 	RuntimeTokenPath      InternalPath = "runtime/links"
+	RuntimeGrantsPath     InternalPath = "runtime/grants"
+	NetworkStatusPath     InternalPath = "runtime/network"
 	LoadedSiteStatePath   InternalPath = "internal/snapshot"
 	ScriptsPath           InternalPath = "internal/scripts"
 )
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/x509"
+	encodingjson "encoding/json"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
 
@@ -13,7 +15,9 @@ import (
 	"github.com/skupperproject/skupper/pkg/utils"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes/scheme"
 )
 
@@ -44,6 +48,46 @@ func (t *Token) Marshal() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// UnmarshalToken parses the Secret and the Links of a token
+// produced by Marshal
+func UnmarshalToken(data []byte) (*Token, error) {
+	token := &Token{}
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 1024)
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		var typeMeta metav1.TypeMeta
+		if err := encodingjson.Unmarshal(raw.Raw, &typeMeta); err != nil {
+			return nil, err
+		}
+		switch typeMeta.Kind {
+		case "Secret":
+			token.Secret = &v1.Secret{}
+			if err := encodingjson.Unmarshal(raw.Raw, token.Secret); err != nil {
+				return nil, err
+			}
+		case "Link":
+			link := &v2alpha1.Link{}
+			if err := encodingjson.Unmarshal(raw.Raw, link); err != nil {
+				return nil, err
+			}
+			token.Links = append(token.Links, link)
+		}
+	}
+	if token.Secret == nil || len(token.Links) == 0 {
+		return nil, fmt.Errorf("token must contain a secret and at least one link")
+	}
+	return token, nil
+}
+
 func CreateTokens(routerAccess v2alpha1.RouterAccess, serverSecret v1.Secret, clientSecret v1.Secret) []*Token {
 	var tokens []*Token
 	interRouter := 0
@@ -162,6 +162,38 @@ func TestCreateTokens(t *testing.T) {
 	}
 }
 
+func TestUnmarshalToken(t *testing.T) {
+	ra := fakeRouterAccessInterRouterRole()
+	clientSecret := v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "link-fake-router-access",
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("fake-client-cert"),
+		},
+	}
+	tokens := CreateTokens(ra, fakeServerSecret([]string{"10.0.0.1"}), clientSecret)
+	assert.Assert(t, len(tokens) > 0)
+	data, err := tokens[0].Marshal()
+	assert.Assert(t, err)
+
+	token, err := UnmarshalToken(data)
+	assert.Assert(t, err)
+	assert.Equal(t, token.Secret.Name, "link-fake-router-access")
+	assert.DeepEqual(t, token.Secret.Data, clientSecret.Data)
+	assert.Equal(t, len(token.Links), 1)
+	assert.DeepEqual(t, token.Links[0].Spec, tokens[0].Links[0].Spec)
+
+	_, err = UnmarshalToken([]byte("---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: no-links\n"))
+	assert.ErrorContains(t, err, "token must contain a secret and at least one link")
+	_, err = UnmarshalToken([]byte("---\napiVersion: skupper.io/v2alpha1\nkind: Link\nmetadata:\n  name: no-secret\n"))
+	assert.ErrorContains(t, err, "token must contain a secret and at least one link")
+}
+
 func fakeRouterAccess() v2alpha1.RouterAccess {
 	var ra v2alpha1.RouterAccess
 	ra.Name = "fake-router-access"
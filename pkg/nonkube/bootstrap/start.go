@@ -18,13 +18,24 @@ func Start(namespace string) error {
 		return fmt.Errorf("failed to create container client: %v", err)
 	}
 
-	containerName := namespace + "-skupper-router"
-	if _, err := cli.ContainerInspect(containerName); err == nil {
-		err = cli.ContainerStart(containerName)
-		if err != nil {
-			return err
+	for _, containerName := range containerNames(namespace) {
+		if _, err := cli.ContainerInspect(containerName); err == nil {
+			err = cli.ContainerStart(containerName)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+// containerNames returns the names of the containers of a site, with
+// the router first.
+func containerNames(namespace string) []string {
+	return []string{
+		namespace + "-skupper-router",
+		namespace + "-skupper-collector",
+		namespace + "-skupper-grant-server",
+	}
+}
@@ -19,11 +19,12 @@ func Stop(namespace string) error {
 		return fmt.Errorf("failed to create container client: %v", err)
 	}
 
-	containerName := namespace + "-skupper-router"
-	if _, err := cli.ContainerInspect(containerName); err == nil {
-		err = cli.ContainerStop(containerName)
-		if err != nil {
-			return err
+	for _, containerName := range containerNames(namespace) {
+		if _, err := cli.ContainerInspect(containerName); err == nil {
+			err = cli.ContainerStop(containerName)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
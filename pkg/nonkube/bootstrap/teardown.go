@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/skupperproject/skupper/api/types"
 	internalclient "github.com/skupperproject/skupper/internal/nonkube/client/compat"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
@@ -64,11 +65,12 @@ func removeRouter(namespace string, platform string) error {
 		return fmt.Errorf("failed to create container client: %v", err)
 	}
 
-	containerName := namespace + "-skupper-router"
-	if _, err := cli.ContainerInspect(containerName); err == nil {
-		err = cli.ContainerRemove(containerName)
-		if err != nil {
-			return err
+	for _, containerName := range containerNames(namespace) {
+		if _, err := cli.ContainerInspect(containerName); err == nil {
+			err = cli.ContainerRemove(containerName)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -88,6 +90,23 @@ func removeService(namespace string, platform string) error {
 		return err
 	}
 
+	if platform == string(types.PlatformSystemd) {
+		grantServerService, err := common.NewSystemdGrantServerServiceInfo(siteState)
+		if err != nil {
+			return err
+		}
+		if err = grantServerService.Remove(); err != nil {
+			return err
+		}
+		collectorService, err := common.NewSystemdCollectorServiceInfo(siteState)
+		if err != nil {
+			return err
+		}
+		if err = collectorService.Remove(); err != nil {
+			return err
+		}
+	}
+
 	systemdService, err := common.NewSystemdServiceInfo(siteState, platform)
 	if err != nil {
 		return err
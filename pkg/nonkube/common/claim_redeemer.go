@@ -24,7 +24,7 @@ func RedeemClaims(siteState *api.SiteState) error {
 
 	logger := NewLogger()
 	for name, claim := range siteState.Claims {
-		err := redeemAccessToken(claim, siteState)
+		err := RedeemAccessToken(claim, siteState)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to redeem claim %s: %w", name, err))
 			logger.Error("RedeemClaims: failed to redeem claim",
@@ -41,8 +41,9 @@ func RedeemClaims(siteState *api.SiteState) error {
 	return nil
 }
 
-// Redeem logic that populates siteState.Secrets and siteState.Links
-func redeemAccessToken(claim *skupperv2alpha1.AccessToken, siteState *api.SiteState) error {
+// RedeemAccessToken populates siteState.Secrets and siteState.Links
+// with the credentials and links obtained from the grant server
+func RedeemAccessToken(claim *skupperv2alpha1.AccessToken, siteState *api.SiteState) error {
 	transport := &http.Transport{}
 	if claim.Spec.Ca != "" {
 		caPool := x509.NewCertPool()
@@ -85,6 +86,9 @@ func redeemAccessToken(claim *skupperv2alpha1.AccessToken, siteState *api.SiteSt
 	siteState.Secrets[decoder.secret.ObjectMeta.Name] = &decoder.secret
 
 	for _, link := range decoder.links {
+		if claim.Spec.LinkCost > 0 {
+			link.Spec.Cost = claim.Spec.LinkCost
+		}
 		siteState.Links[link.ObjectMeta.Name] = &link
 	}
 
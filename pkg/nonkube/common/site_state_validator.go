@@ -142,13 +142,17 @@ func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Li
 		if ip == nil && !validHostname {
 			return fmt.Errorf("invalid listener host: %s - a valid IP address or hostname is expected (listener: %q)", listener.Spec.Host, name)
 		}
-		if utils.IntSliceContains(hostPorts[listener.Spec.Host], listener.Spec.Port) {
+		if err := validateBridgeType(listener.Spec.Type, listener.Spec.TlsCredentials); err != nil {
+			return fmt.Errorf("invalid listener: %s - %w", listener.Name, err)
+		}
+		hostProtocol := listener.Spec.Host + "/" + string(listener.Protocol())
+		if utils.IntSliceContains(hostPorts[hostProtocol], listener.Spec.Port) {
 			return fmt.Errorf("port %d is already mapped for host %q (listener: %q)", listener.Spec.Port, listener.Spec.Host, name)
 		}
 		if listener.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for listener: %s", listener.Name)
 		}
-		hostPorts[listener.Spec.Host] = append(hostPorts[listener.Spec.Host], listener.Spec.Port)
+		hostPorts[hostProtocol] = append(hostPorts[hostProtocol], listener.Spec.Port)
 	}
 	return nil
 }
@@ -166,6 +170,9 @@ func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.
 		if ip == nil && !validHostname {
 			return fmt.Errorf("invalid connector host: %s - a valid IP address or hostname is expected (connector: %q)", connector.Spec.Host, connector.Name)
 		}
+		if err := validateBridgeType(connector.Spec.Type, connector.Spec.TlsCredentials); err != nil {
+			return fmt.Errorf("invalid connector: %s - %w", connector.Name, err)
+		}
 		if connector.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for connector: %s", connector.Name)
 		}
@@ -173,6 +180,20 @@ func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.
 	return nil
 }
 
+func validateBridgeType(bridgeType string, tlsCredentials string) error {
+	switch bridgeType {
+	case "", "tcp":
+		return nil
+	case "udp":
+		if tlsCredentials != "" {
+			return fmt.Errorf("tlsCredentials are not supported for type udp")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %q (expected tcp or udp)", bridgeType)
+	}
+}
+
 func ValidateName(name string) error {
 	if !rfc1123Regex.MatchString(name) {
 		return fmt.Errorf("invalid name %q: %s", name, rfc1123Error)
@@ -160,6 +160,39 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "is already mapped for host",
 		},
+		{
+			info: "valid-listener-udp-same-port-as-tcp",
+			siteState: customize(func(siteState *api.SiteState) {
+				for name, listener := range siteState.Listeners {
+					listener.Spec.Host = "1.2.3.4"
+					if name == "listener-two" {
+						listener.Spec.Type = "udp"
+						listener.Spec.TlsCredentials = ""
+					}
+				}
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-listener-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Type = "sctp"
+				}
+			}),
+			valid:         false,
+			errorContains: "unsupported type \"sctp\"",
+		},
+		{
+			info: "invalid-listener-udp-tls",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Type = "udp"
+				}
+			}),
+			valid:         false,
+			errorContains: "tlsCredentials are not supported for type udp",
+		},
 		{
 			info: "invalid-connector-name",
 			siteState: customize(func(siteState *api.SiteState) {
@@ -201,6 +234,26 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "invalid connector host: ",
 		},
+		{
+			info: "invalid-connector-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.Type = "sctp"
+				}
+			}),
+			valid:         false,
+			errorContains: "unsupported type \"sctp\"",
+		},
+		{
+			info: "invalid-connector-udp-tls",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, connector := range siteState.Connectors {
+					connector.Spec.Type = "udp"
+				}
+			}),
+			valid:         false,
+			errorContains: "tlsCredentials are not supported for type udp",
+		},
 		{
 			info: "invalid-claim-name",
 			siteState: customize(func(siteState *api.SiteState) {
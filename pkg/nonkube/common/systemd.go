@@ -21,6 +21,10 @@ var (
 	SystemdContainerServiceTemplate string
 	//go:embed systemd_service.template
 	SystemdServiceTemplate string
+	//go:embed systemd_collector_service.template
+	SystemdCollectorServiceTemplate string
+	//go:embed systemd_grant_server_service.template
+	SystemdGrantServerServiceTemplate string
 )
 
 const (
@@ -43,11 +47,15 @@ type systemdServiceInfo struct {
 	SiteScriptPath      string
 	SiteConfigPath      string
 	SiteHomePath        string
+	DataHome            string
 	RuntimeDir          string
+	SkupperPath         string
 	getUid              api.IdGetter
 	command             CommandExecutor
 	rootSystemdBasePath string
 	platform            string
+	collector           bool
+	grantServer         bool
 }
 
 func NewSystemdServiceInfo(siteState *api.SiteState, platform string) (SystemdService, error) {
@@ -65,6 +73,7 @@ func NewSystemdServiceInfo(siteState *api.SiteState, platform string) (SystemdSe
 		Namespace:           namespace,
 		SiteScriptPath:      siteScriptPath,
 		SiteConfigPath:      siteConfigPath,
+		DataHome:            api.GetHostDataHome(),
 		RuntimeDir:          api.GetRuntimeDir(),
 		getUid:              os.Getuid,
 		command:             exec.Command,
@@ -73,7 +82,61 @@ func NewSystemdServiceInfo(siteState *api.SiteState, platform string) (SystemdSe
 	}, nil
 }
 
+// NewSystemdCollectorServiceInfo returns the service that runs the
+// network status collector of a site whose router runs as a systemd
+// service. It is bound to the service of the router.
+func NewSystemdCollectorServiceInfo(siteState *api.SiteState) (SystemdService, error) {
+	service, err := NewSystemdServiceInfo(siteState, string(types.PlatformSystemd))
+	if err != nil {
+		return nil, err
+	}
+	collectorService := service.(*systemdServiceInfo)
+	collectorService.collector = true
+	collectorService.SkupperPath = skupperPath()
+	return collectorService, nil
+}
+
+// NewSystemdGrantServerServiceInfo returns the service that runs the
+// grant server of a site whose router runs as a systemd service, so
+// that the tokens issued for the site can be redeemed. It is bound to
+// the service of the router.
+func NewSystemdGrantServerServiceInfo(siteState *api.SiteState) (SystemdService, error) {
+	service, err := NewSystemdServiceInfo(siteState, string(types.PlatformSystemd))
+	if err != nil {
+		return nil, err
+	}
+	grantServerService := service.(*systemdServiceInfo)
+	grantServerService.grantServer = true
+	grantServerService.SkupperPath = skupperPath()
+	return grantServerService, nil
+}
+
+// skupperPath returns the absolute path of the running skupper
+// executable, which the collector and grant server services run, as
+// systemd does not search the user's PATH for it. Should the running
+// executable not be found, the one on the PATH is used.
+func skupperPath() string {
+	if executable, err := os.Executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+			return resolved
+		}
+		return executable
+	}
+	if executable, err := exec.LookPath("skupper"); err == nil {
+		if absolute, err := filepath.Abs(executable); err == nil {
+			return absolute
+		}
+	}
+	return "skupper"
+}
+
 func (s *systemdServiceInfo) GetServiceName() string {
+	if s.collector {
+		return fmt.Sprintf("skupper-collector-%s.service", s.Namespace)
+	}
+	if s.grantServer {
+		return fmt.Sprintf("skupper-grant-server-%s.service", s.Namespace)
+	}
 	return fmt.Sprintf("skupper-%s.service", s.Namespace)
 }
 
@@ -90,7 +153,11 @@ func (s *systemdServiceInfo) Create() error {
 	var buf = new(bytes.Buffer)
 	var service *template.Template
 	logger.Debug("using service template for:", slog.String("platform", s.platform))
-	if s.platform == string(types.PlatformSystemd) {
+	if s.collector {
+		service = template.Must(template.New(s.GetServiceName()).Parse(SystemdCollectorServiceTemplate))
+	} else if s.grantServer {
+		service = template.Must(template.New(s.GetServiceName()).Parse(SystemdGrantServerServiceTemplate))
+	} else if s.platform == string(types.PlatformSystemd) {
 		service = template.Must(template.New(s.GetServiceName()).Parse(SystemdServiceTemplate))
 	} else {
 		service = template.Must(template.New(s.GetServiceName()).Parse(SystemdContainerServiceTemplate))
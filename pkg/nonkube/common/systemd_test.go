@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -71,3 +72,66 @@ func TestSystemdService(t *testing.T) {
 		}
 	}
 }
+
+func TestSystemdCollectorService(t *testing.T) {
+	siteState := fakeSiteState()
+	outputPath := t.TempDir()
+	t.Setenv("SKUPPER_OUTPUT_PATH", outputPath)
+	t.Setenv("XDG_CONFIG_HOME", outputPath)
+
+	systemdService, err := NewSystemdCollectorServiceInfo(siteState)
+	assert.Assert(t, err)
+	assert.Equal(t, systemdService.GetServiceName(), "skupper-collector-default.service")
+	systemdServiceImpl := systemdService.(*systemdServiceInfo)
+	systemdServiceImpl.command = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("echo", "mock")
+	}
+	systemdServiceImpl.getUid = func() int {
+		return 1000
+	}
+	assert.Assert(t, systemdService.Create())
+	serviceFile, err := os.ReadFile(systemdServiceImpl.GetServiceFile())
+	assert.Assert(t, err)
+	executable, err := os.Executable()
+	assert.Assert(t, err)
+	executable, err = filepath.EvalSymlinks(executable)
+	assert.Assert(t, err)
+	assert.Assert(t, filepath.IsAbs(executable))
+	assert.Assert(t, strings.Contains(string(serviceFile), fmt.Sprintf("ExecStart=%s system collector --platform systemd --namespace default", executable)), string(serviceFile))
+	assert.Assert(t, strings.Contains(string(serviceFile), "BindsTo=skupper-default.service"), string(serviceFile))
+	assert.Assert(t, strings.Contains(string(serviceFile), fmt.Sprintf(`Environment="SKUPPER_OUTPUT_PATH=%s"`, outputPath)), string(serviceFile))
+	assert.Assert(t, systemdService.Remove())
+	_, err = os.ReadFile(systemdServiceImpl.GetServiceFile())
+	assert.Assert(t, err != nil)
+}
+
+func TestSystemdGrantServerService(t *testing.T) {
+	siteState := fakeSiteState()
+	outputPath := t.TempDir()
+	t.Setenv("SKUPPER_OUTPUT_PATH", outputPath)
+	t.Setenv("XDG_CONFIG_HOME", outputPath)
+
+	systemdService, err := NewSystemdGrantServerServiceInfo(siteState)
+	assert.Assert(t, err)
+	assert.Equal(t, systemdService.GetServiceName(), "skupper-grant-server-default.service")
+	systemdServiceImpl := systemdService.(*systemdServiceInfo)
+	systemdServiceImpl.command = func(name string, arg ...string) *exec.Cmd {
+		return exec.Command("echo", "mock")
+	}
+	systemdServiceImpl.getUid = func() int {
+		return 1000
+	}
+	assert.Assert(t, systemdService.Create())
+	serviceFile, err := os.ReadFile(systemdServiceImpl.GetServiceFile())
+	assert.Assert(t, err)
+	executable, err := os.Executable()
+	assert.Assert(t, err)
+	executable, err = filepath.EvalSymlinks(executable)
+	assert.Assert(t, err)
+	assert.Assert(t, strings.Contains(string(serviceFile), fmt.Sprintf("ExecStart=%s system grant-server --platform systemd --namespace default", executable)), string(serviceFile))
+	assert.Assert(t, strings.Contains(string(serviceFile), "BindsTo=skupper-default.service"), string(serviceFile))
+	assert.Assert(t, strings.Contains(string(serviceFile), fmt.Sprintf(`Environment="SKUPPER_OUTPUT_PATH=%s"`, outputPath)), string(serviceFile))
+	assert.Assert(t, systemdService.Remove())
+	_, err = os.ReadFile(systemdServiceImpl.GetServiceFile())
+	assert.Assert(t, err != nil)
+}
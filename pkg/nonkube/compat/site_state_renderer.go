@@ -235,6 +235,50 @@ func (s *SiteStateRenderer) prepareContainers() error {
 		//      validate whether CPU and memory thresholds can be
 		//      set to the container
 	}
+	// the collector keeps the network status under the namespace home,
+	// which it sees through SKUPPER_OUTPUT_PATH as the cli does
+	s.containers[types.CollectorComponent] = container.Container{
+		Name:    fmt.Sprintf("%s-skupper-collector", s.siteState.GetNamespace()),
+		Image:   images.GetCliImageName(),
+		Command: []string{"system", "collector", "--platform", s.configRenderer.Platform, "--namespace", s.siteState.GetNamespace()},
+		Env: map[string]string{
+			"SKUPPER_OUTPUT_PATH": "/output",
+		},
+		Labels: map[string]string{
+			types.ComponentAnnotation: types.CollectorComponent,
+			types.SiteId:              s.configRenderer.RouterConfig.GetSiteMetadata().Id,
+		},
+		FileMounts: []container.FileMount{
+			{
+				Source:      siteConfigPath,
+				Destination: path.Join("/output", "namespaces", s.siteState.GetNamespace()),
+				Options:     []string{"z"},
+			},
+		},
+		RestartPolicy: "always",
+	}
+	// the grant server redeems the tokens issued for the site, reading the
+	// AccessGrants and certificates from the namespace home as well
+	s.containers[types.GrantServerComponent] = container.Container{
+		Name:    fmt.Sprintf("%s-skupper-grant-server", s.siteState.GetNamespace()),
+		Image:   images.GetCliImageName(),
+		Command: []string{"system", "grant-server", "--platform", s.configRenderer.Platform, "--namespace", s.siteState.GetNamespace()},
+		Env: map[string]string{
+			"SKUPPER_OUTPUT_PATH": "/output",
+		},
+		Labels: map[string]string{
+			types.ComponentAnnotation: types.GrantServerComponent,
+			types.SiteId:              s.configRenderer.RouterConfig.GetSiteMetadata().Id,
+		},
+		FileMounts: []container.FileMount{
+			{
+				Source:      siteConfigPath,
+				Destination: path.Join("/output", "namespaces", s.siteState.GetNamespace()),
+				Options:     []string{"z"},
+			},
+		},
+		RestartPolicy: "always",
+	}
 	logger := common.NewLogger()
 	if logger.Enabled(nil, slog.LevelDebug) {
 		for name, newContainer := range s.containers {
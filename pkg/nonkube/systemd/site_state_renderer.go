@@ -126,6 +126,20 @@ func (s *SiteStateRenderer) createSystemdService() error {
 	if err = systemd.Create(); err != nil {
 		return fmt.Errorf("unable to create startup service %q - %v\n", systemd.GetServiceName(), err)
 	}
+	collector, err := common.NewSystemdCollectorServiceInfo(s.siteState)
+	if err != nil {
+		return err
+	}
+	if err = collector.Create(); err != nil {
+		return fmt.Errorf("unable to create collector service %q - %v\n", collector.GetServiceName(), err)
+	}
+	grantServer, err := common.NewSystemdGrantServerServiceInfo(s.siteState)
+	if err != nil {
+		return err
+	}
+	if err = grantServer.Create(); err != nil {
+		return fmt.Errorf("unable to create grant server service %q - %v\n", grantServer.GetServiceName(), err)
+	}
 
 	// Validate if lingering is enabled for current user
 	if !api.IsRunningInContainer() {
@@ -139,7 +153,22 @@ func (s *SiteStateRenderer) createSystemdService() error {
 }
 
 func (s *SiteStateRenderer) removeSystemdService() error {
-	// Removing systemd user service
+	// Removing systemd user services, starting with the grant server
+	// and the collector
+	grantServer, err := common.NewSystemdGrantServerServiceInfo(s.loadedSiteState)
+	if err != nil {
+		return err
+	}
+	if err = grantServer.Remove(); err != nil {
+		return fmt.Errorf("unable to remove grant server service %q - %v\n", grantServer.GetServiceName(), err)
+	}
+	collector, err := common.NewSystemdCollectorServiceInfo(s.loadedSiteState)
+	if err != nil {
+		return err
+	}
+	if err = collector.Remove(); err != nil {
+		return fmt.Errorf("unable to remove collector service %q - %v\n", collector.GetServiceName(), err)
+	}
 	systemd, err := common.NewSystemdServiceInfo(s.loadedSiteState, string(types.PlatformSystemd))
 	if err != nil {
 		return err
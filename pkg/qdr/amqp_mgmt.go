@@ -136,6 +136,17 @@ func asTcpEndpoint(record Record) TcpEndpoint {
 	return endpoint
 }
 
+func asUdpEndpoint(record Record) UdpEndpoint {
+	return UdpEndpoint{
+		Name:      record.AsString("name"),
+		Host:      record.AsString("host"),
+		Port:      record.AsString("port"),
+		Address:   record.AsString("address"),
+		SiteId:    record.AsString("siteId"),
+		ProcessID: record.AsString("processId"),
+	}
+}
+
 func asConnection(record Record) Connection {
 	return Connection{
 		Role:       record.AsString("role"),
@@ -367,6 +378,11 @@ func (a *Agent) Create(typename string, name string, attributes map[string]inter
 	return a.request("CREATE", typename, name, &attributes)
 }
 
+func (a *Agent) Update(typename string, name string, attributes map[string]interface{}) error {
+	log.Println("UPDATE", typename, name, attributes)
+	return a.request("UPDATE", typename, name, &attributes)
+}
+
 func (a *Agent) Delete(typename string, name string) error {
 	if name == "" {
 		return fmt.Errorf("Cannot delete entity of type %s with no name", typename)
@@ -758,6 +774,8 @@ func getBridgeTypes() []string {
 	return []string{
 		"io.skupper.router.tcpConnector",
 		"io.skupper.router.tcpListener",
+		"io.skupper.router.udpConnector",
+		"io.skupper.router.udpListener",
 		"io.skupper.router.httpConnector",
 		"io.skupper.router.httpListener",
 	}
@@ -862,6 +880,22 @@ func (a *Agent) GetLocalBridgeConfig() (*BridgeConfig, error) {
 		config.AddTcpListener(asTcpEndpoint(record))
 	}
 
+	results, err = a.Query("io.skupper.router.udpConnector", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpConnector(asUdpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.udpListener", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpListener(asUdpEndpoint(record))
+	}
+
 	return &config, nil
 }
 
@@ -892,6 +926,34 @@ func (a *Agent) UpdateLocalBridgeConfig(changes *BridgeConfigDifference) error {
 			return fmt.Errorf("Error adding tcp listeners: %s", err)
 		}
 	}
+	for _, deleted := range changes.UdpConnectors.Deleted {
+		if err := a.Delete("io.skupper.router.udpConnector", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp connectors: %s", err)
+		}
+	}
+	for _, deleted := range changes.UdpListeners.Deleted {
+		if err := a.Delete("io.skupper.router.udpListener", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp listeners: %s", err)
+		}
+	}
+	for _, added := range changes.UdpConnectors.Added {
+		record := map[string]interface{}{}
+		if err := convert(added, &record); err != nil {
+			return fmt.Errorf("Failed to convert record: %s", err)
+		}
+		if err := a.Create("io.skupper.router.udpConnector", added.Name, record); err != nil {
+			return fmt.Errorf("Error adding udp connectors: %s", err)
+		}
+	}
+	for _, added := range changes.UdpListeners.Added {
+		record := map[string]interface{}{}
+		if err := convert(added, &record); err != nil {
+			return fmt.Errorf("Failed to convert record: %s", err)
+		}
+		if err := a.Create("io.skupper.router.udpListener", added.Name, record); err != nil {
+			return fmt.Errorf("Error adding udp listeners: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -915,6 +977,20 @@ func (a *Agent) GetBridges(routers []Router) ([]BridgeConfig, error) {
 		for _, record := range results {
 			config.AddTcpListener(asTcpEndpoint(record))
 		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpConnector", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpConnector(asUdpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpListener", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpListener(asUdpEndpoint(record))
+		}
 
 		configs = append(configs, config)
 	}
@@ -1129,10 +1205,12 @@ func asListener(record Record) Listener {
 
 func asSslProfile(record Record) SslProfile {
 	return SslProfile{
-		Name:           record.AsString("name"),
-		CertFile:       record.AsString("certFile"),
-		PrivateKeyFile: record.AsString("privateKeyFile"),
-		CaCertFile:     record.AsString("caCertFile"),
+		Name:               record.AsString("name"),
+		CertFile:           record.AsString("certFile"),
+		PrivateKeyFile:     record.AsString("privateKeyFile"),
+		CaCertFile:         record.AsString("caCertFile"),
+		Ordinal:            record.AsInt("ordinal"),
+		OldestValidOrdinal: record.AsInt("oldestValidOrdinal"),
 	}
 }
 
@@ -1405,6 +1483,21 @@ func (a *Agent) CreateSslProfile(profile SslProfile) error {
 	return nil
 }
 
+// UpdateSslProfile sets the ordinal of an existing ssl profile. An
+// increase in the ordinal causes the router to reload the files the
+// profile refers to for new connections. Existing connections are
+// only closed if their ordinal is below the oldest valid ordinal.
+func (a *Agent) UpdateSslProfile(profile SslProfile) error {
+	record := map[string]interface{}{
+		"ordinal":            profile.Ordinal,
+		"oldestValidOrdinal": profile.OldestValidOrdinal,
+	}
+	if err := a.Update("io.skupper.router.sslProfile", profile.Name, record); err != nil {
+		return fmt.Errorf("Error updating SSL Profile: %s", err)
+	}
+	return nil
+}
+
 func ConnectedSitesInfo(selfId string, routers []Router) types.TransportConnectedSites {
 	var connectedSites types.TransportConnectedSites
 	var self *Router
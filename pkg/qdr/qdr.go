@@ -33,10 +33,13 @@ type RouterConfigHandler interface {
 }
 
 type TcpEndpointMap map[string]TcpEndpoint
+type UdpEndpointMap map[string]UdpEndpoint
 
 type BridgeConfig struct {
 	TcpListeners  TcpEndpointMap
 	TcpConnectors TcpEndpointMap
+	UdpListeners  UdpEndpointMap
+	UdpConnectors UdpEndpointMap
 }
 
 func InitialConfig(id string, siteId string, version string, edge bool, helloAge int) RouterConfig {
@@ -51,10 +54,7 @@ func InitialConfig(id string, siteId string, version string, edge bool, helloAge
 		Listeners:   map[string]Listener{},
 		Connectors:  map[string]Connector{},
 		LogConfig:   map[string]LogConfig{},
-		Bridges: BridgeConfig{
-			TcpListeners:  map[string]TcpEndpoint{},
-			TcpConnectors: map[string]TcpEndpoint{},
-		},
+		Bridges:     NewBridgeConfig(),
 	}
 	if edge {
 		config.Metadata.Mode = ModeEdge
@@ -80,6 +80,8 @@ func NewBridgeConfig() BridgeConfig {
 	return BridgeConfig{
 		TcpListeners:  map[string]TcpEndpoint{},
 		TcpConnectors: map[string]TcpEndpoint{},
+		UdpListeners:  map[string]UdpEndpoint{},
+		UdpConnectors: map[string]UdpEndpoint{},
 	}
 }
 
@@ -91,6 +93,12 @@ func NewBridgeConfigCopy(src BridgeConfig) BridgeConfig {
 	for k, v := range src.TcpConnectors {
 		newBridges.TcpConnectors[k] = v
 	}
+	for k, v := range src.UdpListeners {
+		newBridges.UdpListeners[k] = v
+	}
+	for k, v := range src.UdpConnectors {
+		newBridges.UdpConnectors[k] = v
+	}
 	return newBridges
 }
 
@@ -222,6 +230,22 @@ func (r *RouterConfig) RemoveTcpListener(name string) (bool, TcpEndpoint) {
 	return r.Bridges.RemoveTcpListener(name)
 }
 
+func (r *RouterConfig) AddUdpConnector(e UdpEndpoint) {
+	r.Bridges.AddUdpConnector(e)
+}
+
+func (r *RouterConfig) RemoveUdpConnector(name string) (bool, UdpEndpoint) {
+	return r.Bridges.RemoveUdpConnector(name)
+}
+
+func (r *RouterConfig) AddUdpListener(e UdpEndpoint) {
+	r.Bridges.AddUdpListener(e)
+}
+
+func (r *RouterConfig) RemoveUdpListener(name string) (bool, UdpEndpoint) {
+	return r.Bridges.RemoveUdpListener(name)
+}
+
 func (r *RouterConfig) UpdateBridgeConfig(desired BridgeConfig) bool {
 	if reflect.DeepEqual(r.Bridges, desired) {
 		return false
@@ -267,6 +291,34 @@ func (bc *BridgeConfig) RemoveTcpListener(name string) (bool, TcpEndpoint) {
 	}
 }
 
+func (bc *BridgeConfig) AddUdpConnector(e UdpEndpoint) {
+	bc.UdpConnectors[e.Name] = e
+}
+
+func (bc *BridgeConfig) RemoveUdpConnector(name string) (bool, UdpEndpoint) {
+	uc, ok := bc.UdpConnectors[name]
+	if ok {
+		delete(bc.UdpConnectors, name)
+		return true, uc
+	} else {
+		return false, UdpEndpoint{}
+	}
+}
+
+func (bc *BridgeConfig) AddUdpListener(e UdpEndpoint) {
+	bc.UdpListeners[e.Name] = e
+}
+
+func (bc *BridgeConfig) RemoveUdpListener(name string) (bool, UdpEndpoint) {
+	ul, ok := bc.UdpListeners[name]
+	if ok {
+		delete(bc.UdpListeners, name)
+		return true, ul
+	} else {
+		return false, UdpEndpoint{}
+	}
+}
+
 func GetTcpConnectors(bridges []BridgeConfig) []TcpEndpoint {
 	connectors := []TcpEndpoint{}
 	for _, bridge := range bridges {
@@ -371,10 +423,12 @@ type RouterMetadata struct {
 }
 
 type SslProfile struct {
-	Name           string `json:"name,omitempty"`
-	CertFile       string `json:"certFile,omitempty"`
-	PrivateKeyFile string `json:"privateKeyFile,omitempty"`
-	CaCertFile     string `json:"caCertFile,omitempty"`
+	Name               string `json:"name,omitempty"`
+	CertFile           string `json:"certFile,omitempty"`
+	PrivateKeyFile     string `json:"privateKeyFile,omitempty"`
+	CaCertFile         string `json:"caCertFile,omitempty"`
+	Ordinal            int    `json:"ordinal,omitempty"`
+	OldestValidOrdinal int    `json:"oldestValidOrdinal,omitempty"`
 }
 
 type LogConfig struct {
@@ -456,6 +510,15 @@ type TcpEndpoint struct {
 	ProcessID      string `json:"processId,omitempty"`
 }
 
+type UdpEndpoint struct {
+	Name      string `json:"name,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Address   string `json:"address,omitempty"`
+	SiteId    string `json:"siteId,omitempty"`
+	ProcessID string `json:"processId,omitempty"`
+}
+
 type SiteConfig struct {
 	Name      string `json:"name,omitempty"`
 	Location  string `json:"location,omitempty"`
@@ -496,10 +559,7 @@ func UnmarshalRouterConfig(config string) (RouterConfig, error) {
 		Listeners:   map[string]Listener{},
 		Connectors:  map[string]Connector{},
 		LogConfig:   map[string]LogConfig{},
-		Bridges: BridgeConfig{
-			TcpListeners:  map[string]TcpEndpoint{},
-			TcpConnectors: map[string]TcpEndpoint{},
-		},
+		Bridges:     NewBridgeConfig(),
 	}
 	var obj interface{}
 	err := json.Unmarshal([]byte(config), &obj)
@@ -583,6 +643,20 @@ func UnmarshalRouterConfig(config string) (RouterConfig, error) {
 				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
 			}
 			result.Bridges.TcpListeners[listener.Name] = listener
+		case "udpConnector":
+			connector := UdpEndpoint{}
+			err = convert(element[1], &connector)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.UdpConnectors[connector.Name] = connector
+		case "udpListener":
+			listener := UdpEndpoint{}
+			err = convert(element[1], &listener)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.UdpListeners[listener.Name] = listener
 		default:
 		}
 	}
@@ -638,6 +712,20 @@ func MarshalRouterConfig(config RouterConfig) (string, error) {
 		}
 		elements = append(elements, tuple)
 	}
+	for _, e := range config.Bridges.UdpConnectors {
+		tuple := []interface{}{
+			"udpConnector",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
+	for _, e := range config.Bridges.UdpListeners {
+		tuple := []interface{}{
+			"udpListener",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
 	for _, e := range config.LogConfig {
 		tuple := []interface{}{
 			"log",
@@ -770,9 +858,16 @@ type TcpEndpointDifference struct {
 	Added   []TcpEndpoint
 }
 
+type UdpEndpointDifference struct {
+	Deleted []string
+	Added   []UdpEndpoint
+}
+
 type BridgeConfigDifference struct {
 	TcpListeners       TcpEndpointDifference
 	TcpConnectors      TcpEndpointDifference
+	UdpListeners       UdpEndpointDifference
+	UdpConnectors      UdpEndpointDifference
 	AddedSslProfiles   []string
 	DeletedSSlProfiles []string
 }
@@ -832,10 +927,40 @@ func (a TcpEndpointMap) Difference(b TcpEndpointMap) TcpEndpointDifference {
 	return result
 }
 
+func (a UdpEndpoint) Equivalent(b UdpEndpoint) bool {
+	if !equivalentHost(a.Host, b.Host) || a.Port != b.Port || a.Address != b.Address ||
+		a.SiteId != b.SiteId || a.ProcessID != b.ProcessID {
+		return false
+	}
+	return true
+}
+
+func (a UdpEndpointMap) Difference(b UdpEndpointMap) UdpEndpointDifference {
+	result := UdpEndpointDifference{}
+	for key, v1 := range b {
+		v2, ok := a[key]
+		if !ok {
+			result.Added = append(result.Added, v1)
+		} else if !v1.Equivalent(v2) {
+			result.Deleted = append(result.Deleted, v1.Name)
+			result.Added = append(result.Added, v1)
+		}
+	}
+	for key, v1 := range a {
+		_, ok := b[key]
+		if !ok {
+			result.Deleted = append(result.Deleted, v1.Name)
+		}
+	}
+	return result
+}
+
 func (a *BridgeConfig) Difference(b *BridgeConfig) *BridgeConfigDifference {
 	result := BridgeConfigDifference{
 		TcpConnectors: a.TcpConnectors.Difference(b.TcpConnectors),
 		TcpListeners:  a.TcpListeners.Difference(b.TcpListeners),
+		UdpConnectors: a.UdpConnectors.Difference(b.UdpConnectors),
+		UdpListeners:  a.UdpListeners.Difference(b.UdpListeners),
 	}
 
 	result.AddedSslProfiles, result.DeletedSSlProfiles = getSslProfilesDifference(a, b)
@@ -896,13 +1021,19 @@ func (a *TcpEndpointDifference) Empty() bool {
 	return len(a.Deleted) == 0 && len(a.Added) == 0
 }
 
+func (a *UdpEndpointDifference) Empty() bool {
+	return len(a.Deleted) == 0 && len(a.Added) == 0
+}
+
 func (a *BridgeConfigDifference) Empty() bool {
-	return a.TcpConnectors.Empty() && a.TcpListeners.Empty()
+	return a.TcpConnectors.Empty() && a.TcpListeners.Empty() && a.UdpConnectors.Empty() && a.UdpListeners.Empty()
 }
 
 func (a *BridgeConfigDifference) Print() {
 	log.Printf("TcpConnectors added=%v, deleted=%v", a.TcpConnectors.Added, a.TcpConnectors.Deleted)
 	log.Printf("TcpListeners added=%v, deleted=%v", a.TcpListeners.Added, a.TcpListeners.Deleted)
+	log.Printf("UdpConnectors added=%v, deleted=%v", a.UdpConnectors.Added, a.UdpConnectors.Deleted)
+	log.Printf("UdpListeners added=%v, deleted=%v", a.UdpListeners.Added, a.UdpListeners.Deleted)
 	log.Printf("SslProfiles added=%v, deleted=%v", a.AddedSslProfiles, a.DeletedSSlProfiles)
 }
 
@@ -252,6 +252,24 @@ func TestMarshalUnmarshalRouterConfig(t *testing.T) {
 					SiteId:  "def",
 				},
 			},
+			UdpConnectors: map[string]UdpEndpoint{
+				"u1": UdpEndpoint{
+					Name:    "u1",
+					Address: "dns",
+					Host:    "resolver.com",
+					Port:    "53",
+					SiteId:  "abc",
+				},
+			},
+			UdpListeners: map[string]UdpEndpoint{
+				"u2": UdpEndpoint{
+					Name:    "u2",
+					Address: "syslog",
+					Host:    "0.0.0.0",
+					Port:    "514",
+					SiteId:  "def",
+				},
+			},
 		},
 		Addresses: map[string]Address{
 			"happy": Address{
@@ -382,6 +400,20 @@ func TestUnmarshalErrorInvalidTcpListenerValue(t *testing.T) {
 	}
 }
 
+func TestUnmarshalErrorInvalidUdpConnectorValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["udpConnector", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid udpconnector value")
+	}
+}
+
+func TestUnmarshalErrorInvalidUdpListenerValue(t *testing.T) {
+	_, err := UnmarshalRouterConfig(`[["udpListener", ["wrong"]]]`)
+	if err == nil {
+		t.Errorf("Expected error for invalid udplistener value")
+	}
+}
+
 func TestUnmarshalErrorInvalidLogValue(t *testing.T) {
 	_, err := UnmarshalRouterConfig(`[["log", ["wrong"]]]`)
 	if err == nil {
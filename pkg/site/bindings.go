@@ -162,10 +162,7 @@ func (b *Bindings) deleteListener(name string) qdr.ConfigUpdate {
 }
 
 func (b *Bindings) ToBridgeConfig() qdr.BridgeConfig {
-	config := qdr.BridgeConfig{
-		TcpListeners:  qdr.TcpEndpointMap{},
-		TcpConnectors: qdr.TcpEndpointMap{},
-	}
+	config := qdr.NewBridgeConfig()
 	for _, c := range b.connectors {
 		b.configure.connector(b.SiteId, c, &config)
 	}
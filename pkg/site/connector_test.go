@@ -20,6 +20,7 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 		args               args
 		expectedTcpAdded   int
 		expectedTcpDeleted int
+		expectedUdpAdded   int
 	}{
 		{
 			name: "no spec type",
@@ -63,6 +64,28 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			expectedTcpAdded:   1,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "udp spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "dns",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "dns:53",
+						Host:       "10.10.10.1",
+						Port:       53,
+						Type:       "udp",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedUdpAdded:   1,
+		},
 		{
 			name: "bad spec type",
 			args: args{
@@ -92,6 +115,7 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			result := tt.args.config.Difference(&configToUpdate)
 			assert.Assert(t, len(result.TcpConnectors.Added) == tt.expectedTcpAdded)
 			assert.Assert(t, len(result.TcpConnectors.Deleted) == tt.expectedTcpDeleted)
+			assert.Assert(t, len(result.UdpConnectors.Added) == tt.expectedUdpAdded)
 		})
 	}
 }
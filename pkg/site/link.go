@@ -1,7 +1,10 @@
 package site
 
 import (
+	"cmp"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -21,6 +24,13 @@ func NewLink(name string, profilePath string) *Link {
 	}
 }
 
+// Apply adds a connector for the lowest cost of the link's endpoints
+// that have the role the router requires, named after the link. Any
+// other endpoints marked as standby get a connector too, suffixed with
+// its position, which is kept open alongside the first so that the
+// router fails over to the next lowest cost connection when one is
+// lost. Unless an endpoint specifies its own cost, each successive
+// endpoint costs one more than the last.
 func (l *Link) Apply(current *qdr.RouterConfig) bool {
 	if l.definition == nil {
 		return false
@@ -29,24 +39,111 @@ func (l *Link) Apply(current *qdr.RouterConfig) bool {
 	if current.IsEdge() {
 		role = qdr.RoleEdge
 	}
-	endpoint, ok := l.definition.Spec.GetEndpointForRole(string(role))
-	if !ok {
+	connectors, _ := l.connectors(role)
+	if len(connectors) == 0 {
 		return false
 	}
 	profileName := sslProfileName(l.definition)
-	connector := qdr.Connector{
-		Name:       l.name,
-		Cost:       int32(l.definition.Spec.Cost),
-		SslProfile: profileName,
-		Role:       role,
-		Host:       endpoint.Host,
-		Port:       endpoint.Port,
-	}
-	current.AddConnector(connector)
+	for _, connector := range connectors {
+		current.AddConnector(connector)
+	}
+	for name := range current.Connectors {
+		if linkName, index := LinkForConnector(name); linkName == l.name && index >= len(connectors) {
+			current.RemoveConnector(name)
+		}
+	}
 	current.AddSslProfile(qdr.ConfigureSslProfile(profileName, l.profilePath, true))
 	return true //TODO: optimise by indicating if no change was actually needed
 }
 
+// connectors returns the connectors for the link's endpoints with the
+// given role, in order of cost, along with the endpoint each connects
+// to.
+func (l *Link) connectors(role qdr.Role) ([]qdr.Connector, []skupperv2alpha1.Endpoint) {
+	type candidate struct {
+		endpoint skupperv2alpha1.Endpoint
+		cost     int
+	}
+	var candidates []candidate
+	for i, endpoint := range l.definition.Spec.GetEndpointsForRole(string(role)) {
+		candidates = append(candidates, candidate{
+			endpoint: endpoint,
+			cost:     endpointCost(l.definition.Spec.Cost, endpoint, i),
+		})
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.cost, b.cost)
+	})
+	var connectors []qdr.Connector
+	var endpoints []skupperv2alpha1.Endpoint
+	profileName := sslProfileName(l.definition)
+	for _, c := range candidates {
+		if len(connectors) > 0 && !c.endpoint.Standby {
+			continue
+		}
+		connectors = append(connectors, qdr.Connector{
+			Name:       connectorName(l.name, len(connectors)),
+			Cost:       int32(c.cost),
+			SslProfile: profileName,
+			Role:       role,
+			Host:       c.endpoint.Host,
+			Port:       c.endpoint.Port,
+		})
+		endpoints = append(endpoints, c.endpoint)
+	}
+	return connectors, endpoints
+}
+
+// ActiveEndpoint returns the endpoint the router is expected to be
+// using for the link, i.e. the lowest cost of those it is configured to
+// connect to for which connected returns true. The connector name is
+// passed along with the endpoint so that callers can match on either.
+func (l *Link) ActiveEndpoint(edge bool, connected func(connector string, endpoint skupperv2alpha1.Endpoint) bool) (skupperv2alpha1.Endpoint, bool) {
+	if l.definition == nil {
+		return skupperv2alpha1.Endpoint{}, false
+	}
+	role := qdr.RoleInterRouter
+	if edge {
+		role = qdr.RoleEdge
+	}
+	connectors, endpoints := l.connectors(role)
+	for i, connector := range connectors {
+		if connected(connector.Name, endpoints[i]) {
+			return endpoints[i], true
+		}
+	}
+	return skupperv2alpha1.Endpoint{}, false
+}
+
+func endpointCost(linkCost int, endpoint skupperv2alpha1.Endpoint, index int) int {
+	if endpoint.Cost > 0 {
+		return endpoint.Cost
+	}
+	if index == 0 {
+		return linkCost
+	}
+	return max(linkCost, 1) + index
+}
+
+func connectorName(link string, index int) string {
+	if index == 0 {
+		return link
+	}
+	return link + "/" + strconv.Itoa(index+1)
+}
+
+// LinkForConnector returns the name of the link for which a connector
+// was configured, along with its position within the connectors
+// configured for that link.
+func LinkForConnector(name string) (string, int) {
+	if i := strings.LastIndex(name, "/"); i > 0 {
+		if position, err := strconv.Atoi(name[i+1:]); err == nil && position > 1 {
+			return name[:i], position - 1
+		}
+	}
+	return name, 0
+}
+
 func sslProfileName(link *skupperv2alpha1.Link) string {
 	return link.Spec.TlsCredentials + "-profile"
 }
@@ -59,7 +156,8 @@ func (m LinkMap) Apply(current *qdr.RouterConfig) bool {
 	}
 	for _, connector := range current.Connectors {
 		if !strings.HasPrefix(connector.Name, "auto-mesh") {
-			if _, ok := m[connector.Name]; !ok {
+			linkName, _ := LinkForConnector(connector.Name)
+			if _, ok := m[linkName]; !ok {
 				current.RemoveConnector(connector.Name)
 				current.RemoveSslProfile(connector.SslProfile)
 			}
@@ -82,15 +180,23 @@ type RemoveConnector struct {
 	name string
 }
 
+// Apply removes the connector with the given name and any others
+// configured for further endpoints of the same link.
 func (o *RemoveConnector) Apply(current *qdr.RouterConfig) bool {
-	if changed, connector := current.RemoveConnector(o.name); changed {
-		unreferenced := current.UnreferencedSslProfiles()
-		if _, ok := unreferenced[connector.SslProfile]; ok {
-			current.RemoveSslProfile(connector.SslProfile)
+	changed := false
+	for name := range current.Connectors {
+		if linkName, _ := LinkForConnector(name); name != o.name && linkName != o.name {
+			continue
+		}
+		if removed, connector := current.RemoveConnector(name); removed {
+			unreferenced := current.UnreferencedSslProfiles()
+			if _, ok := unreferenced[connector.SslProfile]; ok {
+				current.RemoveSslProfile(connector.SslProfile)
+			}
+			changed = true
 		}
-		return true
 	}
-	return false
+	return changed
 }
 
 func NewRemoveConnector(name string) qdr.ConfigUpdate {
@@ -2,6 +2,7 @@ package site
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -125,6 +126,133 @@ func TestLink_Apply(t *testing.T) {
 	}
 }
 
+func multiEndpointLink(cost int) *skupperv2alpha1.Link {
+	return &skupperv2alpha1.Link{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.LinkSpec{
+			TlsCredentials: "remote",
+			Cost:           cost,
+			Endpoints: []skupperv2alpha1.Endpoint{
+				{Name: string(qdr.RoleInterRouter), Host: "lb.example.com", Port: "55671"},
+				{Name: string(qdr.RoleEdge), Host: "lb.example.com", Port: "45671"},
+				{Name: string(qdr.RoleInterRouter), Host: "route.example.com", Port: "443", Standby: true},
+				{Name: string(qdr.RoleInterRouter), Host: "backup.example.com", Port: "443", Cost: 20, Standby: true},
+			},
+		},
+	}
+}
+
+func TestLink_ApplyMultipleEndpoints(t *testing.T) {
+	config := qdr.InitialConfig("router-1", "site-1", "v2.0", false, 10)
+	l := NewLink("remote", "/etc/skupper-router-certs")
+	l.Update(multiEndpointLink(5))
+	assert.Assert(t, l.Apply(&config))
+	assert.Equal(t, len(config.Connectors), 3)
+	expected := []qdr.Connector{
+		{Name: "remote", Role: qdr.RoleInterRouter, Host: "lb.example.com", Port: "55671", Cost: 5, SslProfile: "remote-profile"},
+		{Name: "remote/2", Role: qdr.RoleInterRouter, Host: "route.example.com", Port: "443", Cost: 6, SslProfile: "remote-profile"},
+		{Name: "remote/3", Role: qdr.RoleInterRouter, Host: "backup.example.com", Port: "443", Cost: 20, SslProfile: "remote-profile"},
+	}
+	for _, connector := range expected {
+		assert.DeepEqual(t, config.Connectors[connector.Name], connector)
+	}
+
+	// endpoints that are not marked as standby are not connected to
+	// unless they are the lowest cost
+	noStandby := multiEndpointLink(5)
+	noStandby.Spec.Endpoints[2].Standby = false
+	l.Update(noStandby)
+	assert.Assert(t, l.Apply(&config))
+	assert.Equal(t, len(config.Connectors), 2)
+	assert.Equal(t, config.Connectors["remote/2"].Host, "backup.example.com")
+	noStandby.Spec.Endpoints[3].Standby = false
+	noStandby.Spec.Endpoints[3].Cost = 1
+	l.Update(noStandby)
+	assert.Assert(t, l.Apply(&config))
+	assert.Equal(t, len(config.Connectors), 1)
+	assert.DeepEqual(t, config.Connectors["remote"], qdr.Connector{Name: "remote", Role: qdr.RoleInterRouter, Host: "backup.example.com", Port: "443", Cost: 1, SslProfile: "remote-profile"})
+
+	// dropping endpoints removes the connectors that were created for them
+	reduced := multiEndpointLink(0)
+	reduced.Spec.Endpoints = reduced.Spec.Endpoints[:3]
+	l.Update(reduced)
+	assert.Assert(t, l.Apply(&config))
+	assert.Equal(t, len(config.Connectors), 2)
+	assert.Equal(t, config.Connectors["remote"].Cost, int32(0))
+	assert.Equal(t, config.Connectors["remote/2"].Cost, int32(2))
+
+	// an edge router only uses edge endpoints
+	edgeConfig := qdr.InitialConfig("router-1", "site-1", "v2.0", true, 10)
+	assert.Assert(t, l.Apply(&edgeConfig))
+	assert.Equal(t, len(edgeConfig.Connectors), 1)
+	assert.Equal(t, edgeConfig.Connectors["remote"].Port, "45671")
+
+	// removing the link removes all its connectors
+	assert.Assert(t, NewRemoveConnector("remote").Apply(&config))
+	assert.Equal(t, len(config.Connectors), 0)
+	_, ok := config.SslProfiles["remote-profile"]
+	assert.Assert(t, !ok)
+}
+
+func TestLink_ActiveEndpoint(t *testing.T) {
+	connected := func(names ...string) func(string, skupperv2alpha1.Endpoint) bool {
+		return func(connector string, _ skupperv2alpha1.Endpoint) bool {
+			return slices.Contains(names, connector)
+		}
+	}
+	l := NewLink("remote", "/etc/skupper-router-certs")
+	_, ok := l.ActiveEndpoint(false, connected("remote"))
+	assert.Assert(t, !ok)
+	l.Update(multiEndpointLink(0))
+	_, ok = l.ActiveEndpoint(false, connected())
+	assert.Assert(t, !ok)
+	endpoint, ok := l.ActiveEndpoint(false, connected("remote/3", "remote/2", "remote"))
+	assert.Assert(t, ok)
+	assert.Equal(t, endpoint.Url(), "lb.example.com:55671")
+	endpoint, ok = l.ActiveEndpoint(false, connected("remote/3", "remote/2"))
+	assert.Assert(t, ok)
+	assert.Equal(t, endpoint.Url(), "route.example.com:443")
+	endpoint, ok = l.ActiveEndpoint(true, connected("remote"))
+	assert.Assert(t, ok)
+	assert.Equal(t, endpoint.Url(), "lb.example.com:45671")
+
+	// endpoints can also be matched by address, and are considered in
+	// order of cost rather than the order they are listed in
+	reordered := multiEndpointLink(0)
+	reordered.Spec.Endpoints[3].Cost = 1
+	l.Update(reordered)
+	endpoint, ok = l.ActiveEndpoint(false, func(_ string, endpoint skupperv2alpha1.Endpoint) bool {
+		return endpoint.Port == "443"
+	})
+	assert.Assert(t, ok)
+	assert.Equal(t, endpoint.Url(), "backup.example.com:443")
+}
+
+func TestLinkForConnector(t *testing.T) {
+	tests := []struct {
+		connector string
+		link      string
+		index     int
+	}{
+		{connector: "remote", link: "remote"},
+		{connector: "remote/2", link: "remote", index: 1},
+		{connector: "remote/10", link: "remote", index: 9},
+		{connector: "remote/1", link: "remote/1"},
+		{connector: "remote/x", link: "remote/x"},
+		{connector: "skupper-router-2", link: "skupper-router-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.connector, func(t *testing.T) {
+			link, index := LinkForConnector(tt.connector)
+			assert.Equal(t, link, tt.link)
+			assert.Equal(t, index, tt.index)
+		})
+	}
+}
+
 func TestLinkMap_Apply(t *testing.T) {
 	id := "router-1"
 	siteId := "site-1"
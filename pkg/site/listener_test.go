@@ -22,6 +22,7 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 		args               args
 		expectedTcpAdded   int
 		expectedTcpDeleted int
+		expectedUdpAdded   int
 	}{
 		{
 			name: "no spec type",
@@ -65,6 +66,28 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 			expectedTcpAdded:   1,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "udp spec type",
+			args: args{
+				siteId: "my-site-123",
+				listener: &skupperv2alpha1.Listener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "dns",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ListenerSpec{
+						RoutingKey: "dns:53",
+						Host:       "10.10.10.1",
+						Port:       53,
+						Type:       "udp",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedUdpAdded:   1,
+		},
 		{
 			name: "bad spec type",
 			args: args{
@@ -95,6 +118,7 @@ func TestUpdateBridgeConfigForListener(t *testing.T) {
 			result := tt.args.config.Difference(&configToUpdate)
 			assert.Assert(t, len(result.TcpListeners.Added) == tt.expectedTcpAdded)
 			assert.Assert(t, len(result.TcpListeners.Deleted) == tt.expectedTcpDeleted)
+			assert.Assert(t, len(result.UdpListeners.Added) == tt.expectedUdpAdded)
 		})
 	}
 }
@@ -3,6 +3,7 @@ package formatter
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -166,17 +167,19 @@ func PrintNetworkStatus(currentSite string, currentNetworkStatus *network.Networ
 
 				if len(siteStatus.RouterStatus) > 0 {
 
+					// sites whose routers cannot be identified are still listed,
+					// with the reason their linked sites are unknown
 					err, index := statusManager.GetRouterIndex(&siteStatus)
 					if err != nil {
-						return err
-					}
-
-					mapSiteLink := statusManager.GetSiteLinkMapPerRouter(&siteStatus.RouterStatus[index], &siteStatus.Site)
-
-					if len(mapSiteLink) > 0 {
-						siteLinks := siteLevel.NewChild("Linked sites:")
-						for key := range mapSiteLink {
-							siteLinks.NewChild(fmt.Sprintln(key))
+						siteLevel.NewChild(fmt.Sprintf("Linked sites: unknown (%s)", err))
+					} else {
+						mapSiteLink := statusManager.GetSiteLinkMapPerRouter(&siteStatus.RouterStatus[index], &siteStatus.Site)
+
+						if len(mapSiteLink) > 0 {
+							siteLinks := siteLevel.NewChild("Linked sites:")
+							for key := range mapSiteLink {
+								siteLinks.NewChild(fmt.Sprintln(key))
+							}
 						}
 					}
 
@@ -217,6 +220,102 @@ func PrintNetworkStatus(currentSite string, currentNetworkStatus *network.Networ
 	return nil
 }
 
+// PrintNetworkStatusTable prints the sites, the links between them and the
+// addresses in the network as tables, marking links that are not up and
+// addresses that have listeners but no connectors.
+func PrintNetworkStatusTable(currentSite string, currentNetworkStatus *network.NetworkStatusInfo) error {
+	statusManager := network.SkupperStatus{NetworkStatus: currentNetworkStatus}
+
+	if len(currentNetworkStatus.SiteStatus) == 0 {
+		fmt.Println("No sites found in the network")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+	fmt.Fprintln(writer, "SITE\tNAMESPACE\tPLATFORM\tVERSION\tROUTERS\tLINKS")
+	for _, siteStatus := range currentNetworkStatus.SiteStatus {
+		siteName := siteStatus.Site.Name
+		if siteStatus.Site.Identity == currentSite {
+			siteName = siteName + " (local)"
+		}
+		links := 0
+		for _, router := range siteStatus.RouterStatus {
+			links += len(statusManager.RemoveLinksFromSameSite(router, siteStatus.Site))
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\n", siteName, siteStatus.Site.Namespace, siteStatus.Site.Platform,
+			siteStatus.Site.Version, len(siteStatus.RouterStatus), links)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+	fmt.Fprintln(writer, "SITE\tLINK\tROLE\tCOST\tSTATUS")
+	for _, siteStatus := range currentNetworkStatus.SiteStatus {
+		for _, router := range siteStatus.RouterStatus {
+			for _, link := range statusManager.RemoveLinksFromSameSite(router, siteStatus.Site) {
+				status := link.Status
+				if !network.IsLinkOperational(link) {
+					status = "! " + displayableValue(link.Status)
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n", siteStatus.Site.Name, link.Name, link.Role, link.LinkCost, status)
+			}
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+	fmt.Fprintln(writer, "ADDRESS\tPROTOCOL\tLISTENERS\tCONNECTORS")
+	for _, address := range currentNetworkStatus.Addresses {
+		connectors := strconv.Itoa(address.ConnectorCount)
+		if address.ListenerCount > 0 && address.ConnectorCount == 0 {
+			connectors = "! " + connectors
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\n", address.Name, address.Protocol, address.ListenerCount, connectors)
+	}
+	return writer.Flush()
+}
+
+// PrintNetworkStatusWarnings summarises the problems highlighted by
+// PrintNetworkStatusTable, so that they are also visible in the tree view.
+func PrintNetworkStatusWarnings(currentNetworkStatus *network.NetworkStatusInfo) {
+	statusManager := network.SkupperStatus{NetworkStatus: currentNetworkStatus}
+
+	var warnings []string
+	nonOperationalLinks := statusManager.GetNonOperationalLinks()
+	sites := make([]string, 0, len(nonOperationalLinks))
+	for site := range nonOperationalLinks {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+	for _, site := range sites {
+		for _, link := range nonOperationalLinks[site] {
+			warnings = append(warnings, fmt.Sprintf("link %s in site %s is not operational (status: %s)", link.Name, site, displayableValue(link.Status)))
+		}
+	}
+	for _, address := range statusManager.GetAddressesWithoutConnectors() {
+		warnings = append(warnings, fmt.Sprintf("address %s has %d listener(s) but no connectors", address.Name, address.ListenerCount))
+	}
+
+	if len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+}
+
+func displayableValue(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
 func PrintServiceStatus(currentNetworkStatus *network.NetworkStatusInfo, mapServiceLabels map[string]map[string]string, verboseServiceStatus bool, showLabels bool, localSiteInfo *network.LocalSiteInfo) error {
 	statusManager := network.SkupperStatus{
 		NetworkStatus: currentNetworkStatus,